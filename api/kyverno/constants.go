@@ -2,11 +2,13 @@ package kyverno
 
 const (
 	// Well known labels
+	LabelAPICallAuth      = "apicall.kyverno.io/auth"
 	LabelAppComponent     = "app.kubernetes.io/component"
 	LabelAppManagedBy     = "app.kubernetes.io/managed-by"
 	LabelCacheEnabled     = "cache.kyverno.io/enabled"
 	LabelCertManagedBy    = "cert.kyverno.io/managed-by"
 	LabelCleanupTtl       = "cleanup.kyverno.io/ttl"
+	LabelGlobalContext    = "globalcontext.kyverno.io/entry"
 	LabelWebhookManagedBy = "webhook.kyverno.io/managed-by"
 	// Well known annotations
 	AnnotationAutogenControllers       = "pod-policies.kyverno.io/autogen-controllers"
@@ -15,6 +17,9 @@ const (
 	AnnotationPolicyScored             = "policies.kyverno.io/scored"
 	AnnotationPolicySeverity           = "policies.kyverno.io/severity"
 	AnnotationCleanupPropagationPolicy = "cleanup.kyverno.io/propagation-policy"
+	AnnotationGlobalContextGeneration  = "globalcontext.kyverno.io/generation"
+	AnnotationGlobalContextChunk       = "globalcontext.kyverno.io/chunk"
+	AnnotationGlobalContextChunks      = "globalcontext.kyverno.io/chunks"
 	// Well known values
 	ValueKyvernoApp        = "kyverno"
 	ValueTtlDateTimeLayout = "2006-01-02T150405Z"
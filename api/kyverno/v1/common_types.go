@@ -235,6 +235,16 @@ type GlobalContextEntryReference struct {
 	// +kubebuilder:validation:Required
 	Name string `json:"name"`
 
+	// Projection is the name of a projection declared by the global context entry.
+	// When set, the projection is used instead of the whole cached data.
+	// +kubebuilder:validation:Optional
+	Projection string `json:"projection,omitempty"`
+
+	// Key looks up the elements of an indexed projection having this key.
+	// It requires Projection to reference an indexed projection.
+	// +kubebuilder:validation:Optional
+	Key string `json:"key,omitempty"`
+
 	// JMESPath is an optional JSON Match Expression that can be used to
 	// transform the JSON response returned from the server. For example
 	// a JMESPath of "items | length(@)" applied to the API server response
@@ -256,6 +266,102 @@ type ServiceCall struct {
 	// the server certificate.
 	// +kubebuilder:validation:Optional
 	CABundle string `json:"caBundle"`
+
+	// Auth configures the authentication of the request with credentials stored in secrets.
+	// When set, the service account token is not added to the request.
+	// +kubebuilder:validation:Optional
+	Auth *ServiceCallAuth `json:"auth,omitempty"`
+}
+
+// ServiceCallAuth configures the authentication of a service call.
+// A client certificate can be combined with either basic or OAuth2 authentication.
+type ServiceCallAuth struct {
+	// ClientCert authenticates the request with a TLS client certificate.
+	// The secret must contain the tls.crt and tls.key keys.
+	// +kubebuilder:validation:Optional
+	ClientCert *ServiceCallSecret `json:"clientCert,omitempty"`
+
+	// Basic authenticates the request with a username and a password.
+	// The secret must contain the username and password keys.
+	// +kubebuilder:validation:Optional
+	Basic *ServiceCallSecret `json:"basic,omitempty"`
+
+	// OAuth2 authenticates the request with a bearer token obtained with the OAuth2 client credentials flow.
+	// +kubebuilder:validation:Optional
+	OAuth2 *OAuth2ClientCredentials `json:"oauth2,omitempty"`
+}
+
+// ServiceCallSecret references a secret holding service call credentials.
+// Controllers caching auth secrets only read secrets labelled with apicall.kyverno.io/auth.
+type ServiceCallSecret struct {
+	// Name of the secret.
+	Name string `json:"name"`
+
+	// Namespace of the secret.
+	Namespace string `json:"namespace"`
+}
+
+// OAuth2ClientCredentials configures the OAuth2 client credentials flow.
+// Tokens are cached and refreshed before they expire.
+type OAuth2ClientCredentials struct {
+	// TokenURL is the URL of the token endpoint.
+	TokenURL string `json:"tokenURL"`
+
+	// Secret references the secret containing the client_id and client_secret keys of the client.
+	Secret ServiceCallSecret `json:"secret"`
+
+	// Scopes optionally requested for the token.
+	// +kubebuilder:validation:Optional
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (a *ServiceCallAuth) Validate(path *field.Path) (errs field.ErrorList) {
+	if a.Basic != nil && a.OAuth2 != nil {
+		errs = append(errs, field.Forbidden(path, "basic and oauth2 authentication are mutually exclusive"))
+	}
+	if a.ClientCert == nil && a.Basic == nil && a.OAuth2 == nil {
+		errs = append(errs, field.Required(path, "one of clientCert, basic or oauth2 is required"))
+	}
+	if a.ClientCert != nil {
+		errs = append(errs, a.ClientCert.Validate(path.Child("clientCert"))...)
+	}
+	if a.Basic != nil {
+		errs = append(errs, a.Basic.Validate(path.Child("basic"))...)
+	}
+	if a.OAuth2 != nil {
+		if a.OAuth2.TokenURL == "" {
+			errs = append(errs, field.Required(path.Child("oauth2", "tokenURL"), "a token URL is required"))
+		}
+		errs = append(errs, a.OAuth2.Secret.Validate(path.Child("oauth2", "secret"))...)
+	}
+	return errs
+}
+
+// Secrets returns the secrets referenced by the authentication
+func (a *ServiceCallAuth) Secrets() []ServiceCallSecret {
+	var secrets []ServiceCallSecret
+	if a.ClientCert != nil {
+		secrets = append(secrets, *a.ClientCert)
+	}
+	if a.Basic != nil {
+		secrets = append(secrets, *a.Basic)
+	}
+	if a.OAuth2 != nil {
+		secrets = append(secrets, a.OAuth2.Secret)
+	}
+	return secrets
+}
+
+// Validate implements programmatic validation
+func (s *ServiceCallSecret) Validate(path *field.Path) (errs field.ErrorList) {
+	if s.Name == "" {
+		errs = append(errs, field.Required(path.Child("name"), "a secret name is required"))
+	}
+	if s.Namespace == "" {
+		errs = append(errs, field.Required(path.Child("namespace"), "a secret namespace is required"))
+	}
+	return errs
 }
 
 // Method is a HTTP request type.
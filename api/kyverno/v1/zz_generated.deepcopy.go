@@ -1064,6 +1064,28 @@ func (in *Mutation) DeepCopy() *Mutation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuth2ClientCredentials) DeepCopyInto(out *OAuth2ClientCredentials) {
+	*out = *in
+	out.Secret = in.Secret
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2ClientCredentials.
+func (in *OAuth2ClientCredentials) DeepCopy() *OAuth2ClientCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuth2ClientCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectFieldBinding) DeepCopyInto(out *ObjectFieldBinding) {
 	*out = *in
@@ -1504,6 +1526,11 @@ func (in *ServiceCall) DeepCopyInto(out *ServiceCall) {
 		*out = make([]HTTPHeader, len(*in))
 		copy(*out, *in)
 	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(ServiceCallAuth)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1517,6 +1544,53 @@ func (in *ServiceCall) DeepCopy() *ServiceCall {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceCallAuth) DeepCopyInto(out *ServiceCallAuth) {
+	*out = *in
+	if in.ClientCert != nil {
+		in, out := &in.ClientCert, &out.ClientCert
+		*out = new(ServiceCallSecret)
+		**out = **in
+	}
+	if in.Basic != nil {
+		in, out := &in.Basic, &out.Basic
+		*out = new(ServiceCallSecret)
+		**out = **in
+	}
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(OAuth2ClientCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceCallAuth.
+func (in *ServiceCallAuth) DeepCopy() *ServiceCallAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceCallAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceCallSecret) DeepCopyInto(out *ServiceCallSecret) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceCallSecret.
+func (in *ServiceCallSecret) DeepCopy() *ServiceCallSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceCallSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Spec) DeepCopyInto(out *Spec) {
 	*out = *in
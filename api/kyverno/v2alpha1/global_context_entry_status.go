@@ -8,6 +8,8 @@ import (
 const (
 	// PolicyConditionReady means that the globalcontextentry is ready
 	GlobalContextEntryConditionReady = "Ready"
+	// GlobalContextEntryConditionStale means that the globalcontextentry serves data that failed to refresh
+	GlobalContextEntryConditionStale = "Stale"
 )
 
 const (
@@ -15,6 +17,10 @@ const (
 	GlobalContextEntryReasonSucceeded = "Succeeded"
 	// GlobalContextEntryReasonFailed is the reason set when the globalcontextentry is not ready
 	GlobalContextEntryReasonFailed = "Failed"
+	// GlobalContextEntryReasonRefreshFailed is the reason set when the globalcontextentry is stale
+	GlobalContextEntryReasonRefreshFailed = "RefreshFailed"
+	// GlobalContextEntryReasonRefreshed is the reason set when the globalcontextentry is not stale
+	GlobalContextEntryReasonRefreshed = "Refreshed"
 )
 
 type GlobalContextEntryStatus struct {
@@ -25,6 +31,10 @@ type GlobalContextEntryStatus struct {
 	// Indicates the time when the globalcontextentry was last refreshed successfully for the API Call
 	// +optional
 	LastRefreshTime metav1.Time `json:"lastRefreshTime,omitempty"`
+	// Indicates the generation of the last data snapshot published for the API Call,
+	// controllers sharing snapshots serve the data of this generation
+	// +optional
+	SnapshotGeneration int64 `json:"snapshotGeneration,omitempty"`
 }
 
 func (status *GlobalContextEntryStatus) SetReady(ready bool, message string) {
@@ -43,6 +53,21 @@ func (status *GlobalContextEntryStatus) SetReady(ready bool, message string) {
 	meta.SetStatusCondition(&status.Conditions, condition)
 }
 
+func (status *GlobalContextEntryStatus) SetStale(stale bool, message string) {
+	condition := metav1.Condition{
+		Type:    GlobalContextEntryConditionStale,
+		Message: message,
+	}
+	if stale {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = GlobalContextEntryReasonRefreshFailed
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = GlobalContextEntryReasonRefreshed
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
 func (status *GlobalContextEntryStatus) UpdateRefreshTime() {
 	status.LastRefreshTime = metav1.Now()
 }
@@ -52,3 +77,9 @@ func (status *GlobalContextEntryStatus) IsReady() bool {
 	condition := meta.FindStatusCondition(status.Conditions, GlobalContextEntryConditionReady)
 	return condition != nil && condition.Status == metav1.ConditionTrue
 }
+
+// IsStale indicates if the globalcontextentry serves data that failed to refresh
+func (status *GlobalContextEntryStatus) IsStale() bool {
+	condition := meta.FindStatusCondition(status.Conditions, GlobalContextEntryConditionStale)
+	return condition != nil && condition.Status == metav1.ConditionTrue
+}
@@ -20,6 +20,8 @@ import (
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -70,6 +72,12 @@ type GlobalContextEntrySpec struct {
 	// 2. Finer-grained control is needed. Example: To restrict the number of resources cached.
 	// +kubebuilder:validation:Optional
 	APICall *ExternalAPICall `json:"apiCall,omitempty"`
+
+	// Projections are named views of the cached data, computed every time the data changes.
+	// Global context references can use a projection instead of the whole data.
+	// +kubebuilder:validation:Optional
+	// +optional
+	Projections []GlobalContextEntryProjection `json:"projections,omitempty"`
 }
 
 func (c *GlobalContextEntrySpec) IsAPICall() bool {
@@ -94,6 +102,75 @@ func (c *GlobalContextEntrySpec) Validate(path *field.Path) (errs field.ErrorLis
 	if c.IsAPICall() {
 		errs = append(errs, c.APICall.Validate(path.Child("apiCall"))...)
 	}
+	names := sets.New[string]()
+	for i, projection := range c.Projections {
+		errs = append(errs, projection.Validate(path.Child("projections").Index(i))...)
+		if names.Has(projection.Name) {
+			errs = append(errs, field.Duplicate(path.Child("projections").Index(i).Child("name"), projection.Name))
+		}
+		names.Insert(projection.Name)
+	}
+	return errs
+}
+
+// GlobalContextEntryProjection defines a named projection of the data cached by a global context entry.
+// When neither JMESPath nor CEL is set the projection is the cached data itself, this is useful to index it.
+type GlobalContextEntryProjection struct {
+	// Name is the name of the projection, used by global context references.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// JMESPath is a JMESPath expression applied to the cached data to compute the projection.
+	// Mutually exclusive with CEL.
+	// +kubebuilder:validation:Optional
+	// +optional
+	JMESPath string `json:"jmesPath,omitempty"`
+	// CEL is a CEL expression applied to the cached data, available in the `data` variable, to compute the projection.
+	// Mutually exclusive with JMESPath.
+	// +kubebuilder:validation:Optional
+	// +optional
+	CEL string `json:"cel,omitempty"`
+	// Index groups the elements of the projection by key, the projection must be a list.
+	// Global context references look up an indexed projection with a key and get the elements having that key.
+	// +kubebuilder:validation:Optional
+	// +optional
+	Index *GlobalContextEntryIndex `json:"index,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (p *GlobalContextEntryProjection) Validate(path *field.Path) (errs field.ErrorList) {
+	if p.Name == "" {
+		errs = append(errs, field.Required(path.Child("name"), "A projection requires a name"))
+	}
+	if p.JMESPath != "" && p.CEL != "" {
+		errs = append(errs, field.Forbidden(path.Child("jmesPath"), "A projection should either have JMESPath or CEL"))
+	}
+	if p.Index != nil {
+		errs = append(errs, p.Index.Validate(path.Child("index"))...)
+	}
+	return errs
+}
+
+// GlobalContextEntryIndex defines how the key of an element of an indexed projection is computed.
+// The key can be a string, a number, a boolean or a list of those to index the element under several keys,
+// elements with a null key are not indexed.
+type GlobalContextEntryIndex struct {
+	// JMESPath is a JMESPath expression applied to each element to compute its key.
+	// Mutually exclusive with CEL.
+	// +kubebuilder:validation:Optional
+	// +optional
+	JMESPath string `json:"jmesPath,omitempty"`
+	// CEL is a CEL expression applied to each element, available in the `element` variable, to compute its key.
+	// Mutually exclusive with JMESPath.
+	// +kubebuilder:validation:Optional
+	// +optional
+	CEL string `json:"cel,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (i *GlobalContextEntryIndex) Validate(path *field.Path) (errs field.ErrorList) {
+	if (i.JMESPath == "" && i.CEL == "") || (i.JMESPath != "" && i.CEL != "") {
+		errs = append(errs, field.Forbidden(path.Child("jmesPath"), "An index should either have JMESPath or CEL"))
+	}
 	return errs
 }
 
@@ -124,6 +201,21 @@ type KubernetesResource struct {
 	// +kubebuilder:validation:Optional
 	// +optional
 	Namespace string `json:"namespace,omitempty"`
+	// LabelSelector restricts the cached resources to the ones matching the label selector.
+	// +kubebuilder:validation:Optional
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	// FieldSelector restricts the cached resources to the ones matching the field selector (Ex., "status.phase=Running").
+	// Supported fields depend on the resource type.
+	// +kubebuilder:validation:Optional
+	// +optional
+	FieldSelector string `json:"fieldSelector,omitempty"`
+	// MetadataOnly caches only the metadata of the resources instead of the whole resources.
+	// Cached elements only hold the `metadata` field, this reduces the memory used by the entry
+	// when policies only look at names, labels or annotations.
+	// +kubebuilder:validation:Optional
+	// +optional
+	MetadataOnly bool `json:"metadataOnly,omitempty"`
 }
 
 // Validate implements programmatic validation
@@ -138,6 +230,16 @@ func (k *KubernetesResource) Validate(path *field.Path) (errs field.ErrorList) {
 	if k.Resource == "" {
 		errs = append(errs, field.Required(path.Child("resource"), "A Resource entry requires a resource"))
 	}
+	if k.LabelSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(k.LabelSelector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("labelSelector"), k.LabelSelector, err.Error()))
+		}
+	}
+	if k.FieldSelector != "" {
+		if _, err := fields.ParseSelector(k.FieldSelector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("fieldSelector"), k.FieldSelector, err.Error()))
+		}
+	}
 	return errs
 }
 
@@ -155,6 +257,19 @@ type ExternalAPICall struct {
 	// +kubebuilder:validation:Optional
 	// +optional
 	RetryLimit int `json:"retryLimit,omitempty"`
+	// RetryBackoff defines the delay before the first retry of a failed APICall.
+	// The delay doubles with random jitter after each retry and is capped by the refresh interval.
+	// Defaults to 1s.
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:validation:Optional
+	// +optional
+	RetryBackoff *metav1.Duration `json:"retryBackoff,omitempty"`
+	// MaxStaleness defines how long the last data fetched successfully keeps being served when refreshing it fails,
+	// the entry is marked as stale meanwhile. When not set, the entry fails as soon as a refresh fails.
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:validation:Optional
+	// +optional
+	MaxStaleness *metav1.Duration `json:"maxStaleness,omitempty"`
 }
 
 // Validate implements programmatic validation
@@ -168,5 +283,14 @@ func (e *ExternalAPICall) Validate(path *field.Path) (errs field.ErrorList) {
 	if e.Data != nil && e.Method != "POST" {
 		errs = append(errs, field.Forbidden(path.Child("method"), "An External API call with data should have method as POST"))
 	}
+	if e.Service != nil && e.Service.Auth != nil {
+		errs = append(errs, e.Service.Auth.Validate(path.Child("service", "auth"))...)
+	}
+	if e.RetryBackoff != nil && e.RetryBackoff.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("retryBackoff"), e.RetryBackoff.Duration.String(), "An External API call retry backoff must not be negative"))
+	}
+	if e.MaxStaleness != nil && e.MaxStaleness.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("maxStaleness"), e.MaxStaleness.Duration.String(), "An External API call max staleness must not be negative"))
+	}
 	return errs
 }
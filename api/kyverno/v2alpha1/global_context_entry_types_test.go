@@ -75,11 +75,122 @@ func TestGlobalContextEntrySpecValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid KubernetesResource with selectors",
+			spec: GlobalContextEntrySpec{
+				KubernetesResource: &KubernetesResource{
+					Version:  "v1",
+					Resource: "pods",
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "nginx"},
+					},
+					FieldSelector: "status.phase=Running",
+					MetadataOnly:  true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid label selector",
+			spec: GlobalContextEntrySpec{
+				KubernetesResource: &KubernetesResource{
+					Version:  "v1",
+					Resource: "pods",
+					LabelSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{
+							Key:      "app",
+							Operator: "Foo",
+						}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid field selector",
+			spec: GlobalContextEntrySpec{
+				KubernetesResource: &KubernetesResource{
+					Version:       "v1",
+					Resource:      "pods",
+					FieldSelector: "status.phase",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name:    "neither KubernetesResource nor APICall",
 			spec:    GlobalContextEntrySpec{},
 			wantErr: true,
 		},
+		{
+			name: "valid projections",
+			spec: GlobalContextEntrySpec{
+				KubernetesResource: &KubernetesResource{
+					Group:    "apps",
+					Version:  "v1",
+					Resource: "deployments",
+				},
+				Projections: []GlobalContextEntryProjection{{
+					Name:     "names",
+					JMESPath: "[].metadata.name",
+				}, {
+					Name: "by-namespace",
+					Index: &GlobalContextEntryIndex{
+						CEL: "element.metadata.namespace",
+					},
+				}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "duplicate projection names",
+			spec: GlobalContextEntrySpec{
+				KubernetesResource: &KubernetesResource{
+					Group:    "apps",
+					Version:  "v1",
+					Resource: "deployments",
+				},
+				Projections: []GlobalContextEntryProjection{{
+					Name:     "names",
+					JMESPath: "[].metadata.name",
+				}, {
+					Name: "names",
+					CEL:  "data.map(d, d.metadata.name)",
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "projection with both JMESPath and CEL",
+			spec: GlobalContextEntrySpec{
+				KubernetesResource: &KubernetesResource{
+					Group:    "apps",
+					Version:  "v1",
+					Resource: "deployments",
+				},
+				Projections: []GlobalContextEntryProjection{{
+					Name:     "names",
+					JMESPath: "[].metadata.name",
+					CEL:      "data.map(d, d.metadata.name)",
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "index without expression",
+			spec: GlobalContextEntrySpec{
+				KubernetesResource: &KubernetesResource{
+					Group:    "apps",
+					Version:  "v1",
+					Resource: "deployments",
+				},
+				Projections: []GlobalContextEntryProjection{{
+					Name:  "by-namespace",
+					Index: &GlobalContextEntryIndex{},
+				}},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -224,6 +335,40 @@ func TestExternalAPICallValidate(t *testing.T) {
 
 			wantErr: true,
 		},
+		{
+			name: "valid retry backoff and max staleness",
+			apiCall: ExternalAPICall{
+				APICall: kyvernov1.APICall{
+					URLPath: "/api/v1/namespaces",
+				},
+				RefreshInterval: &metav1.Duration{Duration: 10 * time.Minute},
+				RetryBackoff:    &metav1.Duration{Duration: 5 * time.Second},
+				MaxStaleness:    &metav1.Duration{Duration: time.Hour},
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative retry backoff",
+			apiCall: ExternalAPICall{
+				APICall: kyvernov1.APICall{
+					URLPath: "/api/v1/namespaces",
+				},
+				RefreshInterval: &metav1.Duration{Duration: 10 * time.Minute},
+				RetryBackoff:    &metav1.Duration{Duration: -time.Second},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative max staleness",
+			apiCall: ExternalAPICall{
+				APICall: kyvernov1.APICall{
+					URLPath: "/api/v1/namespaces",
+				},
+				RefreshInterval: &metav1.Duration{Duration: 10 * time.Minute},
+				MaxStaleness:    &metav1.Duration{Duration: -time.Minute},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,81 @@
+package v2alpha1
+
+import (
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=imageverificationpolicies,scope="Cluster",shortName=ivpol,categories=kyverno
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ImageVerificationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ImageVerificationPolicySpec `json:"spec"`
+	// Status contains policy runtime data.
+	// +optional
+	Status PolicyStatus `json:"status,omitempty"`
+}
+
+func (s *ImageVerificationPolicy) GetMatchConstraints() admissionregistrationv1.MatchResources {
+	if s.Spec.MatchConstraints == nil {
+		return admissionregistrationv1.MatchResources{}
+	}
+	return *s.Spec.MatchConstraints
+}
+
+func (s *ImageVerificationPolicy) GetMatchConditions() []admissionregistrationv1.MatchCondition {
+	return s.Spec.MatchConditions
+}
+
+func (s *ImageVerificationPolicy) GetFailurePolicy() admissionregistrationv1.FailurePolicyType {
+	if s.Spec.FailurePolicy == nil {
+		return admissionregistrationv1.Fail
+	}
+	return *s.Spec.FailurePolicy
+}
+
+func (s *ImageVerificationPolicy) GetWebhookConfiguration() *WebhookConfiguration {
+	return s.Spec.WebhookConfiguration
+}
+
+func (s *ImageVerificationPolicy) GetVariables() []admissionregistrationv1.Variable {
+	return s.Spec.Variables
+}
+
+func (s *ImageVerificationPolicy) GetStatus() *PolicyStatus {
+	return &s.Status
+}
+
+// GetValidationActions returns the validation actions, defaulting to Deny.
+func (s *ImageVerificationPolicy) GetValidationActions() []admissionregistrationv1.ValidationAction {
+	if len(s.Spec.ValidationAction) == 0 {
+		return []admissionregistrationv1.ValidationAction{admissionregistrationv1.Deny}
+	}
+	return s.Spec.ValidationAction
+}
+
+// GetMutateDigest returns true if image references must be mutated to use digests, defaulting to true.
+func (s *ImageVerificationPolicy) GetMutateDigest() bool {
+	return s.Spec.MutateDigest == nil || *s.Spec.MutateDigest
+}
+
+// GetVerifyDigest returns true if image references must use digests, defaulting to true.
+func (s *ImageVerificationPolicy) GetVerifyDigest() bool {
+	return s.Spec.VerifyDigest == nil || *s.Spec.VerifyDigest
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ImageVerificationPolicyList is a list of ImageVerificationPolicy instances
+type ImageVerificationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ImageVerificationPolicy `json:"items"`
+}
@@ -0,0 +1,206 @@
+package v2alpha1
+
+// ImageRule selects images using a glob pattern or a CEL expression.
+type ImageRule struct {
+	// Glob is a glob pattern the image reference must match, e.g. `ghcr.io/kyverno/*`.
+	// +optional
+	Glob string `json:"glob,omitempty"`
+
+	// CELExpression is a CEL expression evaluating to a boolean, the image reference is available under `ref`.
+	// +optional
+	CELExpression string `json:"cel,omitempty"`
+}
+
+// Attestor is a trusted signer, exactly one of Cosign or Notary must be set.
+type Attestor struct {
+	// Name is the name of the attestor, used to reference it in expressions.
+	// Required.
+	Name string `json:"name"`
+
+	// Cosign defines an attestor verifying signatures with cosign.
+	// +optional
+	Cosign *Cosign `json:"cosign,omitempty"`
+
+	// Notary defines an attestor verifying signatures with notary.
+	// +optional
+	Notary *Notary `json:"notary,omitempty"`
+}
+
+// IsCosign returns true if the attestor uses cosign.
+func (a Attestor) IsCosign() bool {
+	return a.Cosign != nil
+}
+
+// IsNotary returns true if the attestor uses notary.
+func (a Attestor) IsNotary() bool {
+	return a.Notary != nil
+}
+
+// Cosign defines the cosign verification settings, exactly one of Key, Keyless or Certificate must be set.
+type Cosign struct {
+	// Key defines a public key or a KMS reference used to verify signatures.
+	// +optional
+	Key *Key `json:"key,omitempty"`
+
+	// Keyless defines the identities expected in keyless signatures.
+	// +optional
+	Keyless *Keyless `json:"keyless,omitempty"`
+
+	// Certificate defines the certificates used to verify signatures.
+	// +optional
+	Certificate *Certificate `json:"certificate,omitempty"`
+
+	// Source defines where signatures are stored.
+	// +optional
+	Source *Source `json:"source,omitempty"`
+
+	// CTLog defines the transparency log settings.
+	// +optional
+	CTLog *CTLog `json:"ctlog,omitempty"`
+
+	// Annotations are the annotations expected in the signature payload.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Key defines a public key used to verify signatures.
+type Key struct {
+	// Data is a PEM encoded public key.
+	// +optional
+	Data string `json:"data,omitempty"`
+
+	// KMS is a KMS reference to the public key, e.g. `gcpkms://projects/[PROJECT]/locations/global/keyRings/[KEYRING]/cryptoKeys/[KEY]`.
+	// +optional
+	KMS string `json:"kms,omitempty"`
+
+	// HashAlgorithm is the signature hash algorithm, defaults to sha256.
+	// +optional
+	HashAlgorithm string `json:"hashAlgorithm,omitempty"`
+}
+
+// Keyless defines the identities expected in keyless signatures.
+type Keyless struct {
+	// Identities lists the accepted identities, a signature is accepted if it matches one of them.
+	// Required.
+	Identities []Identity `json:"identities,omitempty"`
+
+	// Roots is an optional set of PEM encoded trusted root certificates.
+	// Fulcio roots are used when not set.
+	// +optional
+	Roots string `json:"roots,omitempty"`
+}
+
+// Identity defines the expected certificate issuer and subject.
+type Identity struct {
+	// Issuer is the certificate issuer.
+	// +optional
+	Issuer string `json:"issuer,omitempty"`
+
+	// Subject is the certificate subject.
+	// +optional
+	Subject string `json:"subject,omitempty"`
+
+	// IssuerRegExp is a regular expression the certificate issuer must match.
+	// +optional
+	IssuerRegExp string `json:"issuerRegExp,omitempty"`
+
+	// SubjectRegExp is a regular expression the certificate subject must match.
+	// +optional
+	SubjectRegExp string `json:"subjectRegExp,omitempty"`
+}
+
+// Certificate defines the certificates used to verify signatures.
+type Certificate struct {
+	// Certificate is a PEM encoded certificate.
+	// +optional
+	Certificate string `json:"cert,omitempty"`
+
+	// CertificateChain is a PEM encoded certificate chain.
+	// +optional
+	CertificateChain string `json:"certChain,omitempty"`
+}
+
+// Source defines where signatures are stored.
+type Source struct {
+	// Repository is an alternate repository containing the signatures.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+}
+
+// CTLog defines the transparency log settings.
+type CTLog struct {
+	// URL is the address of the transparency log, defaults to the public Rekor instance.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// RekorPubKey is a PEM encoded public key of the transparency log.
+	// +optional
+	RekorPubKey string `json:"rekorPubKey,omitempty"`
+
+	// CTLogPubKey is a PEM encoded public key of the certificate transparency log.
+	// +optional
+	CTLogPubKey string `json:"ctLogPubKey,omitempty"`
+
+	// TSACertChain is a PEM encoded certificate chain of the timestamp authority.
+	// +optional
+	TSACertChain string `json:"tsaCertChain,omitempty"`
+
+	// InsecureIgnoreTlog skips transparency log verification.
+	// +optional
+	InsecureIgnoreTlog bool `json:"insecureIgnoreTlog,omitempty"`
+
+	// InsecureIgnoreSCT skips signed certificate timestamp verification.
+	// +optional
+	InsecureIgnoreSCT bool `json:"insecureIgnoreSCT,omitempty"`
+}
+
+// Notary defines the notary verification settings.
+type Notary struct {
+	// Certs is a PEM encoded certificate or certificate chain.
+	// Required.
+	Certs string `json:"certs,omitempty"`
+
+	// TSACerts is a PEM encoded certificate chain of the timestamp authority.
+	// +optional
+	TSACerts string `json:"tsaCerts,omitempty"`
+}
+
+// Attestation defines an attestation attached to images, exactly one of InToto or Referrer must be set.
+type Attestation struct {
+	// Name is the name of the attestation, used to reference it in expressions.
+	// Required.
+	Name string `json:"name"`
+
+	// InToto defines an in-toto attestation, as produced by cosign.
+	// +optional
+	InToto *InToto `json:"intoto,omitempty"`
+
+	// Referrer defines an OCI referrer, as produced by notary.
+	// +optional
+	Referrer *Referrer `json:"referrer,omitempty"`
+}
+
+// InToto defines an in-toto attestation.
+type InToto struct {
+	// Type is the predicate type of the attestation.
+	// Required.
+	Type string `json:"type"`
+}
+
+// Referrer defines an OCI referrer.
+type Referrer struct {
+	// Type is the artifact type of the referrer.
+	// Required.
+	Type string `json:"type"`
+}
+
+// GetType returns the type of the attestation.
+func (a Attestation) GetType() string {
+	if a.InToto != nil {
+		return a.InToto.Type
+	}
+	if a.Referrer != nil {
+		return a.Referrer.Type
+	}
+	return ""
+}
@@ -1,6 +1,8 @@
 package v2alpha1
 
 import (
+	"strings"
+
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -11,6 +13,8 @@ const (
 	PolicyConditionTypeWebhookConfigured      PolicyConditionType = "WebhookConfigured"
 	PolicyConditionTypePolicyCached           PolicyConditionType = "PolicyCached"
 	PolicyConditionTypeRBACPermissionsGranted PolicyConditionType = "RBACPermissionsGranted"
+	PolicyConditionTypePolicyCompiled         PolicyConditionType = "PolicyCompiled"
+	PolicyConditionTypeReady                  PolicyConditionType = "Ready"
 )
 
 type PolicyStatus struct {
@@ -37,3 +41,25 @@ func (status *PolicyStatus) SetReadyByCondition(c PolicyConditionType, s metav1.
 
 	meta.SetStatusCondition(&status.Conditions, newCondition)
 }
+
+// SetReadyFromConditions sets the Ready condition to true when all the given conditions are true,
+// the message lists the conditions that are missing or not true.
+func (status *PolicyStatus) SetReadyFromConditions(conditions ...PolicyConditionType) {
+	var pending []string
+	for _, c := range conditions {
+		if !meta.IsStatusConditionTrue(status.Conditions, string(c)) {
+			pending = append(pending, string(c))
+		}
+	}
+	status.Ready = len(pending) == 0
+	if status.Ready {
+		status.SetReadyByCondition(PolicyConditionTypeReady, metav1.ConditionTrue, "Policy is ready")
+	} else {
+		status.SetReadyByCondition(PolicyConditionTypeReady, metav1.ConditionFalse, "Waiting for conditions: "+strings.Join(pending, ", "))
+	}
+}
+
+// IsReady indicates if the policy is ready
+func (status *PolicyStatus) IsReady() bool {
+	return meta.IsStatusConditionTrue(status.Conditions, string(PolicyConditionTypeReady))
+}
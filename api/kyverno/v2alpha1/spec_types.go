@@ -1,7 +1,9 @@
 package v2alpha1
 
 import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
 )
 
 // ValidatingPolicySpec is the specification of the desired behavior of the ValidatingPolicy.
@@ -16,6 +18,70 @@ type ValidatingPolicySpec struct {
 	// WebhookConfiguration defines the configuration for the webhook.
 	// +optional
 	WebhookConfiguration *WebhookConfiguration `json:"webhookConfiguration,omitempty"`
+
+	// AutogenConfiguration defines the configuration for the generation of pod controllers rules.
+	// It takes precedence over the pod-policies.kyverno.io/autogen-controllers annotation.
+	// +optional
+	AutogenConfiguration *AutogenConfiguration `json:"autogen,omitempty"`
+}
+
+// GeneratingPolicySpec is the specification of the desired behavior of the GeneratingPolicy.
+type GeneratingPolicySpec struct {
+	// MatchConstraints specifies what resources will trigger this policy.
+	// The policy cares about a request if it matches _all_ Constraints.
+	// Required.
+	MatchConstraints *admissionregistrationv1.MatchResources `json:"matchConstraints,omitempty"`
+
+	// FailurePolicy defines how to handle failures for the policy. Failures can
+	// occur from CEL expression parse errors, type check errors, runtime errors and invalid
+	// or mis-configured policy definitions.
+	// Allowed values are Ignore or Fail. Defaults to Fail.
+	// +optional
+	FailurePolicy *admissionregistrationv1.FailurePolicyType `json:"failurePolicy,omitempty"`
+
+	// MatchConditions is a list of conditions that must be met for a request to trigger the policy.
+	// An empty list of matchConditions matches all requests.
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	MatchConditions []admissionregistrationv1.MatchCondition `json:"matchConditions,omitempty"`
+
+	// Variables contain definitions of variables that can be used in composition of other expressions.
+	// Each variable is defined as a named CEL expression.
+	// The variables defined here will be available under `variables` in other expressions of the policy.
+	// +listType=atomic
+	// +optional
+	Variables []admissionregistrationv1.Variable `json:"variables,omitempty"`
+
+	// Generation defines a set of CEL expressions evaluating to the resources to generate.
+	// Required.
+	// +listType=atomic
+	Generation []Generation `json:"generate,omitempty"`
+
+	// Synchronize controls if generated resources should be kept in-sync with their trigger.
+	// When enabled, generated resources are updated when the trigger changes and deleted when the trigger is deleted.
+	// Optional. Defaults to "false" if not specified.
+	// +optional
+	Synchronize bool `json:"synchronize,omitempty"`
+
+	// OrphanDownstreamOnPolicyDelete controls whether generated resources should be kept when the policy is deleted.
+	// It only applies to synchronized policies.
+	// Optional. Defaults to "false" if not specified.
+	// +optional
+	OrphanDownstreamOnPolicyDelete bool `json:"orphanDownstreamOnPolicyDelete,omitempty"`
+
+	// WebhookConfiguration defines the configuration for the webhook.
+	// +optional
+	WebhookConfiguration *WebhookConfiguration `json:"webhookConfiguration,omitempty"`
+}
+
+type Generation struct {
+	// Expression is a CEL expression evaluating to the resource, or the list of resources, to generate.
+	// Namespaced resources are generated in the trigger namespace unless a namespace is set.
+	// Required.
+	Expression string `json:"expression"`
 }
 
 type WebhookConfiguration struct {
@@ -24,3 +90,144 @@ type WebhookConfiguration struct {
 	// based on the failure policy. The default timeout is 10s, the value must be between 1 and 30 seconds.
 	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
 }
+
+type AutogenConfiguration struct {
+	// PodControllers specifies the pod controllers rules are generated for.
+	// +optional
+	PodControllers *PodControllersGenerationConfiguration `json:"podControllers,omitempty"`
+}
+
+type PodControllersGenerationConfiguration struct {
+	// Controllers lists the pod controllers resources (daemonsets, deployments, jobs, statefulsets, replicasets
+	// and cronjobs), an empty list disables the generation of pod controllers rules.
+	// +optional
+	Controllers []string `json:"controllers,omitempty"`
+}
+
+// MutatingPolicySpec is the specification of the desired behavior of the MutatingPolicy.
+type MutatingPolicySpec struct {
+	// MatchConstraints specifies what resources this policy is designed to mutate.
+	// The policy cares about a request if it matches _all_ Constraints.
+	// Required.
+	MatchConstraints *admissionregistrationv1.MatchResources `json:"matchConstraints,omitempty"`
+
+	// FailurePolicy defines how to handle failures for the policy. Failures can
+	// occur from CEL expression parse errors, type check errors, runtime errors and invalid
+	// or mis-configured policy definitions.
+	// Allowed values are Ignore or Fail. Defaults to Fail.
+	// +optional
+	FailurePolicy *admissionregistrationv1.FailurePolicyType `json:"failurePolicy,omitempty"`
+
+	// MatchConditions is a list of conditions that must be met for a request to be mutated.
+	// An empty list of matchConditions matches all requests.
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	MatchConditions []admissionregistrationv1.MatchCondition `json:"matchConditions,omitempty"`
+
+	// Variables contain definitions of variables that can be used in composition of other expressions.
+	// Each variable is defined as a named CEL expression.
+	// The variables defined here will be available under `variables` in other expressions of the policy.
+	// +listType=atomic
+	// +optional
+	Variables []admissionregistrationv1.Variable `json:"variables,omitempty"`
+
+	// Mutations contain operations to perform on matching objects.
+	// Mutations are evaluated in order and each mutation is applied to the result of the previous one.
+	// Required.
+	// +listType=atomic
+	Mutations []admissionregistrationv1alpha1.Mutation `json:"mutations,omitempty"`
+
+	// ReinvocationPolicy indicates whether mutations may be called multiple times per admission request.
+	// Allowed values are Never and IfNeeded. Defaults to Never.
+	// +optional
+	ReinvocationPolicy admissionregistrationv1.ReinvocationPolicyType `json:"reinvocationPolicy,omitempty"`
+
+	// WebhookConfiguration defines the configuration for the webhook.
+	// +optional
+	WebhookConfiguration *WebhookConfiguration `json:"webhookConfiguration,omitempty"`
+}
+
+// ImageVerificationPolicySpec is the specification of the desired behavior of the ImageVerificationPolicy.
+type ImageVerificationPolicySpec struct {
+	// MatchConstraints specifies what resources this policy is designed to verify.
+	// The policy cares about a request if it matches _all_ Constraints.
+	// Required.
+	MatchConstraints *admissionregistrationv1.MatchResources `json:"matchConstraints,omitempty"`
+
+	// FailurePolicy defines how to handle failures for the policy. Failures can
+	// occur from CEL expression parse errors, type check errors, runtime errors and invalid
+	// or mis-configured policy definitions.
+	// Allowed values are Ignore or Fail. Defaults to Fail.
+	// +optional
+	FailurePolicy *admissionregistrationv1.FailurePolicyType `json:"failurePolicy,omitempty"`
+
+	// ValidationAction specifies the action to be taken when the matched resource violates the policy.
+	// Defaults to Deny.
+	// +listType=set
+	// +optional
+	ValidationAction []admissionregistrationv1.ValidationAction `json:"validationActions,omitempty"`
+
+	// MatchConditions is a list of conditions that must be met for a request to be verified.
+	// An empty list of matchConditions matches all requests.
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	MatchConditions []admissionregistrationv1.MatchCondition `json:"matchConditions,omitempty"`
+
+	// Variables contain definitions of variables that can be used in composition of other expressions.
+	// Each variable is defined as a named CEL expression.
+	// The variables defined here will be available under `variables` in other expressions of the policy.
+	// +listType=atomic
+	// +optional
+	Variables []admissionregistrationv1.Variable `json:"variables,omitempty"`
+
+	// ImageRules selects the images verified by the policy.
+	// An image is selected if it matches at least one rule, all images are selected when no rule is set.
+	// +listType=atomic
+	// +optional
+	ImageRules []ImageRule `json:"imageRules,omitempty"`
+
+	// ImageExtractors defines a mapping from kinds to image extractor configurations.
+	// Images of pods and pod controllers are extracted by default.
+	// +optional
+	ImageExtractors kyvernov1.ImageExtractorConfigs `json:"imageExtractors,omitempty"`
+
+	// Attestors lists the trusted signers, they are available under `attestors` in expressions.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Attestors []Attestor `json:"attestors,omitempty"`
+
+	// Attestations lists the attestations that can be verified, they are available under `attestations` in expressions.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Attestations []Attestation `json:"attestations,omitempty"`
+
+	// Verifications contain CEL expressions used to accept or reject the selected images.
+	// The selected images are available under `images`, indexed by image extractor name.
+	// Required.
+	// +listType=atomic
+	Verifications []admissionregistrationv1.Validation `json:"verifications,omitempty"`
+
+	// MutateDigest enables replacement of image tags with digests.
+	// Defaults to true.
+	// +kubebuilder:default=true
+	// +optional
+	MutateDigest *bool `json:"mutateDigest,omitempty"`
+
+	// VerifyDigest validates that images use a digest, it only applies when digests are not mutated.
+	// Defaults to true.
+	// +kubebuilder:default=true
+	// +optional
+	VerifyDigest *bool `json:"verifyDigest,omitempty"`
+
+	// WebhookConfiguration defines the configuration for the webhook.
+	// +optional
+	WebhookConfiguration *WebhookConfiguration `json:"webhookConfiguration,omitempty"`
+}
@@ -0,0 +1,60 @@
+package v2alpha1
+
+import (
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+)
+
+type ValidatingPolicyStatus struct {
+	PolicyStatus `json:",inline"`
+
+	// ObservedGeneration is the policy generation the status was computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// TypeChecking contains the results of compiling and type checking the policy expressions.
+	// +optional
+	TypeChecking *TypeChecking `json:"typeChecking,omitempty"`
+
+	// Autogen contains the rules generated for pod controllers.
+	// +optional
+	Autogen ValidatingPolicyAutogenStatus `json:"autogen,omitempty"`
+}
+
+type ValidatingPolicyAutogenStatus struct {
+	// Rules lists the rules generated for pod controllers.
+	// +optional
+	Rules []ValidatingPolicyAutogen `json:"rules,omitempty"`
+}
+
+// ValidatingPolicyAutogen is a rule generated from a policy matching pods, it matches pod controllers
+// and has its expressions rewritten to evaluate the pod template.
+type ValidatingPolicyAutogen struct {
+	// Name identifies the generated rule.
+	Name string `json:"name"`
+
+	MatchConstraints *admissionregistrationv1.MatchResources   `json:"matchConstraints,omitempty"`
+	MatchConditions  []admissionregistrationv1.MatchCondition  `json:"matchConditions,omitempty"`
+	Validations      []admissionregistrationv1.Validation      `json:"validations,omitempty"`
+	AuditAnnotation  []admissionregistrationv1.AuditAnnotation `json:"auditAnnotations,omitempty"`
+	Variables        []admissionregistrationv1.Variable        `json:"variables,omitempty"`
+}
+
+// TypeChecking contains the problems found in the policy expressions.
+type TypeChecking struct {
+	// ExpressionErrors lists the expressions that failed to compile, the policy can't be enforced until they are fixed.
+	// +optional
+	ExpressionErrors []ExpressionMessage `json:"expressionErrors,omitempty"`
+
+	// ExpressionWarnings lists the expressions that compiled but don't type check against the matched resources schemas.
+	// +optional
+	ExpressionWarnings []ExpressionMessage `json:"expressionWarnings,omitempty"`
+}
+
+// ExpressionMessage describes a problem found in a policy expression.
+type ExpressionMessage struct {
+	// FieldRef is the path to the field containing the expression, e.g. spec.validations[0].expression.
+	FieldRef string `json:"fieldRef"`
+
+	// Message is the compiler or type checker message.
+	Message string `json:"message"`
+}
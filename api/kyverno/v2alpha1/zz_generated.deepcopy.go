@@ -22,11 +22,86 @@ limitations under the License.
 package v2alpha1
 
 import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	v1 "k8s.io/api/admissionregistration/v1"
+	v1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Attestation) DeepCopyInto(out *Attestation) {
+	*out = *in
+	if in.InToto != nil {
+		in, out := &in.InToto, &out.InToto
+		*out = new(InToto)
+		**out = **in
+	}
+	if in.Referrer != nil {
+		in, out := &in.Referrer, &out.Referrer
+		*out = new(Referrer)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Attestation.
+func (in *Attestation) DeepCopy() *Attestation {
+	if in == nil {
+		return nil
+	}
+	out := new(Attestation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Attestor) DeepCopyInto(out *Attestor) {
+	*out = *in
+	if in.Cosign != nil {
+		in, out := &in.Cosign, &out.Cosign
+		*out = new(Cosign)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notary != nil {
+		in, out := &in.Notary, &out.Notary
+		*out = new(Notary)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Attestor.
+func (in *Attestor) DeepCopy() *Attestor {
+	if in == nil {
+		return nil
+	}
+	out := new(Attestor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutogenConfiguration) DeepCopyInto(out *AutogenConfiguration) {
+	*out = *in
+	if in.PodControllers != nil {
+		in, out := &in.PodControllers, &out.PodControllers
+		*out = new(PodControllersGenerationConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutogenConfiguration.
+func (in *AutogenConfiguration) DeepCopy() *AutogenConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AutogenConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CELPolicyException) DeepCopyInto(out *CELPolicyException) {
 	*out = *in
@@ -36,107 +111,760 @@ func (in *CELPolicyException) DeepCopyInto(out *CELPolicyException) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CELPolicyException.
-func (in *CELPolicyException) DeepCopy() *CELPolicyException {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CELPolicyException.
+func (in *CELPolicyException) DeepCopy() *CELPolicyException {
+	if in == nil {
+		return nil
+	}
+	out := new(CELPolicyException)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CELPolicyException) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CELPolicyExceptionList) DeepCopyInto(out *CELPolicyExceptionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CELPolicyException, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CELPolicyExceptionList.
+func (in *CELPolicyExceptionList) DeepCopy() *CELPolicyExceptionList {
+	if in == nil {
+		return nil
+	}
+	out := new(CELPolicyExceptionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CELPolicyExceptionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CELPolicyExceptionSpec) DeepCopyInto(out *CELPolicyExceptionSpec) {
+	*out = *in
+	if in.PolicyRefs != nil {
+		in, out := &in.PolicyRefs, &out.PolicyRefs
+		*out = make([]PolicyRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.MatchConditions != nil {
+		in, out := &in.MatchConditions, &out.MatchConditions
+		*out = make([]v1.MatchCondition, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CELPolicyExceptionSpec.
+func (in *CELPolicyExceptionSpec) DeepCopy() *CELPolicyExceptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CELPolicyExceptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CTLog) DeepCopyInto(out *CTLog) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CTLog.
+func (in *CTLog) DeepCopy() *CTLog {
+	if in == nil {
+		return nil
+	}
+	out := new(CTLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Certificate) DeepCopyInto(out *Certificate) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Certificate.
+func (in *Certificate) DeepCopy() *Certificate {
+	if in == nil {
+		return nil
+	}
+	out := new(Certificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Cosign) DeepCopyInto(out *Cosign) {
+	*out = *in
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(Key)
+		**out = **in
+	}
+	if in.Keyless != nil {
+		in, out := &in.Keyless, &out.Keyless
+		*out = new(Keyless)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Certificate != nil {
+		in, out := &in.Certificate, &out.Certificate
+		*out = new(Certificate)
+		**out = **in
+	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(Source)
+		**out = **in
+	}
+	if in.CTLog != nil {
+		in, out := &in.CTLog, &out.CTLog
+		*out = new(CTLog)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cosign.
+func (in *Cosign) DeepCopy() *Cosign {
+	if in == nil {
+		return nil
+	}
+	out := new(Cosign)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExpressionMessage) DeepCopyInto(out *ExpressionMessage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExpressionMessage.
+func (in *ExpressionMessage) DeepCopy() *ExpressionMessage {
+	if in == nil {
+		return nil
+	}
+	out := new(ExpressionMessage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalAPICall) DeepCopyInto(out *ExternalAPICall) {
+	*out = *in
+	in.APICall.DeepCopyInto(&out.APICall)
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RetryBackoff != nil {
+		in, out := &in.RetryBackoff, &out.RetryBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxStaleness != nil {
+		in, out := &in.MaxStaleness, &out.MaxStaleness
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalAPICall.
+func (in *ExternalAPICall) DeepCopy() *ExternalAPICall {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalAPICall)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratingPolicy) DeepCopyInto(out *GeneratingPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratingPolicy.
+func (in *GeneratingPolicy) DeepCopy() *GeneratingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GeneratingPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratingPolicyList) DeepCopyInto(out *GeneratingPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GeneratingPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratingPolicyList.
+func (in *GeneratingPolicyList) DeepCopy() *GeneratingPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratingPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GeneratingPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratingPolicySpec) DeepCopyInto(out *GeneratingPolicySpec) {
+	*out = *in
+	if in.MatchConstraints != nil {
+		in, out := &in.MatchConstraints, &out.MatchConstraints
+		*out = new(v1.MatchResources)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailurePolicy != nil {
+		in, out := &in.FailurePolicy, &out.FailurePolicy
+		*out = new(v1.FailurePolicyType)
+		**out = **in
+	}
+	if in.MatchConditions != nil {
+		in, out := &in.MatchConditions, &out.MatchConditions
+		*out = make([]v1.MatchCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make([]v1.Variable, len(*in))
+		copy(*out, *in)
+	}
+	if in.Generation != nil {
+		in, out := &in.Generation, &out.Generation
+		*out = make([]Generation, len(*in))
+		copy(*out, *in)
+	}
+	if in.WebhookConfiguration != nil {
+		in, out := &in.WebhookConfiguration, &out.WebhookConfiguration
+		*out = new(WebhookConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratingPolicySpec.
+func (in *GeneratingPolicySpec) DeepCopy() *GeneratingPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratingPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Generation) DeepCopyInto(out *Generation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Generation.
+func (in *Generation) DeepCopy() *Generation {
+	if in == nil {
+		return nil
+	}
+	out := new(Generation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalContextEntry) DeepCopyInto(out *GlobalContextEntry) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalContextEntry.
+func (in *GlobalContextEntry) DeepCopy() *GlobalContextEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalContextEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GlobalContextEntry) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalContextEntryIndex) DeepCopyInto(out *GlobalContextEntryIndex) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalContextEntryIndex.
+func (in *GlobalContextEntryIndex) DeepCopy() *GlobalContextEntryIndex {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalContextEntryIndex)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalContextEntryList) DeepCopyInto(out *GlobalContextEntryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GlobalContextEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalContextEntryList.
+func (in *GlobalContextEntryList) DeepCopy() *GlobalContextEntryList {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalContextEntryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GlobalContextEntryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalContextEntryProjection) DeepCopyInto(out *GlobalContextEntryProjection) {
+	*out = *in
+	if in.Index != nil {
+		in, out := &in.Index, &out.Index
+		*out = new(GlobalContextEntryIndex)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalContextEntryProjection.
+func (in *GlobalContextEntryProjection) DeepCopy() *GlobalContextEntryProjection {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalContextEntryProjection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalContextEntrySpec) DeepCopyInto(out *GlobalContextEntrySpec) {
+	*out = *in
+	if in.KubernetesResource != nil {
+		in, out := &in.KubernetesResource, &out.KubernetesResource
+		*out = new(KubernetesResource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APICall != nil {
+		in, out := &in.APICall, &out.APICall
+		*out = new(ExternalAPICall)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Projections != nil {
+		in, out := &in.Projections, &out.Projections
+		*out = make([]GlobalContextEntryProjection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalContextEntrySpec.
+func (in *GlobalContextEntrySpec) DeepCopy() *GlobalContextEntrySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalContextEntrySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalContextEntryStatus) DeepCopyInto(out *GlobalContextEntryStatus) {
+	*out = *in
+	if in.Ready != nil {
+		in, out := &in.Ready, &out.Ready
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.LastRefreshTime.DeepCopyInto(&out.LastRefreshTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalContextEntryStatus.
+func (in *GlobalContextEntryStatus) DeepCopy() *GlobalContextEntryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalContextEntryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Identity) DeepCopyInto(out *Identity) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Identity.
+func (in *Identity) DeepCopy() *Identity {
+	if in == nil {
+		return nil
+	}
+	out := new(Identity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageRule) DeepCopyInto(out *ImageRule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageRule.
+func (in *ImageRule) DeepCopy() *ImageRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageVerificationPolicy) DeepCopyInto(out *ImageVerificationPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageVerificationPolicy.
+func (in *ImageVerificationPolicy) DeepCopy() *ImageVerificationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageVerificationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImageVerificationPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageVerificationPolicyList) DeepCopyInto(out *ImageVerificationPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ImageVerificationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageVerificationPolicyList.
+func (in *ImageVerificationPolicyList) DeepCopy() *ImageVerificationPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageVerificationPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImageVerificationPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageVerificationPolicySpec) DeepCopyInto(out *ImageVerificationPolicySpec) {
+	*out = *in
+	if in.MatchConstraints != nil {
+		in, out := &in.MatchConstraints, &out.MatchConstraints
+		*out = new(v1.MatchResources)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailurePolicy != nil {
+		in, out := &in.FailurePolicy, &out.FailurePolicy
+		*out = new(v1.FailurePolicyType)
+		**out = **in
+	}
+	if in.ValidationAction != nil {
+		in, out := &in.ValidationAction, &out.ValidationAction
+		*out = make([]v1.ValidationAction, len(*in))
+		copy(*out, *in)
+	}
+	if in.MatchConditions != nil {
+		in, out := &in.MatchConditions, &out.MatchConditions
+		*out = make([]v1.MatchCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make([]v1.Variable, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImageRules != nil {
+		in, out := &in.ImageRules, &out.ImageRules
+		*out = make([]ImageRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImageExtractors != nil {
+		in, out := &in.ImageExtractors, &out.ImageExtractors
+		*out = make(kyvernov1.ImageExtractorConfigs, len(*in))
+		for key, val := range *in {
+			var outVal []kyvernov1.ImageExtractorConfig
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]kyvernov1.ImageExtractorConfig, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Attestors != nil {
+		in, out := &in.Attestors, &out.Attestors
+		*out = make([]Attestor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Attestations != nil {
+		in, out := &in.Attestations, &out.Attestations
+		*out = make([]Attestation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Verifications != nil {
+		in, out := &in.Verifications, &out.Verifications
+		*out = make([]v1.Validation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MutateDigest != nil {
+		in, out := &in.MutateDigest, &out.MutateDigest
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VerifyDigest != nil {
+		in, out := &in.VerifyDigest, &out.VerifyDigest
+		*out = new(bool)
+		**out = **in
+	}
+	if in.WebhookConfiguration != nil {
+		in, out := &in.WebhookConfiguration, &out.WebhookConfiguration
+		*out = new(WebhookConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageVerificationPolicySpec.
+func (in *ImageVerificationPolicySpec) DeepCopy() *ImageVerificationPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CELPolicyException)
+	out := new(ImageVerificationPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CELPolicyException) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CELPolicyExceptionList) DeepCopyInto(out *CELPolicyExceptionList) {
+func (in *InToto) DeepCopyInto(out *InToto) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]CELPolicyException, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CELPolicyExceptionList.
-func (in *CELPolicyExceptionList) DeepCopy() *CELPolicyExceptionList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InToto.
+func (in *InToto) DeepCopy() *InToto {
 	if in == nil {
 		return nil
 	}
-	out := new(CELPolicyExceptionList)
+	out := new(InToto)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CELPolicyExceptionList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Key) DeepCopyInto(out *Key) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Key.
+func (in *Key) DeepCopy() *Key {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(Key)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CELPolicyExceptionSpec) DeepCopyInto(out *CELPolicyExceptionSpec) {
+func (in *Keyless) DeepCopyInto(out *Keyless) {
 	*out = *in
-	if in.PolicyRefs != nil {
-		in, out := &in.PolicyRefs, &out.PolicyRefs
-		*out = make([]PolicyRef, len(*in))
-		copy(*out, *in)
-	}
-	if in.MatchConditions != nil {
-		in, out := &in.MatchConditions, &out.MatchConditions
-		*out = make([]v1.MatchCondition, len(*in))
+	if in.Identities != nil {
+		in, out := &in.Identities, &out.Identities
+		*out = make([]Identity, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CELPolicyExceptionSpec.
-func (in *CELPolicyExceptionSpec) DeepCopy() *CELPolicyExceptionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Keyless.
+func (in *Keyless) DeepCopy() *Keyless {
 	if in == nil {
 		return nil
 	}
-	out := new(CELPolicyExceptionSpec)
+	out := new(Keyless)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExternalAPICall) DeepCopyInto(out *ExternalAPICall) {
+func (in *KubernetesResource) DeepCopyInto(out *KubernetesResource) {
 	*out = *in
-	in.APICall.DeepCopyInto(&out.APICall)
-	if in.RefreshInterval != nil {
-		in, out := &in.RefreshInterval, &out.RefreshInterval
-		*out = new(metav1.Duration)
-		**out = **in
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalAPICall.
-func (in *ExternalAPICall) DeepCopy() *ExternalAPICall {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesResource.
+func (in *KubernetesResource) DeepCopy() *KubernetesResource {
 	if in == nil {
 		return nil
 	}
-	out := new(ExternalAPICall)
+	out := new(KubernetesResource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GlobalContextEntry) DeepCopyInto(out *GlobalContextEntry) {
+func (in *MutatingPolicy) DeepCopyInto(out *MutatingPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -145,18 +873,18 @@ func (in *GlobalContextEntry) DeepCopyInto(out *GlobalContextEntry) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalContextEntry.
-func (in *GlobalContextEntry) DeepCopy() *GlobalContextEntry {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MutatingPolicy.
+func (in *MutatingPolicy) DeepCopy() *MutatingPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(GlobalContextEntry)
+	out := new(MutatingPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GlobalContextEntry) DeepCopyObject() runtime.Object {
+func (in *MutatingPolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -164,13 +892,13 @@ func (in *GlobalContextEntry) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GlobalContextEntryList) DeepCopyInto(out *GlobalContextEntryList) {
+func (in *MutatingPolicyList) DeepCopyInto(out *MutatingPolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]GlobalContextEntry, len(*in))
+		*out = make([]MutatingPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -178,18 +906,18 @@ func (in *GlobalContextEntryList) DeepCopyInto(out *GlobalContextEntryList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalContextEntryList.
-func (in *GlobalContextEntryList) DeepCopy() *GlobalContextEntryList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MutatingPolicyList.
+func (in *MutatingPolicyList) DeepCopy() *MutatingPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(GlobalContextEntryList)
+	out := new(MutatingPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GlobalContextEntryList) DeepCopyObject() runtime.Object {
+func (in *MutatingPolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -197,72 +925,86 @@ func (in *GlobalContextEntryList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GlobalContextEntrySpec) DeepCopyInto(out *GlobalContextEntrySpec) {
+func (in *MutatingPolicySpec) DeepCopyInto(out *MutatingPolicySpec) {
 	*out = *in
-	if in.KubernetesResource != nil {
-		in, out := &in.KubernetesResource, &out.KubernetesResource
-		*out = new(KubernetesResource)
+	if in.MatchConstraints != nil {
+		in, out := &in.MatchConstraints, &out.MatchConstraints
+		*out = new(v1.MatchResources)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailurePolicy != nil {
+		in, out := &in.FailurePolicy, &out.FailurePolicy
+		*out = new(v1.FailurePolicyType)
 		**out = **in
 	}
-	if in.APICall != nil {
-		in, out := &in.APICall, &out.APICall
-		*out = new(ExternalAPICall)
+	if in.MatchConditions != nil {
+		in, out := &in.MatchConditions, &out.MatchConditions
+		*out = make([]v1.MatchCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make([]v1.Variable, len(*in))
+		copy(*out, *in)
+	}
+	if in.Mutations != nil {
+		in, out := &in.Mutations, &out.Mutations
+		*out = make([]v1alpha1.Mutation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WebhookConfiguration != nil {
+		in, out := &in.WebhookConfiguration, &out.WebhookConfiguration
+		*out = new(WebhookConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalContextEntrySpec.
-func (in *GlobalContextEntrySpec) DeepCopy() *GlobalContextEntrySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MutatingPolicySpec.
+func (in *MutatingPolicySpec) DeepCopy() *MutatingPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GlobalContextEntrySpec)
+	out := new(MutatingPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GlobalContextEntryStatus) DeepCopyInto(out *GlobalContextEntryStatus) {
+func (in *Notary) DeepCopyInto(out *Notary) {
 	*out = *in
-	if in.Ready != nil {
-		in, out := &in.Ready, &out.Ready
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	in.LastRefreshTime.DeepCopyInto(&out.LastRefreshTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalContextEntryStatus.
-func (in *GlobalContextEntryStatus) DeepCopy() *GlobalContextEntryStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Notary.
+func (in *Notary) DeepCopy() *Notary {
 	if in == nil {
 		return nil
 	}
-	out := new(GlobalContextEntryStatus)
+	out := new(Notary)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubernetesResource) DeepCopyInto(out *KubernetesResource) {
+func (in *PodControllersGenerationConfiguration) DeepCopyInto(out *PodControllersGenerationConfiguration) {
 	*out = *in
+	if in.Controllers != nil {
+		in, out := &in.Controllers, &out.Controllers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesResource.
-func (in *KubernetesResource) DeepCopy() *KubernetesResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodControllersGenerationConfiguration.
+func (in *PodControllersGenerationConfiguration) DeepCopy() *PodControllersGenerationConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(KubernetesResource)
+	out := new(PodControllersGenerationConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -306,6 +1048,64 @@ func (in *PolicyStatus) DeepCopy() *PolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Referrer) DeepCopyInto(out *Referrer) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Referrer.
+func (in *Referrer) DeepCopy() *Referrer {
+	if in == nil {
+		return nil
+	}
+	out := new(Referrer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Source) DeepCopyInto(out *Source) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Source.
+func (in *Source) DeepCopy() *Source {
+	if in == nil {
+		return nil
+	}
+	out := new(Source)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TypeChecking) DeepCopyInto(out *TypeChecking) {
+	*out = *in
+	if in.ExpressionErrors != nil {
+		in, out := &in.ExpressionErrors, &out.ExpressionErrors
+		*out = make([]ExpressionMessage, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpressionWarnings != nil {
+		in, out := &in.ExpressionWarnings, &out.ExpressionWarnings
+		*out = make([]ExpressionMessage, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TypeChecking.
+func (in *TypeChecking) DeepCopy() *TypeChecking {
+	if in == nil {
+		return nil
+	}
+	out := new(TypeChecking)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValidatingPolicy) DeepCopyInto(out *ValidatingPolicy) {
 	*out = *in
@@ -334,6 +1134,72 @@ func (in *ValidatingPolicy) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidatingPolicyAutogen) DeepCopyInto(out *ValidatingPolicyAutogen) {
+	*out = *in
+	if in.MatchConstraints != nil {
+		in, out := &in.MatchConstraints, &out.MatchConstraints
+		*out = new(v1.MatchResources)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MatchConditions != nil {
+		in, out := &in.MatchConditions, &out.MatchConditions
+		*out = make([]v1.MatchCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.Validations != nil {
+		in, out := &in.Validations, &out.Validations
+		*out = make([]v1.Validation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AuditAnnotation != nil {
+		in, out := &in.AuditAnnotation, &out.AuditAnnotation
+		*out = make([]v1.AuditAnnotation, len(*in))
+		copy(*out, *in)
+	}
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make([]v1.Variable, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidatingPolicyAutogen.
+func (in *ValidatingPolicyAutogen) DeepCopy() *ValidatingPolicyAutogen {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidatingPolicyAutogen)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidatingPolicyAutogenStatus) DeepCopyInto(out *ValidatingPolicyAutogenStatus) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ValidatingPolicyAutogen, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidatingPolicyAutogenStatus.
+func (in *ValidatingPolicyAutogenStatus) DeepCopy() *ValidatingPolicyAutogenStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidatingPolicyAutogenStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValidatingPolicyList) DeepCopyInto(out *ValidatingPolicyList) {
 	*out = *in
@@ -381,6 +1247,11 @@ func (in *ValidatingPolicySpec) DeepCopyInto(out *ValidatingPolicySpec) {
 		*out = new(WebhookConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AutogenConfiguration != nil {
+		in, out := &in.AutogenConfiguration, &out.AutogenConfiguration
+		*out = new(AutogenConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -394,6 +1265,29 @@ func (in *ValidatingPolicySpec) DeepCopy() *ValidatingPolicySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidatingPolicyStatus) DeepCopyInto(out *ValidatingPolicyStatus) {
+	*out = *in
+	in.PolicyStatus.DeepCopyInto(&out.PolicyStatus)
+	if in.TypeChecking != nil {
+		in, out := &in.TypeChecking, &out.TypeChecking
+		*out = new(TypeChecking)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Autogen.DeepCopyInto(&out.Autogen)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidatingPolicyStatus.
+func (in *ValidatingPolicyStatus) DeepCopy() *ValidatingPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidatingPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookConfiguration) DeepCopyInto(out *WebhookConfiguration) {
 	*out = *in
@@ -60,8 +60,16 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&CELPolicyException{},
 		&CELPolicyExceptionList{},
+		&GeneratingPolicy{},
+		&GeneratingPolicyList{},
+		&ImageVerificationPolicy{},
+		&ImageVerificationPolicyList{},
 		&GlobalContextEntry{},
 		&GlobalContextEntryList{},
+		&ImageVerificationPolicy{},
+		&ImageVerificationPolicyList{},
+		&MutatingPolicy{},
+		&MutatingPolicyList{},
 		&ValidatingPolicy{},
 		&ValidatingPolicyList{},
 	)
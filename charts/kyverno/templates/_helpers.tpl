@@ -54,6 +54,8 @@
 {{- end -}}
 {{- with .globalContext -}}
   {{- $flags = append $flags (print "--maxAPICallResponseLength=" (int .maxApiCallResponseLength)) -}}
+  {{- $flags = append $flags (print "--globalContextSnapshots=" (default false .snapshots)) -}}
+  {{- $flags = append $flags (print "--apiCallAuthSecrets=" (default false .apiCallAuthSecrets)) -}}
 {{- end -}}
 {{- with .logging -}}
   {{- $flags = append $flags (print "--loggingFormat=" .format) -}}
@@ -9,8 +9,11 @@ import (
 	"time"
 
 	"github.com/kyverno/kyverno/cmd/internal"
+	"github.com/kyverno/kyverno/pkg/auth/checker"
 	"github.com/kyverno/kyverno/pkg/background"
 	"github.com/kyverno/kyverno/pkg/breaker"
+	httplib "github.com/kyverno/kyverno/pkg/cel/libs/http"
+	celpolicy "github.com/kyverno/kyverno/pkg/cel/policy"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
@@ -54,6 +57,7 @@ func createrLeaderControllers(
 	urGenerator generator.UpdateRequestGenerator,
 	reportsConfig reportutils.ReportingConfiguration,
 	reportsBreaker breaker.Breaker,
+	celContext celpolicy.Context,
 ) ([]internal.Controller, error) {
 	policyCtrl, err := policy.NewPolicyController(
 		kyvernoClient,
@@ -82,11 +86,15 @@ func createrLeaderControllers(
 		kyvernoInformer.Kyverno().V1().Policies(),
 		kyvernoInformer.Kyverno().V2().UpdateRequests(),
 		kubeInformer.Core().V1().Namespaces(),
+		kyvernoInformer.Kyverno().V2alpha1().GeneratingPolicies(),
+		kyvernoInformer.Kyverno().V2alpha1().CELPolicyExceptions(),
 		eventGenerator,
 		configuration,
 		jp,
 		reportsConfig,
 		reportsBreaker,
+		celContext,
+		urGenerator,
 	)
 	return []internal.Controller{
 		internal.NewController("policy-controller", policyCtrl, 2),
@@ -118,6 +126,8 @@ func main() {
 		internal.WithPolicyExceptions(),
 		internal.WithConfigMapCaching(),
 		internal.WithDeferredLoading(),
+		internal.WithGlobalContextSnapshots(),
+		internal.WithAPICallAuthSecrets(),
 		internal.WithRegistryClient(),
 		internal.WithLeaderElection(),
 		internal.WithKyvernoClient(),
@@ -169,15 +179,19 @@ func main() {
 		)
 		urGenerator := generator.NewUpdateRequestGenerator(setup.Configuration, setup.MetadataClient)
 		gcstore := store.New()
+		apiCallConfig := internal.NewAPICallConfiguration(signalCtx, setup.Logger, setup.KubeClient, maxAPICallResponseLength)
+		gctxSnapshots := internal.NewGlobalContextSnapshots(signalCtx, setup.Logger, setup.KubeClient)
 		gceController := internal.NewController(
 			globalcontextcontroller.ControllerName,
 			globalcontextcontroller.NewController(
 				kyvernoInformer.Kyverno().V2alpha1().GlobalContextEntries(),
 				setup.KyvernoDynamicClient,
+				setup.MetadataClient,
 				setup.KyvernoClient,
 				gcstore,
+				gctxSnapshots,
 				eventGenerator,
-				maxAPICallResponseLength,
+				apiCallConfig,
 				false,
 			),
 			globalcontextcontroller.Workers,
@@ -200,7 +214,7 @@ func main() {
 			setup.KubeClient,
 			setup.KyvernoClient,
 			setup.RegistrySecretLister,
-			apicall.NewAPICallConfiguration(maxAPICallResponseLength),
+			apiCallConfig,
 			polexCache,
 			gcstore,
 		)
@@ -216,6 +230,23 @@ func main() {
 			}
 			return count > maxBackgroundReports
 		})
+		contextProvider, err := celpolicy.NewContextProvider(
+			setup.KubeClient,
+			setup.ImageDataLoaderOptions,
+			gcstore,
+			celpolicy.NewResourceLoader(
+				signalCtx,
+				setup.KyvernoDynamicClient.GetDynamicInterface(),
+				checker.NewSelfChecker(setup.KubeClient.AuthorizationV1().SelfSubjectAccessReviews()),
+			),
+			httplib.NewHTTP(apicall.NewExecutor(setup.Logger.WithName("http"), "http", nil, apiCallConfig)),
+			// scans don't run on behalf of a user, the authorizer is only available in admission
+			nil,
+		)
+		if err != nil {
+			setup.Logger.Error(err, "failed to create cel context provider")
+			os.Exit(1)
+		}
 		// start informers and wait for cache sync
 		if !internal.StartInformersAndWaitForCacheSync(signalCtx, setup.Logger, kyvernoInformer) {
 			setup.Logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
@@ -250,6 +281,7 @@ func main() {
 					urGenerator,
 					setup.ReportingConfiguration,
 					reportsBreaker,
+					contextProvider,
 				)
 				if err != nil {
 					logger.Error(err, "failed to create leader controllers")
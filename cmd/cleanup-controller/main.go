@@ -108,6 +108,8 @@ func main() {
 		internal.WithEventsClient(),
 		internal.WithConfigMapCaching(),
 		internal.WithDeferredLoading(),
+		internal.WithGlobalContextSnapshots(),
+		internal.WithAPICallAuthSecrets(),
 		internal.WithMetadataClient(),
 		internal.WithApiServerClient(),
 		internal.WithFlagSets(flagset),
@@ -169,15 +171,19 @@ func main() {
 			event.Workers,
 		)
 		gcstore := store.New()
+		apiCallConfig := internal.NewAPICallConfiguration(ctx, setup.Logger, setup.KubeClient, maxAPICallResponseLength)
+		gctxSnapshots := internal.NewGlobalContextSnapshots(ctx, setup.Logger, setup.KubeClient)
 		gceController := internal.NewController(
 			globalcontextcontroller.ControllerName,
 			globalcontextcontroller.NewController(
 				kyvernoInformer.Kyverno().V2alpha1().GlobalContextEntries(),
 				setup.KyvernoDynamicClient,
+				setup.MetadataClient,
 				setup.KyvernoClient,
 				gcstore,
+				gctxSnapshots,
 				eventGenerator,
-				maxAPICallResponseLength,
+				apiCallConfig,
 				false,
 			),
 			globalcontextcontroller.Workers,
@@ -0,0 +1,24 @@
+package v1alpha1
+
+// APICallResponse declares a mocked response served to policy apiCall context entries
+type APICallResponse struct {
+	// URLPath is the Kubernetes API path of the call, it is exclusive with Service
+	URLPath string `json:"urlPath,omitempty"`
+
+	// Service is the URL of the service call, it is exclusive with URLPath
+	Service string `json:"service,omitempty"`
+
+	// Method is the request method, defaults to GET
+	Method string `json:"method,omitempty"`
+
+	// Data is the request data, when set only calls with the same data match
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Data map[string]interface{} `json:"data,omitempty"`
+
+	// Response is the mocked response
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Response interface{} `json:"response,omitempty"`
+}
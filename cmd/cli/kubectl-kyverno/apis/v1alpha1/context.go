@@ -0,0 +1,14 @@
+package v1alpha1
+
+// ImageData declares the data served to CEL context.GetImageData calls
+type ImageData struct {
+	// Image is the image reference as used in policies
+	Image string `json:"image"`
+
+	// Data is the image data, it has the same fields as the result of context.GetImageData
+	// (resolvedImage, registry, repository, tag, digest, imageIndex, manifest and config)
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Data map[string]interface{} `json:"data,omitempty"`
+}
@@ -0,0 +1,21 @@
+package v1alpha1
+
+// HTTPResponse declares a recorded response served to CEL http calls
+type HTTPResponse struct {
+	// Method is the request method, defaults to GET
+	Method string `json:"method,omitempty"`
+
+	// URL is the request url
+	URL string `json:"url"`
+
+	// Body is the request body, when set only requests with the same body match
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Body map[string]interface{} `json:"body,omitempty"`
+
+	// Response is the recorded response
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Response interface{} `json:"response,omitempty"`
+}
@@ -35,6 +35,11 @@ type TestResultBase struct {
 	// the user to compare it against the Kyverno generated resource configuration.
 	GeneratedResource string `json:"generatedResource,omitempty"`
 
+	// PartialResources makes the patched and generated resources partial,
+	// only the fields they declare are compared with the actual resources.
+	// +optional
+	PartialResources bool `json:"partialResources,omitempty"`
+
 	// CloneSourceResource takes the resource configuration file in yaml format
 	// from the user which is meant to be cloned by the generate rule.
 	CloneSourceResource string `json:"cloneSourceResource,omitempty"`
@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // ValuesSpec declares values to be loaded by the Kyverno CLI
@@ -12,6 +13,12 @@ type ValuesSpec struct {
 	// +kubebuilder:validation:Schemaless
 	GlobalValues map[string]interface{} `json:"globalValues,omitempty"`
 
+	// GlobalContextEntries are the global context entries data, keyed by entry name
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	GlobalContextEntries map[string]interface{} `json:"globalContextEntries,omitempty"`
+
 	// Policies are the policy values
 	Policies []Policy `json:"policies,omitempty"`
 
@@ -23,4 +30,21 @@ type ValuesSpec struct {
 
 	// Subresources are the subresource/parent resource mappings
 	Subresources []Subresource `json:"subresources,omitempty"`
+
+	// HTTPResponses are the recorded responses served to CEL http calls
+	HTTPResponses []HTTPResponse `json:"httpResponses,omitempty"`
+
+	// APICallResponses are the mocked responses served to policy apiCall context entries
+	APICallResponses []APICallResponse `json:"apiCallResponses,omitempty"`
+
+	// StrictAPICalls makes apiCall context entries without a mocked response fail
+	// instead of calling the cluster or being skipped
+	StrictAPICalls bool `json:"strictAPICalls,omitempty"`
+
+	// Resources are the cluster resources served to CEL context functions
+	// (context.GetConfigMap, context.GetResource and context.ListResources)
+	Resources []runtime.RawExtension `json:"resources,omitempty"`
+
+	// Images are the image data served to CEL context.GetImageData calls
+	Images []ImageData `json:"images,omitempty"`
 }
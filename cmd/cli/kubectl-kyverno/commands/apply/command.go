@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -19,29 +20,37 @@ import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/exception"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/log"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/color"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/formatter"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/processor"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/snapshot"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/userinfo"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/variables"
+	"github.com/kyverno/kyverno/pkg/auth/checker"
 	"github.com/kyverno/kyverno/pkg/autogen"
-	"github.com/kyverno/kyverno/pkg/cel/engine"
+	httplib "github.com/kyverno/kyverno/pkg/cel/libs/http"
 	celpolicy "github.com/kyverno/kyverno/pkg/cel/policy"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/apicall"
+	"github.com/kyverno/kyverno/pkg/engine/context/loaders"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/globalcontext/static"
+	gctxstore "github.com/kyverno/kyverno/pkg/globalcontext/store"
 	"github.com/kyverno/kyverno/pkg/imagedataloader"
 	gitutils "github.com/kyverno/kyverno/pkg/utils/git"
 	policyvalidation "github.com/kyverno/kyverno/pkg/validation/policy"
 	"github.com/spf13/cobra"
-	admissionv1 "k8s.io/api/admission/v1"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 )
@@ -76,6 +85,12 @@ type ApplyCommandConfig struct {
 	inlineExceptions      bool
 	GenerateExceptions    bool
 	GeneratedExceptionTTL time.Duration
+	Watch                 bool
+	Diff                  bool
+	Snapshot              string
+	clusterSnapshot       *snapshot.Snapshot
+	policyCache           *policy.Cache
+	processorCache        *processor.Cache
 }
 
 func Command() *cobra.Command {
@@ -91,53 +106,15 @@ func Command() *cobra.Command {
 			out := cmd.OutOrStdout()
 			color.Init(removeColor)
 			applyCommandConfig.PolicyPaths = args
-			rc, _, skipInvalidPolicies, responses, err := applyCommandConfig.applyCommandHelper(out)
-			if err != nil {
-				return err
-			}
-			cmd.SilenceErrors = true
-			printSkippedAndInvalidPolicies(out, skipInvalidPolicies)
-			if applyCommandConfig.PolicyReport {
-				printReports(out, responses, applyCommandConfig.AuditWarn, applyCommandConfig.OutputFormat)
-			} else if applyCommandConfig.GenerateExceptions {
-				printExceptions(out, responses, applyCommandConfig.AuditWarn, applyCommandConfig.OutputFormat, applyCommandConfig.GeneratedExceptionTTL)
-			} else if table {
-				printTable(out, detailedResults, applyCommandConfig.AuditWarn, responses...)
-			} else {
-				for _, response := range responses {
-					var failedRules []engineapi.RuleResponse
-					resPath := fmt.Sprintf("%s/%s/%s", response.Resource.GetNamespace(), response.Resource.GetKind(), response.Resource.GetName())
-					for _, rule := range response.PolicyResponse.Rules {
-						if rule.Status() == engineapi.RuleStatusFail {
-							failedRules = append(failedRules, rule)
-						}
-						if rule.RuleType() == engineapi.Mutation {
-							if rule.Status() == engineapi.RuleStatusSkip {
-								fmt.Fprintln(out, "\nskipped mutate policy", response.Policy().GetName(), "->", "resource", resPath)
-							} else if rule.Status() == engineapi.RuleStatusError {
-								fmt.Fprintln(out, "\nerror while applying mutate policy", response.Policy().GetName(), "->", "resource", resPath, "\nerror: ", rule.Message())
-							}
-						}
-					}
-					if len(failedRules) > 0 {
-						auditWarn := false
-						if applyCommandConfig.AuditWarn && response.GetValidationFailureAction().Audit() {
-							auditWarn = true
-						}
-						if auditWarn {
-							fmt.Fprintln(out, "policy", response.Policy().GetName(), "->", "resource", resPath, "failed as audit warning:")
-						} else {
-							fmt.Fprintln(out, "policy", response.Policy().GetName(), "->", "resource", resPath, "failed:")
-						}
-						for i, rule := range failedRules {
-							fmt.Fprintln(out, i+1, "-", rule.Name(), rule.Message())
-						}
-						fmt.Fprintln(out, "")
-					}
+			if applyCommandConfig.Watch {
+				if err := applyCommandConfig.checkWatchArguments(); err != nil {
+					return err
 				}
-				printViolations(out, rc)
+				return applyCommandConfig.watch(out, func() error {
+					return applyCommandConfig.run(cmd, out, detailedResults, table)
+				})
 			}
-			return exit(out, rc, applyCommandConfig.warnExitCode, applyCommandConfig.warnNoPassed)
+			return applyCommandConfig.run(cmd, out, detailedResults, table)
 		},
 	}
 	cmd.Flags().StringSliceVarP(&applyCommandConfig.ResourcePaths, "resource", "r", []string{}, "Path to resource files")
@@ -151,7 +128,7 @@ func Command() *cobra.Command {
 	cmd.Flags().StringSliceVarP(&applyCommandConfig.Variables, "set", "s", nil, "Variables that are required")
 	cmd.Flags().StringVarP(&applyCommandConfig.ValuesFile, "values-file", "f", "", "File containing values for policy variables")
 	cmd.Flags().BoolVarP(&applyCommandConfig.PolicyReport, "policy-report", "p", false, "Generates policy report when passed (default policyviolation)")
-	cmd.Flags().StringVarP(&applyCommandConfig.OutputFormat, "output-format", "", "yaml", "Specifies the policy report format (json or yaml). Default: yaml.")
+	cmd.Flags().StringVarP(&applyCommandConfig.OutputFormat, "output-format", "", "yaml", "Specifies the policy report format (json or yaml), or the results format (junit or sarif). Default: yaml.")
 	cmd.Flags().StringVarP(&applyCommandConfig.Namespace, "namespace", "n", "", "Optional Policy parameter passed with cluster flag")
 	cmd.Flags().BoolVarP(&applyCommandConfig.Stdin, "stdin", "i", false, "Optional mutate policy parameter to pipe directly through to kubectl")
 	cmd.Flags().BoolVar(&applyCommandConfig.RegistryAccess, "registry", false, "If set to true, access the image registry using local docker credentials to populate external data")
@@ -170,9 +147,77 @@ func Command() *cobra.Command {
 	cmd.Flags().BoolVarP(&applyCommandConfig.inlineExceptions, "exceptions-with-resources", "", false, "Evaluate policy exceptions from the resources path")
 	cmd.Flags().BoolVarP(&applyCommandConfig.GenerateExceptions, "generate-exceptions", "", false, "Generate policy exceptions for each violation")
 	cmd.Flags().DurationVarP(&applyCommandConfig.GeneratedExceptionTTL, "generated-exception-ttl", "", time.Hour*24*30, "Default TTL for generated exceptions")
+	cmd.Flags().BoolVar(&applyCommandConfig.Diff, "diff", false, "If set to true, compare the results of the policies with the results of their version installed in the cluster, requires --cluster")
+	cmd.Flags().StringVar(&applyCommandConfig.Snapshot, "snapshot", "", "Path to a cluster snapshot archive, policies are applied to the snapshot instead of a live cluster")
+	cmd.Flags().BoolVar(&applyCommandConfig.Watch, "watch", false, "If set to true, watch the policy, resource, exception and values files and apply the policies again when they change")
 	return cmd
 }
 
+func (c *ApplyCommandConfig) run(cmd *cobra.Command, out io.Writer, detailedResults, table bool) error {
+	if c.Diff {
+		return c.diff(cmd, out)
+	}
+	// machine readable formats are printed alone, progress messages are discarded
+	if f, ok := formatter.Get(c.OutputFormat); ok {
+		rc, _, _, responses, err := c.applyCommandHelper(io.Discard)
+		if err != nil {
+			return err
+		}
+		cmd.SilenceErrors = true
+		if err := printFormatted(out, f, c.AuditWarn, responses...); err != nil {
+			return err
+		}
+		return exit(io.Discard, rc, c.warnExitCode, c.warnNoPassed)
+	}
+	rc, _, skipInvalidPolicies, responses, err := c.applyCommandHelper(out)
+	if err != nil {
+		return err
+	}
+	cmd.SilenceErrors = true
+	printSkippedAndInvalidPolicies(out, skipInvalidPolicies)
+	if c.PolicyReport {
+		printReports(out, responses, c.AuditWarn, c.OutputFormat)
+	} else if c.GenerateExceptions {
+		printExceptions(out, responses, c.AuditWarn, c.OutputFormat, c.GeneratedExceptionTTL)
+	} else if table {
+		printTable(out, detailedResults, c.AuditWarn, responses...)
+	} else {
+		for _, response := range responses {
+			var failedRules []engineapi.RuleResponse
+			resPath := fmt.Sprintf("%s/%s/%s", response.Resource.GetNamespace(), response.Resource.GetKind(), response.Resource.GetName())
+			for _, rule := range response.PolicyResponse.Rules {
+				if rule.Status() == engineapi.RuleStatusFail {
+					failedRules = append(failedRules, rule)
+				}
+				if rule.RuleType() == engineapi.Mutation {
+					if rule.Status() == engineapi.RuleStatusSkip {
+						fmt.Fprintln(out, "\nskipped mutate policy", response.Policy().GetName(), "->", "resource", resPath)
+					} else if rule.Status() == engineapi.RuleStatusError {
+						fmt.Fprintln(out, "\nerror while applying mutate policy", response.Policy().GetName(), "->", "resource", resPath, "\nerror: ", rule.Message())
+					}
+				}
+			}
+			if len(failedRules) > 0 {
+				auditWarn := false
+				if c.AuditWarn && response.GetValidationFailureAction().Audit() {
+					auditWarn = true
+				}
+				if auditWarn {
+					fmt.Fprintln(out, "policy", response.Policy().GetName(), "->", "resource", resPath, "failed as audit warning:")
+				} else {
+					fmt.Fprintln(out, "policy", response.Policy().GetName(), "->", "resource", resPath, "failed:")
+				}
+				for i, rule := range failedRules {
+					fmt.Fprintln(out, i+1, "-", rule.Name(), rule.Message())
+				}
+				fmt.Fprintln(out, "")
+			}
+		}
+		printViolations(out, rc)
+	}
+	return exit(out, rc, c.warnExitCode, c.warnNoPassed)
+}
+
 func (c *ApplyCommandConfig) applyCommandHelper(out io.Writer) (*processor.ResultCounts, []*unstructured.Unstructured, SkippedInvalidPolicies, []engineapi.EngineResponse, error) {
 	var skippedInvalidPolicies SkippedInvalidPolicies
 	err := c.checkArguments()
@@ -186,21 +231,16 @@ func (c *ApplyCommandConfig) applyCommandHelper(out io.Writer) (*processor.Resul
 	if err := c.cleanPreviousContent(mutateLogPathIsDir); err != nil {
 		return nil, nil, skippedInvalidPolicies, nil, err
 	}
-	var userInfo *kyvernov2.RequestInfo
-	if c.UserInfoPath != "" {
-		info, err := userinfo.Load(nil, c.UserInfoPath, "")
-		if err != nil {
-			return nil, nil, skippedInvalidPolicies, nil, fmt.Errorf("failed to load request info (%w)", err)
-		}
-		deprecations.CheckUserInfo(out, c.UserInfoPath, info)
-		userInfo = &info.RequestInfo
+	userInfo, err := c.loadUserInfo(out)
+	if err != nil {
+		return nil, nil, skippedInvalidPolicies, nil, err
 	}
 	variables, err := variables.New(out, nil, "", c.ValuesFile, nil, c.Variables...)
 	if err != nil {
 		return nil, nil, skippedInvalidPolicies, nil, fmt.Errorf("failed to decode yaml (%w)", err)
 	}
 	var store store.Store
-	policies, vaps, vapBindings, vps, err := c.loadPolicies()
+	policies, vaps, vapBindings, vps, mps, err := c.loadPolicies()
 	if err != nil {
 		return nil, nil, skippedInvalidPolicies, nil, err
 	}
@@ -215,18 +255,18 @@ func (c *ApplyCommandConfig) applyCommandHelper(out io.Writer) (*processor.Resul
 	if err != nil {
 		return nil, nil, skippedInvalidPolicies, nil, err
 	}
+	if c.clusterSnapshot != nil {
+		if err := c.clusterSnapshot.ResolveRoles(userInfo); err != nil {
+			return nil, nil, skippedInvalidPolicies, nil, err
+		}
+	}
 	resources, err := c.loadResources(out, c.ResourcePaths, policies, vaps, dClient)
 	if err != nil {
 		return nil, nil, skippedInvalidPolicies, nil, err
 	}
-	var exceptions []*kyvernov2.PolicyException
-	if c.inlineExceptions {
-		exceptions = exception.SelectFrom(resources)
-	} else {
-		exceptions, err = exception.Load(c.Exception...)
-		if err != nil {
-			return nil, nil, skippedInvalidPolicies, nil, fmt.Errorf("Error: failed to load exceptions (%s)", err)
-		}
+	exceptions, celExceptions, err := c.loadExceptions(resources)
+	if err != nil {
+		return nil, nil, skippedInvalidPolicies, nil, err
 	}
 	if !c.Stdin && !c.PolicyReport && !c.GenerateExceptions {
 		var policyRulesCount int
@@ -237,40 +277,153 @@ func (c *ApplyCommandConfig) applyCommandHelper(out io.Writer) (*processor.Resul
 		policyRulesCount += len(vaps)
 		// account for vps
 		policyRulesCount += len(vps)
-		if len(exceptions) > 0 {
-			fmt.Fprintf(out, "\nApplying %d policy rule(s) to %d resource(s) with %d exception(s)...\n", policyRulesCount, len(resources), len(exceptions))
+		// account for mps
+		policyRulesCount += len(mps)
+		if exceptionsCount := len(exceptions) + len(celExceptions); exceptionsCount > 0 {
+			fmt.Fprintf(out, "\nApplying %d policy rule(s) to %d resource(s) with %d exception(s)...\n", policyRulesCount, len(resources), exceptionsCount)
 		} else {
 			fmt.Fprintf(out, "\nApplying %d policy rule(s) to %d resource(s)...\n", policyRulesCount, len(resources))
 		}
 	}
+	set := policySet{
+		policies:    policies,
+		vaps:        vaps,
+		vapBindings: vapBindings,
+		vps:         vps,
+		mps:         mps,
+	}
+	rc, resources1, responses, err := c.evaluate(out, &store, variables, set, resources, targetResources, exceptions, celExceptions, &skippedInvalidPolicies, dClient, userInfo, mutateLogPathIsDir)
+	return rc, resources1, skippedInvalidPolicies, responses, err
+}
+
+// policySet holds the policies evaluated by the command
+type policySet struct {
+	policies    []kyvernov1.PolicyInterface
+	vaps        []admissionregistrationv1.ValidatingAdmissionPolicy
+	vapBindings []admissionregistrationv1.ValidatingAdmissionPolicyBinding
+	vps         []kyvernov2alpha1.ValidatingPolicy
+	mps         []kyvernov2alpha1.MutatingPolicy
+}
+
+// evaluate applies a set of policies to the resources, on error the responses collected so far are returned
+func (c *ApplyCommandConfig) evaluate(
+	out io.Writer,
+	store *store.Store,
+	variables *variables.Variables,
+	set policySet,
+	resources []*unstructured.Unstructured,
+	targetResources []*unstructured.Unstructured,
+	exceptions []*kyvernov2.PolicyException,
+	celExceptions []*kyvernov2alpha1.CELPolicyException,
+	skippedInvalidPolicies *SkippedInvalidPolicies,
+	dClient dclient.Interface,
+	userInfo *kyvernov2.RequestInfo,
+	mutateLogPathIsDir bool,
+) (*processor.ResultCounts, []*unstructured.Unstructured, []engineapi.EngineResponse, error) {
+	// global context entries are not fetched from the cluster, their data comes from the snapshot and the values file
+	gctxStore := gctxstore.New()
+	if c.clusterSnapshot != nil {
+		entries, err := c.clusterSnapshot.GlobalContextEntries(jmespath.New(config.NewDefaultConfiguration(false)))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		for name, entry := range entries {
+			gctxStore.Set(name, entry)
+		}
+	}
+	for name, data := range variables.GlobalContextEntries() {
+		gctxStore.Set(name, static.New(data))
+	}
 	rc, resources1, responses1, err := c.applyPolicies(
 		out,
-		&store,
+		store,
 		variables,
-		policies,
+		set.policies,
 		resources,
 		exceptions,
-		&skippedInvalidPolicies,
+		skippedInvalidPolicies,
 		dClient,
+		gctxStore,
 		userInfo,
 		mutateLogPathIsDir,
 	)
 	if err != nil {
-		return rc, resources1, skippedInvalidPolicies, responses1, err
+		return rc, resources1, responses1, err
+	}
+	responses2, err := c.applyValidatingAdmissionPolicies(set.vaps, set.vapBindings, resources1, c.namespaceSelectors(variables), rc, dClient)
+	if err != nil {
+		return rc, resources1, responses1, err
+	}
+	contextResources := append(targetResources, resources1...)
+	if c.clusterSnapshot != nil {
+		for i := range c.clusterSnapshot.Objects {
+			contextResources = append(contextResources, &c.clusterSnapshot.Objects[i])
+		}
+	}
+	contextProvider, err := c.newContextProvider(dClient, gctxStore, variables.HTTPStub(), userInfo, contextResources)
+	if err != nil {
+		return rc, resources1, responses1, err
 	}
-	responses2, err := c.applyValidatingAdmissionPolicies(vaps, vapBindings, resources1, variables.NamespaceSelectors(), rc, dClient)
+	responses3, err := c.applyValidatingPolicies(set.vps, celExceptions, resources1, c.namespaceProvider(variables), rc, contextProvider, userInfo)
 	if err != nil {
-		return rc, resources1, skippedInvalidPolicies, responses1, err
+		return rc, resources1, responses1, err
 	}
-	responses3, err := c.applyValidatingPolicies(vps, resources1, variables.Namespace, rc, dClient)
+	responses4, err := c.applyMutatingPolicies(out, set.mps, resources1, c.namespaceSelectors(variables), rc, contextProvider, userInfo, mutateLogPathIsDir)
 	if err != nil {
-		return rc, resources1, skippedInvalidPolicies, responses1, err
+		return rc, resources1, responses1, err
 	}
 	var responses []engineapi.EngineResponse
 	responses = append(responses, responses1...)
 	responses = append(responses, responses2...)
 	responses = append(responses, responses3...)
-	return rc, resources1, skippedInvalidPolicies, responses, nil
+	responses = append(responses, responses4...)
+	return rc, resources1, responses, nil
+}
+
+// namespaceSelectors returns the labels of the namespaces declared in the values, completed with the snapshot namespaces
+func (c *ApplyCommandConfig) namespaceSelectors(vars *variables.Variables) map[string]map[string]string {
+	if c.clusterSnapshot == nil {
+		return vars.NamespaceSelectors()
+	}
+	labels := c.clusterSnapshot.NamespaceLabels()
+	maps.Copy(labels, vars.NamespaceSelectors())
+	return labels
+}
+
+// namespaceProvider returns the namespaces declared in the values, completed with the snapshot namespaces
+func (c *ApplyCommandConfig) namespaceProvider(vars *variables.Variables) func(string) *corev1.Namespace {
+	if c.clusterSnapshot == nil {
+		return vars.Namespace
+	}
+	return func(name string) *corev1.Namespace {
+		if namespace := vars.Namespace(name); namespace != nil {
+			return namespace
+		}
+		return c.clusterSnapshot.Namespace(name)
+	}
+}
+
+func (c *ApplyCommandConfig) loadUserInfo(out io.Writer) (*kyvernov2.RequestInfo, error) {
+	if c.UserInfoPath == "" {
+		return nil, nil
+	}
+	info, err := userinfo.Load(nil, c.UserInfoPath, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load request info (%w)", err)
+	}
+	deprecations.CheckUserInfo(out, c.UserInfoPath, info)
+	return &info.RequestInfo, nil
+}
+
+func (c *ApplyCommandConfig) loadExceptions(resources []*unstructured.Unstructured) ([]*kyvernov2.PolicyException, []*kyvernov2alpha1.CELPolicyException, error) {
+	if c.inlineExceptions {
+		return exception.SelectFrom(resources), nil, nil
+	}
+	results, err := exception.Load(c.Exception...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error: failed to load exceptions (%s)", err)
+	}
+	return results.Exceptions, results.CELExceptions, nil
 }
 
 func (c *ApplyCommandConfig) getMutateLogPathIsDir() (bool, error) {
@@ -315,47 +468,29 @@ func (c *ApplyCommandConfig) applyValidatingAdmissionPolicies(
 
 func (c *ApplyCommandConfig) applyValidatingPolicies(
 	vps []kyvernov2alpha1.ValidatingPolicy,
+	exceptions []*kyvernov2alpha1.CELPolicyException,
 	resources []*unstructured.Unstructured,
 	namespaceProvider func(string) *corev1.Namespace,
-	_ *processor.ResultCounts,
-	dclient dclient.Interface,
+	rc *processor.ResultCounts,
+	contextProvider celpolicy.Context,
+	userInfo *kyvernov2.RequestInfo,
 ) ([]engineapi.EngineResponse, error) {
-	ctx := context.TODO()
-	compiler := celpolicy.NewCompiler()
-	provider, err := engine.NewProvider(compiler, vps...)
-	if err != nil {
-		return nil, err
+	if len(vps) == 0 {
+		return nil, nil
 	}
-	eng := engine.NewEngine(provider, namespaceProvider, nil)
-	// TODO: mock when no cluster provided
-	var contextProvider celpolicy.Context
-	if dclient != nil {
-		contextProvider, err = celpolicy.NewContextProvider(
-			dclient.GetKubeClient(),
-			[]imagedataloader.Option{imagedataloader.WithLocalCredentials(c.RegistryAccess)},
-		)
-		if err != nil {
-			return nil, err
-		}
+	var responses []engineapi.EngineResponse
+	processor := processor.ValidatingPolicyProcessor{
+		Policies:          vps,
+		Exceptions:        exceptions,
+		NamespaceProvider: namespaceProvider,
+		Context:           contextProvider,
+		UserInfo:          userInfo,
+		Rc:                rc,
+		Cache:             c.processorCache,
 	}
-	responses := make([]engineapi.EngineResponse, 0)
 	for _, resource := range resources {
-		request := engine.Request(
-			contextProvider,
-			resource.GroupVersionKind(),
-			// TODO
-			schema.GroupVersionResource{},
-			// TODO
-			"",
-			resource.GetName(),
-			resource.GetNamespace(),
-			admissionv1.Create,
-			resource,
-			nil,
-			false,
-			nil,
-		)
-		response, err := eng.Handle(ctx, request)
+		processor.Resource = resource
+		ers, err := processor.ApplyPolicyOnResource()
 		if err != nil {
 			if c.ContinueOnFail {
 				fmt.Printf("failed to apply validating policies on resource %s (%v)\n", resource.GetName(), err)
@@ -363,17 +498,88 @@ func (c *ApplyCommandConfig) applyValidatingPolicies(
 			}
 			return responses, fmt.Errorf("failed to apply validating policies on resource %s (%w)", resource.GetName(), err)
 		}
-		// transform response into legacy engine responses
-		for _, r := range response.Policies {
-			engineResponse := engineapi.EngineResponse{
-				Resource: *response.Resource,
-				PolicyResponse: engineapi.PolicyResponse{
-					Rules: r.Rules,
-				},
+		responses = append(responses, ers...)
+	}
+	return responses, nil
+}
+
+func (c *ApplyCommandConfig) newContextProvider(
+	dclient dclient.Interface,
+	gctxStore celpolicy.GlobalContextStore,
+	http httplib.HTTPInterface,
+	userInfo *kyvernov2.RequestInfo,
+	resources []*unstructured.Unstructured,
+) (celpolicy.Context, error) {
+	var client kubernetes.Interface
+	if dclient != nil {
+		client = dclient.GetKubeClient()
+	}
+	var loader celpolicy.ResourceLoader
+	var authz authorizer.Authorizer
+	if c.Cluster && client != nil {
+		loader = celpolicy.NewDirectResourceLoader(
+			dclient.GetDynamicInterface(),
+			checker.NewSelfChecker(client.AuthorizationV1().SelfSubjectAccessReviews()),
+		)
+		authz = checker.NewAuthorizer(client.AuthorizationV1().SubjectAccessReviews())
+	} else {
+		// without a cluster, lookups are served from the resources passed to the command
+		// and authorization decisions are derived from the user info
+		loader = celpolicy.NewFakeResourceLoader(resources...)
+		authz = userinfo.NewAuthorizer(userInfo)
+	}
+	// recorded responses take precedence over real calls
+	if http == nil {
+		http = httplib.NewHTTP(apicall.NewExecutor(log.Log.WithName("http"), "http", nil, apicall.NewAPICallConfiguration(0)))
+	}
+	return celpolicy.NewContextProvider(
+		client,
+		[]imagedataloader.Option{imagedataloader.WithLocalCredentials(c.RegistryAccess)},
+		gctxStore,
+		loader,
+		http,
+		authz,
+	)
+}
+
+func (c *ApplyCommandConfig) applyMutatingPolicies(
+	out io.Writer,
+	mps []kyvernov2alpha1.MutatingPolicy,
+	resources []*unstructured.Unstructured,
+	namespaceSelectorMap map[string]map[string]string,
+	rc *processor.ResultCounts,
+	contextProvider celpolicy.Context,
+	userInfo *kyvernov2.RequestInfo,
+	mutateLogPathIsDir bool,
+) ([]engineapi.EngineResponse, error) {
+	if len(mps) == 0 {
+		return nil, nil
+	}
+	var responses []engineapi.EngineResponse
+	for _, resource := range resources {
+		processor := processor.MutatingPolicyProcessor{
+			Policies:             mps,
+			Resource:             resource,
+			NamespaceSelectorMap: namespaceSelectorMap,
+			Context:              contextProvider,
+			UserInfo:             userInfo,
+			MutateLogPath:        c.MutateLogPath,
+			MutateLogPathIsDir:   mutateLogPathIsDir,
+			Stdin:                c.Stdin,
+			PrintPatchResource:   true,
+			Rc:                   rc,
+			Out:                  out,
+			Cache:                c.processorCache,
+		}
+		ers, err := processor.ApplyPolicyOnResource()
+		if err != nil {
+			if c.ContinueOnFail {
+				fmt.Printf("failed to apply mutating policies on resource %s (%v)\n", resource.GetName(), err)
+				continue
 			}
-			engineResponse = engineResponse.WithPolicy(engineapi.NewValidatingPolicy(&r.Policy))
-			responses = append(responses, engineResponse)
+			return responses, fmt.Errorf("failed to apply mutating policies on resource %s (%w)", resource.GetName(), err)
 		}
+		responses = append(responses, ers...)
 	}
 	return responses, nil
 }
@@ -387,6 +593,7 @@ func (c *ApplyCommandConfig) applyPolicies(
 	exceptions []*kyvernov2.PolicyException,
 	skipInvalidPolicies *SkippedInvalidPolicies,
 	dClient dclient.Interface,
+	gctxStore loaders.Store,
 	userInfo *kyvernov2.RequestInfo,
 	mutateLogPathIsDir bool,
 ) (*processor.ResultCounts, []*unstructured.Unstructured, []engineapi.EngineResponse, error) {
@@ -412,6 +619,13 @@ func (c *ApplyCommandConfig) applyPolicies(
 		}
 		validPolicies = append(validPolicies, pol)
 	}
+	// config maps and global context entries are only served from a snapshot
+	var cmResolver engineapi.ConfigmapResolver
+	if c.clusterSnapshot != nil {
+		cmResolver = c.clusterSnapshot.ConfigmapResolver()
+	} else {
+		gctxStore = nil
+	}
 	var responses []engineapi.EngineResponse
 	for _, resource := range resources {
 		processor := processor.PolicyProcessor{
@@ -424,15 +638,18 @@ func (c *ApplyCommandConfig) applyPolicies(
 			Variables:            vars,
 			UserInfo:             userInfo,
 			PolicyReport:         c.PolicyReport,
-			NamespaceSelectorMap: vars.NamespaceSelectors(),
+			NamespaceSelectorMap: c.namespaceSelectors(vars),
 			Stdin:                c.Stdin,
 			Rc:                   &rc,
 			PrintPatchResource:   true,
-			Cluster:              c.Cluster,
+			Cluster:              c.clusterMode(),
 			Client:               dClient,
+			ConfigmapResolver:    cmResolver,
+			GlobalContextStore:   gctxStore,
 			AuditWarn:            c.AuditWarn,
 			Subresources:         vars.Subresources(),
 			Out:                  out,
+			Cache:                c.processorCache,
 		}
 		ers, err := processor.ApplyPoliciesOnResource()
 		if err != nil {
@@ -453,7 +670,7 @@ func (c *ApplyCommandConfig) applyPolicies(
 }
 
 func (c *ApplyCommandConfig) loadResources(out io.Writer, paths []string, policies []kyvernov1.PolicyInterface, vap []admissionregistrationv1.ValidatingAdmissionPolicy, dClient dclient.Interface) ([]*unstructured.Unstructured, error) {
-	resources, err := common.GetResourceAccordingToResourcePath(out, nil, paths, c.Cluster, policies, vap, dClient, c.Namespace, c.PolicyReport, "")
+	resources, err := common.GetResourceAccordingToResourcePath(out, nil, paths, c.clusterMode(), policies, vap, dClient, c.Namespace, c.PolicyReport, "")
 	if err != nil {
 		return resources, fmt.Errorf("failed to load resources (%w)", err)
 	}
@@ -465,6 +682,7 @@ func (c *ApplyCommandConfig) loadPolicies() (
 	[]admissionregistrationv1.ValidatingAdmissionPolicy,
 	[]admissionregistrationv1.ValidatingAdmissionPolicyBinding,
 	[]kyvernov2alpha1.ValidatingPolicy,
+	[]kyvernov2alpha1.MutatingPolicy,
 	error,
 ) {
 	// load policies
@@ -472,18 +690,19 @@ func (c *ApplyCommandConfig) loadPolicies() (
 	var vaps []admissionregistrationv1.ValidatingAdmissionPolicy
 	var vapBindings []admissionregistrationv1.ValidatingAdmissionPolicyBinding
 	var vps []kyvernov2alpha1.ValidatingPolicy
+	var mps []kyvernov2alpha1.MutatingPolicy
 
 	for _, path := range c.PolicyPaths {
 		isGit := source.IsGit(path)
 		if isGit {
 			gitSourceURL, err := url.Parse(path)
 			if err != nil {
-				return nil, nil, nil, nil, fmt.Errorf("failed to load policies (%w)", err)
+				return nil, nil, nil, nil, nil, fmt.Errorf("failed to load policies (%w)", err)
 			}
 			pathElems := strings.Split(gitSourceURL.Path[1:], "/")
 			if len(pathElems) <= 1 {
 				err := fmt.Errorf("invalid URL path %s - expected https://<any_git_source_domain>/:owner/:repository/:branch (without --git-branch flag) OR https://<any_git_source_domain>/:owner/:repository/:directory (with --git-branch flag)", gitSourceURL.Path)
-				return nil, nil, nil, nil, fmt.Errorf("failed to parse URL (%w)", err)
+				return nil, nil, nil, nil, nil, fmt.Errorf("failed to parse URL (%w)", err)
 			}
 			gitSourceURL.Path = strings.Join([]string{pathElems[0], pathElems[1]}, "/")
 			repoURL := gitSourceURL.String()
@@ -492,11 +711,11 @@ func (c *ApplyCommandConfig) loadPolicies() (
 			fs := memfs.New()
 			if _, err := gitutils.Clone(repoURL, fs, c.GitBranch); err != nil {
 				log.Log.V(3).Info(fmt.Sprintf("failed to clone repository  %v as it is not valid", repoURL), "error", err)
-				return nil, nil, nil, nil, fmt.Errorf("failed to clone repository (%w)", err)
+				return nil, nil, nil, nil, nil, fmt.Errorf("failed to clone repository (%w)", err)
 			}
 			policyYamls, err := gitutils.ListYamls(fs, gitPathToYamls)
 			if err != nil {
-				return nil, nil, nil, nil, fmt.Errorf("failed to list YAMLs in repository (%w)", err)
+				return nil, nil, nil, nil, nil, fmt.Errorf("failed to list YAMLs in repository (%w)", err)
 			}
 			for _, policyYaml := range policyYamls {
 				loaderResults, err := policy.Load(fs, "", policyYaml)
@@ -512,9 +731,10 @@ func (c *ApplyCommandConfig) loadPolicies() (
 				vaps = append(vaps, loaderResults.VAPs...)
 				vapBindings = append(vapBindings, loaderResults.VAPBindings...)
 				vps = append(vps, loaderResults.ValidatingPolicies...)
+				mps = append(mps, loaderResults.MutatingPolicies...)
 			}
 		} else {
-			loaderResults, err := policy.Load(nil, "", path)
+			loaderResults, err := c.policyCache.Load(nil, "", path)
 			if loaderResults != nil && loaderResults.NonFatalErrors != nil {
 				for _, err := range loaderResults.NonFatalErrors {
 					log.Log.Error(err.Error, "Non-fatal parsing error for single document")
@@ -527,6 +747,7 @@ func (c *ApplyCommandConfig) loadPolicies() (
 				vaps = append(vaps, loaderResults.VAPs...)
 				vapBindings = append(vapBindings, loaderResults.VAPBindings...)
 				vps = append(vps, loaderResults.ValidatingPolicies...)
+				mps = append(mps, loaderResults.MutatingPolicies...)
 			}
 		}
 		for _, policy := range policies {
@@ -535,7 +756,7 @@ func (c *ApplyCommandConfig) loadPolicies() (
 			}
 		}
 	}
-	return policies, vaps, vapBindings, vps, nil
+	return policies, vaps, vapBindings, vps, mps, nil
 }
 
 func (c *ApplyCommandConfig) initStoreAndClusterClient(store *store.Store, targetResources ...*unstructured.Unstructured) (dclient.Interface, error) {
@@ -564,6 +785,15 @@ func (c *ApplyCommandConfig) initStoreAndClusterClient(store *store.Store, targe
 			return nil, err
 		}
 	}
+	if c.Snapshot != "" {
+		c.clusterSnapshot, err = snapshot.Load(c.Snapshot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load snapshot (%w)", err)
+		}
+		// api calls are served from the snapshot objects
+		store.AllowApiCall(true)
+		return c.clusterSnapshot.Client(targetResources...)
+	}
 	if len(targetResources) > 0 && !c.Cluster {
 		var targets []runtime.Object
 		for _, t := range targetResources {
@@ -602,12 +832,20 @@ func (c *ApplyCommandConfig) checkArguments() error {
 	if (len(c.PolicyPaths) > 0 && c.PolicyPaths[0] == "-") && len(c.ResourcePaths) > 0 && c.ResourcePaths[0] == "-" {
 		return fmt.Errorf("a stdin pipe can be used for either policies or resources, not both")
 	}
-	if len(c.ResourcePaths) == 0 && !c.Cluster {
-		return fmt.Errorf("resource file(s) or cluster required")
+	if c.Cluster && c.Snapshot != "" {
+		return fmt.Errorf("--cluster and --snapshot can't be used together")
+	}
+	if len(c.ResourcePaths) == 0 && !c.clusterMode() {
+		return fmt.Errorf("resource file(s), cluster or snapshot required")
 	}
 	return nil
 }
 
+// clusterMode returns true when resources are fetched from a live cluster or a snapshot
+func (c *ApplyCommandConfig) clusterMode() bool {
+	return c.Cluster || c.Snapshot != ""
+}
+
 type WarnExitCodeError struct {
 	ExitCode int
 }
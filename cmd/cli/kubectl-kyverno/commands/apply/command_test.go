@@ -12,6 +12,9 @@ import (
 
 	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/report"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/snapshot"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -537,6 +540,70 @@ func Test_Apply_ValidatingPolicies(t *testing.T) {
 				},
 			}},
 		},
+		{
+			config: ApplyCommandConfig{
+				PolicyPaths:   []string{"../../../../../test/cli/test-validating-policy/disallow-host-path/policy.yaml"},
+				ResourcePaths: []string{"../../../../../test/cli/test-validating-policy/disallow-host-path/deployment1.yaml"},
+				PolicyReport:  true,
+			},
+			expectedPolicyReports: []policyreportv1alpha2.PolicyReport{{
+				Summary: policyreportv1alpha2.PolicyReportSummary{
+					Pass:  1,
+					Fail:  0,
+					Skip:  0,
+					Error: 0,
+					Warn:  0,
+				},
+			}},
+		},
+		{
+			config: ApplyCommandConfig{
+				PolicyPaths:   []string{"../../../../../test/cli/test-validating-policy/disallow-host-path/policy.yaml"},
+				ResourcePaths: []string{"../../../../../test/cli/test-validating-policy/disallow-host-path/deployment2.yaml"},
+				PolicyReport:  true,
+			},
+			expectedPolicyReports: []policyreportv1alpha2.PolicyReport{{
+				Summary: policyreportv1alpha2.PolicyReportSummary{
+					Pass:  0,
+					Fail:  1,
+					Skip:  0,
+					Error: 0,
+					Warn:  0,
+				},
+			}},
+		},
+		{
+			config: ApplyCommandConfig{
+				PolicyPaths:   []string{"../../../../../test/cli/test-validating-policy/disallow-host-path/policy.yaml"},
+				ResourcePaths: []string{"../../../../../test/cli/test-validating-policy/disallow-host-path/cronjob1.yaml"},
+				PolicyReport:  true,
+			},
+			expectedPolicyReports: []policyreportv1alpha2.PolicyReport{{
+				Summary: policyreportv1alpha2.PolicyReportSummary{
+					Pass:  0,
+					Fail:  1,
+					Skip:  0,
+					Error: 0,
+					Warn:  0,
+				},
+			}},
+		},
+		{
+			config: ApplyCommandConfig{
+				PolicyPaths:   []string{"../../../../../test/cli/test-validating-policy/disallow-host-path/policy-no-autogen.yaml"},
+				ResourcePaths: []string{"../../../../../test/cli/test-validating-policy/disallow-host-path/pod2.yaml"},
+				PolicyReport:  true,
+			},
+			expectedPolicyReports: []policyreportv1alpha2.PolicyReport{{
+				Summary: policyreportv1alpha2.PolicyReportSummary{
+					Pass:  0,
+					Fail:  1,
+					Skip:  0,
+					Error: 0,
+					Warn:  0,
+				},
+			}},
+		},
 	}
 
 	compareSummary := func(expected policyreportv1alpha2.PolicyReportSummary, actual policyreportv1alpha2.PolicyReportSummary, desc string) {
@@ -582,6 +649,62 @@ func Test_Apply_ValidatingPolicies(t *testing.T) {
 	}
 }
 
+func Test_Apply_MutatingPolicies(t *testing.T) {
+	config := ApplyCommandConfig{
+		PolicyPaths:   []string{"../../../../../test/cli/test-mutating-policy/add-labels/policy.yaml"},
+		ResourcePaths: []string{"../../../../../test/cli/test-mutating-policy/add-labels/resource.yaml"},
+		PolicyReport:  true,
+	}
+	rc, _, _, responses, err := config.applyCommandHelper(io.Discard)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, rc.Pass)
+	assert.Equal(t, 0, rc.Error)
+	assert.Equal(t, 1, len(responses))
+	labels := responses[0].PatchedResource.GetLabels()
+	assert.Equal(t, "platform", labels["team"])
+	assert.Equal(t, "prod", labels["env"])
+}
+
+func Test_Apply_Snapshot(t *testing.T) {
+	config := ApplyCommandConfig{
+		PolicyPaths: []string{"../../_testdata/snapshot/policy.yaml"},
+		Snapshot:    saveSnapshot(t, "../../_testdata/snapshot/cluster.yaml"),
+	}
+	rc, resources, _, responses, err := config.applyCommandHelper(io.Discard)
+	assert.NoError(t, err)
+	// pods, namespace labels, config maps and services are served from the snapshot
+	assert.Len(t, resources, 3)
+	assert.Equal(t, 4, rc.Pass)
+	assert.Equal(t, 1, rc.Fail)
+	assert.Equal(t, 0, rc.Error)
+	for _, response := range responses {
+		for _, rule := range response.PolicyResponse.Rules {
+			if rule.Status() == engineapi.RuleStatusFail {
+				assert.Equal(t, "bad", response.Resource.GetName())
+				assert.Equal(t, "image must be nginx:1.27", rule.Message())
+			}
+		}
+	}
+	config.Cluster = true
+	_, _, _, _, err = config.applyCommandHelper(io.Discard)
+	assert.EqualError(t, err, "--cluster and --snapshot can't be used together")
+}
+
+// saveSnapshot saves the objects of a file as a snapshot archive and returns its path
+func saveSnapshot(t *testing.T, path string) string {
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	objects, err := resource.GetUnstructuredResources(data)
+	assert.NoError(t, err)
+	snap := &snapshot.Snapshot{Metadata: snapshot.Metadata{Version: snapshot.Version}}
+	for _, object := range objects {
+		snap.Objects = append(snap.Objects, *object)
+	}
+	archive := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+	assert.NoError(t, snapshot.Save(archive, snap))
+	return archive
+}
+
 func copyFileToThisDir(sourceFile string) (string, error) {
 	input, err := os.ReadFile(sourceFile)
 	if err != nil {
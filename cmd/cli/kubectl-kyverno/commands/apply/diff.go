@@ -0,0 +1,375 @@
+package apply
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/color"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/formatter"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/variables"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	changeNewFailure      = "newly failing"
+	changeNewPass         = "newly passing"
+	changeNewMutation     = "new mutation"
+	changeMutationChanged = "mutation changed"
+	changeMutationRemoved = "mutation removed"
+)
+
+func (s policySet) count() int {
+	return len(s.policies) + len(s.vaps) + len(s.vps) + len(s.mps)
+}
+
+// installedVersions selects the installed policies having the same kind and name as a policy of the set
+func (s policySet) installedVersions(installed *policy.LoaderResults) policySet {
+	var selected policySet
+	policies := sets.New[string]()
+	for _, policy := range s.policies {
+		policies.Insert(policy.GetKind() + "/" + policy.GetNamespace() + "/" + policy.GetName())
+	}
+	for _, policy := range installed.Policies {
+		if policies.Has(policy.GetKind() + "/" + policy.GetNamespace() + "/" + policy.GetName()) {
+			selected.policies = append(selected.policies, policy)
+		}
+	}
+	vaps := sets.New[string]()
+	for _, vap := range s.vaps {
+		vaps.Insert(vap.Name)
+	}
+	for _, vap := range installed.VAPs {
+		if vaps.Has(vap.Name) {
+			selected.vaps = append(selected.vaps, vap)
+		}
+	}
+	for _, binding := range installed.VAPBindings {
+		if vaps.Has(binding.Spec.PolicyName) {
+			selected.vapBindings = append(selected.vapBindings, binding)
+		}
+	}
+	vps := sets.New[string]()
+	for _, vp := range s.vps {
+		vps.Insert(vp.Name)
+	}
+	for _, vp := range installed.ValidatingPolicies {
+		if vps.Has(vp.Name) {
+			selected.vps = append(selected.vps, vp)
+		}
+	}
+	mps := sets.New[string]()
+	for _, mp := range s.mps {
+		mps.Insert(mp.Name)
+	}
+	for _, mp := range installed.MutatingPolicies {
+		if mps.Has(mp.Name) {
+			selected.mps = append(selected.mps, mp)
+		}
+	}
+	return selected
+}
+
+func (c *ApplyCommandConfig) checkDiffArguments() error {
+	if !c.Cluster {
+		return fmt.Errorf("--diff requires --cluster")
+	}
+	if c.PolicyReport || c.GenerateExceptions {
+		return fmt.Errorf("--diff can't be used together with --policy-report or --generate-exceptions")
+	}
+	if c.Stdin || c.MutateLogPath != "" {
+		return fmt.Errorf("--diff can't be used together with --stdin or --output")
+	}
+	if _, ok := formatter.Get(c.OutputFormat); ok {
+		return fmt.Errorf("--diff can't be used with the %s output format", c.OutputFormat)
+	}
+	return nil
+}
+
+// diff evaluates the policies and their installed version against the same cluster resources and
+// reports the results that differ
+func (c *ApplyCommandConfig) diff(cmd *cobra.Command, out io.Writer) error {
+	if err := c.checkArguments(); err != nil {
+		return err
+	}
+	if err := c.checkDiffArguments(); err != nil {
+		return err
+	}
+	userInfo, err := c.loadUserInfo(out)
+	if err != nil {
+		return err
+	}
+	variables, err := variables.New(out, nil, "", c.ValuesFile, nil, c.Variables...)
+	if err != nil {
+		return fmt.Errorf("failed to decode yaml (%w)", err)
+	}
+	policies, vaps, vapBindings, vps, mps, err := c.loadPolicies()
+	if err != nil {
+		return err
+	}
+	changed := policySet{
+		policies:    policies,
+		vaps:        vaps,
+		vapBindings: vapBindings,
+		vps:         vps,
+		mps:         mps,
+	}
+	var store store.Store
+	dClient, err := c.initStoreAndClusterClient(&store)
+	if err != nil {
+		return err
+	}
+	results, err := policy.LoadFromCluster(context.TODO(), dClient)
+	if err != nil {
+		return fmt.Errorf("failed to load installed policies (%w)", err)
+	}
+	installed := changed.installedVersions(results)
+	// resources are fetched once, both sets are evaluated against the same snapshot
+	resources, err := c.loadResources(out, c.ResourcePaths, append(installed.policies, changed.policies...), append(installed.vaps, changed.vaps...), dClient)
+	if err != nil {
+		return err
+	}
+	exceptions, celExceptions, err := c.loadExceptions(resources)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "\nComparing %d policy(ies) with %d installed version(s) on %d resource(s)...\n", changed.count(), installed.count(), len(resources))
+	// skipped and invalid policies are tracked per run, installed and changed versions share the same names
+	var skippedBefore, skippedAfter SkippedInvalidPolicies
+	_, _, before, err := c.evaluate(io.Discard, &store, variables, installed, copyResources(resources), nil, exceptions, celExceptions, &skippedBefore, dClient, userInfo, false)
+	if err != nil {
+		return err
+	}
+	_, _, after, err := c.evaluate(io.Discard, &store, variables, changed, copyResources(resources), nil, exceptions, celExceptions, &skippedAfter, dClient, userInfo, false)
+	if err != nil {
+		return err
+	}
+	cmd.SilenceErrors = true
+	printSkippedAndInvalidRun(out, "installed", skippedBefore)
+	printSkippedAndInvalidRun(out, "changed", skippedAfter)
+	changes := diffResponses(before, after)
+	printChanges(out, changes)
+	for _, change := range changes {
+		if change.change == changeNewFailure {
+			return fmt.Errorf("exit as the policy changes introduce new failures")
+		}
+	}
+	return nil
+}
+
+// printSkippedAndInvalidRun prints the skipped and invalid policies of the run evaluating the given policy versions
+func printSkippedAndInvalidRun(out io.Writer, versions string, skippedInvalidPolicies SkippedInvalidPolicies) {
+	if len(skippedInvalidPolicies.skipped) == 0 && len(skippedInvalidPolicies.invalid) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "\nEvaluating the %s policies:\n", versions)
+	printSkippedAndInvalidPolicies(out, skippedInvalidPolicies)
+}
+
+func copyResources(resources []*unstructured.Unstructured) []*unstructured.Unstructured {
+	copies := make([]*unstructured.Unstructured, 0, len(resources))
+	for _, resource := range resources {
+		copies = append(copies, resource.DeepCopy())
+	}
+	return copies
+}
+
+// outcomeKey identifies the result of a policy rule, or the mutation of a policy when rule is empty, on a resource
+type outcomeKey struct {
+	policyKind        string
+	policyNamespace   string
+	policyName        string
+	rule              string
+	resourceKind      string
+	resourceNamespace string
+	resourceName      string
+}
+
+type outcome struct {
+	status  engineapi.RuleStatus
+	message string
+}
+
+// outcomeChange is a result that differs between the installed and the changed policies
+type outcomeChange struct {
+	outcomeKey
+	change  string
+	before  engineapi.RuleStatus
+	after   engineapi.RuleStatus
+	message string
+}
+
+func collectOutcomes(responses []engineapi.EngineResponse) (map[outcomeKey]outcome, map[outcomeKey]string) {
+	outcomes := map[outcomeKey]outcome{}
+	mutations := map[outcomeKey]string{}
+	for _, response := range responses {
+		policy := response.Policy()
+		key := outcomeKey{
+			policyKind:        policy.GetKind(),
+			policyNamespace:   policy.GetNamespace(),
+			policyName:        policy.GetName(),
+			resourceKind:      response.Resource.GetKind(),
+			resourceNamespace: response.Resource.GetNamespace(),
+			resourceName:      response.Resource.GetName(),
+		}
+		if patches := response.GetPatches(); len(patches) > 0 {
+			if data, err := json.Marshal(patches); err == nil {
+				mutations[key] = string(data)
+			}
+		}
+		for _, rule := range response.PolicyResponse.Rules {
+			key := key
+			key.rule = rule.Name()
+			outcomes[key] = outcome{
+				status:  rule.Status(),
+				message: rule.Message(),
+			}
+		}
+	}
+	return outcomes, mutations
+}
+
+func isFailure(status engineapi.RuleStatus) bool {
+	return status == engineapi.RuleStatusFail || status == engineapi.RuleStatusError
+}
+
+// diffResponses compares the responses of the installed policies with the responses of the changed policies,
+// changes are sorted by resource, policy and rule
+func diffResponses(before, after []engineapi.EngineResponse) []outcomeChange {
+	beforeOutcomes, beforeMutations := collectOutcomes(before)
+	afterOutcomes, afterMutations := collectOutcomes(after)
+	var changes []outcomeChange
+	keys := sets.New[outcomeKey]()
+	for key := range beforeOutcomes {
+		keys.Insert(key)
+	}
+	for key := range afterOutcomes {
+		keys.Insert(key)
+	}
+	for key := range keys {
+		b, a := beforeOutcomes[key], afterOutcomes[key]
+		change := outcomeChange{
+			outcomeKey: key,
+			before:     b.status,
+			after:      a.status,
+			message:    a.message,
+		}
+		if !isFailure(b.status) && isFailure(a.status) {
+			change.change = changeNewFailure
+		} else if isFailure(b.status) && !isFailure(a.status) {
+			change.change = changeNewPass
+			if a.status == "" {
+				change.message = b.message
+			}
+		} else {
+			continue
+		}
+		changes = append(changes, change)
+	}
+	keys = sets.New[outcomeKey]()
+	for key := range beforeMutations {
+		keys.Insert(key)
+	}
+	for key := range afterMutations {
+		keys.Insert(key)
+	}
+	for key := range keys {
+		b, a := beforeMutations[key], afterMutations[key]
+		change := outcomeChange{
+			outcomeKey: key,
+			message:    a,
+		}
+		if b == "" {
+			change.change = changeNewMutation
+		} else if a == "" {
+			change.change = changeMutationRemoved
+			change.message = b
+		} else if a != b {
+			change.change = changeMutationChanged
+		} else {
+			continue
+		}
+		changes = append(changes, change)
+	}
+	slices.SortFunc(changes, func(x, y outcomeChange) int {
+		return cmp.Or(
+			cmp.Compare(x.resourceKind, y.resourceKind),
+			cmp.Compare(x.resourceNamespace, y.resourceNamespace),
+			cmp.Compare(x.resourceName, y.resourceName),
+			cmp.Compare(x.policyKind, y.policyKind),
+			cmp.Compare(x.policyNamespace, y.policyNamespace),
+			cmp.Compare(x.policyName, y.policyName),
+			cmp.Compare(x.rule, y.rule),
+		)
+	})
+	return changes
+}
+
+type changeRow struct {
+	ID       int    `header:"id,text"`
+	Change   string `header:"change"`
+	Policy   string `header:"policy"`
+	Rule     string `header:"rule"`
+	Resource string `header:"resource"`
+	Before   string `header:"before"`
+	After    string `header:"after"`
+	Message  string `header:"message"`
+}
+
+func printChanges(out io.Writer, changes []outcomeChange) {
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "\nNo changes in policy results")
+		return
+	}
+	var failures, passes, mutations int
+	rows := make([]changeRow, 0, len(changes))
+	for i, change := range changes {
+		switch change.change {
+		case changeNewFailure:
+			failures++
+		case changeNewPass:
+			passes++
+		default:
+			mutations++
+		}
+		rows = append(rows, changeRow{
+			ID:       i + 1,
+			Change:   change.change,
+			Policy:   color.Policy(change.policyNamespace, change.policyName),
+			Rule:     color.Rule(change.rule),
+			Resource: color.Resource(change.resourceKind, change.resourceNamespace, change.resourceName),
+			Before:   statusText(change.before),
+			After:    statusText(change.after),
+			Message:  change.message,
+		})
+	}
+	fmt.Fprintln(out)
+	printer := table.NewTablePrinter(out)
+	printer.Print(rows)
+	fmt.Fprintf(out, "\nnewly failing: %d, newly passing: %d, mutation changes: %d\n", failures, passes, mutations)
+}
+
+func statusText(status engineapi.RuleStatus) string {
+	switch status {
+	case engineapi.RuleStatusPass:
+		return color.ResultPass()
+	case engineapi.RuleStatusFail:
+		return color.ResultFail()
+	case engineapi.RuleStatusWarn:
+		return color.ResultWarn()
+	case engineapi.RuleStatusError:
+		return color.ResultError()
+	case engineapi.RuleStatusSkip:
+		return color.ResultSkip()
+	}
+	return "-"
+}
@@ -0,0 +1,119 @@
+package apply
+
+import (
+	"bytes"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/color"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newPod(name string, labels map[string]string) unstructured.Unstructured {
+	var pod unstructured.Unstructured
+	pod.SetAPIVersion("v1")
+	pod.SetKind("Pod")
+	pod.SetNamespace("default")
+	pod.SetName(name)
+	pod.SetLabels(labels)
+	return pod
+}
+
+func newResponse(policyName string, pod unstructured.Unstructured, rules ...engineapi.RuleResponse) engineapi.EngineResponse {
+	pol := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: policyName}}
+	response := engineapi.NewEngineResponse(pod, engineapi.NewKyvernoPolicy(pol), nil)
+	response.PolicyResponse.Add(engineapi.ExecutionStats{}, rules...)
+	return response
+}
+
+func Test_diffResponses(t *testing.T) {
+	before := []engineapi.EngineResponse{
+		newResponse("require-labels", newPod("a", nil), *engineapi.RulePass("check", engineapi.Validation, "ok", nil)),
+		newResponse("require-labels", newPod("b", nil), *engineapi.RuleFail("check", engineapi.Validation, "missing label", nil)),
+		newResponse("require-labels", newPod("c", nil), *engineapi.RulePass("check", engineapi.Validation, "ok", nil)),
+	}
+	mutated := newResponse("add-labels", newPod("c", nil), *engineapi.RulePass("add", engineapi.Mutation, "mutated", nil))
+	mutated = mutated.WithPatchedResource(newPod("c", map[string]string{"team": "kyverno"}))
+	after := []engineapi.EngineResponse{
+		newResponse("require-labels", newPod("a", nil), *engineapi.RuleFail("check", engineapi.Validation, "missing label", nil)),
+		newResponse("require-labels", newPod("b", nil), *engineapi.RulePass("check", engineapi.Validation, "ok", nil)),
+		newResponse("require-labels", newPod("c", nil), *engineapi.RulePass("check", engineapi.Validation, "ok", nil)),
+		newResponse("disallow-latest", newPod("c", nil), *engineapi.RuleError("check", engineapi.Validation, "failed", nil, nil)),
+		mutated,
+	}
+	changes := diffResponses(before, after)
+	var got [][]string
+	for _, change := range changes {
+		got = append(got, []string{change.resourceName, change.policyName, change.rule, change.change, string(change.before), string(change.after)})
+	}
+	assert.Equal(t, [][]string{
+		{"a", "require-labels", "check", changeNewFailure, "pass", "fail"},
+		{"b", "require-labels", "check", changeNewPass, "fail", "pass"},
+		{"c", "add-labels", "", changeNewMutation, "", ""},
+		{"c", "disallow-latest", "check", changeNewFailure, "", "error"},
+	}, got)
+	assert.Contains(t, changes[2].message, "team")
+	assert.Empty(t, diffResponses(before, before))
+}
+
+func Test_installedVersions(t *testing.T) {
+	changed := policySet{
+		policies: []kyvernov1.PolicyInterface{
+			&kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "require-labels"}},
+			&kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "new-policy"}},
+		},
+		vps: []kyvernov2alpha1.ValidatingPolicy{
+			{ObjectMeta: metav1.ObjectMeta{Name: "check-images"}},
+		},
+	}
+	installed := &policy.LoaderResults{
+		Policies: []kyvernov1.PolicyInterface{
+			&kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "require-labels"}},
+			&kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "other-policy"}},
+			&kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Name: "require-labels", Namespace: "default"}},
+		},
+		ValidatingPolicies: []kyvernov2alpha1.ValidatingPolicy{
+			{ObjectMeta: metav1.ObjectMeta{Name: "check-images"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "check-labels"}},
+		},
+	}
+	selected := changed.installedVersions(installed)
+	assert.Len(t, selected.policies, 1)
+	assert.Equal(t, "ClusterPolicy", selected.policies[0].GetKind())
+	assert.Equal(t, "require-labels", selected.policies[0].GetName())
+	assert.Len(t, selected.vps, 1)
+	assert.Equal(t, "check-images", selected.vps[0].Name)
+	assert.Equal(t, 2, selected.count())
+}
+
+func Test_printChanges(t *testing.T) {
+	color.Init(true)
+	var out bytes.Buffer
+	printChanges(&out, nil)
+	assert.Contains(t, out.String(), "No changes in policy results")
+	out.Reset()
+	printChanges(&out, diffResponses(
+		[]engineapi.EngineResponse{newResponse("require-labels", newPod("a", nil), *engineapi.RulePass("check", engineapi.Validation, "ok", nil))},
+		[]engineapi.EngineResponse{newResponse("require-labels", newPod("a", nil), *engineapi.RuleFail("check", engineapi.Validation, "missing label", nil))},
+	))
+	assert.Contains(t, out.String(), "newly failing")
+	assert.Contains(t, out.String(), "default/Pod/a")
+	assert.Contains(t, out.String(), "newly failing: 1, newly passing: 0, mutation changes: 0")
+}
+
+func Test_checkDiffArguments(t *testing.T) {
+	c := ApplyCommandConfig{Diff: true}
+	assert.EqualError(t, c.checkDiffArguments(), "--diff requires --cluster")
+	c.Cluster = true
+	assert.NoError(t, c.checkDiffArguments())
+	c.PolicyReport = true
+	assert.Error(t, c.checkDiffArguments())
+	c.PolicyReport = false
+	c.OutputFormat = "junit"
+	assert.EqualError(t, c.checkDiffArguments(), "--diff can't be used with the junit output format")
+}
@@ -15,10 +15,26 @@ var examples = [][]string{
 		"# Apply on a folder of resources",
 		"kyverno apply /path/to/policy.yaml /path/to/folderOfPolicies --resource=/path/to/resources/",
 	},
+	{
+		"# Apply on a folder of resources and print the results as SARIF",
+		"kyverno apply /path/to/policy.yaml --resource=/path/to/resources/ --output-format sarif > results.sarif",
+	},
+	{
+		"# Apply on a folder of resources and apply again every time a policy or a resource changes",
+		"kyverno apply /path/to/policy.yaml --resource=/path/to/resources/ --watch",
+	},
 	{
 		"# Apply on a cluster",
 		"kyverno apply /path/to/policy.yaml /path/to/folderOfPolicies --cluster",
 	},
+	{
+		"# Compare the results of changed policies with the results of the policies installed in the cluster",
+		"kyverno apply /path/to/folderOfPolicies --cluster --diff",
+	},
+	{
+		"# Apply on the resources of a cluster snapshot taken with kyverno snapshot, without access to the cluster",
+		"kyverno apply /path/to/policy.yaml --snapshot snapshot.tar.gz",
+	},
 	{
 		"# Apply policies from a gitSourceURL on a cluster",
 		"kyverno apply https://github.com/kyverno/policies/openshift/ --git-branch main --cluster",
@@ -0,0 +1,55 @@
+package apply
+
+import (
+	"io"
+	"strings"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/formatter"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy/annotations"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+)
+
+func printFormatted(out io.Writer, f formatter.Formatter, auditWarn bool, engineResponses ...engineapi.EngineResponse) error {
+	var results []formatter.Result
+	for _, engineResponse := range engineResponses {
+		policy := engineResponse.Policy()
+		policyName := policy.GetName()
+		if policy.GetNamespace() != "" {
+			policyName = policy.GetNamespace() + "/" + policyName
+		}
+		scored := annotations.Scored(policy.GetAnnotations())
+		res := engineResponse.Resource
+		resourceName := strings.Join([]string{res.GetAPIVersion(), res.GetKind(), res.GetNamespace(), res.GetName()}, "/")
+		location, _ := resource.GetLocation(res.GetKind(), res.GetNamespace(), res.GetName())
+		for _, ruleResponse := range engineResponse.PolicyResponse.Rules {
+			result := formatter.Result{
+				Suite:    policyName,
+				Policy:   policyName,
+				Rule:     ruleResponse.Name(),
+				Resource: resourceName,
+				Path:     location.Path,
+				Line:     location.Line,
+				Message:  ruleResponse.Message(),
+			}
+			switch ruleResponse.Status() {
+			case engineapi.RuleStatusPass:
+				result.Status = formatter.StatusPass
+			case engineapi.RuleStatusFail:
+				if !scored || (auditWarn && engineResponse.GetValidationFailureAction().Audit()) {
+					result.Status = formatter.StatusWarn
+				} else {
+					result.Status = formatter.StatusFail
+				}
+			case engineapi.RuleStatusWarn:
+				result.Status = formatter.StatusWarn
+			case engineapi.RuleStatusError:
+				result.Status = formatter.StatusError
+			case engineapi.RuleStatusSkip:
+				result.Status = formatter.StatusSkip
+			}
+			results = append(results, result)
+		}
+	}
+	return f.Format(out, results)
+}
@@ -0,0 +1,88 @@
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/formatter"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/processor"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/watch"
+)
+
+func (c *ApplyCommandConfig) checkWatchArguments() error {
+	if c.Stdin {
+		return fmt.Errorf("--watch can't be used together with --stdin")
+	}
+	if _, ok := formatter.Get(c.OutputFormat); ok {
+		return fmt.Errorf("--watch can't be used with the %s output format", c.OutputFormat)
+	}
+	for _, path := range c.PolicyPaths {
+		if source.IsGit(path) {
+			return fmt.Errorf("--watch is not supported with git repositories (%s)", path)
+		}
+	}
+	if len(c.watchedPaths()) == 0 {
+		return fmt.Errorf("--watch requires local policy or resource files")
+	}
+	return nil
+}
+
+// watchedPaths returns the local files and folders used by the command
+func (c *ApplyCommandConfig) watchedPaths() []string {
+	var paths []string
+	paths = append(paths, c.PolicyPaths...)
+	paths = append(paths, c.ResourcePaths...)
+	paths = append(paths, c.TargetResourcePaths...)
+	paths = append(paths, c.Exception...)
+	paths = append(paths, c.ValuesFile, c.UserInfoPath, c.Snapshot)
+	var local []string
+	for _, path := range paths {
+		if path == "" || path == "-" || source.IsHttp(path) || source.IsGit(path) {
+			continue
+		}
+		local = append(local, path)
+	}
+	return local
+}
+
+// watch calls run every time a watched file changes, loaded policies and compiled expressions are kept across runs
+func (c *ApplyCommandConfig) watch(out io.Writer, run func() error) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	c.policyCache = policy.NewCache()
+	c.processorCache = processor.NewCache()
+	w, err := watch.New(watch.DefaultDebounce)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+	if err := w.Add(c.watchedPaths()...); err != nil {
+		return err
+	}
+	for {
+		if err := run(); err != nil {
+			fmt.Fprintln(out, "Error:", err)
+		}
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Watching for changes, press Ctrl+C to stop ...")
+		changed, err := w.Next(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+			return err
+		}
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Changes detected, applying policies again ...")
+		for _, path := range changed {
+			fmt.Fprintln(out, "  Changed:", path)
+		}
+	}
+}
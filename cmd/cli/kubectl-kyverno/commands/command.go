@@ -8,8 +8,10 @@ import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/fix"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/jp"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/json"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/lint"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/migrate"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/oci"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/snapshot"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/test"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/version"
 	"github.com/spf13/cobra"
@@ -31,7 +33,9 @@ func RootCommand(experimental bool) *cobra.Command {
 		docs.Command(cmd),
 		jp.Command(),
 		json.Command(),
+		lint.Command(),
 		migrate.Command(),
+		snapshot.Command(),
 		test.Command(),
 		version.Command(),
 	)
@@ -0,0 +1,28 @@
+package lint
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "lint [policy paths]...",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+			return options.execute(cmd.OutOrStdout(), args...)
+		},
+	}
+	cmd.Flags().StringVar(&options.outputFormat, "output-format", outputFormatText, "Output format (text or sarif)")
+	cmd.Flags().StringArrayVar(&options.severities, "severity", nil, "Severity of a check in the check=severity form, severity is one of error, warning, info or off")
+	cmd.Flags().BoolVar(&options.removeColor, "remove-color", false, "Remove any color from output")
+	return cmd
+}
@@ -0,0 +1,188 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPolicy = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+spec:
+  background: false
+  rules:
+  - name: check-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+`
+
+func writePolicy(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "policy.yaml"), []byte(testPolicy), 0o600))
+	return dir
+}
+
+func TestCommand(t *testing.T) {
+	dir := writePolicy(t)
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{dir, "--remove-color"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "require-labels/check-team: warning: validate rule has no message (missing-message)")
+	assert.Contains(t, string(out), "1 problem(s): 0 error(s), 1 warning(s), 0 info(s)")
+}
+
+func TestCommandWithErrorSeverity(t *testing.T) {
+	dir := writePolicy(t)
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetOut(bytes.NewBufferString(""))
+	cmd.SetErr(bytes.NewBufferString(""))
+	cmd.SetArgs([]string{dir, "--severity", "missing-message=error"})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "1 problem(s) with the error severity found")
+}
+
+func TestCommandWithSarifOutput(t *testing.T) {
+	dir := writePolicy(t)
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{dir, "--output-format", "sarif"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Results []struct {
+				RuleID string `json:"ruleId"`
+				Level  string `json:"level"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	assert.Len(t, log.Runs, 1)
+	assert.Len(t, log.Runs[0].Results, 1)
+	assert.Equal(t, "missing-message", log.Runs[0].Results[0].RuleID)
+	assert.Equal(t, "warning", log.Runs[0].Results[0].Level)
+}
+
+func TestCommandWithValidatingPolicy(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "policy.yaml"), []byte(`
+apiVersion: kyverno.io/v2alpha1
+kind: ValidatingPolicy
+metadata:
+  name: check-replicas
+spec:
+  matchConstraints:
+    resourceRules:
+    - apiGroups: [apps]
+      apiVersions: [v1]
+      operations: [CREATE]
+      resources: [deployments]
+  validations:
+  - expression: "object.spec.replicas >"
+`), 0o600))
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetErr(bytes.NewBufferString(""))
+	cmd.SetArgs([]string{dir, "--remove-color"})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "1 problem(s) with the error severity found")
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "policy.yaml: check-replicas: error: spec.validations[0].expression")
+	assert.Contains(t, string(out), "(cel-type-check)")
+	// the compilation errors can be turned off like other checks
+	cmd = Command()
+	cmd.SetOut(bytes.NewBufferString(""))
+	cmd.SetArgs([]string{dir, "--severity", "cel-type-check=off"})
+	assert.NoError(t, cmd.Execute())
+}
+
+func TestCommandWithInvalidSeverity(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetErr(bytes.NewBufferString(""))
+	cmd.SetArgs([]string{".", "--severity", "missing-message=fatal"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommandWithInvalidOutputFormat(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetErr(bytes.NewBufferString(""))
+	cmd.SetArgs([]string{".", "--output-format", "xml"})
+	err := cmd.Execute()
+	assert.EqualError(t, err, `invalid output format "xml", must be one of text, sarif`)
+}
+
+func TestCommandWithInvalidArg(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: requires at least 1 arg(s), only received 0`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandWithInvalidFlag(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--xxx"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: unknown flag: --xxx`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
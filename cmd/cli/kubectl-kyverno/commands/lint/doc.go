@@ -0,0 +1,40 @@
+package lint
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#lint`
+
+var description = []string{
+	`Report problems and best practice violations in Kyverno policies.`,
+	``,
+	`The lint command checks every rule of the policies found in the provided files, folders or URLs`,
+	`and reports the problems with a severity. Severities can be changed or checks turned off with --severity.`,
+	`The command fails when at least one problem has the error severity.`,
+	`ValidatingPolicies and MutatingPolicies are compiled, their errors are reported by the cel-type-check check.`,
+	``,
+	`Checks:`,
+	`  background-variables       rules of background policies using admission only variables (error)`,
+	`  wildcard-foreach           rules matching wildcard kinds that use foreach (warning)`,
+	`  unknown-request-path       JMESPath expressions referencing unknown request fields (error)`,
+	`  unused-context-entry       context entries never used by their rule (warning)`,
+	`  overlapping-match-exclude  exclude blocks covering a whole match block (warning)`,
+	`  cel-type-check             CEL expressions and CEL policies failing to compile or type check (error)`,
+	`  missing-message            validate rules and CEL expressions without a message (warning)`,
+}
+
+var examples = [][]string{
+	{
+		`# Lint the policies of a local folder`,
+		`kyverno lint .`,
+	},
+	{
+		`# Lint a policy and report missing messages as errors and unused context entries as infos`,
+		`kyverno lint policy.yaml --severity missing-message=error --severity unused-context-entry=info`,
+	},
+	{
+		`# Lint the policies of a local folder without checking CEL expressions`,
+		`kyverno lint . --severity cel-type-check=off`,
+	},
+	{
+		`# Lint the policies of a local folder and write a SARIF report`,
+		`kyverno lint . --output-format sarif > lint.sarif`,
+	},
+}
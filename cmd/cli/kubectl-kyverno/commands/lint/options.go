@@ -0,0 +1,156 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/lint"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/color"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
+	gitutils "github.com/kyverno/kyverno/pkg/utils/git"
+)
+
+const (
+	outputFormatText  = "text"
+	outputFormatSarif = "sarif"
+)
+
+type options struct {
+	outputFormat string
+	severities   []string
+	removeColor  bool
+}
+
+func (o options) validate() error {
+	if o.outputFormat != outputFormatText && o.outputFormat != outputFormatSarif {
+		return fmt.Errorf("invalid output format %q, must be one of %s, %s", o.outputFormat, outputFormatText, outputFormatSarif)
+	}
+	if _, err := lint.ParseSeverities(o.severities...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (o options) execute(out io.Writer, paths ...string) error {
+	severities, err := lint.ParseSeverities(o.severities...)
+	if err != nil {
+		return err
+	}
+	var findings []lint.Finding
+	for _, path := range paths {
+		files, err := find(path)
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			results, err := policy.Load(nil, "", file)
+			if err != nil {
+				// folders usually contain tests and resources along with policies
+				if file != path {
+					continue
+				}
+				return fmt.Errorf("failed to load policies from %s (%w)", file, err)
+			}
+			var fileFindings []lint.Finding
+			for _, policy := range results.Policies {
+				fileFindings = append(fileFindings, lint.Lint(policy, severities)...)
+			}
+			for i := range results.ValidatingPolicies {
+				fileFindings = append(fileFindings, lint.LintValidatingPolicy(&results.ValidatingPolicies[i], severities)...)
+			}
+			for i := range results.MutatingPolicies {
+				fileFindings = append(fileFindings, lint.LintMutatingPolicy(&results.MutatingPolicies[i], severities)...)
+			}
+			for _, finding := range fileFindings {
+				if !source.IsStdin(file) && !source.IsHttp(file) {
+					finding.Path = file
+				}
+				findings = append(findings, finding)
+			}
+		}
+	}
+	if o.outputFormat == outputFormatSarif {
+		if err := lint.WriteSarif(out, findings, severities); err != nil {
+			return err
+		}
+	} else {
+		color.Init(o.removeColor)
+		printFindings(out, findings)
+	}
+	if errors := count(findings, lint.SeverityError); errors > 0 {
+		return fmt.Errorf("%d problem(s) with the error severity found", errors)
+	}
+	return nil
+}
+
+// find returns the yaml files of a folder, skipping hidden files and folders,
+// other paths are returned as they are
+func find(path string) ([]string, error) {
+	if source.IsStdin(path) || source.IsHttp(path) {
+		return []string{path}, nil
+	}
+	var files []string
+	err := filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if file != path && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if file == path || gitutils.IsYaml(info) {
+			files = append(files, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func printFindings(out io.Writer, findings []lint.Finding) {
+	for _, finding := range findings {
+		location := color.Policy("", finding.Policy)
+		if finding.Rule != "" {
+			location += "/" + color.Rule(finding.Rule)
+		}
+		if finding.Path != "" {
+			location = finding.Path + ": " + location
+		}
+		fmt.Fprintf(out, "%s: %s: %s (%s)\n", location, finding.Severity, finding.Message, finding.Check)
+	}
+	if len(findings) == 0 {
+		fmt.Fprintln(out, "No problems found.")
+		return
+	}
+	fmt.Fprintf(out, "\n%d problem(s): %d error(s), %d warning(s), %d info(s)\n",
+		len(findings),
+		count(findings, lint.SeverityError),
+		count(findings, lint.SeverityWarning),
+		count(findings, lint.SeverityInfo),
+	)
+}
+
+func count(findings []lint.Finding, severity lint.Severity) int {
+	n := 0
+	for _, finding := range findings {
+		if finding.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,166 @@
+package snapshot
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/snapshot"
+	"github.com/kyverno/kyverno/pkg/admissionpolicy"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/spf13/cobra"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+type options struct {
+	kubeConfig        string
+	context           string
+	namespace         string
+	output            string
+	kinds             []string
+	includeSecretData bool
+}
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "snapshot [policy paths]...",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return options.execute(cmd.OutOrStdout(), args...)
+		},
+	}
+	cmd.Flags().StringVarP(&options.output, "output", "o", "snapshot.tar.gz", "Path of the snapshot archive")
+	cmd.Flags().StringVarP(&options.namespace, "namespace", "n", "", "Limit namespaced resources to a single namespace")
+	cmd.Flags().StringSliceVar(&options.kinds, "kind", nil, "Additional kinds of resources to export")
+	cmd.Flags().BoolVar(&options.includeSecretData, "include-secret-data", false, "Export the values of secrets, by default they are redacted")
+	cmd.Flags().StringVar(&options.kubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVar(&options.context, "context", "", "The name of the kubeconfig context to use")
+	return cmd
+}
+
+func (o options) execute(out io.Writer, policyPaths ...string) error {
+	if len(policyPaths) == 0 && len(o.kinds) == 0 {
+		return fmt.Errorf("policies or kinds are required")
+	}
+	snapshotOptions := snapshot.Options{
+		Context:           o.context,
+		Namespace:         o.namespace,
+		Kinds:             o.kinds,
+		IncludeSecretData: o.includeSecretData,
+	}
+	if len(policyPaths) > 0 {
+		results, err := policy.Load(nil, "", policyPaths...)
+		if err != nil {
+			return fmt.Errorf("failed to load policies (%w)", err)
+		}
+		addPolicies(&snapshotOptions, results)
+	}
+	client, err := o.client()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, "Exporting cluster state...")
+	snap, err := snapshot.Take(context.Background(), out, client, snapshotOptions)
+	if err != nil {
+		return fmt.Errorf("failed to export cluster state (%w)", err)
+	}
+	if err := snapshot.Save(o.output, snap); err != nil {
+		return fmt.Errorf("failed to write snapshot (%w)", err)
+	}
+	fmt.Fprintf(out, "Snapshot written to %s (%d objects, %d global context entries)\n", o.output, len(snap.Objects), len(snap.Metadata.GlobalContextEntries))
+	return nil
+}
+
+func (o options) client() (dclient.Interface, error) {
+	restConfig, err := config.CreateClientConfigWithContext(o.kubeConfig, o.context)
+	if err != nil {
+		return nil, err
+	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return dclient.NewClient(context.Background(), dynamicClient, kubeClient, 15*time.Minute)
+}
+
+// addPolicies adds the kinds matched by the policies and the objects referenced by their context entries to the snapshot options
+func addPolicies(options *snapshot.Options, results *policy.LoaderResults) {
+	for _, policy := range results.Policies {
+		for _, rule := range autogen.Default.ComputeRules(policy, "") {
+			options.Kinds = append(options.Kinds, rule.MatchResources.GetKinds()...)
+			for _, entry := range rule.Context {
+				addContextEntry(options, entry)
+			}
+		}
+	}
+	for _, vap := range results.VAPs {
+		addMatchConstraints(options, vap.Spec.MatchConstraints)
+	}
+	for _, vp := range results.ValidatingPolicies {
+		addMatchConstraints(options, vp.Spec.MatchConstraints)
+	}
+	for _, mp := range results.MutatingPolicies {
+		addMatchConstraints(options, mp.Spec.MatchConstraints)
+	}
+	// autogen rules repeat the context entries of their original rule, duplicates are removed
+	slices.Sort(options.Kinds)
+	options.Kinds = slices.Compact(options.Kinds)
+	slices.SortFunc(options.ConfigMaps, func(x, y types.NamespacedName) int {
+		return cmp.Compare(x.String(), y.String())
+	})
+	options.ConfigMaps = slices.Compact(options.ConfigMaps)
+	slices.Sort(options.APICalls)
+	options.APICalls = slices.Compact(options.APICalls)
+}
+
+func addMatchConstraints(options *snapshot.Options, matchConstraints *admissionregistrationv1.MatchResources) {
+	if matchConstraints == nil {
+		return
+	}
+	options.Kinds = append(options.Kinds, admissionpolicy.GetKinds(matchConstraints)...)
+}
+
+// addContextEntry adds the config map or the API resource referenced by a context entry,
+// when they contain variables all the objects they could reference are exported
+func addContextEntry(options *snapshot.Options, entry kyvernov1.ContextEntry) {
+	if cm := entry.ConfigMap; cm != nil {
+		name, namespace := cm.Name, cm.Namespace
+		if namespace == "" {
+			namespace = "default"
+		}
+		if isVariable(namespace) {
+			name, namespace = "", ""
+		}
+		if isVariable(name) {
+			name = ""
+		}
+		options.ConfigMaps = append(options.ConfigMaps, types.NamespacedName{Namespace: namespace, Name: name})
+	}
+	if call := entry.APICall; call != nil && call.URLPath != "" {
+		options.APICalls = append(options.APICalls, call.URLPath)
+	}
+}
+
+func isVariable(value string) bool {
+	return strings.Contains(value, "{{")
+}
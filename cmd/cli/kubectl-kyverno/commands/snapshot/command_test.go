@@ -0,0 +1,114 @@
+package snapshot
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/snapshot"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestCommandWithoutPolicies(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "policies or kinds are required")
+}
+
+func TestCommandWithInvalidFlag(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--xxx"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: unknown flag: --xxx`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
+
+func Test_addPolicies(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policies.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: check-registry
+spec:
+  rules:
+  - name: check-registry
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    context:
+    - name: settings
+      configMap:
+        name: settings
+    - name: teams
+      configMap:
+        name: teams
+        namespace: "{{request.namespace}}"
+    - name: services
+      apiCall:
+        urlPath: "/api/v1/namespaces/{{request.namespace}}/services"
+    validate:
+      message: invalid registry
+      deny:
+        conditions:
+          any:
+          - key: "{{ settings.data.registry }}"
+            operator: Equals
+            value: ""
+---
+apiVersion: kyverno.io/v2alpha1
+kind: ValidatingPolicy
+metadata:
+  name: check-deployments
+spec:
+  matchConstraints:
+    resourceRules:
+    - apiGroups: [apps]
+      apiVersions: [v1]
+      operations: [CREATE]
+      resources: [deployments]
+  validations:
+  - expression: "true"
+`), 0o600))
+	results, err := policy.Load(nil, "", path)
+	assert.NoError(t, err)
+	var options snapshot.Options
+	addPolicies(&options, results)
+	// kinds of autogen rules are included
+	assert.Equal(t, []string{"CronJob", "DaemonSet", "Deployment", "Job", "Pod", "ReplicaSet", "ReplicationController", "StatefulSet", "apps/v1/Deployment"}, options.Kinds)
+	assert.Equal(t, []types.NamespacedName{
+		{},
+		{Namespace: "default", Name: "settings"},
+	}, options.ConfigMaps)
+	assert.Equal(t, []string{"/api/v1/namespaces/{{request.namespace}}/services"}, options.APICalls)
+}
@@ -0,0 +1,28 @@
+package snapshot
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#snapshot`
+
+var description = []string{
+	`Exports the cluster state used by policies into a snapshot archive.`,
+	``,
+	`The snapshot contains the resources matched by the policies, namespaces and their labels, config maps referenced by context entries,`,
+	`global context entries and their data, and RBAC roles and bindings.`,
+	`Secret values are redacted and only their keys are exported unless --include-secret-data is set.`,
+	``,
+	`A snapshot can be passed to the apply and test commands with the --snapshot flag to evaluate policies offline.`,
+}
+
+var examples = [][]string{
+	{
+		"# Export the cluster state used by policies",
+		"kyverno snapshot /path/to/policy.yaml /path/to/folderOfPolicies -o snapshot.tar.gz",
+	},
+	{
+		"# Export the cluster state of a single namespace, including pods",
+		"kyverno snapshot /path/to/policy.yaml --namespace default --kind Pod",
+	},
+	{
+		"# Apply policies on the snapshot resources",
+		"kyverno apply /path/to/policy.yaml --snapshot snapshot.tar.gz",
+	},
+}
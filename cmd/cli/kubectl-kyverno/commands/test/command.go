@@ -4,17 +4,21 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"strings"
 
 	"github.com/go-git/go-billy/v5"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/coverage"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/deprecations"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/color"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/formatter"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/report"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/snapshot"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/filter"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
-	"github.com/sergi/go-diff/diffmatchpatch"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -23,6 +27,13 @@ func Command() *cobra.Command {
 	var testCase string
 	var fileName, gitBranch string
 	var registryAccess, failOnly, removeColor, detailedResults bool
+	var coverageEnabled bool
+	var coverageOutput, coverageFormat string
+	var outputFormat string
+	var watch bool
+	var parallel int
+	var snapshotPath string
+	var updateGolden bool
 	cmd := &cobra.Command{
 		Use:          "test [local folder or git repository]...",
 		Short:        command.FormatDescription(true, websiteUrl, false, description...),
@@ -31,8 +42,16 @@ func Command() *cobra.Command {
 		Args:         cobra.MinimumNArgs(1),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, dirPath []string) (err error) {
-			color.Init(removeColor)
-			return testCommandExecute(cmd.OutOrStdout(), dirPath, fileName, gitBranch, testCase, registryAccess, failOnly, detailedResults)
+			// machine readable formats never contain colors
+			color.Init(removeColor || outputFormat != outputFormatTable)
+			var coverageReport *coverageOptions
+			if coverageEnabled {
+				coverageReport = &coverageOptions{
+					output: coverageOutput,
+					format: coverageFormat,
+				}
+			}
+			return testCommandExecute(cmd.OutOrStdout(), dirPath, fileName, gitBranch, testCase, registryAccess, failOnly, detailedResults, coverageReport, outputFormat, watch, parallel, snapshotPath, updateGolden)
 		},
 	}
 	cmd.Flags().StringVarP(&fileName, "file-name", "f", "kyverno-test.yaml", "Test filename")
@@ -42,6 +61,14 @@ func Command() *cobra.Command {
 	cmd.Flags().BoolVar(&failOnly, "fail-only", false, "If set to true, display all the failing test only as output for the test command")
 	cmd.Flags().BoolVar(&removeColor, "remove-color", false, "Remove any color from output")
 	cmd.Flags().BoolVar(&detailedResults, "detailed-results", false, "If set to true, display detailed results")
+	cmd.Flags().BoolVar(&coverageEnabled, "coverage", false, "If set to true, report which policy rules and CEL validations were exercised by the tests")
+	cmd.Flags().StringVar(&coverageOutput, "coverage-output", "", "If set, write the coverage report to this file (requires --coverage)")
+	cmd.Flags().StringVar(&coverageFormat, "coverage-format", coverageFormatJSON, "Coverage report file format (json or cobertura)")
+	cmd.Flags().StringVar(&outputFormat, "output-format", outputFormatTable, "Results format (table, junit or sarif)")
+	cmd.Flags().BoolVar(&watch, "watch", false, "If set to true, watch the files used by the tests and run the affected tests again when they change")
+	cmd.Flags().IntVar(&parallel, "parallel", 1, "Number of test cases to run concurrently, results are reported in the same order as a serial run")
+	cmd.Flags().StringVar(&snapshotPath, "snapshot", "", "Path to a cluster snapshot archive serving the cluster state (namespaces, config maps, global context entries, RBAC and other resources) to the tests")
+	cmd.Flags().BoolVar(&updateGolden, "update-golden", false, "If set to true, rewrite the patched and generated resource files of the tests from the actual resources when they differ")
 	return cmd
 }
 
@@ -60,11 +87,44 @@ func testCommandExecute(
 	registryAccess bool,
 	failOnly bool,
 	detailedResults bool,
+	coverageReport *coverageOptions,
+	outputFormat string,
+	watch bool,
+	parallel int,
+	snapshotPath string,
+	updateGolden bool,
 ) (err error) {
 	// check input dir
 	if len(dirPath) == 0 {
 		return fmt.Errorf("a directory is required")
 	}
+	if parallel < 1 {
+		return fmt.Errorf("invalid parallelism %d, must be greater than 0", parallel)
+	}
+	if err := coverageReport.validate(); err != nil {
+		return err
+	}
+	if watch {
+		if err := validateWatch(dirPath, coverageReport, outputFormat, updateGolden); err != nil {
+			return err
+		}
+	}
+	// machine readable formats are printed alone once all tests ran, everything else is discarded
+	var resultsFormatter formatter.Formatter
+	var formattedResults []formatter.Result
+	if outputFormat != outputFormatTable {
+		f, ok := formatter.Get(outputFormat)
+		if !ok {
+			return fmt.Errorf("invalid output format %q, must be one of %s", outputFormat, strings.Join(append([]string{outputFormatTable}, formatter.Formats()...), ", "))
+		}
+		resultsFormatter = f
+		defer func(out io.Writer) {
+			if formatErr := resultsFormatter.Format(out, formattedResults); formatErr != nil && err == nil {
+				err = formatErr
+			}
+		}(out)
+		out = io.Discard
+	}
 	// parse filter
 	filter, errors := filter.ParseFilter(testCase)
 	if len(errors) > 0 {
@@ -101,35 +161,103 @@ func testCommandExecute(
 			return errors[0]
 		}
 	}
+	// caches are kept warm across runs in watch mode
+	cache := newTestCache()
+	if snapshotPath != "" {
+		if cache.snapshot, err = snapshot.Load(snapshotPath); err != nil {
+			return fmt.Errorf("failed to load snapshot (%w)", err)
+		}
+	}
+	run := func(tests test.TestCases) error {
+		return runTests(out, tests, filter, cache, parallel, registryAccess, failOnly, detailedResults, coverageReport, updateGolden, func(test test.TestCase, rows ...table.Row) {
+			if resultsFormatter != nil {
+				formattedResults = append(formattedResults, formatRows(test, rows...)...)
+			}
+		})
+	}
+	if !watch {
+		return run(tests)
+	}
+	return watchTests(out, dirPath, fileName, tests, run)
+}
+
+func runTests(
+	out io.Writer,
+	tests test.TestCases,
+	filter filter.Filter,
+	cache *testCache,
+	parallel int,
+	registryAccess bool,
+	failOnly bool,
+	detailedResults bool,
+	coverageReport *coverageOptions,
+	updateGolden bool,
+	onResults func(test.TestCase, ...table.Row),
+) error {
 	rc := &resultCounts{}
 	var fullTable table.Table
-	for _, test := range tests {
+	var collector *coverage.Collector
+	if coverageReport != nil {
+		collector = coverage.NewCollector()
+	}
+	// filter results, tests without results to check are not run
+	runs := make([]*testRun, len(tests))
+	var scheduled []*testRun
+	for i, test := range tests {
+		if test.Err != nil {
+			continue
+		}
+		var filteredResults []v1alpha1.TestResult
+		for _, res := range test.Test.Results {
+			if filter.Apply(res) {
+				filteredResults = append(filteredResults, res)
+			}
+		}
+		if len(filteredResults) == 0 {
+			continue
+		}
+		runs[i] = newTestRun(test, filteredResults)
+		scheduled = append(scheduled, runs[i])
+	}
+	if parallel > 1 {
+		stop := startTestRuns(scheduled, parallel, registryAccess, cache)
+		defer stop()
+	}
+	// results are always processed in order
+	for i, test := range tests {
 		if test.Err == nil {
 			deprecations.CheckTest(out, test.Path, test.Test)
-			// filter results
-			var filteredResults []v1alpha1.TestResult
-			for _, res := range test.Test.Results {
-				if filter.Apply(res) {
-					filteredResults = append(filteredResults, res)
-				}
-			}
-			if len(filteredResults) == 0 {
+			run := runs[i]
+			if run == nil {
 				continue
 			}
 			resourcePath := filepath.Dir(test.Path)
-			responses, err := runTest(out, test, registryAccess)
+			var responses *TestResponse
+			var err error
+			if parallel > 1 {
+				responses, err = run.wait(out)
+			} else {
+				responses, err = runTest(out, test, registryAccess, cache)
+			}
 			if err != nil {
 				return fmt.Errorf("failed to run test (%w)", err)
 			}
+			if collector != nil {
+				collector.AddPolicies(responses.Policies...)
+				for _, ers := range responses.Trigger {
+					collector.AddResponses(ers...)
+				}
+			}
 			fmt.Fprintln(out, "  Checking results ...")
 			var resultsTable table.Table
-			if err := printTestResult(filteredResults, responses, rc, &resultsTable, test.Fs, resourcePath); err != nil {
+			if err := printTestResult(run.results, responses, rc, &resultsTable, test.Fs, resourcePath, updateGolden); err != nil {
 				return fmt.Errorf("failed to print test result (%w)", err)
 			}
 			if err := printCheckResult(test.Test.Checks, *responses, rc, &resultsTable); err != nil {
 				return fmt.Errorf("failed to print test result (%w)", err)
 			}
 			fullTable.AddFailed(resultsTable.RawRows...)
+			onResults(test, resultsTable.RawRows...)
 			printer := table.NewTablePrinter(out)
 			fmt.Fprintln(out)
 			printer.Print(resultsTable.Rows(detailedResults))
@@ -142,6 +270,11 @@ func testCommandExecute(
 		fmt.Fprintf(out, "\nTest Summary: %d out of %d tests failed\n", rc.Fail, rc.Pass+rc.Skip+rc.Fail)
 	}
 	fmt.Fprintln(out)
+	if collector != nil {
+		if err := coverageReport.write(out, collector.Report()); err != nil {
+			return err
+		}
+	}
 	if rc.Fail > 0 {
 		if !failOnly {
 			printFailedTestResult(out, fullTable, detailedResults)
@@ -151,47 +284,52 @@ func testCommandExecute(
 	return nil
 }
 
-func checkResult(test v1alpha1.TestResult, fs billy.Filesystem, resoucePath string, response engineapi.EngineResponse, rule engineapi.RuleResponse, actualResource unstructured.Unstructured) (bool, string, string) {
+func checkResult(test v1alpha1.TestResult, fs billy.Filesystem, resoucePath string, response engineapi.EngineResponse, rule engineapi.RuleResponse, actualResource unstructured.Unstructured, updateGolden bool) (bool, string, string) {
 	expected := test.Result
 	// fallback to the deprecated field
 	if expected == "" {
 		expected = test.Status
 	}
+	patchedResources := test.PatchedResources
 	// fallback on deprecated field
-	if test.PatchedResource != "" {
-		equals, diff, err := getAndCompareResource(actualResource, fs, filepath.Join(resoucePath, test.PatchedResource))
-		if err != nil {
-			return false, err.Error(), "Resource error"
-		}
-		if !equals {
-			dmp := diffmatchpatch.New()
-			legend := dmp.DiffPrettyText(dmp.DiffMain("only in expected", "only in actual", false))
-			return false, fmt.Sprintf("Patched resource didn't match the patched resource in the test result\n(%s)\n\n%s", legend, diff), "Resource diff"
-		}
+	if patchedResources == "" {
+		patchedResources = test.PatchedResource
 	}
-	if test.GeneratedResource != "" {
-		equals, diff, err := getAndCompareResource(actualResource, fs, filepath.Join(resoucePath, test.GeneratedResource))
+	var updated bool
+	for _, golden := range []struct {
+		path string
+		kind string
+	}{{patchedResources, "patched"}, {test.GeneratedResource, "generated"}} {
+		if golden.path == "" {
+			continue
+		}
+		differences, goldenUpdated, err := getAndCompareResource(actualResource, fs, filepath.Join(resoucePath, golden.path), test.PartialResources, updateGolden)
 		if err != nil {
 			return false, err.Error(), "Resource error"
 		}
-		if !equals {
-			dmp := diffmatchpatch.New()
-			legend := dmp.DiffPrettyText(dmp.DiffMain("only in expected", "only in actual", false))
-			return false, fmt.Sprintf("Patched resource didn't match the generated resource in the test result\n(%s)\n\n%s", legend, diff), "Resource diff"
+		if len(differences) > 0 {
+			legend := fmt.Sprintf("%s, %s, %s", color.DiffMissing("- only in expected"), color.DiffUnexpected("+ only in actual"), color.DiffChanged("~ expected -> actual"))
+			return false, fmt.Sprintf("Patched resource didn't match the %s resource in the test result\n(%s)\n\n%s", golden.kind, legend, formatDifferences(differences)), "Resource diff"
 		}
+		updated = updated || goldenUpdated
 	}
 	result := report.ComputePolicyReportResult(false, response, rule)
 	if result.Result != expected {
 		return false, result.Message, fmt.Sprintf("Want %s, got %s", expected, result.Result)
 	}
+	if updated {
+		return true, result.Message, "Golden file updated"
+	}
 	return true, result.Message, "Ok"
 }
 
-func lookupRuleResponses(test v1alpha1.TestResult, responses ...engineapi.RuleResponse) []engineapi.RuleResponse {
+func lookupRuleResponses(test v1alpha1.TestResult, policy engineapi.GenericPolicy, responses ...engineapi.RuleResponse) []engineapi.RuleResponse {
 	var matches []engineapi.RuleResponse
 	// Since there are no rules in case of validating admission policies, responses are returned without checking rule names.
 	if test.IsValidatingAdmissionPolicy {
 		matches = responses
+	} else if policy != nil && policy.AsValidatingPolicy() != nil {
+		matches = lookupValidationResponses(test, responses...)
 	} else {
 		for _, response := range responses {
 			rule := response.Name()
@@ -203,3 +341,26 @@ func lookupRuleResponses(test v1alpha1.TestResult, responses ...engineapi.RuleRe
 	}
 	return matches
 }
+
+// lookupValidationResponses returns the responses of a validating policy matching the test, validations are
+// referenced by index (0) or by rule name (rule-0), autogen rules included. Responses that don't belong to a
+// validation (exceptions, evaluation errors) match any validation.
+func lookupValidationResponses(test v1alpha1.TestResult, responses ...engineapi.RuleResponse) []engineapi.RuleResponse {
+	if test.Rule == "" {
+		return responses
+	}
+	var matches []engineapi.RuleResponse
+	for _, response := range responses {
+		name := response.Name()
+		index := strings.LastIndex(name, "rule-")
+		if index < 0 {
+			matches = append(matches, response)
+			continue
+		}
+		rule := name[index:]
+		if test.Rule == name || test.Rule == rule || test.Rule == strings.TrimPrefix(rule, "rule-") {
+			matches = append(matches, response)
+		}
+	}
+	return matches
+}
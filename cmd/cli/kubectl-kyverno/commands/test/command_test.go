@@ -3,9 +3,14 @@ package test
 import (
 	"bytes"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/color"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/snapshot"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -48,3 +53,85 @@ func TestCommandHelp(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
 }
+
+func TestCommandWithInvalidCoverageFormat(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{".", "--coverage", "--coverage-format", "xml"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: invalid coverage format "xml", must be one of json or cobertura`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandWithInvalidOutputFormat(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{".", "--output-format", "xml"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: invalid output format "xml", must be one of table, junit, sarif`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandWithInvalidParallelism(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{".", "--parallel", "0"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: invalid parallelism 0, must be greater than 0`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestParallelRunMatchesSerialRun(t *testing.T) {
+	color.Init(true)
+	dirs := []string{
+		"../../../../../test/cli/test-mutate",
+		"../../../../../test/cli/test-validating-admission-policy",
+		"../../../../../test/cli/test-validating-policy",
+	}
+	run := func(parallel int) (string, error) {
+		var out bytes.Buffer
+		err := testCommandExecute(&out, dirs, "kyverno-test.yaml", "", "", false, false, true, nil, outputFormatTable, false, parallel, "", false)
+		return out.String(), err
+	}
+	serial, serialErr := run(1)
+	parallel, parallelErr := run(4)
+	assert.Equal(t, serialErr, parallelErr)
+	assert.Equal(t, serial, parallel)
+	assert.Contains(t, serial, "Test Summary")
+}
+
+func TestCommandWithSnapshot(t *testing.T) {
+	color.Init(true)
+	data, err := os.ReadFile("../../_testdata/snapshot/cluster.yaml")
+	assert.NoError(t, err)
+	objects, err := resource.GetUnstructuredResources(data)
+	assert.NoError(t, err)
+	snap := &snapshot.Snapshot{Metadata: snapshot.Metadata{Version: snapshot.Version}}
+	for _, object := range objects {
+		snap.Objects = append(snap.Objects, *object)
+	}
+	archive := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+	assert.NoError(t, snapshot.Save(archive, snap))
+	var out bytes.Buffer
+	// namespace labels, config maps and services are served from the snapshot
+	err = testCommandExecute(&out, []string{"../../_testdata/snapshot"}, "kyverno-test.yaml", "", "", false, false, false, nil, outputFormatTable, false, 1, archive, false)
+	assert.NoError(t, err, out.String())
+	assert.Contains(t, out.String(), "Test Summary: 6 tests passed and 0 tests failed")
+	err = testCommandExecute(&out, []string{"../../_testdata/snapshot"}, "kyverno-test.yaml", "", "", false, false, false, nil, outputFormatTable, false, 1, filepath.Join(t.TempDir(), "missing.tar.gz"), false)
+	assert.ErrorContains(t, err, "failed to load snapshot")
+}
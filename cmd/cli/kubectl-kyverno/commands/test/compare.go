@@ -1,36 +1,140 @@
 package test
 
 import (
+	"bytes"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/go-git/go-billy/v5"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/log"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/color"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
-	"github.com/sergi/go-diff/diffmatchpatch"
-	"gopkg.in/yaml.v2"
+	yamlutils "github.com/kyverno/kyverno/ext/yaml"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
 )
 
-func getAndCompareResource(actualResource unstructured.Unstructured, fs billy.Filesystem, path string) (bool, string, error) {
+// getAndCompareResource returns the field level differences between an actual resource and the expected resource
+// of a golden file. With subset, only the fields declared by the expected resource are compared.
+// With update, the golden file is rewritten from the actual resource when they differ and no differences are returned.
+func getAndCompareResource(actualResource unstructured.Unstructured, fs billy.Filesystem, path string, subset bool, update bool) ([]resource.Difference, bool, error) {
 	expectedResource, err := resource.GetResourceFromPath(fs, path, actualResource.GetAPIVersion(), actualResource.GetKind(), actualResource.GetNamespace(), actualResource.GetName())
 	if err != nil {
-		return false, "", fmt.Errorf("error: failed to load resource (%s)", err)
+		return nil, false, fmt.Errorf("error: failed to load resource (%s)", err)
 	}
-	resource.FixupGenerateLabels(actualResource)
-	resource.FixupGenerateLabels(*expectedResource)
+	actual := *actualResource.DeepCopy()
+	expected := *expectedResource.DeepCopy()
+	resource.FixupGenerateLabels(actual)
+	resource.FixupGenerateLabels(expected)
+	differences, err := resource.Diff(actual, expected, subset)
+	if err != nil {
+		return nil, false, fmt.Errorf("error: failed to compare resources (%s)", err)
+	}
+	if len(differences) == 0 {
+		return nil, false, nil
+	}
+	log.Log.V(4).Info("Resource diff", "expected", expectedResource, "actual", actualResource, "differences", differences)
+	if !update {
+		return differences, false, nil
+	}
+	if fs != nil {
+		return nil, false, fmt.Errorf("error: failed to update %s, golden files of remote tests can't be updated", path)
+	}
+	if err := updateGoldenFile(path, actualResource, *expectedResource, subset); err != nil {
+		return nil, false, fmt.Errorf("error: failed to update %s (%s)", path, err)
+	}
+	return nil, true, nil
+}
 
-	equals, err := resource.Compare(actualResource, *expectedResource, true)
+// updateGoldenFile replaces the expected resource in a golden file with the actual resource,
+// other documents of the file are kept as they are
+func updateGoldenFile(path string, actual, expected unstructured.Unstructured, subset bool) error {
+	golden, err := goldenResource(actual, expected, subset)
+	if err != nil {
+		return err
+	}
+	content, err := yaml.Marshal(golden.Object)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	documents, err := yamlutils.SplitDocuments(data)
+	if err != nil {
+		return err
+	}
+	var found bool
+	for i, document := range documents {
+		resources, err := resource.GetUnstructuredResources(document)
+		if err != nil || len(resources) != 1 || !sameResource(*resources[0], expected) {
+			continue
+		}
+		documents[i] = content
+		found = true
+		break
+	}
+	if !found {
+		return fmt.Errorf("resource %s not found", expected.GetName())
+	}
+	var out bytes.Buffer
+	for i, document := range documents {
+		if i > 0 {
+			out.WriteString("---\n")
+		}
+		out.Write(document)
+		if !bytes.HasSuffix(document, []byte("\n")) {
+			out.WriteString("\n")
+		}
+	}
+	info, err := os.Stat(path)
 	if err != nil {
-		return false, "", fmt.Errorf("error: failed to compare resources (%s)", err)
-	}
-	if !equals {
-		log.Log.V(4).Info("Resource diff", "expected", expectedResource, "actual", actualResource)
-		es, _ := yaml.Marshal(expectedResource)
-		as, _ := yaml.Marshal(actualResource)
-		dmp := diffmatchpatch.New()
-		diffs := dmp.DiffMain(string(es), string(as), false)
-		log.Log.V(4).Info("\n" + dmp.DiffPrettyText(diffs) + "\n")
-		return false, dmp.DiffPrettyText(diffs), nil
-	}
-	return true, "", nil
+		return err
+	}
+	return os.WriteFile(path, out.Bytes(), info.Mode())
+}
+
+// goldenResource returns the actual resource as it is written in a golden file, without server populated fields
+// and generate labels, partial expected resources are kept partial
+func goldenResource(actual, expected unstructured.Unstructured, subset bool) (unstructured.Unstructured, error) {
+	actual = *actual.DeepCopy()
+	if labels := actual.GetLabels(); labels != nil {
+		for key := range labels {
+			if strings.HasPrefix(key, "generate.kyverno.io/") {
+				delete(labels, key)
+			}
+		}
+		actual.SetLabels(labels)
+	}
+	if subset {
+		return resource.Project(actual, expected)
+	}
+	return resource.Normalize(actual)
+}
+
+func sameResource(a, b unstructured.Unstructured) bool {
+	return a.GetAPIVersion() == b.GetAPIVersion() && a.GetKind() == b.GetKind() && a.GetNamespace() == b.GetNamespace() && a.GetName() == b.GetName()
+}
+
+// formatDifferences prints one line per difference, missing fields are only in the expected resource
+// and unexpected fields are only in the actual resource
+func formatDifferences(differences []resource.Difference) string {
+	lines := make([]string, 0, len(differences))
+	for _, difference := range differences {
+		path := difference.Path
+		if path == "" {
+			path = "."
+		}
+		switch difference.Type {
+		case resource.Missing:
+			lines = append(lines, color.DiffMissing(fmt.Sprintf("- %s: %s", path, resource.FormatValue(difference.Expected))))
+		case resource.Unexpected:
+			lines = append(lines, color.DiffUnexpected(fmt.Sprintf("+ %s: %s", path, resource.FormatValue(difference.Actual))))
+		default:
+			lines = append(lines, color.DiffChanged(fmt.Sprintf("~ %s: %s -> %s", path, resource.FormatValue(difference.Expected), resource.FormatValue(difference.Actual))))
+		}
+	}
+	return strings.Join(lines, "\n")
 }
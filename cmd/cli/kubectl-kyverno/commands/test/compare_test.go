@@ -0,0 +1,106 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/color"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const goldenFile = `# expected resources
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: other
+data:
+  foo: bar
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+data:
+  image: nginx:1.27
+  registry: ghcr.io
+`
+
+func newConfigMap(data map[string]interface{}) unstructured.Unstructured {
+	return unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":            "settings",
+				"namespace":       "default",
+				"resourceVersion": "42",
+			},
+			"data": data,
+		},
+	}
+}
+
+func Test_getAndCompareResource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "golden.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(goldenFile), 0o600))
+	// equal
+	differences, updated, err := getAndCompareResource(newConfigMap(map[string]interface{}{"image": "nginx:1.27", "registry": "ghcr.io"}), nil, path, false, false)
+	assert.NoError(t, err)
+	assert.False(t, updated)
+	assert.Empty(t, differences)
+	// subset
+	differences, _, err = getAndCompareResource(newConfigMap(map[string]interface{}{"image": "nginx:1.27", "registry": "ghcr.io", "tag": "latest"}), nil, path, true, false)
+	assert.NoError(t, err)
+	assert.Empty(t, differences)
+	// differences
+	actual := newConfigMap(map[string]interface{}{"image": "nginx:latest", "registry": "ghcr.io"})
+	differences, _, err = getAndCompareResource(actual, nil, path, false, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []resource.Difference{{Type: resource.Changed, Path: "data.image", Expected: "nginx:1.27", Actual: "nginx:latest"}}, differences)
+	// update
+	differences, updated, err = getAndCompareResource(actual, nil, path, false, true)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+	assert.Empty(t, differences)
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, `# expected resources
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: other
+data:
+  foo: bar
+---
+apiVersion: v1
+data:
+  image: nginx:latest
+  registry: ghcr.io
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: default
+`, string(data))
+	differences, updated, err = getAndCompareResource(actual, nil, path, false, false)
+	assert.NoError(t, err)
+	assert.False(t, updated)
+	assert.Empty(t, differences)
+	// not found
+	_, _, err = getAndCompareResource(actual, nil, filepath.Join(t.TempDir(), "missing.yaml"), false, false)
+	assert.Error(t, err)
+}
+
+func Test_formatDifferences(t *testing.T) {
+	color.Init(true)
+	got := formatDifferences([]resource.Difference{
+		{Type: resource.Missing, Path: "metadata.labels", Expected: map[string]interface{}{"app": "nginx"}},
+		{Type: resource.Unexpected, Path: "data.tag", Actual: "latest"},
+		{Type: resource.Changed, Path: "data.image", Expected: "nginx:1.27", Actual: "nginx:latest"},
+	})
+	assert.Equal(t, `- metadata.labels: {"app":"nginx"}
++ data.tag: "latest"
+~ data.image: "nginx:1.27" -> "nginx:latest"`, got)
+}
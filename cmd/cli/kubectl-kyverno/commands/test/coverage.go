@@ -0,0 +1,57 @@
+package test
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/coverage"
+)
+
+const (
+	coverageFormatJSON      = "json"
+	coverageFormatCobertura = "cobertura"
+)
+
+type coverageOptions struct {
+	output string
+	format string
+}
+
+func (o *coverageOptions) validate() error {
+	if o == nil {
+		return nil
+	}
+	switch o.format {
+	case coverageFormatJSON, coverageFormatCobertura:
+		return nil
+	default:
+		return fmt.Errorf("invalid coverage format %q, must be one of %s or %s", o.format, coverageFormatJSON, coverageFormatCobertura)
+	}
+}
+
+func (o *coverageOptions) write(out io.Writer, report coverage.Report) error {
+	coverage.Print(out, report)
+	fmt.Fprintln(out)
+	if o.output == "" {
+		return nil
+	}
+	var data []byte
+	var err error
+	switch o.format {
+	case coverageFormatCobertura:
+		data, err = report.Cobertura(time.Now().Unix())
+	default:
+		data, err = report.JSON()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to serialize coverage report (%w)", err)
+	}
+	if err := os.WriteFile(o.output, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write coverage report (%w)", err)
+	}
+	fmt.Fprintln(out, "Coverage report written to", o.output)
+	fmt.Fprintln(out)
+	return nil
+}
@@ -25,4 +25,28 @@ var examples = [][]string{
 		`# Test some specific test cases out of many test cases in a local folder`,
 		`kyverno test . --test-case-selector "policy=disallow-latest-tag, rule=require-image-tag, resource=test-require-image-tag-pass"`,
 	},
+	{
+		`# Test a local folder and write a cobertura coverage report of the policy rules exercised by the tests`,
+		`kyverno test . --coverage --coverage-output coverage.xml --coverage-format cobertura`,
+	},
+	{
+		`# Test a local folder and print the results as JUnit XML`,
+		`kyverno test . --output-format junit > results.xml`,
+	},
+	{
+		`# Test a local folder and run the affected test cases again every time a file changes`,
+		`kyverno test . --watch`,
+	},
+	{
+		`# Test a local folder running up to 4 test cases concurrently`,
+		`kyverno test . --parallel 4`,
+	},
+	{
+		`# Test a local folder against the cluster state of a snapshot taken with kyverno snapshot`,
+		`kyverno test . --snapshot snapshot.tar.gz`,
+	},
+	{
+		`# Test a local folder and rewrite the patched and generated resource files that don't match the actual resources`,
+		`kyverno test . --update-golden`,
+	},
 }
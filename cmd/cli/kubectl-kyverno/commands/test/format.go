@@ -0,0 +1,50 @@
+package test
+
+import (
+	"strings"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/formatter"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
+)
+
+const outputFormatTable = "table"
+
+// formatRows converts the result rows of a test, rows must be built without colors.
+// Results are located at the resource manifest when known, at the test file otherwise.
+func formatRows(testCase test.TestCase, rows ...table.Row) []formatter.Result {
+	suite := testCase.Path
+	if testCase.Test != nil {
+		if name := testCase.Test.GetName(); name != "" {
+			suite = name
+		} else if testCase.Test.Name != "" {
+			suite = testCase.Test.Name
+		}
+	}
+	files := testCase.Files()
+	results := make([]formatter.Result, 0, len(rows))
+	for _, row := range rows {
+		result := formatter.Result{
+			Suite:    suite,
+			Policy:   row.Policy,
+			Rule:     row.Rule,
+			Resource: row.Resource,
+			Path:     testCase.Path,
+			Status:   formatter.StatusPass,
+			Message:  row.Message,
+		}
+		if row.IsFailure {
+			result.Status = formatter.StatusFail
+			result.Message = strings.TrimSuffix(row.Reason+": "+row.Message, ": ")
+		}
+		// resources are displayed as [apiVersion/]kind/namespace/name
+		if parts := strings.Split(row.Resource, "/"); len(parts) >= 3 {
+			if location, ok := resource.FindLocation(parts[len(parts)-3], parts[len(parts)-2], parts[len(parts)-1], files...); ok {
+				result.Path, result.Line = location.Path, location.Line
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/go-git/go-billy/v5"
@@ -28,8 +30,8 @@ func printCheckResult(
 	for _, check := range checks {
 		// filter engine responses
 		var matchingEngineResponses []engineapi.EngineResponse
-		for _, engineresponses := range responses.Trigger {
-			matchingEngineResponses = append(matchingEngineResponses, engineresponses...)
+		for _, resource := range slices.Sorted(maps.Keys(responses.Trigger)) {
+			matchingEngineResponses = append(matchingEngineResponses, responses.Trigger[resource]...)
 		}
 		// 1. by resource
 		if check.Match.Resource != nil {
@@ -173,6 +175,7 @@ func printTestResult(
 	resultsTable *table.Table,
 	fs billy.Filesystem,
 	resoucePath string,
+	updateGolden bool,
 ) error {
 	testCount := 1
 	for _, test := range tests {
@@ -184,7 +187,7 @@ func printTestResult(
 		if test.Resources != nil {
 			for _, r := range test.Resources {
 				for _, m := range []map[string][]engineapi.EngineResponse{responses.Target, responses.Trigger} {
-					for resourceGVKAndName := range m {
+					for _, resourceGVKAndName := range slices.Sorted(maps.Keys(m)) {
 						nameParts := strings.Split(resourceGVKAndName, ",")
 						nsAndName := strings.Split(r, "/")
 						if len(nsAndName) == 1 {
@@ -202,7 +205,7 @@ func printTestResult(
 			}
 			for _, resourceSpec := range test.ResourceSpecs {
 				for _, m := range []map[string][]engineapi.EngineResponse{responses.Target, responses.Trigger} {
-					for resourceGVKAndName := range m {
+					for _, resourceGVKAndName := range slices.Sorted(maps.Keys(m)) {
 						nameParts := strings.Split(resourceGVKAndName, ",")
 						if resourceSpec.Group == "" {
 							if resourceSpec.Version != nameParts[0] {
@@ -226,12 +229,8 @@ func printTestResult(
 
 		// The test specifies no resources, check all results
 		if len(resources) == 0 {
-			for r := range responses.Target {
-				resources = append(resources, r)
-			}
-			for r := range responses.Trigger {
-				resources = append(resources, r)
-			}
+			resources = append(resources, slices.Sorted(maps.Keys(responses.Target))...)
+			resources = append(resources, slices.Sorted(maps.Keys(responses.Trigger))...)
 		}
 
 		for _, resource := range resources {
@@ -243,11 +242,11 @@ func printTestResult(
 					if response.Policy().GetName() != polNameNs[len(polNameNs)-1] {
 						continue
 					}
-					for _, rule := range lookupRuleResponses(test, response.PolicyResponse.Rules...) {
+					for _, rule := range lookupRuleResponses(test, response.Policy(), response.PolicyResponse.Rules...) {
 						r := response.Resource
 
 						if test.IsValidatingAdmissionPolicy {
-							ok, message, reason := checkResult(test, fs, resoucePath, response, rule, r)
+							ok, message, reason := checkResult(test, fs, resoucePath, response, rule, r, updateGolden)
 							if strings.Contains(message, "not found in manifest") {
 								resourceSkipped = true
 								continue
@@ -263,7 +262,7 @@ func printTestResult(
 								r = response.PatchedResource
 							}
 
-							ok, message, reason := checkResult(test, fs, resoucePath, response, rule, r)
+							ok, message, reason := checkResult(test, fs, resoucePath, response, rule, r, updateGolden)
 							if strings.Contains(message, "not found in manifest") {
 								resourceSkipped = true
 								continue
@@ -275,7 +274,7 @@ func printTestResult(
 						} else {
 							generatedResources := rule.GeneratedResources()
 							for _, r := range generatedResources {
-								ok, message, reason := checkResult(test, fs, resoucePath, response, rule, *r)
+								ok, message, reason := checkResult(test, fs, resoucePath, response, rule, *r, updateGolden)
 
 								success := ok || (!ok && test.Result == policyreportv1alpha2.StatusFail)
 								resourceRows := createRowsAccordingToResults(test, rc, &testCount, success, message, reason, r.GetName())
@@ -313,7 +312,7 @@ func printTestResult(
 					name, ns, kind, apiVersion := nameParts[len(nameParts)-1], nameParts[len(nameParts)-2], nameParts[len(nameParts)-3], nameParts[len(nameParts)-4]
 
 					r, rule := extractPatchedTargetFromEngineResponse(apiVersion, kind, name, ns, response)
-					ok, message, reason := checkResult(test, fs, resoucePath, response, *rule, *r)
+					ok, message, reason := checkResult(test, fs, resoucePath, response, *rule, *r, updateGolden)
 
 					success := ok || (!ok && test.Result == policyreportv1alpha2.StatusFail)
 					resourceRows := createRowsAccordingToResults(test, rc, &testCount, success, message, reason, strings.Replace(resource, ",", "/", -1))
@@ -0,0 +1,64 @@
+package test
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
+)
+
+// testRun is a test case scheduled for execution.
+// When tests run concurrently their output is buffered and replayed in order, so that the output and the
+// results are the same as a serial run.
+type testRun struct {
+	test      test.TestCase
+	results   []v1alpha1.TestResult
+	output    bytes.Buffer
+	responses *TestResponse
+	err       error
+	done      chan struct{}
+}
+
+func newTestRun(test test.TestCase, results []v1alpha1.TestResult) *testRun {
+	return &testRun{
+		test:    test,
+		results: results,
+		done:    make(chan struct{}),
+	}
+}
+
+// wait waits for the test to complete and copies its output
+func (r *testRun) wait(out io.Writer) (*TestResponse, error) {
+	<-r.done
+	if _, err := out.Write(r.output.Bytes()); err != nil {
+		return nil, err
+	}
+	return r.responses, r.err
+}
+
+// startTestRuns runs the tests in the background, at most parallel tests at a time.
+// Every test has its own store, caches are safe for concurrent use. The returned function stops scheduling tests.
+func startTestRuns(runs []*testRun, parallel int, registryAccess bool, cache *testCache) func() {
+	stop := make(chan struct{})
+	slots := make(chan struct{}, parallel)
+	go func() {
+		for _, run := range runs {
+			select {
+			case slots <- struct{}{}:
+			case <-stop:
+				return
+			}
+			go func(run *testRun) {
+				defer func() { <-slots }()
+				defer close(run.done)
+				run.responses, run.err = runTest(&run.output, run.test, registryAccess, cache)
+			}(run)
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(stop) })
+	}
+}
@@ -3,6 +3,7 @@ package test
 import (
 	"fmt"
 	"io"
+	"maps"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
@@ -13,6 +14,7 @@ import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/processor"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/snapshot"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/userinfo"
@@ -21,10 +23,17 @@ import (
 	"github.com/kyverno/kyverno/ext/output/pluralize"
 	"github.com/kyverno/kyverno/pkg/autogen"
 	"github.com/kyverno/kyverno/pkg/background/generate"
+	celpolicy "github.com/kyverno/kyverno/pkg/cel/policy"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/context/loaders"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/globalcontext/static"
+	gctxstore "github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"github.com/kyverno/kyverno/pkg/imagedataloader"
 	policyvalidation "github.com/kyverno/kyverno/pkg/validation/policy"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -33,9 +42,25 @@ import (
 type TestResponse struct {
 	Trigger map[string][]engineapi.EngineResponse
 	Target  map[string][]engineapi.EngineResponse
+	// Policies contains the policies applied by the test
+	Policies []engineapi.GenericPolicy
 }
 
-func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestResponse, error) {
+// testCache holds the loaded policies, the compiled expressions and the cluster snapshot shared by test runs
+type testCache struct {
+	policies  *policy.Cache
+	processor *processor.Cache
+	snapshot  *snapshot.Snapshot
+}
+
+func newTestCache() *testCache {
+	return &testCache{
+		policies:  policy.NewCache(),
+		processor: processor.NewCache(),
+	}
+}
+
+func runTest(out io.Writer, testCase test.TestCase, registryAccess bool, cache *testCache) (*TestResponse, error) {
 	// don't process test case with errors
 	if testCase.Err != nil {
 		return nil, testCase.Err
@@ -65,7 +90,7 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestR
 	// policies
 	fmt.Fprintln(out, "  Loading policies", "...")
 	policyFullPath := path.GetFullPaths(testCase.Test.Policies, testDir, isGit)
-	results, err := policy.Load(testCase.Fs, testDir, policyFullPath...)
+	results, err := cache.policies.Load(testCase.Fs, testDir, policyFullPath...)
 	if err != nil {
 		return nil, fmt.Errorf("error: failed to load policies (%s)", err)
 	}
@@ -95,21 +120,33 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestR
 	}
 
 	// this will be a dclient containing all target resources. a policy may not do anything with any targets in these
-	dClient, err = dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{}, targets...)
-	if err != nil {
-		return nil, err
+	if cache.snapshot != nil {
+		// the snapshot serves the cluster state, target resources take precedence over snapshot objects
+		dClient, err = cache.snapshot.Client(targetResources...)
+		if err != nil {
+			return nil, err
+		}
+		if err := cache.snapshot.ResolveRoles(userInfo); err != nil {
+			return nil, err
+		}
+	} else {
+		dClient, err = dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{}, targets...)
+		if err != nil {
+			return nil, err
+		}
+		dClient.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
 	}
-	dClient.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
 
 	// exceptions
 	fmt.Fprintln(out, "  Loading exceptions", "...")
 	exceptionFullPath := path.GetFullPaths(testCase.Test.PolicyExceptions, testDir, isGit)
-	exceptions, err := exception.Load(exceptionFullPath...)
+	exceptionResults, err := exception.Load(exceptionFullPath...)
 	if err != nil {
 		return nil, fmt.Errorf("error: failed to load exceptions (%s)", err)
 	}
+	exceptions, celExceptions := exceptionResults.Exceptions, exceptionResults.CELExceptions
 	// Validates that exceptions cannot be used with ValidatingAdmissionPolicies.
-	if len(results.VAPs) > 0 && len(exceptions) > 0 {
+	if len(results.VAPs) > 0 && len(exceptions)+len(celExceptions) > 0 {
 		return nil, fmt.Errorf("error: use of exceptions with ValidatingAdmissionPolicies is not supported")
 	}
 	// init store
@@ -119,14 +156,72 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestR
 	if vars != nil {
 		vars.SetInStore(&store)
 	}
+	// cel context, everything is served from the test files and the snapshot, test files take precedence
+	gctxStore := gctxstore.New()
+	namespaceSelectors := vars.NamespaceSelectors()
+	namespaceProvider := vars.Namespace
+	var snapshotResources []*unstructured.Unstructured
+	var cmResolver engineapi.ConfigmapResolver
+	var policyGctxStore loaders.Store
+	if cache.snapshot != nil {
+		// api calls are served from the snapshot objects
+		store.AllowApiCall(true)
+		entries, err := cache.snapshot.GlobalContextEntries(jmespath.New(config.NewDefaultConfiguration(false)))
+		if err != nil {
+			return nil, err
+		}
+		for name, entry := range entries {
+			gctxStore.Set(name, entry)
+		}
+		namespaceSelectors = cache.snapshot.NamespaceLabels()
+		maps.Copy(namespaceSelectors, vars.NamespaceSelectors())
+		namespaceProvider = func(name string) *corev1.Namespace {
+			if namespace := vars.Namespace(name); namespace != nil {
+				return namespace
+			}
+			return cache.snapshot.Namespace(name)
+		}
+		for i := range cache.snapshot.Objects {
+			snapshotResources = append(snapshotResources, &cache.snapshot.Objects[i])
+		}
+		cmResolver = cache.snapshot.ConfigmapResolver()
+		policyGctxStore = gctxStore
+	}
+	for name, data := range vars.GlobalContextEntries() {
+		gctxStore.Set(name, static.New(data))
+	}
+	contextResources, err := vars.ContextResources()
+	if err != nil {
+		return nil, err
+	}
+	images, err := vars.ImageData()
+	if err != nil {
+		return nil, err
+	}
+	// image data declared in the values is served first, other images are only fetched with registry access
+	var imageDataFallback imagedataloader.Fetcher
+	if registryAccess {
+		if imageDataFallback, err = imagedataloader.New(nil); err != nil {
+			return nil, err
+		}
+	}
+	contextResources = append(contextResources, targetResources...)
+	contextResources = append(contextResources, resources...)
+	contextResources = append(contextResources, snapshotResources...)
+	contextProvider := celpolicy.NewFakeContextProvider(
+		gctxStore,
+		celpolicy.NewFakeResourceLoader(contextResources...),
+		celpolicy.NewFakeImageDataLoader(imageDataFallback, images...),
+		vars.HTTPStub(),
+		userinfo.NewAuthorizer(userInfo),
+	)
 
-	policyCount := len(results.Policies) + len(results.VAPs)
-	policyPlural := pluralize.Pluralize(len(results.Policies)+len(results.VAPs), "policy", "policies")
+	policyCount := len(results.Policies) + len(results.VAPs) + len(results.ValidatingPolicies) + len(results.MutatingPolicies)
+	policyPlural := pluralize.Pluralize(policyCount, "policy", "policies")
 	resourceCount := len(uniques)
 	resourcePlural := pluralize.Pluralize(len(uniques), "resource", "resources")
-	if len(exceptions) > 0 {
-		exceptionCount := len(exceptions)
-		exceptionsPlural := pluralize.Pluralize(len(exceptions), "exception", "exceptions")
+	if exceptionCount := len(exceptions) + len(celExceptions); exceptionCount > 0 {
+		exceptionsPlural := pluralize.Pluralize(exceptionCount, "exception", "exceptions")
 		fmt.Fprintln(out, "  Applying", policyCount, policyPlural, "to", resourceCount, resourcePlural, "with", exceptionCount, exceptionsPlural, "...")
 	} else {
 		fmt.Fprintln(out, "  Applying", policyCount, policyPlural, "to", resourceCount, resourcePlural, "...")
@@ -194,6 +289,18 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestR
 		Trigger: map[string][]engineapi.EngineResponse{},
 		Target:  map[string][]engineapi.EngineResponse{},
 	}
+	for _, pol := range validPolicies {
+		testResponse.Policies = append(testResponse.Policies, engineapi.NewKyvernoPolicy(pol))
+	}
+	for i := range results.VAPs {
+		testResponse.Policies = append(testResponse.Policies, engineapi.NewValidatingAdmissionPolicy(&results.VAPs[i]))
+	}
+	for i := range results.ValidatingPolicies {
+		testResponse.Policies = append(testResponse.Policies, engineapi.NewValidatingPolicy(&results.ValidatingPolicies[i]))
+	}
+	for i := range results.MutatingPolicies {
+		testResponse.Policies = append(testResponse.Policies, engineapi.NewMutatingPolicy(&results.MutatingPolicies[i]))
+	}
 	for _, resource := range uniques {
 		// the policy processor is for multiple policies at once
 		processor := processor.PolicyProcessor{
@@ -205,13 +312,16 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestR
 			Variables:                 vars,
 			UserInfo:                  userInfo,
 			PolicyReport:              true,
-			NamespaceSelectorMap:      vars.NamespaceSelectors(),
+			NamespaceSelectorMap:      namespaceSelectors,
 			Rc:                        &resultCounts,
 			RuleToCloneSourceResource: ruleToCloneSourceResource,
 			Cluster:                   false,
 			Client:                    dClient,
+			ConfigmapResolver:         cmResolver,
+			GlobalContextStore:        policyGctxStore,
 			Subresources:              vars.Subresources(),
 			Out:                       io.Discard,
+			Cache:                     cache.processor,
 		}
 		ers, err := processor.ApplyPoliciesOnResource()
 		if err != nil {
@@ -235,7 +345,7 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestR
 			Policies:             results.VAPs,
 			Bindings:             results.VAPBindings,
 			Resource:             resource,
-			NamespaceSelectorMap: vars.NamespaceSelectors(),
+			NamespaceSelectorMap: namespaceSelectors,
 			PolicyReport:         true,
 			Rc:                   &resultCounts,
 		}
@@ -246,6 +356,42 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestR
 		resourceKey := generateResourceKey(resource)
 		testResponse.Trigger[resourceKey] = append(testResponse.Trigger[resourceKey], ers...)
 	}
+	vpolProcessor := processor.ValidatingPolicyProcessor{
+		Policies:          results.ValidatingPolicies,
+		Exceptions:        celExceptions,
+		NamespaceProvider: namespaceProvider,
+		Context:           contextProvider,
+		UserInfo:          userInfo,
+		Rc:                &resultCounts,
+		Cache:             cache.processor,
+	}
+	for _, resource := range uniques {
+		vpolProcessor.Resource = resource
+		ers, err := vpolProcessor.ApplyPolicyOnResource()
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply validating policies on resource %s (%w)", resource.GetName(), err)
+		}
+		resourceKey := generateResourceKey(resource)
+		testResponse.Trigger[resourceKey] = append(testResponse.Trigger[resourceKey], ers...)
+	}
+	for _, resource := range uniques {
+		processor := processor.MutatingPolicyProcessor{
+			Policies:             results.MutatingPolicies,
+			Resource:             resource,
+			NamespaceSelectorMap: namespaceSelectors,
+			Context:              contextProvider,
+			UserInfo:             userInfo,
+			Rc:                   &resultCounts,
+			Out:                  io.Discard,
+			Cache:                cache.processor,
+		}
+		ers, err := processor.ApplyPolicyOnResource()
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply mutating policies on resource %s (%w)", resource.GetName(), err)
+		}
+		resourceKey := generateResourceKey(resource)
+		testResponse.Trigger[resourceKey] = append(testResponse.Trigger[resourceKey], ers...)
+	}
 	// this is an array of responses of all policies, generated by all of their rules
 	return &testResponse, nil
 }
@@ -0,0 +1,125 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/watch"
+)
+
+func validateWatch(dirPath []string, coverageReport *coverageOptions, outputFormat string, updateGolden bool) error {
+	for _, path := range dirPath {
+		if source.IsGit(path) {
+			return fmt.Errorf("--watch is not supported with git repositories (%s)", path)
+		}
+	}
+	if coverageReport != nil {
+		return fmt.Errorf("--watch can't be used together with --coverage")
+	}
+	if outputFormat != outputFormatTable {
+		return fmt.Errorf("--watch can only be used with the %s output format", outputFormatTable)
+	}
+	// updated golden files would trigger new runs
+	if updateGolden {
+		return fmt.Errorf("--watch can't be used together with --update-golden")
+	}
+	return nil
+}
+
+func watchTests(out io.Writer, dirPath []string, fileName string, tests test.TestCases, run func(test.TestCases) error) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	w, err := watch.New(watch.DefaultDebounce)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+	// test folders are watched recursively to pick up new tests, files outside are watched one by one
+	if err := w.Add(dirPath...); err != nil {
+		return err
+	}
+	runWatched(out, tests, run)
+	for {
+		for _, test := range tests {
+			if err := w.Add(test.Files()...); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintln(out, "Watching for changes, press Ctrl+C to stop ...")
+		changed, err := w.Next(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+			return err
+		}
+		reloaded, err := loadTests(dirPath, fileName, "")
+		if err != nil {
+			fmt.Fprintln(out)
+			fmt.Fprintln(out, "Error loading tests:", err)
+			continue
+		}
+		tests = reloaded
+		affected := affectedTests(tests, changed...)
+		if len(affected) == 0 {
+			continue
+		}
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Changes detected, running", len(affected), "affected test(s) ...")
+		for _, path := range changed {
+			fmt.Fprintln(out, "  Changed:", path)
+		}
+		runWatched(out, affected, run)
+	}
+}
+
+// runWatched runs the tests and reports errors and failures instead of returning them, watching goes on
+func runWatched(out io.Writer, tests test.TestCases, run func(test.TestCases) error) {
+	if errs := tests.Errors(); len(errs) > 0 {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Test errors:")
+		for _, e := range errs {
+			fmt.Fprintln(out, "  Path:", e.Path)
+			fmt.Fprintln(out, "    Error:", e.Err)
+		}
+	}
+	if err := run(tests); err != nil {
+		fmt.Fprintln(out, "Error:", err)
+	}
+	fmt.Fprintln(out)
+}
+
+// affectedTests returns the tests depending on at least one of the changed paths
+func affectedTests(tests test.TestCases, changed ...string) test.TestCases {
+	var affected test.TestCases
+	for _, test := range tests {
+		if dependsOn(test, changed...) {
+			affected = append(affected, test)
+		}
+	}
+	return affected
+}
+
+func dependsOn(test test.TestCase, changed ...string) bool {
+	for _, file := range test.Files() {
+		file, err := filepath.Abs(file)
+		if err != nil {
+			continue
+		}
+		for _, path := range changed {
+			// a file used by the test changed, or a folder containing files used by the test was moved
+			if watch.Contains(file, path) || watch.Contains(path, file) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAffectedTests(t *testing.T) {
+	abs := func(path string) string {
+		path, err := filepath.Abs(path)
+		assert.NoError(t, err)
+		return path
+	}
+	tests := test.TestCases{{
+		Path: "foo/kyverno-test.yaml",
+		Test: &v1alpha1.Test{
+			Policies:  []string{"policy.yaml"},
+			Resources: []string{"resources"},
+		},
+	}, {
+		Path: "bar/kyverno-test.yaml",
+		Test: &v1alpha1.Test{
+			Policies:  []string{"../foo/policy.yaml"},
+			Resources: []string{"resources.yaml"},
+		},
+	}}
+	names := func(tests test.TestCases) []string {
+		var paths []string
+		for _, test := range tests {
+			paths = append(paths, test.Path)
+		}
+		return paths
+	}
+	assert.Equal(t, []string{"foo/kyverno-test.yaml", "bar/kyverno-test.yaml"}, names(affectedTests(tests, abs("foo/policy.yaml"))))
+	assert.Equal(t, []string{"foo/kyverno-test.yaml"}, names(affectedTests(tests, abs("foo/resources/pod.yaml"))))
+	assert.Equal(t, []string{"bar/kyverno-test.yaml"}, names(affectedTests(tests, abs("bar/kyverno-test.yaml"))))
+	assert.Equal(t, []string{"bar/kyverno-test.yaml"}, names(affectedTests(tests, abs("bar"))))
+	assert.Empty(t, affectedTests(tests, abs("foo/other.yaml"), abs("baz/policy.yaml")))
+}
+
+func TestValidateWatch(t *testing.T) {
+	assert.NoError(t, validateWatch([]string{"."}, nil, outputFormatTable, false))
+	assert.Error(t, validateWatch([]string{"https://github.com/kyverno/policies/pod-security"}, nil, outputFormatTable, false))
+	assert.Error(t, validateWatch([]string{"."}, &coverageOptions{format: coverageFormatJSON}, outputFormatTable, false))
+	assert.Error(t, validateWatch([]string{"."}, nil, "junit", false))
+	assert.Error(t, validateWatch([]string{"."}, nil, outputFormatTable, true))
+}
@@ -0,0 +1,133 @@
+package coverage
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// cobertura types, see https://github.com/cobertura/cobertura/blob/master/cobertura/src/site/htdocs/xml/coverage-04.dtd
+// policies are mapped to classes (grouped in packages by kind), rules to lines and rule branches to conditions.
+
+type cobertura struct {
+	XMLName         xml.Name       `xml:"coverage"`
+	LineRate        string         `xml:"line-rate,attr"`
+	BranchRate      string         `xml:"branch-rate,attr"`
+	LinesCovered    int            `xml:"lines-covered,attr"`
+	LinesValid      int            `xml:"lines-valid,attr"`
+	BranchesCovered int            `xml:"branches-covered,attr"`
+	BranchesValid   int            `xml:"branches-valid,attr"`
+	Complexity      string         `xml:"complexity,attr"`
+	Version         string         `xml:"version,attr"`
+	Timestamp       int64          `xml:"timestamp,attr"`
+	Sources         []string       `xml:"sources>source"`
+	Packages        []coberturaPkg `xml:"packages>package"`
+}
+
+type coberturaPkg struct {
+	Name       string           `xml:"name,attr"`
+	LineRate   string           `xml:"line-rate,attr"`
+	BranchRate string           `xml:"branch-rate,attr"`
+	Complexity string           `xml:"complexity,attr"`
+	Classes    []coberturaClass `xml:"classes>class"`
+}
+
+type coberturaClass struct {
+	Name       string          `xml:"name,attr"`
+	Filename   string          `xml:"filename,attr"`
+	LineRate   string          `xml:"line-rate,attr"`
+	BranchRate string          `xml:"branch-rate,attr"`
+	Complexity string          `xml:"complexity,attr"`
+	Methods    struct{}        `xml:"methods"`
+	Lines      []coberturaLine `xml:"lines>line"`
+}
+
+type coberturaLine struct {
+	Number            int    `xml:"number,attr"`
+	Hits              int    `xml:"hits,attr"`
+	Branch            bool   `xml:"branch,attr"`
+	ConditionCoverage string `xml:"condition-coverage,attr,omitempty"`
+}
+
+func rate(covered, total int) string {
+	if total == 0 {
+		return "1"
+	}
+	return strconv.FormatFloat(float64(covered)/float64(total), 'f', 4, 64)
+}
+
+// Cobertura returns the report serialized in the cobertura xml format
+func (r Report) Cobertura(timestamp int64) ([]byte, error) {
+	linesCovered, linesValid := r.Rules()
+	branchesCovered, branchesValid := r.Branches()
+	out := cobertura{
+		LineRate:        rate(linesCovered, linesValid),
+		BranchRate:      rate(branchesCovered, branchesValid),
+		LinesCovered:    linesCovered,
+		LinesValid:      linesValid,
+		BranchesCovered: branchesCovered,
+		BranchesValid:   branchesValid,
+		Complexity:      "0",
+		Version:         "kyverno",
+		Timestamp:       timestamp,
+		Sources:         []string{"."},
+	}
+	type counters struct{ rulesCovered, rules, branchesCovered, branches int }
+	packages := map[string]int{}
+	var totals []counters
+	for _, policy := range r.Policies {
+		index, ok := packages[policy.Kind]
+		if !ok {
+			out.Packages = append(out.Packages, coberturaPkg{Name: policy.Kind, Complexity: "0"})
+			totals = append(totals, counters{})
+			index = len(out.Packages) - 1
+			packages[policy.Kind] = index
+		}
+		name := policy.Name
+		if policy.Namespace != "" {
+			name = policy.Namespace + "/" + policy.Name
+		}
+		class := coberturaClass{
+			Name:       name,
+			Filename:   strings.ToLower(policy.Kind) + "/" + name,
+			Complexity: "0",
+			Lines:      []coberturaLine{},
+		}
+		var c counters
+		for i, rule := range policy.Rules {
+			line := coberturaLine{
+				Number: i + 1,
+				Hits:   rule.Hits(),
+			}
+			c.rules++
+			if rule.Covered() {
+				c.rulesCovered++
+			}
+			if branches := rule.InstrumentedBranches(); branches > 0 {
+				covered := rule.CoveredBranches()
+				line.Branch = true
+				line.ConditionCoverage = fmt.Sprintf("%d%% (%d/%d)", covered*100/branches, covered, branches)
+				c.branchesCovered += covered
+				c.branches += branches
+			}
+			class.Lines = append(class.Lines, line)
+		}
+		class.LineRate = rate(c.rulesCovered, c.rules)
+		class.BranchRate = rate(c.branchesCovered, c.branches)
+		out.Packages[index].Classes = append(out.Packages[index].Classes, class)
+		totals[index].rules += c.rules
+		totals[index].rulesCovered += c.rulesCovered
+		totals[index].branches += c.branches
+		totals[index].branchesCovered += c.branchesCovered
+	}
+	for i, c := range totals {
+		out.Packages[i].LineRate = rate(c.rulesCovered, c.rules)
+		out.Packages[i].BranchRate = rate(c.branchesCovered, c.branches)
+	}
+	data, err := xml.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
@@ -0,0 +1,322 @@
+package coverage
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	celautogen "github.com/kyverno/kyverno/pkg/cel/autogen"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+)
+
+const (
+	BranchPreconditionsMet   = "preconditions:met"
+	BranchPreconditionsUnmet = "preconditions:unmet"
+)
+
+// policy level responses emitted by the CEL engines, they apply to every rule of the policy
+var celPolicyLevelRules = map[string]struct{}{
+	"exception":  {},
+	"match":      {},
+	"evaluation": {},
+}
+
+// Report contains the coverage of a set of policies
+type Report struct {
+	Policies []PolicyCoverage `json:"policies"`
+}
+
+// PolicyCoverage contains the coverage of a policy rules
+type PolicyCoverage struct {
+	Kind      string         `json:"kind"`
+	Namespace string         `json:"namespace,omitempty"`
+	Name      string         `json:"name"`
+	Rules     []RuleCoverage `json:"rules"`
+}
+
+// RuleCoverage counts the outcomes of a rule (or CEL validation) across all test cases
+type RuleCoverage struct {
+	Name     string           `json:"name"`
+	Type     string           `json:"type,omitempty"`
+	Pass     int              `json:"pass"`
+	Fail     int              `json:"fail"`
+	Skip     int              `json:"skip"`
+	Warn     int              `json:"warn"`
+	Error    int              `json:"error"`
+	Branches []BranchCoverage `json:"branches,omitempty"`
+}
+
+// BranchCoverage counts the hits of a rule branch (preconditions, anyPattern alternative, foreach declaration).
+// Branches the engine doesn't report on are listed as not instrumented and excluded from the totals.
+type BranchCoverage struct {
+	Name            string `json:"name"`
+	Hits            int    `json:"hits"`
+	NotInstrumented bool   `json:"notInstrumented,omitempty"`
+}
+
+// Hits returns the number of times the rule was evaluated
+func (r RuleCoverage) Hits() int {
+	return r.Pass + r.Fail + r.Skip + r.Warn + r.Error
+}
+
+// Covered returns true if the rule was evaluated at least once
+func (r RuleCoverage) Covered() bool {
+	return r.Hits() > 0
+}
+
+// CoveredBranches returns the number of instrumented branches hit at least once
+func (r RuleCoverage) CoveredBranches() int {
+	count := 0
+	for _, branch := range r.Branches {
+		if !branch.NotInstrumented && branch.Hits > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// InstrumentedBranches returns the number of branches the coverage is computed for
+func (r RuleCoverage) InstrumentedBranches() int {
+	count := 0
+	for _, branch := range r.Branches {
+		if !branch.NotInstrumented {
+			count++
+		}
+	}
+	return count
+}
+
+// Rules returns the number of covered rules and the total number of rules
+func (r Report) Rules() (int, int) {
+	covered, total := 0, 0
+	for _, policy := range r.Policies {
+		for _, rule := range policy.Rules {
+			total++
+			if rule.Covered() {
+				covered++
+			}
+		}
+	}
+	return covered, total
+}
+
+// Branches returns the number of covered branches and the total number of branches
+func (r Report) Branches() (int, int) {
+	covered, total := 0, 0
+	for _, policy := range r.Policies {
+		for _, rule := range policy.Rules {
+			total += rule.InstrumentedBranches()
+			covered += rule.CoveredBranches()
+		}
+	}
+	return covered, total
+}
+
+// JSON returns the report serialized in json
+func (r Report) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+type policyEntry struct {
+	coverage PolicyCoverage
+	rules    map[string]int
+	// celRules is true for policies reporting policy level responses (CEL engines)
+	celRules bool
+}
+
+// Collector records rule responses against the rules declared by policies
+type Collector struct {
+	policies map[string]*policyEntry
+}
+
+func NewCollector() *Collector {
+	return &Collector{
+		policies: map[string]*policyEntry{},
+	}
+}
+
+func policyKey(policy engineapi.GenericPolicy) string {
+	return strings.Join([]string{policy.GetKind(), policy.GetNamespace(), policy.GetName()}, "/")
+}
+
+// AddPolicies registers the rules declared by the given policies, rules that are never hit are reported as not covered
+func (c *Collector) AddPolicies(policies ...engineapi.GenericPolicy) {
+	for _, policy := range policies {
+		c.policy(policy)
+	}
+}
+
+// AddResponses records the outcomes of the given engine responses
+func (c *Collector) AddResponses(responses ...engineapi.EngineResponse) {
+	for _, response := range responses {
+		policy := response.Policy()
+		if policy == nil {
+			continue
+		}
+		entry := c.policy(policy)
+		for i := range response.PolicyResponse.Rules {
+			rule := &response.PolicyResponse.Rules[i]
+			if _, ok := celPolicyLevelRules[rule.Name()]; ok && entry.celRules && len(entry.coverage.Rules) > 0 {
+				for index := range entry.coverage.Rules {
+					record(&entry.coverage.Rules[index], rule)
+				}
+				continue
+			}
+			index, ok := entry.rules[rule.Name()]
+			if !ok {
+				index = entry.add(RuleCoverage{Name: rule.Name(), Type: string(rule.RuleType())})
+			}
+			record(&entry.coverage.Rules[index], rule)
+		}
+	}
+}
+
+// Report returns the coverage report, policies are sorted by kind, namespace and name
+func (c *Collector) Report() Report {
+	keys := make([]string, 0, len(c.policies))
+	for key := range c.policies {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	report := Report{
+		Policies: make([]PolicyCoverage, 0, len(keys)),
+	}
+	for _, key := range keys {
+		report.Policies = append(report.Policies, c.policies[key].coverage)
+	}
+	return report
+}
+
+func (c *Collector) policy(policy engineapi.GenericPolicy) *policyEntry {
+	key := policyKey(policy)
+	if entry, ok := c.policies[key]; ok {
+		return entry
+	}
+	entry := &policyEntry{
+		coverage: PolicyCoverage{
+			Kind:      policy.GetKind(),
+			Namespace: policy.GetNamespace(),
+			Name:      policy.GetName(),
+			Rules:     []RuleCoverage{},
+		},
+		rules: map[string]int{},
+	}
+	for _, rule := range policyRules(policy) {
+		entry.add(rule)
+	}
+	entry.celRules = policy.AsValidatingPolicy() != nil || policy.AsMutatingPolicy() != nil
+	c.policies[key] = entry
+	return entry
+}
+
+func (e *policyEntry) add(rule RuleCoverage) int {
+	e.coverage.Rules = append(e.coverage.Rules, rule)
+	e.rules[rule.Name] = len(e.coverage.Rules) - 1
+	return len(e.coverage.Rules) - 1
+}
+
+func policyRules(policy engineapi.GenericPolicy) []RuleCoverage {
+	var rules []RuleCoverage
+	if pol := policy.AsKyvernoPolicy(); pol != nil {
+		for _, rule := range autogen.Default.ComputeRules(pol, "") {
+			rules = append(rules, kyvernoRule(rule))
+		}
+	} else if vap := policy.AsValidatingAdmissionPolicy(); vap != nil {
+		rules = append(rules, RuleCoverage{Name: vap.GetName(), Type: string(engineapi.Validation)})
+	} else if vpol := policy.AsValidatingPolicy(); vpol != nil {
+		for i := range vpol.Spec.Validations {
+			rules = append(rules, RuleCoverage{Name: fmt.Sprintf("rule-%d", i), Type: string(engineapi.Validation)})
+		}
+		for _, autogenRule := range celautogen.ComputeRules(vpol) {
+			for i := range autogenRule.Validations {
+				rules = append(rules, RuleCoverage{Name: fmt.Sprintf("%s-rule-%d", autogenRule.Name, i), Type: string(engineapi.Validation)})
+			}
+		}
+	} else if mpol := policy.AsMutatingPolicy(); mpol != nil {
+		for i := range mpol.Spec.Mutations {
+			rules = append(rules, RuleCoverage{Name: fmt.Sprintf("rule-%d", i), Type: string(engineapi.Mutation)})
+		}
+	}
+	return rules
+}
+
+func kyvernoRule(rule kyvernov1.Rule) RuleCoverage {
+	coverage := RuleCoverage{
+		Name: rule.Name,
+	}
+	switch {
+	case rule.HasValidate():
+		coverage.Type = string(engineapi.Validation)
+	case rule.HasMutate():
+		coverage.Type = string(engineapi.Mutation)
+	case rule.HasGenerate():
+		coverage.Type = string(engineapi.Generation)
+	case rule.HasVerifyImages():
+		coverage.Type = string(engineapi.ImageVerify)
+	}
+	if rule.GetAnyAllConditions() != nil || len(rule.CELPreconditions) != 0 {
+		coverage.Branches = append(coverage.Branches, BranchCoverage{Name: BranchPreconditionsMet}, BranchCoverage{Name: BranchPreconditionsUnmet})
+	}
+	if rule.Validation != nil {
+		if rule.Validation.RawAnyPattern != nil {
+			var anyPatterns []any
+			if err := json.Unmarshal(rule.Validation.RawAnyPattern.Raw, &anyPatterns); err == nil {
+				for i := range anyPatterns {
+					coverage.Branches = append(coverage.Branches, BranchCoverage{Name: fmt.Sprintf("anyPattern[%d]", i)})
+				}
+			}
+		}
+		for i := range rule.Validation.ForEachValidation {
+			coverage.Branches = append(coverage.Branches, foreachBranch(i))
+		}
+	}
+	if rule.Mutation != nil {
+		for i := range rule.Mutation.ForEachMutation {
+			coverage.Branches = append(coverage.Branches, foreachBranch(i))
+		}
+	}
+	return coverage
+}
+
+// foreachBranch returns the branch of a foreach declaration, the engine doesn't report which
+// declarations were evaluated so they are listed without being counted
+func foreachBranch(index int) BranchCoverage {
+	return BranchCoverage{Name: fmt.Sprintf("foreach[%d]", index), NotInstrumented: true}
+}
+
+func record(coverage *RuleCoverage, rule *engineapi.RuleResponse) {
+	switch rule.Status() {
+	case engineapi.RuleStatusPass:
+		coverage.Pass++
+	case engineapi.RuleStatusFail:
+		coverage.Fail++
+	case engineapi.RuleStatusSkip:
+		coverage.Skip++
+	case engineapi.RuleStatusWarn:
+		coverage.Warn++
+	case engineapi.RuleStatusError:
+		coverage.Error++
+	}
+	applied := rule.HasStatus(engineapi.RuleStatusPass, engineapi.RuleStatusFail, engineapi.RuleStatusWarn)
+	for i := range coverage.Branches {
+		branch := &coverage.Branches[i]
+		switch {
+		case branch.Name == BranchPreconditionsMet:
+			if applied {
+				branch.Hits++
+			}
+		case branch.Name == BranchPreconditionsUnmet:
+			if rule.Status() == engineapi.RuleStatusSkip && strings.Contains(rule.Message(), "preconditions not met") {
+				branch.Hits++
+			}
+		case strings.HasPrefix(branch.Name, "anyPattern["):
+			// the engine reports the alternative that matched in the rule message
+			if rule.Status() == engineapi.RuleStatusPass && strings.Contains(rule.Message(), branch.Name+" passed") {
+				branch.Hits++
+			}
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package coverage
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+var clusterPolicy = []byte(`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: check-configmaps
+spec:
+  rules:
+  - name: any-pattern
+    match:
+      any:
+      - resources:
+          kinds: [ConfigMap]
+    preconditions:
+      all:
+      - key: "{{ request.object.metadata.name }}"
+        operator: NotEquals
+        value: ignored
+    validate:
+      anyPattern:
+      - data:
+          foo: bar
+      - data:
+          bar: foo
+  - name: foreach
+    match:
+      any:
+      - resources:
+          kinds: [ConfigMap]
+    validate:
+      foreach:
+      - list: request.object.metadata.labels
+        deny:
+          conditions:
+            any:
+            - key: "{{ element }}"
+              operator: Equals
+              value: forbidden
+  - name: never-hit
+    match:
+      any:
+      - resources:
+          kinds: [ConfigMap]
+    validate:
+      pattern:
+        data:
+          foo: bar
+`)
+
+func loadClusterPolicy(t *testing.T) engineapi.GenericPolicy {
+	var policy kyvernov1.ClusterPolicy
+	assert.NoError(t, yaml.Unmarshal(clusterPolicy, &policy))
+	return engineapi.NewKyvernoPolicy(&policy)
+}
+
+func response(policy engineapi.GenericPolicy, rules ...engineapi.RuleResponse) engineapi.EngineResponse {
+	return engineapi.NewEngineResponse(unstructured.Unstructured{}, policy, nil).WithPolicyResponse(engineapi.PolicyResponse{Rules: rules})
+}
+
+func TestCollector_kyvernoPolicy(t *testing.T) {
+	policy := loadClusterPolicy(t)
+	collector := NewCollector()
+	collector.AddPolicies(policy)
+	collector.AddResponses(
+		response(policy,
+			*engineapi.RulePass("any-pattern", engineapi.Validation, "validation rule 'any-pattern' anyPattern[1] passed.", nil),
+			*engineapi.RuleFail("foreach", engineapi.Validation, "validation failure", nil),
+		),
+		response(policy,
+			*engineapi.RuleSkip("any-pattern", engineapi.Validation, "preconditions not met", nil),
+			*engineapi.RulePass("foreach", engineapi.Validation, "rule passed", nil),
+		),
+	)
+	report := collector.Report()
+	assert.Len(t, report.Policies, 1)
+	assert.Equal(t, "ClusterPolicy", report.Policies[0].Kind)
+	assert.Equal(t, "check-configmaps", report.Policies[0].Name)
+	assert.Equal(t, []RuleCoverage{{
+		Name: "any-pattern",
+		Type: "Validation",
+		Pass: 1,
+		Skip: 1,
+		Branches: []BranchCoverage{
+			{Name: BranchPreconditionsMet, Hits: 1},
+			{Name: BranchPreconditionsUnmet, Hits: 1},
+			{Name: "anyPattern[0]"},
+			{Name: "anyPattern[1]", Hits: 1},
+		},
+	}, {
+		Name:     "foreach",
+		Type:     "Validation",
+		Pass:     1,
+		Fail:     1,
+		Branches: []BranchCoverage{{Name: "foreach[0]", NotInstrumented: true}},
+	}, {
+		Name: "never-hit",
+		Type: "Validation",
+	}}, report.Policies[0].Rules)
+	covered, total := report.Rules()
+	assert.Equal(t, 2, covered)
+	assert.Equal(t, 3, total)
+	// foreach declarations are not counted
+	covered, total = report.Branches()
+	assert.Equal(t, 3, covered)
+	assert.Equal(t, 4, total)
+}
+
+func TestCollector_validatingPolicy(t *testing.T) {
+	policy := engineapi.NewValidatingPolicy(&kyvernov2alpha1.ValidatingPolicy{
+		Spec: kyvernov2alpha1.ValidatingPolicySpec{
+			ValidatingAdmissionPolicySpec: admissionregistrationv1.ValidatingAdmissionPolicySpec{
+				MatchConstraints: &admissionregistrationv1.MatchResources{
+					ResourceRules: []admissionregistrationv1.NamedRuleWithOperations{{
+						RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+							Rule: admissionregistrationv1.Rule{
+								APIGroups:   []string{""},
+								APIVersions: []string{"v1"},
+								Resources:   []string{"configmaps"},
+							},
+						},
+					}},
+				},
+				Validations: []admissionregistrationv1.Validation{
+					{Expression: "true"},
+					{Expression: "false"},
+				},
+			},
+		},
+	})
+	collector := NewCollector()
+	collector.AddResponses(
+		response(policy,
+			*engineapi.RulePass("rule-0", engineapi.Validation, "success", nil),
+			*engineapi.RuleFail("rule-1", engineapi.Validation, "failure", nil),
+		),
+		// exceptions skip the whole policy
+		response(policy, *engineapi.RuleSkip("exception", engineapi.Validation, "rule is skipped due to policy exception", nil)),
+	)
+	report := collector.Report()
+	assert.Len(t, report.Policies, 1)
+	assert.Equal(t, []RuleCoverage{
+		{Name: "rule-0", Type: "Validation", Pass: 1, Skip: 1},
+		{Name: "rule-1", Type: "Validation", Fail: 1, Skip: 1},
+	}, report.Policies[0].Rules)
+}
+
+func TestReport_JSON(t *testing.T) {
+	policy := loadClusterPolicy(t)
+	collector := NewCollector()
+	collector.AddPolicies(policy)
+	data, err := collector.Report().JSON()
+	assert.NoError(t, err)
+	var report Report
+	assert.NoError(t, json.Unmarshal(data, &report))
+	assert.Equal(t, collector.Report(), report)
+}
+
+func TestReport_Cobertura(t *testing.T) {
+	policy := loadClusterPolicy(t)
+	collector := NewCollector()
+	collector.AddPolicies(policy)
+	collector.AddResponses(response(policy, *engineapi.RulePass("never-hit", engineapi.Validation, "", nil)))
+	data, err := collector.Report().Cobertura(0)
+	assert.NoError(t, err)
+	out := string(data)
+	assert.True(t, strings.HasPrefix(out, "<?xml"))
+	assert.Contains(t, out, `<coverage line-rate="0.3333" branch-rate="0.0000" lines-covered="1" lines-valid="3" branches-covered="0" branches-valid="4"`)
+	// rules with foreach declarations only have no instrumented branches
+	assert.Contains(t, out, `<line number="2" hits="0" branch="false"></line>`)
+	assert.Contains(t, out, `<package name="ClusterPolicy" line-rate="0.3333" branch-rate="0.0000" complexity="0">`)
+	assert.Contains(t, out, `<class name="check-configmaps" filename="clusterpolicy/check-configmaps"`)
+	assert.Contains(t, out, `<line number="1" hits="0" branch="true" condition-coverage="0% (0/4)"></line>`)
+	assert.Contains(t, out, `<line number="3" hits="1" branch="false"></line>`)
+}
+
+func TestPrint(t *testing.T) {
+	var out strings.Builder
+	Print(&out, Report{})
+	assert.Contains(t, out.String(), "Rules coverage: 0/0 (100%)")
+}
@@ -0,0 +1,66 @@
+package coverage
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/color"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+)
+
+type row struct {
+	ID       int    `header:"id,text"`
+	Policy   string `header:"policy"`
+	Rule     string `header:"rule"`
+	Pass     int    `header:"pass,text"`
+	Fail     int    `header:"fail,text"`
+	Skip     int    `header:"skip,text"`
+	Warn     int    `header:"warn,text"`
+	Error    int    `header:"error,text"`
+	Branches string `header:"branches"`
+	Covered  string `header:"covered"`
+}
+
+// Print prints the coverage summary table followed by the coverage totals
+func Print(out io.Writer, report Report) {
+	var rows []row
+	for _, policy := range report.Policies {
+		for _, rule := range policy.Rules {
+			r := row{
+				ID:     len(rows) + 1,
+				Policy: color.Policy(policy.Namespace, policy.Name),
+				Rule:   color.Rule(rule.Name),
+				Pass:   rule.Pass,
+				Fail:   rule.Fail,
+				Skip:   rule.Skip,
+				Warn:   rule.Warn,
+				Error:  rule.Error,
+			}
+			if branches := rule.InstrumentedBranches(); branches > 0 {
+				r.Branches = fmt.Sprintf("%d/%d", rule.CoveredBranches(), branches)
+			}
+			if rule.Covered() {
+				r.Covered = color.ResultPass()
+			} else {
+				r.Covered = color.ResultFail()
+			}
+			rows = append(rows, r)
+		}
+	}
+	fmt.Fprintln(out, "Coverage:")
+	if len(rows) != 0 {
+		printer := table.NewTablePrinter(out)
+		printer.Print(rows)
+	}
+	rulesCovered, rules := report.Rules()
+	branchesCovered, branches := report.Branches()
+	fmt.Fprintf(out, "\nRules coverage: %d/%d (%s)\n", rulesCovered, rules, percent(rulesCovered, rules))
+	fmt.Fprintf(out, "Branches coverage: %d/%d (%s)\n", branchesCovered, branches, percent(branchesCovered, branches))
+}
+
+func percent(covered, total int) string {
+	if total == 0 {
+		return "100%"
+	}
+	return fmt.Sprintf("%.1f%%", float64(covered)*100/float64(total))
+}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/data"
 	"github.com/kyverno/kyverno/ext/resource/convert"
@@ -17,12 +18,27 @@ import (
 )
 
 var (
-	exceptionV2beta1 = schema.GroupVersion(kyvernov2beta1.GroupVersion).WithKind("PolicyException")
-	exceptionV2      = schema.GroupVersion(kyvernov2.GroupVersion).WithKind("PolicyException")
+	exceptionV2beta1     = schema.GroupVersion(kyvernov2beta1.GroupVersion).WithKind("PolicyException")
+	exceptionV2          = schema.GroupVersion(kyvernov2.GroupVersion).WithKind("PolicyException")
+	celExceptionV2alpha1 = schema.GroupVersion(kyvernov2alpha1.GroupVersion).WithKind("CELPolicyException")
 )
 
-func Load(paths ...string) ([]*kyvernov2.PolicyException, error) {
-	var out []*kyvernov2.PolicyException
+// LoaderResults holds the exceptions loaded from files, CEL exceptions apply to CEL based policies only
+type LoaderResults struct {
+	Exceptions    []*kyvernov2.PolicyException
+	CELExceptions []*kyvernov2alpha1.CELPolicyException
+}
+
+func (l *LoaderResults) merge(results *LoaderResults) {
+	if results == nil {
+		return
+	}
+	l.Exceptions = append(l.Exceptions, results.Exceptions...)
+	l.CELExceptions = append(l.CELExceptions, results.CELExceptions...)
+}
+
+func Load(paths ...string) (*LoaderResults, error) {
+	out := &LoaderResults{}
 	for _, path := range paths {
 		bytes, err := os.ReadFile(filepath.Clean(path))
 		if err != nil {
@@ -32,17 +48,17 @@ func Load(paths ...string) ([]*kyvernov2.PolicyException, error) {
 		if err != nil {
 			return nil, fmt.Errorf("unable to load exceptions (%w)", err)
 		}
-		out = append(out, exceptions...)
+		out.merge(exceptions)
 	}
 	return out, nil
 }
 
-func load(content []byte) ([]*kyvernov2.PolicyException, error) {
+func load(content []byte) (*LoaderResults, error) {
 	documents, err := yamlutils.SplitDocuments(content)
 	if err != nil {
 		return nil, err
 	}
-	var exceptions []*kyvernov2.PolicyException
+	results := &LoaderResults{}
 	crds, err := data.Crds()
 	if err != nil {
 		return nil, err
@@ -64,12 +80,18 @@ func load(content []byte) ([]*kyvernov2.PolicyException, error) {
 			if err != nil {
 				return nil, err
 			}
-			exceptions = append(exceptions, exception)
+			results.Exceptions = append(results.Exceptions, exception)
+		case celExceptionV2alpha1:
+			exception, err := convert.To[kyvernov2alpha1.CELPolicyException](untyped)
+			if err != nil {
+				return nil, err
+			}
+			results.CELExceptions = append(results.CELExceptions, exception)
 		default:
 			return nil, fmt.Errorf("policy exception type not supported %s", gvk)
 		}
 	}
-	return exceptions, nil
+	return results, nil
 }
 
 func SelectFrom(resources []*unstructured.Unstructured) []*kyvernov2.PolicyException {
@@ -15,10 +15,11 @@ import (
 
 func Test_load(t *testing.T) {
 	tests := []struct {
-		name       string
-		policies   string
-		wantLoaded int
-		wantErr    bool
+		name          string
+		policies      string
+		wantLoaded    int
+		wantCELLoaded int
+		wantErr       bool
 	}{{
 		name:     "not a policy exception",
 		policies: "../_testdata/resources/namespace.yaml",
@@ -31,6 +32,10 @@ func Test_load(t *testing.T) {
 		name:     "policy exception and policy",
 		policies: "../_testdata/exceptions/exception-and-policy.yaml",
 		wantErr:  true,
+	}, {
+		name:          "cel policy exception",
+		policies:      "../_testdata/exceptions/cel-exception.yaml",
+		wantCELLoaded: 1,
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -39,8 +44,10 @@ func Test_load(t *testing.T) {
 			require.NoError(t, err)
 			if res, err := load(bytes); (err != nil) != tt.wantErr {
 				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
-			} else if len(res) != tt.wantLoaded {
-				t.Errorf("Load() loaded amount = %v, wantLoaded %v", len(res), tt.wantLoaded)
+			} else if res != nil && len(res.Exceptions) != tt.wantLoaded {
+				t.Errorf("Load() loaded amount = %v, wantLoaded %v", len(res.Exceptions), tt.wantLoaded)
+			} else if res != nil && len(res.CELExceptions) != tt.wantCELLoaded {
+				t.Errorf("Load() loaded CEL amount = %v, wantCELLoaded %v", len(res.CELExceptions), tt.wantCELLoaded)
 			}
 		})
 	}
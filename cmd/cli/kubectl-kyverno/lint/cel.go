@@ -0,0 +1,101 @@
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	celpolicy "github.com/kyverno/kyverno/pkg/cel/policy"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/admission/plugin/cel"
+	"k8s.io/apiserver/pkg/admission/plugin/policy/validating"
+	"k8s.io/apiserver/pkg/admission/plugin/webhook/matchconditions"
+	"k8s.io/apiserver/pkg/cel/environment"
+)
+
+// checkCELExpressions compiles the CEL expressions of a rule, objects are not typed
+// so only syntax errors, unknown references and mismatched types are reported
+func checkCELExpressions(_ kyvernov1.PolicyInterface, rule kyvernov1.Rule) []string {
+	if !rule.HasValidateCEL() && len(rule.CELPreconditions) == 0 {
+		return nil
+	}
+	compiler, err := cel.NewCompositedCompiler(environment.MustBaseEnvSet(environment.DefaultCompatibilityVersion(), false))
+	if err != nil {
+		return []string{fmt.Sprintf("failed to create the CEL compiler: %s", err)}
+	}
+	options := cel.OptionalVariableDeclarations{
+		HasAuthorizer: true,
+	}
+	var messages []string
+	check := func(path string, result cel.CompilationResult) {
+		if result.Error != nil {
+			// drop the source excerpts following each error
+			var lines []string
+			for _, line := range strings.Split(result.Error.Detail, "\n") {
+				if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "|") {
+					lines = append(lines, line)
+				}
+			}
+			messages = append(messages, fmt.Sprintf("%s: %s", path, strings.Join(lines, "; ")))
+		}
+	}
+	if rule.HasValidateCEL() {
+		spec := rule.Validation.CEL
+		options.HasParams = spec.ParamKind != nil
+		for i, variable := range spec.Variables {
+			result := compiler.CompileAndStoreVariable(&validating.Variable{Name: variable.Name, Expression: variable.Expression}, options, environment.StoredExpressions)
+			check(fmt.Sprintf("validate.cel.variables[%d].expression", i), result)
+		}
+		for i, expression := range spec.Expressions {
+			result := compiler.CompileCELExpression(&validating.ValidationCondition{Expression: expression.Expression}, options, environment.StoredExpressions)
+			check(fmt.Sprintf("validate.cel.expressions[%d].expression", i), result)
+			if expression.MessageExpression != "" {
+				result := compiler.CompileCELExpression(&validating.MessageExpressionCondition{MessageExpression: expression.MessageExpression}, options, environment.StoredExpressions)
+				check(fmt.Sprintf("validate.cel.expressions[%d].messageExpression", i), result)
+			}
+		}
+		for i, annotation := range spec.AuditAnnotations {
+			result := compiler.CompileCELExpression(&validating.AuditAnnotationCondition{Key: annotation.Key, ValueExpression: annotation.ValueExpression}, options, environment.StoredExpressions)
+			check(fmt.Sprintf("validate.cel.auditAnnotations[%d].valueExpression", i), result)
+		}
+	}
+	for i, condition := range rule.CELPreconditions {
+		result := compiler.CompileCELExpression(&matchconditions.MatchCondition{Name: condition.Name, Expression: condition.Expression}, options, environment.StoredExpressions)
+		check(fmt.Sprintf("celPreconditions[%d].expression", i), result)
+	}
+	return messages
+}
+
+// LintValidatingPolicy compiles a ValidatingPolicy, compilation errors are reported by the cel-type-check check
+func LintValidatingPolicy(policy *kyvernov2alpha1.ValidatingPolicy, severities Severities) []Finding {
+	_, errs := celpolicy.NewCompiler().Compile(policy, nil)
+	return compilationFindings(policy.GetName(), errs, severities)
+}
+
+// LintMutatingPolicy compiles a MutatingPolicy, compilation errors are reported by the cel-type-check check
+func LintMutatingPolicy(policy *kyvernov2alpha1.MutatingPolicy, severities Severities) []Finding {
+	_, errs := celpolicy.NewCompiler().CompileMutating(policy, nil)
+	return compilationFindings(policy.GetName(), errs, severities)
+}
+
+// compilationFindings reports the field errors of a CEL policy, they are not bound to a rule
+func compilationFindings(name string, errs field.ErrorList, severities Severities) []Finding {
+	checks := Checks()
+	check := checks[slices.IndexFunc(checks, func(check Check) bool { return check.ID == "cel-type-check" })]
+	severity := severities.get(check)
+	if severity == SeverityOff {
+		return nil
+	}
+	var findings []Finding
+	for _, err := range errs {
+		findings = append(findings, Finding{
+			Check:    check.ID,
+			Severity: severity,
+			Policy:   name,
+			Message:  err.Error(),
+		})
+	}
+	return findings
+}
@@ -0,0 +1,334 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/ext/wildcard"
+	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	policyvalidation "github.com/kyverno/kyverno/pkg/validation/policy"
+)
+
+// requestFields are the fields of the admission request available to JMESPath expressions
+var requestFields = []string{
+	"clusterRoles",
+	"dryRun",
+	"kind",
+	"name",
+	"namespace",
+	"object",
+	"oldObject",
+	"operation",
+	"options",
+	"requestKind",
+	"requestResource",
+	"requestSubResource",
+	"resource",
+	"roles",
+	"subResource",
+	"uid",
+	"userInfo",
+}
+
+var regexRequestField = regexp.MustCompile(`(?:^|[^\w.])request\.([a-zA-Z_][a-zA-Z0-9_]*)`)
+
+func checkBackgroundVariables(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) []string {
+	if !policy.GetSpec().BackgroundProcessingEnabled() || rule.HasMutateExisting() {
+		return nil
+	}
+	var messages []string
+	for _, filter := range filters(rule.MatchResources) {
+		if !datautils.DeepEqual(filter.UserInfo, kyvernov1.UserInfo{}) {
+			messages = append(messages, fmt.Sprintf("match %s filters on user information which is not available in background mode", filter.path))
+		}
+	}
+	if rule.ExcludeResources != nil {
+		for _, filter := range filters(*rule.ExcludeResources) {
+			if !datautils.DeepEqual(filter.UserInfo, kyvernov1.UserInfo{}) {
+				messages = append(messages, fmt.Sprintf("exclude %s filters on user information which is not available in background mode", filter.path))
+			}
+		}
+	}
+	for _, variable := range uniqueVariables(rule) {
+		for _, forbidden := range policyvalidation.ForbiddenUserVariables {
+			if forbidden.MatchString(variable) {
+				messages = append(messages, fmt.Sprintf("variable %s is not available in background mode, set spec.background to false or remove it", variable))
+				break
+			}
+		}
+	}
+	return messages
+}
+
+func checkWildcardForeach(_ kyvernov1.PolicyInterface, rule kyvernov1.Rule) []string {
+	hasForeach := (rule.Validation != nil && len(rule.Validation.ForEachValidation) > 0) ||
+		(rule.Mutation != nil && len(rule.Mutation.ForEachMutation) > 0) ||
+		(rule.Generation != nil && len(rule.Generation.ForEachGeneration) > 0)
+	if !hasForeach {
+		return nil
+	}
+	var messages []string
+	for _, filter := range filters(rule.MatchResources) {
+		for _, kind := range filter.Kinds {
+			if strings.Contains(kind, "*") {
+				messages = append(messages, fmt.Sprintf("match %s uses the wildcard kind %s together with foreach, the loop runs for every matching resource", filter.path, kind))
+			}
+		}
+	}
+	return messages
+}
+
+func checkRequestPaths(_ kyvernov1.PolicyInterface, rule kyvernov1.Rule) []string {
+	var messages []string
+	for _, variable := range uniqueVariables(rule) {
+		var unknown []string
+		for _, match := range regexRequestField.FindAllStringSubmatch(variable, -1) {
+			if !slices.Contains(requestFields, match[1]) && !slices.Contains(unknown, match[1]) {
+				unknown = append(unknown, match[1])
+			}
+		}
+		for _, field := range unknown {
+			messages = append(messages, fmt.Sprintf("variable %s references request.%s which is not a field of the admission request", variable, field))
+		}
+	}
+	return messages
+}
+
+func checkUnusedContextEntries(_ kyvernov1.PolicyInterface, rule kyvernov1.Rule) []string {
+	used := variables(rule)
+	var messages []string
+	for _, entries := range contextEntries(rule) {
+		for _, entry := range entries.entries {
+			if entry.Name == "" {
+				continue
+			}
+			// references made by the entry itself don't count
+			own := variables(entry)
+			reference := regexp.MustCompile(`(?:^|[^\w.])"?` + regexp.QuoteMeta(entry.Name) + `"?(?:[^\w-]|$)`)
+			count := 0
+			for _, variable := range used {
+				if reference.MatchString(variable) {
+					count++
+				}
+			}
+			for _, variable := range own {
+				if reference.MatchString(variable) {
+					count--
+				}
+			}
+			if count <= 0 {
+				messages = append(messages, fmt.Sprintf("context entry %s declared in %s is never used", entry.Name, entries.path))
+			}
+		}
+	}
+	return messages
+}
+
+func checkMatchExcludeOverlap(_ kyvernov1.PolicyInterface, rule kyvernov1.Rule) []string {
+	if rule.ExcludeResources == nil {
+		return nil
+	}
+	var excludes []filter
+	for _, filter := range filters(*rule.ExcludeResources) {
+		// all exclude filters must match together unless there is only one
+		if strings.HasPrefix(filter.path, "all") && len(rule.ExcludeResources.All) > 1 {
+			continue
+		}
+		if !datautils.DeepEqual(filter.ResourceFilter, kyvernov1.ResourceFilter{}) {
+			excludes = append(excludes, filter)
+		}
+	}
+	var messages []string
+	for _, match := range filters(rule.MatchResources) {
+		for _, exclude := range excludes {
+			if covers(exclude.ResourceFilter, match.ResourceFilter) {
+				messages = append(messages, fmt.Sprintf("exclude %s covers match %s, the resources it matches are always excluded", exclude.path, match.path))
+			}
+		}
+	}
+	return messages
+}
+
+func checkMissingMessages(_ kyvernov1.PolicyInterface, rule kyvernov1.Rule) []string {
+	validation := rule.Validation
+	if validation == nil || validation.Message != "" {
+		return nil
+	}
+	var messages []string
+	if validation.RawPattern != nil || validation.RawAnyPattern != nil || validation.Deny != nil || len(validation.ForEachValidation) > 0 {
+		messages = append(messages, "validate rule has no message")
+	}
+	if validation.CEL != nil {
+		for i, expression := range validation.CEL.Expressions {
+			if expression.Message == "" && expression.MessageExpression == "" {
+				messages = append(messages, fmt.Sprintf("validate.cel.expressions[%d] has no message or messageExpression", i))
+			}
+		}
+	}
+	return messages
+}
+
+// filter is a match or exclude resource filter with its location in the block
+type filter struct {
+	kyvernov1.ResourceFilter
+	path string
+}
+
+// filters returns the resource filters of a match or exclude block, including the deprecated resources syntax
+func filters(resources kyvernov1.MatchResources) []filter {
+	var out []filter
+	legacy := kyvernov1.ResourceFilter{
+		ResourceDescription: resources.ResourceDescription,
+		UserInfo:            resources.UserInfo,
+	}
+	if !datautils.DeepEqual(legacy, kyvernov1.ResourceFilter{}) {
+		out = append(out, filter{ResourceFilter: legacy, path: "resources"})
+	}
+	for i, f := range resources.Any {
+		out = append(out, filter{ResourceFilter: f, path: fmt.Sprintf("any[%d]", i)})
+	}
+	for i, f := range resources.All {
+		out = append(out, filter{ResourceFilter: f, path: fmt.Sprintf("all[%d]", i)})
+	}
+	return out
+}
+
+// covers returns true if every resource matched by the match filter is also matched by the exclude filter
+func covers(exclude, match kyvernov1.ResourceFilter) bool {
+	if !coversNames(exclude.Kinds, match.Kinds) {
+		return false
+	}
+	if !coversNames(names(exclude.ResourceDescription), names(match.ResourceDescription)) {
+		return false
+	}
+	if !coversNames(exclude.Namespaces, match.Namespaces) {
+		return false
+	}
+	if len(exclude.Operations) > 0 {
+		if len(match.Operations) == 0 {
+			return false
+		}
+		for _, operation := range match.Operations {
+			if !slices.Contains(exclude.Operations, operation) {
+				return false
+			}
+		}
+	}
+	if exclude.Annotations != nil && !datautils.DeepEqual(exclude.Annotations, match.Annotations) {
+		return false
+	}
+	if exclude.Selector != nil && !datautils.DeepEqual(exclude.Selector, match.Selector) {
+		return false
+	}
+	if exclude.NamespaceSelector != nil && !datautils.DeepEqual(exclude.NamespaceSelector, match.NamespaceSelector) {
+		return false
+	}
+	if !datautils.DeepEqual(exclude.UserInfo, kyvernov1.UserInfo{}) && !datautils.DeepEqual(exclude.UserInfo, match.UserInfo) {
+		return false
+	}
+	return true
+}
+
+// coversNames returns true if the exclude patterns are empty or match all the match names
+func coversNames(exclude, match []string) bool {
+	if len(exclude) == 0 {
+		return true
+	}
+	if len(match) == 0 {
+		return false
+	}
+	for _, name := range match {
+		if !slices.ContainsFunc(exclude, func(pattern string) bool { return wildcard.Match(pattern, name) }) {
+			return false
+		}
+	}
+	return true
+}
+
+func names(description kyvernov1.ResourceDescription) []string {
+	if description.Name == "" {
+		return description.Names
+	}
+	return append([]string{description.Name}, description.Names...)
+}
+
+// entries are the context entries declared in a section of a rule
+type entries struct {
+	entries []kyvernov1.ContextEntry
+	path    string
+}
+
+func contextEntries(rule kyvernov1.Rule) []entries {
+	out := []entries{{entries: rule.Context, path: "context"}}
+	if rule.Validation != nil {
+		for i, foreach := range rule.Validation.ForEachValidation {
+			out = append(out, entries{entries: foreach.Context, path: fmt.Sprintf("validate.foreach[%d].context", i)})
+		}
+	}
+	if rule.Mutation != nil {
+		for i, foreach := range rule.Mutation.ForEachMutation {
+			out = append(out, entries{entries: foreach.Context, path: fmt.Sprintf("mutate.foreach[%d].context", i)})
+		}
+		for i, target := range rule.Mutation.Targets {
+			out = append(out, entries{entries: target.Context, path: fmt.Sprintf("mutate.targets[%d].context", i)})
+		}
+	}
+	if rule.Generation != nil {
+		for i, foreach := range rule.Generation.ForEachGeneration {
+			out = append(out, entries{entries: foreach.Context, path: fmt.Sprintf("generate.foreach[%d].context", i)})
+		}
+	}
+	return out
+}
+
+// jmesPathFields are the fields holding a JMESPath expression without the {{...}} delimiters
+var jmesPathFields = []string{"jmesPath", "list"}
+
+// variables returns all the {{...}} variables found in the string values of an object,
+// and the JMESPath expressions of fields that don't need the delimiters
+func variables(obj any) []string {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil
+	}
+	var document any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil
+	}
+	var out []string
+	var walk func(any)
+	walk = func(value any) {
+		switch value := value.(type) {
+		case map[string]any:
+			for key, v := range value {
+				if expression, ok := v.(string); ok && slices.Contains(jmesPathFields, key) && !regex.IsVariable(expression) {
+					out = append(out, expression)
+					continue
+				}
+				walk(v)
+			}
+		case []any:
+			for _, v := range value {
+				walk(v)
+			}
+		case string:
+			for _, match := range regex.RegexVariables.FindAllStringSubmatch(value, -1) {
+				out = append(out, match[2])
+			}
+		}
+	}
+	walk(document)
+	return out
+}
+
+// uniqueVariables returns the sorted variables of an object without duplicates
+func uniqueVariables(obj any) []string {
+	out := variables(obj)
+	slices.Sort(out)
+	return slices.Compact(out)
+}
@@ -0,0 +1,152 @@
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+	SeverityOff     Severity = "off"
+)
+
+var severities = []Severity{SeverityError, SeverityWarning, SeverityInfo, SeverityOff}
+
+// Check is a best practice check run against every rule of a policy
+type Check struct {
+	// ID identifies the check, it is used to configure its severity
+	ID string
+	// Description explains what the check reports
+	Description string
+	// Severity is the default severity of the findings
+	Severity Severity
+	run      func(kyvernov1.PolicyInterface, kyvernov1.Rule) []string
+}
+
+// Checks returns the checks run by Lint
+func Checks() []Check {
+	return []Check{
+		{
+			ID:          "background-variables",
+			Description: "Rules of background policies must not use variables that are only available in admission requests",
+			Severity:    SeverityError,
+			run:         checkBackgroundVariables,
+		},
+		{
+			ID:          "wildcard-foreach",
+			Description: "Rules matching wildcard kinds should not use foreach, the loop runs for every resource of the cluster",
+			Severity:    SeverityWarning,
+			run:         checkWildcardForeach,
+		},
+		{
+			ID:          "unknown-request-path",
+			Description: "JMESPath expressions should only reference known fields of the admission request",
+			Severity:    SeverityError,
+			run:         checkRequestPaths,
+		},
+		{
+			ID:          "unused-context-entry",
+			Description: "Context entries should be used by the rule that declares them",
+			Severity:    SeverityWarning,
+			run:         checkUnusedContextEntries,
+		},
+		{
+			ID:          "overlapping-match-exclude",
+			Description: "Exclude blocks should not cover a whole match block, the resources it matches are always excluded",
+			Severity:    SeverityWarning,
+			run:         checkMatchExcludeOverlap,
+		},
+		{
+			ID:          "cel-type-check",
+			Description: "CEL expressions must compile and type check",
+			Severity:    SeverityError,
+			run:         checkCELExpressions,
+		},
+		{
+			ID:          "missing-message",
+			Description: "Validate rules should have a message explaining the violation",
+			Severity:    SeverityWarning,
+			run:         checkMissingMessages,
+		},
+	}
+}
+
+// Finding is a problem reported by a check
+type Finding struct {
+	// Check is the ID of the check reporting the problem
+	Check string
+	// Severity is the severity of the problem
+	Severity Severity
+	// Path is the file the policy was loaded from, if known
+	Path string
+	// Policy is the policy name, prefixed with its namespace for namespaced policies
+	Policy string
+	// Rule is the rule name, it is empty for CEL policies
+	Rule string
+	// Message describes the problem
+	Message string
+}
+
+// Severities overrides the default severities of the checks
+type Severities map[string]Severity
+
+// ParseSeverities parses severity overrides in the check=severity form
+func ParseSeverities(values ...string) (Severities, error) {
+	checks := Checks()
+	out := Severities{}
+	for _, value := range values {
+		id, severity, ok := strings.Cut(value, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid severity %q, must be of the form check=severity", value)
+		}
+		if !slices.ContainsFunc(checks, func(check Check) bool { return check.ID == id }) {
+			return nil, fmt.Errorf("unknown check %q", id)
+		}
+		if !slices.Contains(severities, Severity(severity)) {
+			return nil, fmt.Errorf("invalid severity %q for check %s, must be one of error, warning, info or off", severity, id)
+		}
+		out[id] = Severity(severity)
+	}
+	return out, nil
+}
+
+func (s Severities) get(check Check) Severity {
+	if severity, ok := s[check.ID]; ok {
+		return severity
+	}
+	return check.Severity
+}
+
+// Lint runs the checks against the rules of a policy, checks with the off severity are skipped
+func Lint(policy kyvernov1.PolicyInterface, severities Severities) []Finding {
+	name := policy.GetName()
+	if policy.IsNamespaced() {
+		name = policy.GetNamespace() + "/" + name
+	}
+	var findings []Finding
+	for _, rule := range policy.GetSpec().Rules {
+		for _, check := range Checks() {
+			severity := severities.get(check)
+			if severity == SeverityOff {
+				continue
+			}
+			for _, message := range check.run(policy, rule) {
+				findings = append(findings, Finding{
+					Check:    check.ID,
+					Severity: severity,
+					Policy:   name,
+					Rule:     rule.Name,
+					Message:  message,
+				})
+			}
+		}
+	}
+	return findings
+}
@@ -0,0 +1,314 @@
+package lint
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadPolicy(t *testing.T, content string) kyvernov1.PolicyInterface {
+	t.Helper()
+	policies, _, _, _, err := yamlutils.GetPolicy([]byte(content))
+	assert.NoError(t, err)
+	assert.Len(t, policies, 1)
+	return policies[0]
+}
+
+func checks(findings []Finding) []string {
+	var out []string
+	for _, finding := range findings {
+		out = append(out, finding.Check)
+	}
+	return out
+}
+
+func TestParseSeverities(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    Severities
+		wantErr bool
+	}{{
+		name: "empty",
+		want: Severities{},
+	}, {
+		name:   "valid",
+		values: []string{"missing-message=error", "cel-type-check=off"},
+		want:   Severities{"missing-message": SeverityError, "cel-type-check": SeverityOff},
+	}, {
+		name:    "missing separator",
+		values:  []string{"missing-message"},
+		wantErr: true,
+	}, {
+		name:    "unknown check",
+		values:  []string{"foo=error"},
+		wantErr: true,
+	}, {
+		name:    "unknown severity",
+		values:  []string{"missing-message=fatal"},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSeverities(tt.values...)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     string
+		severities Severities
+		want       []string
+	}{{
+		name: "clean",
+		policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+spec:
+  background: true
+  rules:
+  - name: check-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    context:
+    - name: team
+      variable:
+        jmesPath: request.object.metadata.labels.team
+    validate:
+      message: "label team is required"
+      deny:
+        conditions:
+          any:
+          - key: "{{ team || '' }}"
+            operator: Equals
+            value: ""
+`,
+	}, {
+		name: "background variables",
+		policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: check-user
+spec:
+  background: true
+  rules:
+  - name: check-user
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: "{{ request.userInfo.username }} is not allowed"
+      deny: {}
+`,
+		want: []string{"background-variables"},
+	}, {
+		name: "background variables ignored when background is false",
+		policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: check-user
+spec:
+  background: false
+  rules:
+  - name: check-user
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: "{{ request.userInfo.username }} is not allowed"
+      deny: {}
+`,
+	}, {
+		name: "wildcard foreach",
+		policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: check-labels
+spec:
+  background: false
+  rules:
+  - name: check-labels
+    match:
+      any:
+      - resources:
+          kinds:
+          - "*"
+    validate:
+      message: "labels must not be empty"
+      foreach:
+      - list: "request.object.metadata.labels | items(@, 'key', 'value')"
+        deny:
+          conditions:
+            any:
+            - key: "{{ element.value }}"
+              operator: Equals
+              value: ""
+`,
+		want: []string{"wildcard-foreach"},
+	}, {
+		name: "unknown request path",
+		policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: check-namespace
+spec:
+  background: false
+  rules:
+  - name: check-namespace
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: "namespace is required"
+      deny:
+        conditions:
+          any:
+          - key: "{{ request.namespce }}"
+            operator: Equals
+            value: ""
+`,
+		want: []string{"unknown-request-path"},
+	}, {
+		name: "unused context entry",
+		policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: check-registry
+spec:
+  background: false
+  rules:
+  - name: check-registry
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    context:
+    - name: settings
+      configMap:
+        name: settings
+        namespace: default
+    validate:
+      message: "image is required"
+      pattern:
+        spec:
+          containers:
+          - image: "?*"
+`,
+		want: []string{"unused-context-entry"},
+	}, {
+		name: "overlapping match and exclude",
+		policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: check-pods
+spec:
+  background: false
+  rules:
+  - name: check-pods
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+          namespaces:
+          - kube-system
+    exclude:
+      any:
+      - resources:
+          namespaces:
+          - kube-*
+    validate:
+      message: "image is required"
+      pattern:
+        spec:
+          containers:
+          - image: "?*"
+`,
+		want: []string{"overlapping-match-exclude"},
+	}, {
+		name: "cel type check and missing message",
+		policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: check-replicas
+spec:
+  background: false
+  rules:
+  - name: check-replicas
+    match:
+      any:
+      - resources:
+          kinds:
+          - Deployment
+    validate:
+      cel:
+        expressions:
+        - expression: "object.spec.replicas <= 5 + 'five'"
+`,
+		want: []string{"cel-type-check", "missing-message"},
+	}, {
+		name: "severity override",
+		policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: check-replicas
+spec:
+  background: false
+  rules:
+  - name: check-replicas
+    match:
+      any:
+      - resources:
+          kinds:
+          - Deployment
+    validate:
+      cel:
+        expressions:
+        - expression: "object.spec.replicas <= 5 + 'five'"
+`,
+		severities: Severities{"cel-type-check": SeverityOff, "missing-message": SeverityInfo},
+		want:       []string{"missing-message"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := Lint(loadPolicy(t, tt.policy), tt.severities)
+			assert.Equal(t, tt.want, checks(findings))
+			for _, finding := range findings {
+				assert.NotEmpty(t, finding.Message)
+				if severity, ok := tt.severities[finding.Check]; ok {
+					assert.Equal(t, severity, finding.Severity)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package lint
+
+import (
+	"io"
+	"path/filepath"
+	"slices"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/formatter"
+)
+
+func sarifLevel(severity Severity) string {
+	switch severity {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "note"
+	default:
+		return "none"
+	}
+}
+
+// WriteSarif writes findings as a SARIF log, checks are reported as rules and findings are located at the policy rule
+func WriteSarif(out io.Writer, findings []Finding, severities Severities) error {
+	checks := Checks()
+	run := formatter.NewSarifRun("kyverno-lint")
+	for _, check := range checks {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, formatter.SarifRule{
+			ID:                   check.ID,
+			ShortDescription:     formatter.SarifMessage{Text: check.Description},
+			DefaultConfiguration: &formatter.SarifConfiguration{Level: sarifLevel(severities.get(check))},
+		})
+	}
+	for _, finding := range findings {
+		logicalLocation := formatter.SarifLogicalLocation{
+			FullyQualifiedName: finding.Policy + "/" + finding.Rule,
+			Kind:               "rule",
+		}
+		if finding.Rule == "" {
+			logicalLocation = formatter.SarifLogicalLocation{FullyQualifiedName: finding.Policy, Kind: "policy"}
+		}
+		location := formatter.SarifLocation{
+			LogicalLocations: []formatter.SarifLogicalLocation{logicalLocation},
+		}
+		if finding.Path != "" {
+			location.PhysicalLocation = &formatter.SarifPhysicalLocation{
+				ArtifactLocation: formatter.SarifArtifactLocation{URI: filepath.ToSlash(finding.Path)},
+			}
+		}
+		run.Results = append(run.Results, formatter.SarifResult{
+			RuleID:    finding.Check,
+			RuleIndex: slices.IndexFunc(checks, func(check Check) bool { return check.ID == finding.Check }),
+			Level:     sarifLevel(finding.Severity),
+			Message:   formatter.SarifMessage{Text: finding.Message},
+			Locations: []formatter.SarifLocation{location},
+		})
+	}
+	return formatter.WriteSarif(out, run)
+}
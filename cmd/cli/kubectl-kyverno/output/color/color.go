@@ -79,3 +79,15 @@ func ResultError() string {
 func ResultSkip() string {
 	return color.BoldFgCyan.Sprint("Skip")
 }
+
+func DiffMissing(text string) string {
+	return color.BoldRed.Sprint(text)
+}
+
+func DiffUnexpected(text string) string {
+	return color.BoldGreen.Sprint(text)
+}
+
+func DiffChanged(text string) string {
+	return color.BoldYellow.Sprint(text)
+}
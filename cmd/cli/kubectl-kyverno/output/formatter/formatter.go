@@ -0,0 +1,72 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+type Status string
+
+const (
+	StatusPass  Status = "pass"
+	StatusFail  Status = "fail"
+	StatusWarn  Status = "warn"
+	StatusError Status = "error"
+	StatusSkip  Status = "skip"
+)
+
+// Result is a single outcome to be reported, for apply it is a rule response, for test it is a test case result
+type Result struct {
+	// Suite groups results together (the policy for apply, the test for test)
+	Suite string
+	// Policy is the policy name
+	Policy string
+	// Rule is the rule name
+	Rule string
+	// Resource identifies the resource the result applies to
+	Resource string
+	// Path is the file the resource was loaded from, if known
+	Path string
+	// Line is the line of the resource in the file, if known
+	Line int
+	// Status is the result status
+	Status Status
+	// Message is the result message
+	Message string
+}
+
+// Formatter writes results in a machine readable format
+type Formatter interface {
+	Format(out io.Writer, results []Result) error
+}
+
+var formatters = map[string]Formatter{
+	"junit": junit{},
+	"sarif": sarif{},
+}
+
+// Get returns the formatter registered for the given format
+func Get(format string) (Formatter, bool) {
+	formatter, ok := formatters[format]
+	return formatter, ok
+}
+
+// Formats returns the names of the registered formats
+func Formats() []string {
+	names := make([]string, 0, len(formatters))
+	for name := range formatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate returns an error if the format is not registered
+func Validate(format string) error {
+	if _, ok := Get(format); !ok {
+		return fmt.Errorf("invalid output format %q, must be one of %s", format, strings.Join(Formats(), ", "))
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var results = []Result{{
+	Suite:    "disallow-host-path",
+	Policy:   "disallow-host-path",
+	Rule:     "host-path",
+	Resource: "v1/Pod/default/good-pod",
+	Path:     "pod1.yaml",
+	Line:     1,
+	Status:   StatusPass,
+}, {
+	Suite:    "disallow-host-path",
+	Policy:   "disallow-host-path",
+	Rule:     "host-path",
+	Resource: "v1/Pod/default/bad-pod",
+	Path:     "pods.yaml",
+	Line:     12,
+	Status:   StatusFail,
+	Message:  "hostPath volumes are forbidden",
+}, {
+	Suite:    "require-labels",
+	Policy:   "require-labels",
+	Rule:     "team",
+	Resource: "v1/Pod/default/bad-pod",
+	Status:   StatusWarn,
+	Message:  "label team is required",
+}, {
+	Suite:    "require-labels",
+	Policy:   "require-labels",
+	Rule:     "owner",
+	Resource: "v1/Pod/default/bad-pod",
+	Status:   StatusSkip,
+}}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate("junit"))
+	assert.NoError(t, Validate("sarif"))
+	assert.EqualError(t, Validate("xml"), `invalid output format "xml", must be one of junit, sarif`)
+}
+
+func TestJunit(t *testing.T) {
+	f, ok := Get("junit")
+	assert.True(t, ok)
+	var out bytes.Buffer
+	assert.NoError(t, f.Format(&out, results))
+	var report junitTestSuites
+	assert.NoError(t, xml.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, 4, report.Tests)
+	assert.Equal(t, 1, report.Failures)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Len(t, report.Suites, 2)
+	assert.Equal(t, "disallow-host-path", report.Suites[0].Name)
+	assert.Equal(t, 2, report.Suites[0].Tests)
+	failed := report.Suites[0].TestCases[1]
+	assert.Equal(t, "host-path v1/Pod/default/bad-pod", failed.Name)
+	assert.Equal(t, "disallow-host-path", failed.ClassName)
+	assert.Equal(t, "pods.yaml", failed.File)
+	assert.Equal(t, 12, failed.Line)
+	assert.NotNil(t, failed.Failure)
+	assert.Equal(t, "hostPath volumes are forbidden", failed.Failure.Message)
+	assert.Equal(t, "warning: label team is required", report.Suites[1].TestCases[0].SystemOut)
+	assert.NotNil(t, report.Suites[1].TestCases[1].Skipped)
+}
+
+func TestSarif(t *testing.T) {
+	f, ok := Get("sarif")
+	assert.True(t, ok)
+	var out bytes.Buffer
+	assert.NoError(t, f.Format(&out, results))
+	var log SarifLog
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &log))
+	assert.Equal(t, SarifVersion, log.Version)
+	assert.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Len(t, run.Tool.Driver.Rules, 3)
+	assert.Len(t, run.Results, 2)
+	failed := run.Results[0]
+	assert.Equal(t, "disallow-host-path/host-path", failed.RuleID)
+	assert.Equal(t, 0, failed.RuleIndex)
+	assert.Equal(t, "error", failed.Level)
+	assert.Equal(t, "pods.yaml", failed.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 12, failed.Locations[0].PhysicalLocation.Region.StartLine)
+	assert.Equal(t, "v1/Pod/default/bad-pod", failed.Locations[0].LogicalLocations[0].FullyQualifiedName)
+	warned := run.Results[1]
+	assert.Equal(t, "require-labels/team", warned.RuleID)
+	assert.Equal(t, 1, warned.RuleIndex)
+	assert.Equal(t, "warning", warned.Level)
+	assert.Nil(t, warned.Locations[0].PhysicalLocation)
+}
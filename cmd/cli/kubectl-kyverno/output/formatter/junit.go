@@ -0,0 +1,97 @@
+package formatter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// junit maps suites to test suites and results to test cases named after the rule and the resource
+type junit struct{}
+
+func (junit) Format(out io.Writer, results []Result) error {
+	report := junitTestSuites{
+		Name: "kyverno",
+	}
+	suites := map[string]int{}
+	for _, result := range results {
+		index, ok := suites[result.Suite]
+		if !ok {
+			report.Suites = append(report.Suites, junitTestSuite{Name: result.Suite})
+			index = len(report.Suites) - 1
+			suites[result.Suite] = index
+		}
+		suite := &report.Suites[index]
+		testCase := junitTestCase{
+			Name:      fmt.Sprintf("%s %s", result.Rule, result.Resource),
+			ClassName: result.Policy,
+			File:      result.Path,
+			Line:      result.Line,
+		}
+		switch result.Status {
+		case StatusFail:
+			testCase.Failure = &junitMessage{Message: result.Message, Type: string(result.Status), Text: result.Message}
+			suite.Failures++
+		case StatusError:
+			testCase.Error = &junitMessage{Message: result.Message, Type: string(result.Status), Text: result.Message}
+			suite.Errors++
+		case StatusSkip:
+			testCase.Skipped = &junitMessage{Message: result.Message}
+			suite.Skipped++
+		case StatusWarn:
+			testCase.SystemOut = "warning: " + result.Message
+		}
+		suite.Tests++
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	for _, suite := range report.Suites {
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Skipped += suite.Skipped
+	}
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(out, xml.Header+string(data)); err != nil {
+		return err
+	}
+	return nil
+}
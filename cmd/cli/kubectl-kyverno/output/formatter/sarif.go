@@ -0,0 +1,165 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+)
+
+const (
+	SarifVersion = "2.1.0"
+	SarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// SarifLog is a SARIF log, the types below cover the subset of the SARIF model reported by the CLI
+type SarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+type SarifRun struct {
+	Tool    SarifTool     `json:"tool"`
+	Results []SarifResult `json:"results"`
+}
+
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+type SarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []SarifRule `json:"rules"`
+}
+
+type SarifRule struct {
+	ID                   string              `json:"id"`
+	Name                 string              `json:"name,omitempty"`
+	ShortDescription     SarifMessage        `json:"shortDescription"`
+	DefaultConfiguration *SarifConfiguration `json:"defaultConfiguration,omitempty"`
+}
+
+type SarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+type SarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   SarifMessage    `json:"message"`
+	Locations []SarifLocation `json:"locations,omitempty"`
+}
+
+type SarifLocation struct {
+	PhysicalLocation *SarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []SarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+	Region           *SarifRegion          `json:"region,omitempty"`
+}
+
+type SarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type SarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type SarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// NewSarifRun returns an empty run of the given tool
+func NewSarifRun(name string) SarifRun {
+	return SarifRun{
+		Tool: SarifTool{
+			Driver: SarifDriver{
+				Name:           name,
+				InformationURI: "https://kyverno.io",
+				Rules:          []SarifRule{},
+			},
+		},
+		Results: []SarifResult{},
+	}
+}
+
+// WriteSarif writes the runs as an indented SARIF log
+func WriteSarif(out io.Writer, runs ...SarifRun) error {
+	data, err := json.MarshalIndent(SarifLog{
+		Version: SarifVersion,
+		Schema:  SarifSchema,
+		Runs:    runs,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(data))
+	return err
+}
+
+// sarif maps policy rules to rules and reports failures, warnings and errors as results located at the resource manifest
+type sarif struct{}
+
+func (sarif) Format(out io.Writer, results []Result) error {
+	run := NewSarifRun("kyverno")
+	rules := map[string]int{}
+	for _, result := range results {
+		id := result.Policy + "/" + result.Rule
+		index, ok := rules[id]
+		if !ok {
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, SarifRule{
+				ID:               id,
+				Name:             result.Rule,
+				ShortDescription: SarifMessage{Text: fmt.Sprintf("Rule %s of policy %s", result.Rule, result.Policy)},
+			})
+			index = len(run.Tool.Driver.Rules) - 1
+			rules[id] = index
+		}
+		var level string
+		switch result.Status {
+		case StatusFail, StatusError:
+			level = "error"
+		case StatusWarn:
+			level = "warning"
+		default:
+			continue
+		}
+		message := result.Message
+		if message == "" {
+			message = fmt.Sprintf("%s %s", result.Resource, result.Status)
+		}
+		location := SarifLocation{
+			LogicalLocations: []SarifLogicalLocation{{
+				FullyQualifiedName: result.Resource,
+				Kind:               "resource",
+			}},
+		}
+		if result.Path != "" {
+			location.PhysicalLocation = &SarifPhysicalLocation{
+				ArtifactLocation: SarifArtifactLocation{URI: filepath.ToSlash(result.Path)},
+			}
+			if result.Line > 0 {
+				location.PhysicalLocation.Region = &SarifRegion{StartLine: result.Line}
+			}
+		}
+		run.Results = append(run.Results, SarifResult{
+			RuleID:    id,
+			RuleIndex: index,
+			Level:     level,
+			Message:   SarifMessage{Text: message},
+			Locations: []SarifLocation{location},
+		})
+	}
+	return WriteSarif(out, run)
+}
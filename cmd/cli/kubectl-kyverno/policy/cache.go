@@ -0,0 +1,77 @@
+package policy
+
+import (
+	"crypto/sha256"
+	"sync"
+
+	"github.com/go-git/go-billy/v5"
+)
+
+// Cache keeps the policies loaded from files, a file is only parsed again when its content changes.
+type Cache struct {
+	lock    sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	sum     [sha256.Size]byte
+	results *LoaderResults
+}
+
+func NewCache() *Cache {
+	return &Cache{
+		entries: map[string]cacheEntry{},
+	}
+}
+
+// Load loads policies like Load does, reusing the results of files that didn't change since the last call.
+// A nil cache loads policies without caching.
+func (c *Cache) Load(fs billy.Filesystem, resourcePath string, paths ...string) (*LoaderResults, error) {
+	if c == nil {
+		return Load(fs, resourcePath, paths...)
+	}
+	return LoadWithLoader(c.loader(defaultLoader), fs, resourcePath, paths...)
+}
+
+func (c *Cache) loader(next loader) loader {
+	return func(path string, content []byte) (*LoaderResults, error) {
+		sum := sha256.Sum256(content)
+		c.lock.Lock()
+		entry, ok := c.entries[path]
+		c.lock.Unlock()
+		if ok && entry.sum == sum {
+			return entry.results.deepCopy(), nil
+		}
+		results, err := next(path, content)
+		if err != nil {
+			return nil, err
+		}
+		c.lock.Lock()
+		c.entries[path] = cacheEntry{sum: sum, results: results}
+		c.lock.Unlock()
+		return results.deepCopy(), nil
+	}
+}
+
+// deepCopy copies loaded policies, callers are free to modify them and tests running concurrently don't share objects
+func (l *LoaderResults) deepCopy() *LoaderResults {
+	out := &LoaderResults{
+		NonFatalErrors: append([]LoaderError(nil), l.NonFatalErrors...),
+	}
+	for _, policy := range l.Policies {
+		out.Policies = append(out.Policies, policy.CreateDeepCopy())
+	}
+	for i := range l.VAPs {
+		out.VAPs = append(out.VAPs, *l.VAPs[i].DeepCopy())
+	}
+	for i := range l.VAPBindings {
+		out.VAPBindings = append(out.VAPBindings, *l.VAPBindings[i].DeepCopy())
+	}
+	for i := range l.ValidatingPolicies {
+		out.ValidatingPolicies = append(out.ValidatingPolicies, *l.ValidatingPolicies[i].DeepCopy())
+	}
+	for i := range l.MutatingPolicies {
+		out.MutatingPolicies = append(out.MutatingPolicies, *l.MutatingPolicies[i].DeepCopy())
+	}
+	return out
+}
@@ -0,0 +1,43 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache_Load(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "policy.yaml")
+	content, err := os.ReadFile("../_testdata/policies/cpol-limit-configmap-for-sa.yaml")
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(path, content, 0o600))
+	cache := NewCache()
+	calls := 0
+	loader := cache.loader(func(path string, content []byte) (*LoaderResults, error) {
+		calls++
+		return defaultLoader(path, content)
+	})
+	results, err := LoadWithLoader(loader, nil, "", path)
+	assert.NoError(t, err)
+	assert.Len(t, results.Policies, 1)
+	results, err = LoadWithLoader(loader, nil, "", path)
+	assert.NoError(t, err)
+	assert.Len(t, results.Policies, 1)
+	assert.Equal(t, 1, calls)
+	// files are loaded again when their content changes
+	assert.NoError(t, os.WriteFile(path, append(content, []byte("\n# changed\n")...), 0o600))
+	results, err = LoadWithLoader(loader, nil, "", path)
+	assert.NoError(t, err)
+	assert.Len(t, results.Policies, 1)
+	assert.Equal(t, 2, calls)
+}
+
+func TestCache_LoadNil(t *testing.T) {
+	var cache *Cache
+	results, err := cache.Load(nil, "", "../_testdata/policies/cpol-limit-configmap-for-sa.yaml")
+	assert.NoError(t, err)
+	assert.Len(t, results.Policies, 1)
+}
@@ -0,0 +1,80 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/log"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// clusterKinds are the policy kinds loaded from a cluster
+var clusterKinds = []schema.GroupVersionKind{
+	clusterPolicyV1,
+	policyV1,
+	vapV1,
+	vapBindingV1,
+	vpV2alpha1,
+	mpV2alpha1,
+}
+
+// LoadFromCluster loads the policies installed in a cluster, kinds not served by the cluster are skipped
+// and other errors are returned
+func LoadFromCluster(ctx context.Context, client dclient.Interface) (*LoaderResults, error) {
+	results := &LoaderResults{}
+	for _, gvk := range clusterKinds {
+		gvr, err := servedResource(client, gvk)
+		if err != nil {
+			if isNotServed(err) {
+				log.Log.V(3).Info("policy kind not served by the cluster", "kind", gvk.Kind, "error", err)
+				continue
+			}
+			return nil, fmt.Errorf("failed to discover %s (%w)", gvk.Kind, err)
+		}
+		list, err := client.GetDynamicInterface().Resource(gvr).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			if isNotServed(err) {
+				log.Log.V(3).Info("policy kind not served by the cluster", "kind", gvk.Kind, "error", err)
+				continue
+			}
+			return nil, fmt.Errorf("failed to list %s (%w)", gvk.Kind, err)
+		}
+		for _, item := range list.Items {
+			if err := results.add(gvk, item); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, policy := range results.Policies {
+		policy.GetSpec().UseServerSideApply = false
+	}
+	return results, nil
+}
+
+// servedResource returns the resource serving the kind, a no kind match error is returned when the kind is not served
+func servedResource(client dclient.Interface, gvk schema.GroupVersionKind) (schema.GroupVersionResource, error) {
+	notServed := &meta.NoKindMatchError{GroupKind: gvk.GroupKind(), SearchedVersions: []string{gvk.Version}}
+	resources, err := client.GetKubeClient().Discovery().ServerResourcesForGroupVersion(gvk.GroupVersion().String())
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return schema.GroupVersionResource{}, notServed
+		}
+		return schema.GroupVersionResource{}, err
+	}
+	for _, resource := range resources.APIResources {
+		// subresources share the kind of their parent
+		if resource.Kind == gvk.Kind && !strings.Contains(resource.Name, "/") {
+			return gvk.GroupVersion().WithResource(resource.Name), nil
+		}
+	}
+	return schema.GroupVersionResource{}, notServed
+}
+
+func isNotServed(err error) bool {
+	return kerrors.IsNotFound(err) || meta.IsNoMatchError(err)
+}
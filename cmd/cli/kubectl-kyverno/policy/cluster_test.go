@@ -0,0 +1,84 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+var (
+	clusterPoliciesV1 = kyvernov1.SchemeGroupVersion.WithResource("clusterpolicies")
+	policiesV1        = kyvernov1.SchemeGroupVersion.WithResource("policies")
+)
+
+// newClusterClient returns a fake client serving the kyverno.io/v1 policy kinds only
+func newClusterClient(t *testing.T) dclient.Interface {
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		clusterPoliciesV1: "ClusterPolicyList",
+		policiesV1:        "PolicyList",
+	})
+	assert.NoError(t, err)
+	client.GetKubeClient().Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{{
+		GroupVersion: "kyverno.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "clusterpolicies", Kind: "ClusterPolicy"},
+			{Name: "clusterpolicies/status", Kind: "ClusterPolicy"},
+			{Name: "policies", Kind: "Policy", Namespaced: true},
+		},
+	}}
+	return client
+}
+
+func TestLoadFromCluster(t *testing.T) {
+	client := newClusterClient(t)
+	installed := &kyvernov1.ClusterPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "kyverno.io/v1",
+			Kind:       "ClusterPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "require-labels",
+		},
+		Spec: kyvernov1.Spec{
+			UseServerSideApply: true,
+			Rules: []kyvernov1.Rule{{
+				Name: "check-labels",
+			}},
+		},
+	}
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(installed)
+	assert.NoError(t, err)
+	_, err = client.GetDynamicInterface().Resource(clusterPoliciesV1).Create(context.TODO(), &unstructured.Unstructured{Object: object}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	results, err := LoadFromCluster(context.TODO(), client)
+	assert.NoError(t, err)
+	assert.Len(t, results.Policies, 1)
+	assert.Equal(t, "require-labels", results.Policies[0].GetName())
+	assert.Equal(t, "check-labels", results.Policies[0].GetSpec().Rules[0].Name)
+	assert.False(t, results.Policies[0].GetSpec().UseServerSideApply)
+	assert.Empty(t, results.VAPs)
+	assert.Empty(t, results.ValidatingPolicies)
+	assert.Empty(t, results.MutatingPolicies)
+}
+
+func TestLoadFromCluster_error(t *testing.T) {
+	client := newClusterClient(t)
+	client.GetDynamicInterface().(*fakedynamic.FakeDynamicClient).PrependReactor("list", "policies", func(clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, kerrors.NewForbidden(schema.GroupResource{Group: "kyverno.io", Resource: "policies"}, "", errors.New("forbidden"))
+	})
+	_, err := LoadFromCluster(context.TODO(), client)
+	assert.Error(t, err)
+	assert.True(t, kerrors.IsForbidden(err))
+}
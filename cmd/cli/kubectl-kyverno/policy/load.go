@@ -23,6 +23,8 @@ import (
 	"github.com/kyverno/kyverno/pkg/utils/git"
 	"github.com/pkg/errors"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/kubectl-validate/pkg/openapiclient"
 )
 
@@ -34,6 +36,7 @@ var (
 	vapV1                 = admissionregistrationv1.SchemeGroupVersion.WithKind("ValidatingAdmissionPolicy")
 	vapBindingV1          = admissionregistrationv1.SchemeGroupVersion.WithKind("ValidatingAdmissionPolicyBinding")
 	vpV2alpha1            = kyvernov2alpha1.SchemeGroupVersion.WithKind("ValidatingPolicy")
+	mpV2alpha1            = kyvernov2alpha1.SchemeGroupVersion.WithKind("MutatingPolicy")
 	LegacyLoader          = legacyLoader
 	KubectlValidateLoader = kubectlValidateLoader
 	defaultLoader         = func(path string, bytes []byte) (*LoaderResults, error) {
@@ -55,6 +58,7 @@ type LoaderResults struct {
 	VAPs               []admissionregistrationv1.ValidatingAdmissionPolicy
 	VAPBindings        []admissionregistrationv1.ValidatingAdmissionPolicyBinding
 	ValidatingPolicies []kyvernov2alpha1.ValidatingPolicy
+	MutatingPolicies   []kyvernov2alpha1.MutatingPolicy
 	NonFatalErrors     []LoaderError
 }
 
@@ -66,6 +70,7 @@ func (l *LoaderResults) merge(results *LoaderResults) {
 	l.VAPs = append(l.VAPs, results.VAPs...)
 	l.VAPBindings = append(l.VAPBindings, results.VAPBindings...)
 	l.ValidatingPolicies = append(l.ValidatingPolicies, results.ValidatingPolicies...)
+	l.MutatingPolicies = append(l.MutatingPolicies, results.MutatingPolicies...)
 	l.NonFatalErrors = append(l.NonFatalErrors, results.NonFatalErrors...)
 }
 
@@ -76,6 +81,50 @@ func (l *LoaderResults) addError(path string, err error) {
 	})
 }
 
+func (l *LoaderResults) add(gvk schema.GroupVersionKind, untyped unstructured.Unstructured) error {
+	switch gvk {
+	case policyV1, policyV2:
+		typed, err := convert.To[kyvernov1.Policy](untyped)
+		if err != nil {
+			return err
+		}
+		l.Policies = append(l.Policies, typed)
+	case clusterPolicyV1, clusterPolicyV2:
+		typed, err := convert.To[kyvernov1.ClusterPolicy](untyped)
+		if err != nil {
+			return err
+		}
+		l.Policies = append(l.Policies, typed)
+	case vapV1:
+		typed, err := convert.To[admissionregistrationv1.ValidatingAdmissionPolicy](untyped)
+		if err != nil {
+			return err
+		}
+		l.VAPs = append(l.VAPs, *typed)
+	case vapBindingV1:
+		typed, err := convert.To[admissionregistrationv1.ValidatingAdmissionPolicyBinding](untyped)
+		if err != nil {
+			return err
+		}
+		l.VAPBindings = append(l.VAPBindings, *typed)
+	case vpV2alpha1:
+		typed, err := convert.To[kyvernov2alpha1.ValidatingPolicy](untyped)
+		if err != nil {
+			return err
+		}
+		l.ValidatingPolicies = append(l.ValidatingPolicies, *typed)
+	case mpV2alpha1:
+		typed, err := convert.To[kyvernov2alpha1.MutatingPolicy](untyped)
+		if err != nil {
+			return err
+		}
+		l.MutatingPolicies = append(l.MutatingPolicies, *typed)
+	default:
+		return fmt.Errorf("policy type not supported %s", gvk)
+	}
+	return nil
+}
+
 type loader = func(string, []byte) (*LoaderResults, error)
 
 func Load(fs billy.Filesystem, resourcePath string, paths ...string) (*LoaderResults, error) {
@@ -140,39 +189,8 @@ func kubectlValidateLoader(path string, content []byte) (*LoaderResults, error)
 			results.addError(path, err)
 			continue
 		}
-		switch gvk {
-		case policyV1, policyV2:
-			typed, err := convert.To[kyvernov1.Policy](untyped)
-			if err != nil {
-				return nil, err
-			}
-			results.Policies = append(results.Policies, typed)
-		case clusterPolicyV1, clusterPolicyV2:
-			typed, err := convert.To[kyvernov1.ClusterPolicy](untyped)
-			if err != nil {
-				return nil, err
-			}
-			results.Policies = append(results.Policies, typed)
-		case vapV1:
-			typed, err := convert.To[admissionregistrationv1.ValidatingAdmissionPolicy](untyped)
-			if err != nil {
-				return nil, err
-			}
-			results.VAPs = append(results.VAPs, *typed)
-		case vapBindingV1:
-			typed, err := convert.To[admissionregistrationv1.ValidatingAdmissionPolicyBinding](untyped)
-			if err != nil {
-				return nil, err
-			}
-			results.VAPBindings = append(results.VAPBindings, *typed)
-		case vpV2alpha1:
-			typed, err := convert.To[kyvernov2alpha1.ValidatingPolicy](untyped)
-			if err != nil {
-				return nil, err
-			}
-			results.ValidatingPolicies = append(results.ValidatingPolicies, *typed)
-		default:
-			return nil, fmt.Errorf("policy type not supported %s", gvk)
+		if err := results.add(gvk, untyped); err != nil {
+			return nil, err
 		}
 	}
 	return results, nil
@@ -0,0 +1,137 @@
+package processor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	celpolicy "github.com/kyverno/kyverno/pkg/cel/policy"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Cache holds state shared by processors, keeping it around across runs avoids parsing
+// the same JMESPath queries and compiling the same CEL policies again.
+type Cache struct {
+	jp       jmespath.Interface
+	compiler celpolicy.Compiler
+}
+
+func NewCache() *Cache {
+	return &Cache{
+		jp: &jmespathCache{
+			Interface: jmespath.New(config.NewDefaultConfiguration(false)),
+		},
+		compiler: &compilerCache{
+			Compiler: celpolicy.NewCompiler(),
+		},
+	}
+}
+
+func (c *Cache) jmespath(cfg config.Configuration) jmespath.Interface {
+	if c == nil {
+		return jmespath.New(cfg)
+	}
+	return c.jp
+}
+
+func (c *Cache) celCompiler() celpolicy.Compiler {
+	if c == nil {
+		return celpolicy.NewCompiler()
+	}
+	return c.compiler
+}
+
+type jmespathCache struct {
+	jmespath.Interface
+	queries sync.Map
+}
+
+func (c *jmespathCache) Query(query string) (jmespath.Query, error) {
+	if q, ok := c.queries.Load(query); ok {
+		return q.(jmespath.Query), nil
+	}
+	q, err := c.Interface.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	c.queries.Store(query, q)
+	return q, nil
+}
+
+func (c *jmespathCache) Search(query string, data interface{}) (interface{}, error) {
+	q, err := c.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	return q.Search(data)
+}
+
+// compilerCache compiles policies once per content, compilation errors are not cached.
+// It is safe for concurrent use, compiled policies are immutable.
+type compilerCache struct {
+	celpolicy.Compiler
+	lock      sync.Mutex
+	policies  map[string]celpolicy.CompiledPolicy
+	mutations map[string]celpolicy.CompiledMutatingPolicy
+}
+
+func (c *compilerCache) Compile(policy *kyvernov2alpha1.ValidatingPolicy, exceptions []kyvernov2alpha1.CELPolicyException) (celpolicy.CompiledPolicy, field.ErrorList) {
+	key, err := cacheKey(policy, exceptions)
+	if err != nil {
+		return c.Compiler.Compile(policy, exceptions)
+	}
+	c.lock.Lock()
+	compiled, ok := c.policies[key]
+	c.lock.Unlock()
+	if ok {
+		return compiled, nil
+	}
+	compiled, errs := c.Compiler.Compile(policy, exceptions)
+	if errs != nil {
+		return compiled, errs
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.policies == nil {
+		c.policies = map[string]celpolicy.CompiledPolicy{}
+	}
+	c.policies[key] = compiled
+	return compiled, nil
+}
+
+func (c *compilerCache) CompileMutating(policy *kyvernov2alpha1.MutatingPolicy, exceptions []kyvernov2alpha1.CELPolicyException) (celpolicy.CompiledMutatingPolicy, field.ErrorList) {
+	key, err := cacheKey(policy, exceptions)
+	if err != nil {
+		return c.Compiler.CompileMutating(policy, exceptions)
+	}
+	c.lock.Lock()
+	compiled, ok := c.mutations[key]
+	c.lock.Unlock()
+	if ok {
+		return compiled, nil
+	}
+	compiled, errs := c.Compiler.CompileMutating(policy, exceptions)
+	if errs != nil {
+		return compiled, errs
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.mutations == nil {
+		c.mutations = map[string]celpolicy.CompiledMutatingPolicy{}
+	}
+	c.mutations[key] = compiled
+	return compiled, nil
+}
+
+func cacheKey(objects ...any) (string, error) {
+	data, err := json.Marshal(objects)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
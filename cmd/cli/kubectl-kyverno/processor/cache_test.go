@@ -0,0 +1,68 @@
+package processor
+
+import (
+	"testing"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	celpolicy "github.com/kyverno/kyverno/pkg/cel/policy"
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+type countingCompiler struct {
+	celpolicy.Compiler
+	calls int
+	fail  bool
+}
+
+func (c *countingCompiler) Compile(*kyvernov2alpha1.ValidatingPolicy, []kyvernov2alpha1.CELPolicyException) (celpolicy.CompiledPolicy, field.ErrorList) {
+	c.calls++
+	if c.fail {
+		return nil, field.ErrorList{field.Invalid(field.NewPath("spec"), nil, "invalid")}
+	}
+	return nil, nil
+}
+
+func TestCache_Compile(t *testing.T) {
+	compiler := &countingCompiler{}
+	cache := &compilerCache{Compiler: compiler}
+	policy := &kyvernov2alpha1.ValidatingPolicy{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}
+	_, errs := cache.Compile(policy, nil)
+	assert.Assert(t, errs == nil)
+	_, errs = cache.Compile(policy.DeepCopy(), nil)
+	assert.Assert(t, errs == nil)
+	assert.Equal(t, compiler.calls, 1)
+	// a change in the policy or its exceptions invalidates the cache
+	_, _ = cache.Compile(policy, []kyvernov2alpha1.CELPolicyException{{ObjectMeta: metav1.ObjectMeta{Name: "bar"}}})
+	assert.Equal(t, compiler.calls, 2)
+	policy.Labels = map[string]string{"foo": "bar"}
+	_, _ = cache.Compile(policy, nil)
+	assert.Equal(t, compiler.calls, 3)
+	// errors are not cached
+	compiler.fail = true
+	policy.Name = "bar"
+	_, errs = cache.Compile(policy, nil)
+	assert.Assert(t, errs != nil)
+	_, errs = cache.Compile(policy, nil)
+	assert.Assert(t, errs != nil)
+	assert.Equal(t, compiler.calls, 5)
+}
+
+func TestCache_JMESPath(t *testing.T) {
+	var cache *Cache
+	assert.Assert(t, cache.jmespath(config.NewDefaultConfiguration(false)) != nil)
+	cache = NewCache()
+	jp := cache.jmespath(config.NewDefaultConfiguration(false))
+	q1, err := jp.Query("foo.bar")
+	assert.NilError(t, err)
+	q2, err := jp.Query("foo.bar")
+	assert.NilError(t, err)
+	assert.Equal(t, q1, q2)
+	result, err := jp.Search("foo.bar", map[string]any{"foo": map[string]any{"bar": "baz"}})
+	assert.NilError(t, err)
+	assert.Equal(t, result, "baz")
+	_, err = jp.Search("foo.[", nil)
+	assert.Assert(t, err != nil)
+}
@@ -0,0 +1,116 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/cel/engine"
+	celpolicy "github.com/kyverno/kyverno/pkg/cel/policy"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type MutatingPolicyProcessor struct {
+	Policies             []kyvernov2alpha1.MutatingPolicy
+	Resource             *unstructured.Unstructured
+	NamespaceSelectorMap map[string]map[string]string
+	Context              celpolicy.Context
+	UserInfo             *kyvernov2.RequestInfo
+	MutateLogPath        string
+	MutateLogPathIsDir   bool
+	Stdin                bool
+	PrintPatchResource   bool
+	Rc                   *ResultCounts
+	Out                  io.Writer
+	Cache                *Cache
+}
+
+func (p *MutatingPolicyProcessor) ApplyPolicyOnResource() ([]engineapi.EngineResponse, error) {
+	if len(p.Policies) == 0 {
+		return nil, nil
+	}
+	provider, err := engine.NewMutatingProvider(p.Cache.celCompiler(), p.Policies...)
+	if err != nil {
+		return nil, err
+	}
+	nsResolver := func(name string) *corev1.Namespace {
+		return &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: p.NamespaceSelectorMap[name],
+			},
+		}
+	}
+	eng := engine.NewMutatingEngine(provider, nsResolver, nil, nil)
+	var userInfo authenticationv1.UserInfo
+	if p.UserInfo != nil {
+		userInfo = p.UserInfo.AdmissionUserInfo
+	}
+	request := engine.Request(
+		p.Context,
+		p.Resource.GroupVersionKind(),
+		// TODO
+		schema.GroupVersionResource{},
+		// TODO
+		"",
+		p.Resource.GetName(),
+		p.Resource.GetNamespace(),
+		admissionv1.Create,
+		userInfo,
+		p.Resource,
+		nil,
+		false,
+		nil,
+	)
+	response, err := eng.Handle(context.TODO(), request, nil)
+	if err != nil {
+		return nil, err
+	}
+	// transform response into legacy engine responses
+	responses := make([]engineapi.EngineResponse, 0, len(response.Policies))
+	for _, r := range response.Policies {
+		engineResponse := engineapi.EngineResponse{
+			Resource: *response.Resource,
+			PolicyResponse: engineapi.PolicyResponse{
+				Rules: r.Rules,
+			},
+		}
+		engineResponse = engineResponse.WithPolicy(engineapi.NewMutatingPolicy(&r.Policy))
+		if response.PatchedResource != nil {
+			engineResponse = engineResponse.WithPatchedResource(*response.PatchedResource)
+		}
+		printMutatedRes := p.Rc.addMutatingPolicyResponse(engineResponse)
+		if printMutatedRes && p.PrintPatchResource {
+			if err := p.printOutput(engineResponse); err != nil {
+				return responses, fmt.Errorf("failed to print mutated result (%w)", err)
+			}
+		}
+		responses = append(responses, engineResponse)
+	}
+	return responses, nil
+}
+
+func (p *MutatingPolicyProcessor) printOutput(response engineapi.EngineResponse) error {
+	// reuse the output logic of kyverno policies
+	processor := PolicyProcessor{
+		Resource:           *p.Resource,
+		MutateLogPath:      p.MutateLogPath,
+		MutateLogPathIsDir: p.MutateLogPathIsDir,
+		Stdin:              p.Stdin,
+		Out:                p.Out,
+	}
+	resourcePath := fmt.Sprintf("%s/%s/%s", p.Resource.GetNamespace(), p.Resource.GetKind(), p.Resource.GetName())
+	if err := processor.printOutput(response.PatchedResource.Object, response, resourcePath, false); err != nil {
+		return err
+	}
+	fmt.Fprintf(p.Out, "\n\nMutation:\nMutation has been applied successfully.")
+	return nil
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/adapters"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/context/loaders"
 	"github.com/kyverno/kyverno/pkg/engine/factories"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
@@ -52,14 +53,17 @@ type PolicyProcessor struct {
 	PrintPatchResource        bool
 	RuleToCloneSourceResource map[string]string
 	Client                    dclient.Interface
+	ConfigmapResolver         engineapi.ConfigmapResolver
+	GlobalContextStore        loaders.Store
 	AuditWarn                 bool
 	Subresources              []v1alpha1.Subresource
 	Out                       io.Writer
+	Cache                     *Cache
 }
 
 func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse, error) {
 	cfg := config.NewDefaultConfiguration(false)
-	jp := jmespath.New(cfg)
+	jp := p.Cache.jmespath(cfg)
 	resource := p.Resource
 	namespaceLabels := p.NamespaceSelectorMap[p.Resource.GetNamespace()]
 	policyExceptionLister := &policyExceptionLister{
@@ -73,15 +77,19 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 	if rclient == nil {
 		rclient = registryclient.NewOrDie()
 	}
+	var contextLoaderOptions []factories.ContextLoaderFactoryOptions
+	if p.GlobalContextStore != nil {
+		contextLoaderOptions = append(contextLoaderOptions, factories.WithGlobalContextStore(p.GlobalContextStore))
+	}
 	isCluster := false
 	eng := engine.NewEngine(
 		cfg,
 		config.NewDefaultMetricsConfiguration(),
-		jmespath.New(cfg),
+		jp,
 		client,
 		factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), nil),
 		imageverifycache.DisabledImageVerifyCache(),
-		store.ContextLoaderFactory(p.Store, nil),
+		store.ContextLoaderFactory(p.Store, p.ConfigmapResolver, contextLoaderOptions...),
 		exceptions.New(policyExceptionLister),
 		&isCluster,
 	)
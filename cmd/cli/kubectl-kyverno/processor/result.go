@@ -130,3 +130,36 @@ func (rc *ResultCounts) addValidatingAdmissionResponse(engineResponse engineapi.
 		}
 	}
 }
+
+func (rc *ResultCounts) addValidatingPolicyResponse(engineResponse engineapi.EngineResponse) {
+	for _, ruleResp := range engineResponse.PolicyResponse.Rules {
+		switch ruleResp.Status() {
+		case engineapi.RuleStatusPass:
+			rc.Pass++
+		case engineapi.RuleStatusFail:
+			rc.Fail++
+		case engineapi.RuleStatusError:
+			rc.Error++
+		case engineapi.RuleStatusSkip:
+			rc.Skip++
+		}
+	}
+}
+
+func (rc *ResultCounts) addMutatingPolicyResponse(engineResponse engineapi.EngineResponse) bool {
+	printMutatedRes := false
+	for _, ruleResp := range engineResponse.PolicyResponse.Rules {
+		switch ruleResp.Status() {
+		case engineapi.RuleStatusPass:
+			rc.Pass++
+			printMutatedRes = true
+		case engineapi.RuleStatusFail:
+			rc.Fail++
+		case engineapi.RuleStatusError:
+			rc.Error++
+		case engineapi.RuleStatusSkip:
+			rc.Skip++
+		}
+	}
+	return printMutatedRes
+}
@@ -0,0 +1,84 @@
+package processor
+
+import (
+	"context"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/cel/engine"
+	"github.com/kyverno/kyverno/pkg/cel/matching"
+	celpolicy "github.com/kyverno/kyverno/pkg/cel/policy"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ValidatingPolicyProcessor applies validating policies on resources, the policies are compiled on the first
+// call and the processor can be reused for other resources by changing Resource
+type ValidatingPolicyProcessor struct {
+	Policies          []kyvernov2alpha1.ValidatingPolicy
+	Exceptions        []*kyvernov2alpha1.CELPolicyException
+	Resource          *unstructured.Unstructured
+	NamespaceProvider func(string) *corev1.Namespace
+	Context           celpolicy.Context
+	UserInfo          *kyvernov2.RequestInfo
+	Rc                *ResultCounts
+	Cache             *Cache
+
+	engine engine.Engine
+}
+
+func (p *ValidatingPolicyProcessor) ApplyPolicyOnResource() ([]engineapi.EngineResponse, error) {
+	if len(p.Policies) == 0 {
+		return nil, nil
+	}
+	if p.engine == nil {
+		provider, err := engine.NewProvider(p.Cache.celCompiler(), p.Policies, p.Exceptions)
+		if err != nil {
+			return nil, err
+		}
+		p.engine = engine.NewEngine(provider, p.NamespaceProvider, matching.NewMatcher())
+	}
+	var userInfo authenticationv1.UserInfo
+	if p.UserInfo != nil {
+		userInfo = p.UserInfo.AdmissionUserInfo
+	}
+	// TODO: use the rest mapper when a cluster is available
+	gvr, _ := meta.UnsafeGuessKindToResource(p.Resource.GroupVersionKind())
+	request := engine.Request(
+		p.Context,
+		p.Resource.GroupVersionKind(),
+		gvr,
+		// TODO
+		"",
+		p.Resource.GetName(),
+		p.Resource.GetNamespace(),
+		admissionv1.Create,
+		userInfo,
+		p.Resource,
+		nil,
+		false,
+		nil,
+	)
+	response, err := p.engine.Handle(context.TODO(), request)
+	if err != nil {
+		return nil, err
+	}
+	// transform response into legacy engine responses
+	responses := make([]engineapi.EngineResponse, 0, len(response.Policies))
+	for _, r := range response.Policies {
+		engineResponse := engineapi.EngineResponse{
+			Resource: *response.Resource,
+			PolicyResponse: engineapi.PolicyResponse{
+				Rules: r.Rules,
+			},
+		}
+		engineResponse = engineResponse.WithPolicy(engineapi.NewValidatingPolicy(&r.Policy))
+		p.Rc.addValidatingPolicyResponse(engineResponse)
+		responses = append(responses, engineResponse)
+	}
+	return responses, nil
+}
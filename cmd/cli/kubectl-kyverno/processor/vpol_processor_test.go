@@ -0,0 +1,73 @@
+package processor
+
+import (
+	"testing"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	celpolicy "github.com/kyverno/kyverno/pkg/cel/policy"
+	"gotest.tools/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+type delegatingCompiler struct {
+	celpolicy.Compiler
+	calls int
+}
+
+func (c *delegatingCompiler) Compile(policy *kyvernov2alpha1.ValidatingPolicy, exceptions []kyvernov2alpha1.CELPolicyException) (celpolicy.CompiledPolicy, field.ErrorList) {
+	c.calls++
+	return c.Compiler.Compile(policy, exceptions)
+}
+
+func TestValidatingPolicyProcessor_compilesOnce(t *testing.T) {
+	compiler := &delegatingCompiler{Compiler: celpolicy.NewCompiler()}
+	context, err := celpolicy.NewContextProvider(nil, nil, nil, nil, nil, nil)
+	assert.NilError(t, err)
+	processor := ValidatingPolicyProcessor{
+		Policies: []kyvernov2alpha1.ValidatingPolicy{{
+			ObjectMeta: metav1.ObjectMeta{Name: "require-team"},
+			Spec: kyvernov2alpha1.ValidatingPolicySpec{
+				ValidatingAdmissionPolicySpec: admissionregistrationv1.ValidatingAdmissionPolicySpec{
+					MatchConstraints: &admissionregistrationv1.MatchResources{
+						ResourceRules: []admissionregistrationv1.NamedRuleWithOperations{{
+							RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+								Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+								Rule: admissionregistrationv1.Rule{
+									APIGroups:   []string{""},
+									APIVersions: []string{"v1"},
+									Resources:   []string{"configmaps"},
+								},
+							},
+						}},
+					},
+					Validations: []admissionregistrationv1.Validation{{
+						Expression: "has(object.metadata.labels) && 'team' in object.metadata.labels",
+					}},
+				},
+			},
+		}},
+		NamespaceProvider: func(string) *corev1.Namespace { return nil },
+		Context:           context,
+		Rc:                &ResultCounts{},
+		Cache:             &Cache{compiler: compiler},
+	}
+	for _, labels := range []map[string]string{{"team": "foo"}, nil} {
+		resource := &unstructured.Unstructured{}
+		resource.SetAPIVersion("v1")
+		resource.SetKind("ConfigMap")
+		resource.SetName("test")
+		resource.SetNamespace("default")
+		resource.SetLabels(labels)
+		processor.Resource = resource
+		responses, err := processor.ApplyPolicyOnResource()
+		assert.NilError(t, err)
+		assert.Equal(t, len(responses), 1)
+	}
+	assert.Equal(t, compiler.calls, 1)
+	assert.Equal(t, processor.Rc.Pass, 1)
+	assert.Equal(t, processor.Rc.Fail, 1)
+}
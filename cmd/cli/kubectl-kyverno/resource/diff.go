@@ -0,0 +1,191 @@
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type DifferenceType string
+
+const (
+	// Changed means the field has different values in the actual and expected resources
+	Changed DifferenceType = "changed"
+	// Missing means the field is in the expected resource only
+	Missing DifferenceType = "missing"
+	// Unexpected means the field is in the actual resource only
+	Unexpected DifferenceType = "unexpected"
+)
+
+// Difference is a field level difference between an actual and an expected resource
+type Difference struct {
+	Type     DifferenceType
+	Path     string
+	Expected interface{}
+	Actual   interface{}
+}
+
+// serverFields are populated by the API server and never compared
+var serverFields = [][]string{
+	{"metadata", "creationTimestamp"},
+	{"metadata", "generation"},
+	{"metadata", "managedFields"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "selfLink"},
+	{"metadata", "uid"},
+}
+
+// Normalize returns a copy of a resource without the fields populated by the API server and without empty fields,
+// values are converted to their json types
+func Normalize(obj unstructured.Unstructured) (unstructured.Unstructured, error) {
+	if obj.Object == nil {
+		return obj, nil
+	}
+	// the json round trip copies the resource
+	data, err := json.Marshal(obj.Object)
+	if err != nil {
+		return obj, err
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return obj, err
+	}
+	for _, field := range serverFields {
+		unstructured.RemoveNestedField(out, field...)
+	}
+	return Tidy(unstructured.Unstructured{Object: out}), nil
+}
+
+// Diff returns the differences between an actual and an expected resource, sorted by path.
+// When subset is true the expected resource is partial and fields it doesn't declare are not compared,
+// lists must still have the same length.
+func Diff(actual, expected unstructured.Unstructured, subset bool) ([]Difference, error) {
+	a, err := Normalize(actual)
+	if err != nil {
+		return nil, err
+	}
+	e, err := Normalize(expected)
+	if err != nil {
+		return nil, err
+	}
+	var differences []Difference
+	diff("", toValue(a.Object), toValue(e.Object), subset, &differences)
+	return differences, nil
+}
+
+// Project returns the actual resource restricted to the fields declared by a partial expected resource
+func Project(actual, expected unstructured.Unstructured) (unstructured.Unstructured, error) {
+	a, err := Normalize(actual)
+	if err != nil {
+		return a, err
+	}
+	e, err := Normalize(expected)
+	if err != nil {
+		return a, err
+	}
+	projected, _ := project(toValue(a.Object), toValue(e.Object)).(map[string]interface{})
+	return unstructured.Unstructured{Object: projected}, nil
+}
+
+func toValue(obj map[string]interface{}) interface{} {
+	if obj == nil {
+		return map[string]interface{}{}
+	}
+	return obj
+}
+
+func diff(path string, actual, expected interface{}, subset bool, differences *[]Difference) {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		if a, ok := actual.(map[string]interface{}); ok {
+			keys := slices.Collect(maps.Keys(e))
+			if !subset {
+				for key := range a {
+					if _, ok := e[key]; !ok {
+						keys = append(keys, key)
+					}
+				}
+			}
+			slices.Sort(keys)
+			for _, key := range keys {
+				av, aok := a[key]
+				ev, eok := e[key]
+				switch {
+				case !aok:
+					*differences = append(*differences, Difference{Type: Missing, Path: fieldPath(path, key), Expected: ev})
+				case !eok:
+					*differences = append(*differences, Difference{Type: Unexpected, Path: fieldPath(path, key), Actual: av})
+				default:
+					diff(fieldPath(path, key), av, ev, subset, differences)
+				}
+			}
+			return
+		}
+	case []interface{}:
+		if a, ok := actual.([]interface{}); ok && len(a) == len(e) {
+			for i := range e {
+				diff(fmt.Sprintf("%s[%d]", path, i), a[i], e[i], subset, differences)
+			}
+			return
+		}
+	default:
+		if reflect.DeepEqual(actual, expected) {
+			return
+		}
+	}
+	*differences = append(*differences, Difference{Type: Changed, Path: path, Expected: expected, Actual: actual})
+}
+
+func project(actual, expected interface{}) interface{} {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		if a, ok := actual.(map[string]interface{}); ok {
+			out := map[string]interface{}{}
+			for key, ev := range e {
+				if av, ok := a[key]; ok {
+					out[key] = project(av, ev)
+				}
+			}
+			return out
+		}
+	case []interface{}:
+		if a, ok := actual.([]interface{}); ok {
+			out := make([]interface{}, 0, len(a))
+			for i, av := range a {
+				if i < len(e) {
+					av = project(av, e[i])
+				}
+				out = append(out, av)
+			}
+			return out
+		}
+	}
+	return actual
+}
+
+var simpleKey = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+func fieldPath(path, key string) string {
+	if !simpleKey.MatchString(key) {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// FormatValue returns the json representation of a difference value
+func FormatValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return strings.TrimSpace(string(data))
+}
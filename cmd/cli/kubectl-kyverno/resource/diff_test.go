@@ -0,0 +1,166 @@
+package resource
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestDiff(t *testing.T) {
+	actual := unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"name":              "nginx",
+				"uid":               "d4b7c6a1",
+				"resourceVersion":   "42",
+				"creationTimestamp": "2025-01-01T00:00:00Z",
+				"labels": map[string]interface{}{
+					"app.kubernetes.io/name": "nginx",
+				},
+			},
+			"spec": map[string]interface{}{
+				"replicas": int64(2),
+				"containers": []interface{}{
+					map[string]interface{}{"name": "nginx", "image": "nginx:latest"},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		expected map[string]interface{}
+		subset   bool
+		want     []Difference
+	}{{
+		name: "equal",
+		expected: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"name":   "nginx",
+				"labels": map[string]interface{}{"app.kubernetes.io/name": "nginx"},
+			},
+			"spec": map[string]interface{}{
+				"replicas": 2,
+				"containers": []interface{}{
+					map[string]interface{}{"name": "nginx", "image": "nginx:latest"},
+				},
+			},
+		},
+	}, {
+		name: "differences",
+		expected: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"name":        "nginx",
+				"annotations": map[string]interface{}{"team": "web"},
+			},
+			"spec": map[string]interface{}{
+				"replicas": 2,
+				"containers": []interface{}{
+					map[string]interface{}{"name": "nginx", "image": "nginx:1.27"},
+				},
+			},
+		},
+		want: []Difference{
+			{Type: Missing, Path: "metadata.annotations", Expected: map[string]interface{}{"team": "web"}},
+			{Type: Unexpected, Path: "metadata.labels", Actual: map[string]interface{}{"app.kubernetes.io/name": "nginx"}},
+			{Type: Changed, Path: "spec.containers[0].image", Expected: "nginx:1.27", Actual: "nginx:latest"},
+		},
+	}, {
+		name: "subset",
+		expected: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"labels": map[string]interface{}{"app.kubernetes.io/name": "nginx"},
+			},
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{"image": "nginx:latest"},
+				},
+			},
+		},
+		subset: true,
+	}, {
+		name: "subset with differences",
+		expected: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"labels": map[string]interface{}{"app.kubernetes.io/name": "web"},
+			},
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{"image": "nginx:latest"},
+					map[string]interface{}{"image": "busybox"},
+				},
+			},
+		},
+		subset: true,
+		want: []Difference{
+			{Type: Changed, Path: `metadata.labels["app.kubernetes.io/name"]`, Expected: "web", Actual: "nginx"},
+			{
+				Type:     Changed,
+				Path:     "spec.containers",
+				Expected: []interface{}{map[string]interface{}{"image": "nginx:latest"}, map[string]interface{}{"image": "busybox"}},
+				Actual:   []interface{}{map[string]interface{}{"name": "nginx", "image": "nginx:latest"}},
+			},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Diff(actual, unstructured.Unstructured{Object: tt.expected}, tt.subset)
+			if err != nil {
+				t.Errorf("Diff() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProject(t *testing.T) {
+	actual := unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata":   map[string]interface{}{"name": "nginx", "uid": "d4b7c6a1"},
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{"name": "nginx", "image": "nginx:latest"},
+					map[string]interface{}{"name": "sidecar", "image": "busybox"},
+				},
+			},
+		},
+	}
+	expected := unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "nginx", "uid": "d4b7c6a1"},
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{"image": "nginx:1.27"},
+				},
+			},
+		},
+	}
+	want := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "nginx"},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"image": "nginx:latest"},
+				map[string]interface{}{"name": "sidecar", "image": "busybox"},
+			},
+		},
+	}
+	got, err := Project(actual, expected)
+	if err != nil {
+		t.Errorf("Project() error = %v", err)
+		return
+	}
+	if !reflect.DeepEqual(got.Object, want) {
+		t.Errorf("Project() = %v, want %v", got.Object, want)
+	}
+}
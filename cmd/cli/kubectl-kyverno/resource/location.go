@@ -0,0 +1,123 @@
+package resource
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	yamlutils "github.com/kyverno/kyverno/ext/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Location identifies the manifest a resource was loaded from
+type Location struct {
+	// Path is the path of the file containing the resource
+	Path string
+	// Line is the line where the resource document starts in the file (1 based)
+	Line int
+}
+
+var locations = struct {
+	sync.RWMutex
+	byKey map[string][]Location
+}{
+	byKey: map[string][]Location{},
+}
+
+func locationKey(kind, namespace, name string) string {
+	return strings.Join([]string{kind, namespace, name}, "/")
+}
+
+// SetLocation records the location of a resource, a resource can be recorded at several locations
+func SetLocation(resource *unstructured.Unstructured, location Location) {
+	key := locationKey(resource.GetKind(), resource.GetNamespace(), resource.GetName())
+	locations.Lock()
+	defer locations.Unlock()
+	known := locations.byKey[key]
+	for i := range known {
+		if known[i].Path == location.Path {
+			known[i] = location
+			return
+		}
+	}
+	locations.byKey[key] = append(known, location)
+}
+
+// GetLocation returns the last recorded location of a resource loaded from a file, if known
+func GetLocation(kind, namespace, name string) (Location, bool) {
+	locations.RLock()
+	defer locations.RUnlock()
+	known := locations.byKey[locationKey(kind, namespace, name)]
+	if len(known) == 0 {
+		return Location{}, false
+	}
+	return known[len(known)-1], true
+}
+
+// FindLocation returns the location of a resource loaded from one of the given files or folders, if known
+func FindLocation(kind, namespace, name string, paths ...string) (Location, bool) {
+	locations.RLock()
+	defer locations.RUnlock()
+	known := locations.byKey[locationKey(kind, namespace, name)]
+	for i := len(known) - 1; i >= 0; i-- {
+		location := filepath.Clean(known[i].Path)
+		for _, path := range paths {
+			path = filepath.Clean(path)
+			if location == path || strings.HasPrefix(location, path+string(filepath.Separator)) {
+				return known[i], true
+			}
+		}
+	}
+	return Location{}, false
+}
+
+// GetUnstructuredResourcesFromFile parses the resources in the given file content and records their locations
+func GetUnstructuredResourcesFromFile(path string, resourceBytes []byte) ([]*unstructured.Unstructured, error) {
+	documents, lines := splitDocuments(resourceBytes)
+	resources := make([]*unstructured.Unstructured, 0, len(documents))
+	for i, document := range documents {
+		resource, err := YamlToUnstructured(document)
+		if err != nil {
+			return nil, err
+		}
+		SetLocation(resource, Location{Path: path, Line: lines[i]})
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// splitDocuments splits a multi documents yaml, it returns the documents and the line each document starts at
+func splitDocuments(content []byte) ([][]byte, []int) {
+	var documents [][]byte
+	var lines []int
+	var current []byte
+	start := 0
+	flush := func() {
+		if !yamlutils.IsEmptyDocument(current) {
+			documents = append(documents, current)
+			lines = append(lines, start)
+		}
+		current, start = nil, 0
+	}
+	for i, line := range bytes.SplitAfter(content, []byte("\n")) {
+		if isSeparator(line) {
+			flush()
+			continue
+		}
+		if trimmed := bytes.TrimSpace(line); start == 0 && len(trimmed) != 0 && trimmed[0] != '#' {
+			start = i + 1
+		}
+		current = append(current, line...)
+	}
+	flush()
+	return documents, lines
+}
+
+func isSeparator(line []byte) bool {
+	if !bytes.HasPrefix(line, []byte("---")) {
+		return false
+	}
+	rest := bytes.TrimSpace(line[3:])
+	return len(rest) == 0 || rest[0] == '#'
+}
@@ -0,0 +1,85 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUnstructuredResourcesFromFile(t *testing.T) {
+	content := []byte(`# leading comment
+apiVersion: v1
+kind: Pod
+metadata:
+  name: first
+  namespace: default
+---
+---
+# comment
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: second
+--- # trailing separator comment
+apiVersion: v1
+kind: Pod
+metadata:
+  name: third
+`)
+	resources, err := GetUnstructuredResourcesFromFile("resources.yaml", content)
+	assert.NoError(t, err)
+	assert.Len(t, resources, 3)
+	tests := []struct {
+		kind      string
+		namespace string
+		name      string
+		want      Location
+	}{{
+		kind:      "Pod",
+		namespace: "default",
+		name:      "first",
+		want:      Location{Path: "resources.yaml", Line: 2},
+	}, {
+		kind:      "ConfigMap",
+		namespace: "default",
+		name:      "second",
+		want:      Location{Path: "resources.yaml", Line: 10},
+	}, {
+		kind:      "Pod",
+		namespace: "default",
+		name:      "third",
+		want:      Location{Path: "resources.yaml", Line: 15},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := GetLocation(tt.kind, tt.namespace, tt.name)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+	_, ok := GetLocation("Pod", "default", "unknown")
+	assert.False(t, ok)
+}
+
+func TestFindLocation(t *testing.T) {
+	content := []byte(`apiVersion: v1
+kind: Pod
+metadata:
+  name: find-location
+`)
+	_, err := GetUnstructuredResourcesFromFile("foo/pod.yaml", content)
+	assert.NoError(t, err)
+	_, err = GetUnstructuredResourcesFromFile("bar/resources/pod.yaml", append([]byte("\n"), content...))
+	assert.NoError(t, err)
+	location, ok := FindLocation("Pod", "default", "find-location", "foo/pod.yaml")
+	assert.True(t, ok)
+	assert.Equal(t, Location{Path: "foo/pod.yaml", Line: 1}, location)
+	location, ok = FindLocation("Pod", "default", "find-location", "bar/resources")
+	assert.True(t, ok)
+	assert.Equal(t, Location{Path: "bar/resources/pod.yaml", Line: 2}, location)
+	_, ok = FindLocation("Pod", "default", "find-location", "bar/res")
+	assert.False(t, ok)
+	location, ok = GetLocation("Pod", "default", "find-location")
+	assert.True(t, ok)
+	assert.Equal(t, "bar/resources/pod.yaml", location.Path)
+}
@@ -0,0 +1,346 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	openapiv2 "github.com/google/gnostic-models/openapiv2"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/ext/resource/convert"
+	"github.com/kyverno/kyverno/ext/wildcard"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/globalcontext/projection"
+	"github.com/kyverno/kyverno/pkg/globalcontext/static"
+	gctxstore "github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"github.com/kyverno/kyverno/pkg/userinfo"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// Client returns a client serving the objects of the snapshot, additional objects are served too
+// and replace snapshot objects with the same identity. Raw GET calls to the API paths of the
+// snapshot resources are served from the objects.
+func (s *Snapshot) Client(objects ...*unstructured.Unstructured) (dclient.Interface, error) {
+	all := make([]*unstructured.Unstructured, 0, len(s.Objects)+len(objects))
+	for i := range s.Objects {
+		all = append(all, &s.Objects[i])
+	}
+	all = append(all, objects...)
+	resources := append([]Resource{}, s.Metadata.Resources...)
+	disco := snapshotDiscovery{resources: resources}
+	for _, object := range all {
+		gvk := object.GroupVersionKind()
+		if _, err := disco.GetGVRFromGVK(gvk); err == nil {
+			continue
+		}
+		// objects not coming from the cluster have no recorded resource
+		gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+		disco.resources = append(disco.resources, Resource{
+			Group:      gvk.Group,
+			Version:    gvk.Version,
+			Kind:       gvk.Kind,
+			Resource:   gvr.Resource,
+			Namespaced: object.GetNamespace() != "",
+		})
+	}
+	gvrToListKind := map[schema.GroupVersionResource]string{}
+	for _, resource := range disco.resources {
+		gvrToListKind[resource.groupVersionResource()] = resource.Kind + "List"
+	}
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), gvrToListKind)
+	if err != nil {
+		return nil, err
+	}
+	client.SetDiscovery(disco)
+	// objects are created through the client to be stored under the recorded resources
+	for _, object := range all {
+		object := object.DeepCopy()
+		object.SetResourceVersion("")
+		_, err := client.CreateResource(context.TODO(), object.GetAPIVersion(), object.GetKind(), object.GetNamespace(), object, false)
+		if apierrors.IsAlreadyExists(err) {
+			_, err = client.UpdateResource(context.TODO(), object.GetAPIVersion(), object.GetKind(), object.GetNamespace(), object, false)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s %s/%s from snapshot (%w)", object.GetKind(), object.GetNamespace(), object.GetName(), err)
+		}
+	}
+	return snapshotClient{Interface: client}, nil
+}
+
+// ConfigmapResolver returns a resolver serving the config maps of the snapshot
+func (s *Snapshot) ConfigmapResolver() engineapi.ConfigmapResolver {
+	return configMapResolver{snapshot: s}
+}
+
+// Namespace returns a namespace of the snapshot, nil if not found
+func (s *Snapshot) Namespace(name string) *corev1.Namespace {
+	for _, object := range s.objects("v1", "Namespace") {
+		if object.GetName() == name {
+			namespace, err := convert.To[corev1.Namespace](object)
+			if err != nil {
+				return nil
+			}
+			return namespace
+		}
+	}
+	return nil
+}
+
+// NamespaceLabels returns the labels of the snapshot namespaces, keyed by namespace name
+func (s *Snapshot) NamespaceLabels() map[string]map[string]string {
+	labels := map[string]map[string]string{}
+	for _, object := range s.objects("v1", "Namespace") {
+		labels[object.GetName()] = object.GetLabels()
+	}
+	return labels
+}
+
+// ResolveRoles sets the roles and cluster roles bound to the user of a request by the role bindings of the snapshot,
+// roles already set in the request are kept
+func (s *Snapshot) ResolveRoles(info *kyvernov2.RequestInfo) error {
+	if info == nil || len(info.Roles) > 0 || len(info.ClusterRoles) > 0 {
+		return nil
+	}
+	roles, clusterRoles, err := userinfo.GetRoleRef(roleBindingLister{snapshot: s}, clusterRoleBindingLister{snapshot: s}, info.AdmissionUserInfo)
+	if err != nil {
+		return fmt.Errorf("failed to resolve roles from snapshot (%w)", err)
+	}
+	info.Roles = roles
+	info.ClusterRoles = clusterRoles
+	return nil
+}
+
+// GlobalContextEntries returns entries serving the global context entries data of the snapshot,
+// projections declared by the snapshot global context entries are computed too
+func (s *Snapshot) GlobalContextEntries(jp jmespath.Interface) (map[string]gctxstore.Entry, error) {
+	specs := map[string][]kyvernov2alpha1.GlobalContextEntryProjection{}
+	for _, object := range s.objects(kyvernov2alpha1.SchemeGroupVersion.String(), "GlobalContextEntry") {
+		entry, err := convert.To[kyvernov2alpha1.GlobalContextEntry](object)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert global context entry %s (%w)", object.GetName(), err)
+		}
+		specs[entry.Name] = entry.Spec.Projections
+	}
+	entries := make(map[string]gctxstore.Entry, len(s.Metadata.GlobalContextEntries))
+	for name, data := range s.Metadata.GlobalContextEntries {
+		projections, err := projection.New(jp, specs[name]...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile projections of global context entry %s (%w)", name, err)
+		}
+		entries[name] = static.NewWithProjections(data, projections)
+	}
+	return entries, nil
+}
+
+func (s *Snapshot) objects(apiVersion, kind string) []unstructured.Unstructured {
+	var objects []unstructured.Unstructured
+	for _, object := range s.Objects {
+		if object.GetAPIVersion() == apiVersion && object.GetKind() == kind {
+			objects = append(objects, object)
+		}
+	}
+	return objects
+}
+
+// snapshotClient serves raw GET calls from the objects of the snapshot
+type snapshotClient struct {
+	dclient.Interface
+}
+
+func (c snapshotClient) RawAbsPath(ctx context.Context, path string, method string, _ io.Reader) ([]byte, error) {
+	if method != "GET" {
+		return nil, fmt.Errorf("%s %s is not supported with a snapshot", method, path)
+	}
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	gvr, namespace, name, err := parseAPIPath(u.Path)
+	if err != nil {
+		return nil, err
+	}
+	gvk, err := c.Discovery().GetGVKFromGVR(gvr)
+	if err != nil {
+		return nil, err
+	}
+	if name != "" {
+		object, err := c.GetResource(ctx, gvk.GroupVersion().String(), gvk.Kind, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		return object.MarshalJSON()
+	}
+	var selector *metav1.LabelSelector
+	if query := u.Query().Get("labelSelector"); query != "" {
+		if selector, err = metav1.ParseToLabelSelector(query); err != nil {
+			return nil, err
+		}
+	}
+	list, err := c.ListResource(ctx, gvk.GroupVersion().String(), gvk.Kind, namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+	list.SetAPIVersion(gvk.GroupVersion().String())
+	list.SetKind(gvk.Kind + "List")
+	return list.MarshalJSON()
+}
+
+// parseAPIPath returns the resource, namespace and name targeted by an API path, subresources are not supported
+func parseAPIPath(path string) (schema.GroupVersionResource, string, string, error) {
+	var gvr schema.GroupVersionResource
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(segments) >= 3 && segments[0] == "api":
+		gvr.Version, segments = segments[1], segments[2:]
+	case len(segments) >= 4 && segments[0] == "apis":
+		gvr.Group, gvr.Version, segments = segments[1], segments[2], segments[3:]
+	default:
+		return gvr, "", "", fmt.Errorf("unsupported API path %s", path)
+	}
+	var namespace, name string
+	if len(segments) >= 3 && segments[0] == "namespaces" {
+		namespace, segments = segments[1], segments[2:]
+	}
+	if len(segments) > 2 {
+		return gvr, "", "", fmt.Errorf("unsupported API path %s, subresources are not supported", path)
+	}
+	gvr.Resource = segments[0]
+	if len(segments) == 2 {
+		name = segments[1]
+	}
+	return gvr, namespace, name, nil
+}
+
+func isVariable(value string) bool {
+	return strings.Contains(value, "{{")
+}
+
+func (r Resource) groupVersionResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: r.Group, Version: r.Version, Resource: r.Resource}
+}
+
+func (r Resource) groupVersionKind() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: r.Group, Version: r.Version, Kind: r.Kind}
+}
+
+// snapshotDiscovery serves the API resources recorded in a snapshot, subresources are not recorded
+type snapshotDiscovery struct {
+	resources []Resource
+}
+
+func (d snapshotDiscovery) FindResources(group, version, kind, subresource string) (map[dclient.TopLevelApiDescription]metav1.APIResource, error) {
+	found := map[dclient.TopLevelApiDescription]metav1.APIResource{}
+	if subresource == "" {
+		for _, resource := range d.resources {
+			if wildcard.Match(group, resource.Group) && wildcard.Match(version, resource.Version) && wildcard.Match(kind, resource.Kind) {
+				gvk := resource.groupVersionKind()
+				found[dclient.TopLevelApiDescription{
+					GroupVersion: gvk.GroupVersion(),
+					Kind:         resource.Kind,
+					Resource:     resource.Resource,
+				}] = metav1.APIResource{
+					Name:       resource.Resource,
+					Namespaced: resource.Namespaced,
+					Group:      resource.Group,
+					Version:    resource.Version,
+					Kind:       resource.Kind,
+				}
+			}
+		}
+	}
+	if len(found) == 0 {
+		return nil, fmt.Errorf("failed to find resource (%s/%s/%s/%s) in snapshot", group, version, kind, subresource)
+	}
+	return found, nil
+}
+
+func (d snapshotDiscovery) GetGVRFromGVK(gvk schema.GroupVersionKind) (schema.GroupVersionResource, error) {
+	for _, resource := range d.resources {
+		if resource.groupVersionKind() == gvk {
+			return resource.groupVersionResource(), nil
+		}
+	}
+	return schema.GroupVersionResource{}, fmt.Errorf("failed to find resource for %s in snapshot", gvk)
+}
+
+func (d snapshotDiscovery) GetGVKFromGVR(gvr schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	for _, resource := range d.resources {
+		if resource.groupVersionResource() == gvr {
+			return resource.groupVersionKind(), nil
+		}
+	}
+	return schema.GroupVersionKind{}, fmt.Errorf("failed to find kind for %s in snapshot", gvr)
+}
+
+func (d snapshotDiscovery) OpenAPISchema() (*openapiv2.Document, error) {
+	return nil, nil
+}
+
+func (d snapshotDiscovery) CachedDiscoveryInterface() discovery.CachedDiscoveryInterface {
+	return nil
+}
+
+type configMapResolver struct {
+	snapshot *Snapshot
+}
+
+func (r configMapResolver) Get(_ context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	for _, object := range r.snapshot.objects("v1", "ConfigMap") {
+		if object.GetNamespace() == namespace && object.GetName() == name {
+			return convert.To[corev1.ConfigMap](object)
+		}
+	}
+	return nil, apierrors.NewNotFound(corev1.Resource("configmaps"), name)
+}
+
+type roleBindingLister struct {
+	snapshot *Snapshot
+}
+
+func (l roleBindingLister) List(selector labels.Selector) ([]*rbacv1.RoleBinding, error) {
+	var bindings []*rbacv1.RoleBinding
+	for _, object := range l.snapshot.objects(rbacv1.SchemeGroupVersion.String(), "RoleBinding") {
+		if !selector.Matches(labels.Set(object.GetLabels())) {
+			continue
+		}
+		binding, err := convert.To[rbacv1.RoleBinding](object)
+		if err != nil {
+			return nil, err
+		}
+		bindings = append(bindings, binding)
+	}
+	return bindings, nil
+}
+
+type clusterRoleBindingLister struct {
+	snapshot *Snapshot
+}
+
+func (l clusterRoleBindingLister) List(selector labels.Selector) ([]*rbacv1.ClusterRoleBinding, error) {
+	var bindings []*rbacv1.ClusterRoleBinding
+	for _, object := range l.snapshot.objects(rbacv1.SchemeGroupVersion.String(), "ClusterRoleBinding") {
+		if !selector.Matches(labels.Set(object.GetLabels())) {
+			continue
+		}
+		binding, err := convert.To[rbacv1.ClusterRoleBinding](object)
+		if err != nil {
+			return nil, err
+		}
+		bindings = append(bindings, binding)
+	}
+	return bindings, nil
+}
@@ -0,0 +1,130 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/stretchr/testify/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestClient(t *testing.T) {
+	snap := newSnapshot()
+	// additional objects replace snapshot objects and can have kinds not recorded in the snapshot
+	pod := newObject("v1", "Pod", "team-a", "nginx", nil)
+	pod.SetLabels(map[string]string{"app": "nginx", "version": "2"})
+	deployment := newObject("apps/v1", "Deployment", "team-a", "nginx", nil)
+	client, err := snap.Client(&pod, &deployment)
+	assert.NoError(t, err)
+	found, err := client.Discovery().FindResources("*", "*", "Pod", "")
+	assert.NoError(t, err)
+	assert.Len(t, found, 1)
+	for api, resource := range found {
+		assert.Equal(t, "pods", api.Resource)
+		assert.True(t, resource.Namespaced)
+	}
+	pods, err := client.ListResource(context.TODO(), "v1", "Pod", "team-a", nil)
+	assert.NoError(t, err)
+	assert.Len(t, pods.Items, 1)
+	assert.Equal(t, "2", pods.Items[0].GetLabels()["version"])
+	_, err = client.GetResource(context.TODO(), "apps/v1", "Deployment", "team-a", "nginx")
+	assert.NoError(t, err)
+	// the snapshot is left untouched
+	assert.Len(t, snap.Objects, 5)
+}
+
+func TestClientRawAbsPath(t *testing.T) {
+	client, err := newSnapshot().Client()
+	assert.NoError(t, err)
+	data, err := client.RawAbsPath(context.TODO(), "/api/v1/namespaces/team-a/pods?labelSelector=app%3Dnginx", "GET", nil)
+	assert.NoError(t, err)
+	var list map[string]any
+	assert.NoError(t, json.Unmarshal(data, &list))
+	assert.Equal(t, "PodList", list["kind"])
+	assert.Len(t, list["items"], 1)
+	data, err = client.RawAbsPath(context.TODO(), "/api/v1/namespaces/team-a/pods?labelSelector=app%3Dother", "GET", nil)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(data, &list))
+	assert.Empty(t, list["items"])
+	data, err = client.RawAbsPath(context.TODO(), "/api/v1/namespaces/team-a", "GET", nil)
+	assert.NoError(t, err)
+	var namespace map[string]any
+	assert.NoError(t, json.Unmarshal(data, &namespace))
+	assert.Equal(t, "Namespace", namespace["kind"])
+	_, err = client.RawAbsPath(context.TODO(), "/api/v1/namespaces/team-a/pods", "POST", nil)
+	assert.Error(t, err)
+}
+
+func Test_parseAPIPath(t *testing.T) {
+	tests := []struct {
+		path      string
+		gvr       schema.GroupVersionResource
+		namespace string
+		name      string
+		wantErr   bool
+	}{
+		{path: "/api/v1/namespaces", gvr: schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}},
+		{path: "/api/v1/namespaces/default", gvr: schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}, name: "default"},
+		{path: "/api/v1/namespaces/default/pods", gvr: schema.GroupVersionResource{Version: "v1", Resource: "pods"}, namespace: "default"},
+		{path: "/apis/apps/v1/namespaces/{{request.namespace}}/deployments/nginx", gvr: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, namespace: "{{request.namespace}}", name: "nginx"},
+		{path: "/apis/apps/v1/deployments", gvr: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}},
+		{path: "/api/v1/namespaces/default/pods/nginx/status", wantErr: true},
+		{path: "/healthz", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			gvr, namespace, name, err := parseAPIPath(tt.path)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.gvr, gvr)
+			assert.Equal(t, tt.namespace, namespace)
+			assert.Equal(t, tt.name, name)
+		})
+	}
+}
+
+func TestConfigmapResolver(t *testing.T) {
+	resolver := newSnapshot().ConfigmapResolver()
+	cm, err := resolver.Get(context.TODO(), "team-a", "settings")
+	assert.NoError(t, err)
+	assert.Equal(t, "ghcr.io", cm.Data["registry"])
+	_, err = resolver.Get(context.TODO(), "default", "settings")
+	assert.Error(t, err)
+}
+
+func TestNamespaces(t *testing.T) {
+	snap := newSnapshot()
+	assert.Equal(t, map[string]map[string]string{"team-a": {"team": "a"}}, snap.NamespaceLabels())
+	namespace := snap.Namespace("team-a")
+	assert.NotNil(t, namespace)
+	assert.Equal(t, "a", namespace.Labels["team"])
+	assert.Nil(t, snap.Namespace("team-b"))
+}
+
+func TestResolveRoles(t *testing.T) {
+	snap := newSnapshot()
+	info := &kyvernov2.RequestInfo{
+		AdmissionUserInfo: authenticationv1.UserInfo{
+			Username: "alice",
+			Groups:   []string{"everyone"},
+		},
+	}
+	assert.NoError(t, snap.ResolveRoles(info))
+	assert.Equal(t, []string{"team-a:developer"}, info.Roles)
+	assert.Equal(t, []string{"view"}, info.ClusterRoles)
+	// roles set in the request are kept
+	info = &kyvernov2.RequestInfo{
+		Roles:             []string{"team-b:admin"},
+		AdmissionUserInfo: authenticationv1.UserInfo{Username: "alice"},
+	}
+	assert.NoError(t, snap.ResolveRoles(info))
+	assert.Equal(t, []string{"team-b:admin"}, info.Roles)
+	assert.Empty(t, info.ClusterRoles)
+	assert.NoError(t, snap.ResolveRoles(nil))
+}
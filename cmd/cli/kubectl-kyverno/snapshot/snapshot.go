@@ -0,0 +1,174 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	extyaml "github.com/kyverno/kyverno/ext/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Version is the version of the snapshot format
+	Version = "v1"
+
+	metadataFile = "metadata.yaml"
+	objectsFile  = "objects.yaml"
+)
+
+// Snapshot is an offline copy of the cluster state used when evaluating policies.
+// It is stored as a gzipped tar archive containing a metadata file and a multi document objects file.
+type Snapshot struct {
+	Metadata Metadata
+	Objects  []unstructured.Unstructured
+}
+
+type Metadata struct {
+	// Version is the version of the snapshot format
+	Version string `json:"version"`
+	// Context is the name of the kubeconfig context the snapshot was taken from
+	Context string `json:"context,omitempty"`
+	// Namespace limits namespaced objects to a single namespace when set
+	Namespace string `json:"namespace,omitempty"`
+	// CreationTimestamp is the time the snapshot was taken
+	CreationTimestamp time.Time `json:"creationTimestamp"`
+	// Resources are the API resources of the objects in the snapshot
+	Resources []Resource `json:"resources,omitempty"`
+	// GlobalContextEntries are the global context entries data, keyed by entry name
+	GlobalContextEntries map[string]any `json:"globalContextEntries,omitempty"`
+}
+
+// Resource describes an API resource as discovered in the cluster
+type Resource struct {
+	Group      string `json:"group,omitempty"`
+	Version    string `json:"version"`
+	Kind       string `json:"kind"`
+	Resource   string `json:"resource"`
+	Namespaced bool   `json:"namespaced,omitempty"`
+}
+
+// Write writes the snapshot archive
+func Write(w io.Writer, snapshot *Snapshot) error {
+	metadata, err := yaml.Marshal(snapshot.Metadata)
+	if err != nil {
+		return err
+	}
+	var objects bytes.Buffer
+	for i := range snapshot.Objects {
+		data, err := yaml.Marshal(snapshot.Objects[i].Object)
+		if err != nil {
+			return err
+		}
+		objects.WriteString("---\n")
+		objects.Write(data)
+	}
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, file := range []struct {
+		name string
+		data []byte
+	}{
+		{metadataFile, metadata},
+		{objectsFile, objects.Bytes()},
+	} {
+		header := &tar.Header{
+			Name:    file.name,
+			Mode:    0o600,
+			Size:    int64(len(file.data)),
+			ModTime: snapshot.Metadata.CreationTimestamp,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(file.data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// Read reads a snapshot archive
+func Read(r io.Reader) (*Snapshot, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot archive (%w)", err)
+	}
+	defer gz.Close()
+	files := map[string][]byte{}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid snapshot archive (%w)", err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		files[header.Name] = data
+	}
+	metadata, ok := files[metadataFile]
+	if !ok {
+		return nil, fmt.Errorf("invalid snapshot archive, %s not found", metadataFile)
+	}
+	var snapshot Snapshot
+	if err := yaml.UnmarshalStrict(metadata, &snapshot.Metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot metadata (%w)", err)
+	}
+	if snapshot.Metadata.Version != Version {
+		return nil, fmt.Errorf("unsupported snapshot version %q, expected %q", snapshot.Metadata.Version, Version)
+	}
+	documents, err := extyaml.SplitDocuments(files[objectsFile])
+	if err != nil {
+		return nil, err
+	}
+	for _, document := range documents {
+		json, err := yaml.YAMLToJSON(document)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse snapshot object (%w)", err)
+		}
+		var object unstructured.Unstructured
+		if err := object.UnmarshalJSON(json); err != nil {
+			return nil, fmt.Errorf("failed to parse snapshot object (%w)", err)
+		}
+		snapshot.Objects = append(snapshot.Objects, object)
+	}
+	return &snapshot, nil
+}
+
+// Save writes the snapshot archive to a file
+func Save(path string, snapshot *Snapshot) error {
+	file, err := os.Create(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	if err := Write(file, snapshot); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Load reads a snapshot archive from a file
+func Load(path string) (*Snapshot, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Read(file)
+}
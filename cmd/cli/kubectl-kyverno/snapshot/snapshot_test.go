@@ -0,0 +1,96 @@
+package snapshot
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newObject(apiVersion, kind, namespace, name string, fields map[string]any) unstructured.Unstructured {
+	object := unstructured.Unstructured{Object: map[string]any{}}
+	for key, value := range fields {
+		object.Object[key] = value
+	}
+	object.SetAPIVersion(apiVersion)
+	object.SetKind(kind)
+	object.SetNamespace(namespace)
+	object.SetName(name)
+	return object
+}
+
+func newSnapshot() *Snapshot {
+	namespace := newObject("v1", "Namespace", "", "team-a", nil)
+	namespace.SetLabels(map[string]string{"team": "a"})
+	pod := newObject("v1", "Pod", "team-a", "nginx", map[string]any{
+		"spec": map[string]any{"containers": []any{map[string]any{"name": "nginx", "image": "nginx:latest"}}},
+	})
+	pod.SetLabels(map[string]string{"app": "nginx"})
+	return &Snapshot{
+		Metadata: Metadata{
+			Version:           Version,
+			CreationTimestamp: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			Resources: []Resource{
+				{Version: "v1", Kind: "Namespace", Resource: "namespaces"},
+				{Version: "v1", Kind: "Pod", Resource: "pods", Namespaced: true},
+				{Version: "v1", Kind: "ConfigMap", Resource: "configmaps", Namespaced: true},
+				{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "RoleBinding", Resource: "rolebindings", Namespaced: true},
+				{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRoleBinding", Resource: "clusterrolebindings"},
+			},
+			GlobalContextEntries: map[string]any{
+				"deployments": []any{map[string]any{"metadata": map[string]any{"name": "nginx"}}},
+			},
+		},
+		Objects: []unstructured.Unstructured{
+			namespace,
+			pod,
+			newObject("v1", "ConfigMap", "team-a", "settings", map[string]any{
+				"data": map[string]any{"registry": "ghcr.io"},
+			}),
+			newObject("rbac.authorization.k8s.io/v1", "RoleBinding", "team-a", "developers", map[string]any{
+				"roleRef":  map[string]any{"apiGroup": "rbac.authorization.k8s.io", "kind": "Role", "name": "developer"},
+				"subjects": []any{map[string]any{"kind": "User", "name": "alice"}},
+			}),
+			newObject("rbac.authorization.k8s.io/v1", "ClusterRoleBinding", "", "viewers", map[string]any{
+				"roleRef":  map[string]any{"apiGroup": "rbac.authorization.k8s.io", "kind": "ClusterRole", "name": "view"},
+				"subjects": []any{map[string]any{"kind": "Group", "name": "everyone"}},
+			}),
+		},
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	snap := newSnapshot()
+	var buffer bytes.Buffer
+	assert.NoError(t, Write(&buffer, snap))
+	read, err := Read(&buffer)
+	assert.NoError(t, err)
+	assert.Equal(t, snap.Metadata.CreationTimestamp, read.Metadata.CreationTimestamp.UTC())
+	assert.Equal(t, snap.Metadata.Resources, read.Metadata.Resources)
+	assert.Equal(t, snap.Metadata.GlobalContextEntries, read.Metadata.GlobalContextEntries)
+	assert.Equal(t, snap.Objects, read.Objects)
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+	assert.NoError(t, Save(path, newSnapshot()))
+	snap, err := Load(path)
+	assert.NoError(t, err)
+	assert.Len(t, snap.Objects, 5)
+	_, err = Load(filepath.Join(t.TempDir(), "missing.tar.gz"))
+	assert.Error(t, err)
+}
+
+func TestReadInvalid(t *testing.T) {
+	_, err := Read(bytes.NewBufferString("not an archive"))
+	assert.ErrorContains(t, err, "invalid snapshot archive")
+	snap := newSnapshot()
+	snap.Metadata.Version = "v0"
+	var buffer bytes.Buffer
+	assert.NoError(t, Write(&buffer, snap))
+	_, err = Read(&buffer)
+	assert.EqualError(t, err, `unsupported snapshot version "v0", expected "v1"`)
+}
@@ -0,0 +1,363 @@
+package snapshot
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/ext/resource/convert"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Options selects the cluster state captured in a snapshot
+type Options struct {
+	// Context is the name of the kubeconfig context, it is recorded in the metadata
+	Context string
+	// Namespace limits namespaced objects to a single namespace when set
+	Namespace string
+	// Kinds are the kinds of the resources matched by policies, in the format used by policy match blocks
+	Kinds []string
+	// ConfigMaps are the config maps referenced by policy context entries,
+	// an empty name selects all the config maps of the namespace and an empty namespace selects all namespaces
+	ConfigMaps []types.NamespacedName
+	// APICalls are the URL paths of the API calls made by policy context entries,
+	// the resources they target are captured to serve the calls
+	APICalls []string
+	// IncludeSecretData keeps the values of secrets, by default they are redacted and only the keys are captured
+	IncludeSecretData bool
+}
+
+var (
+	namespaceKinds    = []string{"v1/Namespace"}
+	configMapResource = Resource{Version: "v1", Kind: "ConfigMap", Resource: "configmaps", Namespaced: true}
+	rbacKinds         = []string{
+		rbacv1.SchemeGroupVersion.String() + "/Role",
+		rbacv1.SchemeGroupVersion.String() + "/RoleBinding",
+		rbacv1.SchemeGroupVersion.String() + "/ClusterRole",
+		rbacv1.SchemeGroupVersion.String() + "/ClusterRoleBinding",
+	}
+	globalContextEntryKind = kyvernov2alpha1.SchemeGroupVersion.String() + "/GlobalContextEntry"
+)
+
+// Take captures a snapshot of the cluster state used when evaluating policies:
+// matched resources, namespaces, referenced config maps, global context entries and their data, and RBAC.
+// Objects that can't be captured are reported as warnings, secret values are redacted unless options include them.
+func Take(ctx context.Context, out io.Writer, client dclient.Interface, options Options) (*Snapshot, error) {
+	t := taker{
+		client:  client,
+		out:     out,
+		options: options,
+		objects: map[string]unstructured.Unstructured{},
+		apis:    map[Resource]struct{}{},
+		snapshot: &Snapshot{
+			Metadata: Metadata{
+				Version:           Version,
+				Context:           options.Context,
+				Namespace:         options.Namespace,
+				CreationTimestamp: time.Now().UTC().Truncate(time.Second),
+			},
+		},
+	}
+	var kinds []string
+	kinds = append(kinds, namespaceKinds...)
+	kinds = append(kinds, rbacKinds...)
+	kinds = append(kinds, options.Kinds...)
+	for _, kind := range kinds {
+		if err := t.list(ctx, kind); err != nil {
+			return nil, err
+		}
+	}
+	for _, path := range options.APICalls {
+		if err := t.listAPICall(ctx, path); err != nil {
+			return nil, err
+		}
+	}
+	for _, cm := range options.ConfigMaps {
+		if err := t.configMap(ctx, cm); err != nil {
+			return nil, err
+		}
+	}
+	if err := t.globalContextEntries(ctx); err != nil {
+		return nil, err
+	}
+	return t.result(), nil
+}
+
+type taker struct {
+	client   dclient.Interface
+	out      io.Writer
+	options  Options
+	objects  map[string]unstructured.Unstructured
+	apis     map[Resource]struct{}
+	snapshot *Snapshot
+}
+
+func (t *taker) warn(format string, args ...any) {
+	fmt.Fprintf(t.out, "  warning: "+format+"\n", args...)
+}
+
+func isSecret(apiVersion, kind string) bool {
+	return apiVersion == "v1" && kind == "Secret"
+}
+
+// redact replaces the values of a secret with empty strings, the keys are kept for policies checking them
+func (t *taker) redact(secret map[string]any) {
+	if t.options.IncludeSecretData {
+		return
+	}
+	for _, field := range []string{"data", "stringData"} {
+		if values, ok := secret[field].(map[string]any); ok {
+			for key := range values {
+				values[key] = ""
+			}
+		}
+	}
+}
+
+func (t *taker) add(object unstructured.Unstructured) {
+	object = *object.DeepCopy()
+	object.SetManagedFields(nil)
+	object.SetResourceVersion("")
+	if isSecret(object.GetAPIVersion(), object.GetKind()) {
+		t.redact(object.Object)
+	}
+	key := object.GetAPIVersion() + "/" + object.GetKind() + "/" + object.GetNamespace() + "/" + object.GetName()
+	t.objects[key] = object
+}
+
+// list captures the objects of the API resources matching a kind selector, subresources are ignored
+func (t *taker) list(ctx context.Context, selector string) error {
+	group, version, kind, subresource := kubeutils.ParseKindSelector(selector)
+	if subresource != "" {
+		return nil
+	}
+	apis, err := t.client.Discovery().FindResources(group, version, kind, "")
+	if err != nil {
+		t.warn("failed to find resource %s (%s)", selector, err)
+		return nil
+	}
+	for api, resource := range apis {
+		if api.SubResource != "" {
+			continue
+		}
+		if err := t.listResource(ctx, Resource{
+			Group:      api.GroupVersion.Group,
+			Version:    api.GroupVersion.Version,
+			Kind:       api.Kind,
+			Resource:   api.Resource,
+			Namespaced: resource.Namespaced,
+		}, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listResource captures the objects of an API resource, in all namespaces when namespace is empty
+func (t *taker) listResource(ctx context.Context, resource Resource, namespace string) error {
+	if resource.Namespaced && t.options.Namespace != "" {
+		if namespace != "" && namespace != t.options.Namespace {
+			return nil
+		}
+		namespace = t.options.Namespace
+	}
+	t.apis[resource] = struct{}{}
+	gv := resource.groupVersionKind().GroupVersion().String()
+	list, err := t.client.ListResource(ctx, gv, resource.Kind, namespace, nil)
+	if err != nil {
+		if apierrors.IsForbidden(err) {
+			t.warn("not allowed to list %s (%s)", resource.Resource, err)
+			return nil
+		}
+		return fmt.Errorf("failed to list %s (%w)", resource.Resource, err)
+	}
+	for _, item := range list.Items {
+		if resource.Kind == "Namespace" && t.options.Namespace != "" && item.GetName() != t.options.Namespace {
+			continue
+		}
+		t.add(item)
+	}
+	return nil
+}
+
+// resource finds the API resource of a group version resource
+func (t *taker) resource(gvr schema.GroupVersionResource) (Resource, bool) {
+	gvk, err := t.client.Discovery().GetGVKFromGVR(gvr)
+	if err != nil {
+		t.warn("failed to find resource %s (%s)", gvr, err)
+		return Resource{}, false
+	}
+	apis, err := t.client.Discovery().FindResources(gvk.Group, gvk.Version, gvk.Kind, "")
+	if err != nil {
+		t.warn("failed to find resource %s (%s)", gvk, err)
+		return Resource{}, false
+	}
+	for api, resource := range apis {
+		if api.SubResource == "" && api.Resource == gvr.Resource {
+			return Resource{
+				Group:      gvk.Group,
+				Version:    gvk.Version,
+				Kind:       gvk.Kind,
+				Resource:   gvr.Resource,
+				Namespaced: resource.Namespaced,
+			}, true
+		}
+	}
+	t.warn("failed to find resource %s", gvr)
+	return Resource{}, false
+}
+
+// listAPICall captures the resources targeted by an API call, the call can contain variables
+func (t *taker) listAPICall(ctx context.Context, path string) error {
+	path, _, _ = strings.Cut(path, "?")
+	gvr, namespace, _, err := parseAPIPath(path)
+	if err != nil {
+		t.warn("%s", err)
+		return nil
+	}
+	resource, ok := t.resource(gvr)
+	if !ok {
+		return nil
+	}
+	if isVariable(namespace) {
+		namespace = ""
+	}
+	return t.listResource(ctx, resource, namespace)
+}
+
+func (t *taker) configMap(ctx context.Context, cm types.NamespacedName) error {
+	if cm.Name == "" {
+		return t.listResource(ctx, configMapResource, cm.Namespace)
+	}
+	if t.options.Namespace != "" && cm.Namespace != t.options.Namespace {
+		return nil
+	}
+	t.apis[configMapResource] = struct{}{}
+	object, err := t.client.GetResource(ctx, "v1", "ConfigMap", cm.Namespace, cm.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) || apierrors.IsForbidden(err) {
+			t.warn("failed to get config map %s (%s)", cm, err)
+			return nil
+		}
+		return fmt.Errorf("failed to get config map %s (%w)", cm, err)
+	}
+	t.add(*object)
+	return nil
+}
+
+// globalContextEntries captures the global context entries and the data they cache
+func (t *taker) globalContextEntries(ctx context.Context) error {
+	if err := t.list(ctx, globalContextEntryKind); err != nil {
+		return err
+	}
+	data := map[string]any{}
+	for _, object := range t.objects {
+		if object.GetAPIVersion() != kyvernov2alpha1.SchemeGroupVersion.String() || object.GetKind() != "GlobalContextEntry" {
+			continue
+		}
+		entry, err := convert.To[kyvernov2alpha1.GlobalContextEntry](object)
+		if err != nil {
+			return fmt.Errorf("failed to convert global context entry %s (%w)", object.GetName(), err)
+		}
+		if value, ok := t.globalContextEntryData(ctx, entry); ok {
+			data[entry.Name] = value
+		}
+	}
+	if len(data) > 0 {
+		t.snapshot.Metadata.GlobalContextEntries = data
+	}
+	return nil
+}
+
+func (t *taker) globalContextEntryData(ctx context.Context, entry *kyvernov2alpha1.GlobalContextEntry) (any, bool) {
+	if resource := entry.Spec.KubernetesResource; resource != nil {
+		gvr := schema.GroupVersionResource{Group: resource.Group, Version: resource.Version, Resource: resource.Resource}
+		if _, err := t.client.Discovery().GetGVKFromGVR(gvr); err != nil {
+			t.warn("failed to find resource of global context entry %s (%s)", entry.Name, err)
+			return nil, false
+		}
+		options := metav1.ListOptions{FieldSelector: resource.FieldSelector}
+		if resource.LabelSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(resource.LabelSelector)
+			if err != nil {
+				t.warn("invalid label selector in global context entry %s (%s)", entry.Name, err)
+				return nil, false
+			}
+			options.LabelSelector = selector.String()
+		}
+		list, err := t.client.GetDynamicInterface().Resource(gvr).Namespace(resource.Namespace).List(ctx, options)
+		if err != nil {
+			t.warn("failed to list resources of global context entry %s (%s)", entry.Name, err)
+			return nil, false
+		}
+		items := make([]any, 0, len(list.Items))
+		for _, item := range list.Items {
+			// metadata only entries cache the metadata of the resources
+			if resource.MetadataOnly {
+				items = append(items, map[string]any{"metadata": item.Object["metadata"]})
+			} else {
+				if isSecret(item.GetAPIVersion(), item.GetKind()) {
+					t.redact(item.Object)
+				}
+				items = append(items, item.Object)
+			}
+		}
+		return items, true
+	}
+	if call := entry.Spec.APICall; call != nil {
+		if call.Service != nil || (call.Method != "" && call.Method != "GET") {
+			t.warn("global context entry %s is not captured, only GET calls to the Kubernetes API are supported", entry.Name)
+			return nil, false
+		}
+		response, err := t.client.RawAbsPath(ctx, call.URLPath, "GET", nil)
+		if err != nil {
+			t.warn("failed to call %s for global context entry %s (%s)", call.URLPath, entry.Name, err)
+			return nil, false
+		}
+		var data any
+		if err := json.Unmarshal(response, &data); err != nil {
+			t.warn("failed to decode the response of global context entry %s (%s)", entry.Name, err)
+			return nil, false
+		}
+		return data, true
+	}
+	return nil, false
+}
+
+func (t *taker) result() *Snapshot {
+	for resource := range t.apis {
+		t.snapshot.Metadata.Resources = append(t.snapshot.Metadata.Resources, resource)
+	}
+	slices.SortFunc(t.snapshot.Metadata.Resources, func(x, y Resource) int {
+		return cmp.Or(
+			cmp.Compare(x.Group, y.Group),
+			cmp.Compare(x.Version, y.Version),
+			cmp.Compare(x.Kind, y.Kind),
+		)
+	})
+	for _, object := range t.objects {
+		t.snapshot.Objects = append(t.snapshot.Objects, object)
+	}
+	slices.SortFunc(t.snapshot.Objects, func(x, y unstructured.Unstructured) int {
+		return cmp.Or(
+			cmp.Compare(x.GetAPIVersion(), y.GetAPIVersion()),
+			cmp.Compare(x.GetKind(), y.GetKind()),
+			cmp.Compare(x.GetNamespace(), y.GetNamespace()),
+			cmp.Compare(x.GetName(), y.GetName()),
+		)
+	})
+	return t.snapshot
+}
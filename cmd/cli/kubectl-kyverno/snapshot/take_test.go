@@ -0,0 +1,106 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestTake(t *testing.T) {
+	client, err := newSnapshot().Client()
+	assert.NoError(t, err)
+	var out bytes.Buffer
+	snap, err := Take(context.TODO(), &out, client, Options{
+		Context:    "kind-kind",
+		Namespace:  "team-a",
+		Kinds:      []string{"Pod", "Deployment"},
+		ConfigMaps: []types.NamespacedName{{Namespace: "team-a", Name: "settings"}, {Namespace: "team-a", Name: "missing"}, {Namespace: "team-b", Name: "settings"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, Version, snap.Metadata.Version)
+	assert.Equal(t, "kind-kind", snap.Metadata.Context)
+	var names []string
+	for _, object := range snap.Objects {
+		names = append(names, object.GetKind()+"/"+object.GetName())
+	}
+	assert.Equal(t, []string{
+		"ClusterRoleBinding/viewers",
+		"RoleBinding/developers",
+		"ConfigMap/settings",
+		"Namespace/team-a",
+		"Pod/nginx",
+	}, names)
+	assert.Contains(t, out.String(), "warning: failed to find resource Deployment")
+	assert.Contains(t, out.String(), "warning: failed to get config map team-a/missing")
+	// the snapshot taken from a snapshot serves the same objects
+	client, err = snap.Client()
+	assert.NoError(t, err)
+	pods, err := client.ListResource(context.TODO(), "v1", "Pod", "", nil)
+	assert.NoError(t, err)
+	assert.Len(t, pods.Items, 1)
+}
+
+func TestTakeGlobalContextEntries(t *testing.T) {
+	snap := newSnapshot()
+	snap.Metadata.Resources = append(snap.Metadata.Resources, Resource{Group: "kyverno.io", Version: "v2alpha1", Kind: "GlobalContextEntry", Resource: "globalcontextentries"})
+	snap.Objects = append(snap.Objects,
+		newObject("v1", "Pod", "team-a", "redis", map[string]any{
+			"spec": map[string]any{"containers": []any{map[string]any{"name": "redis", "image": "redis:latest"}}},
+		}),
+		newObject("kyverno.io/v2alpha1", "GlobalContextEntry", "", "nginx-pods", map[string]any{
+			"spec": map[string]any{
+				"kubernetesResource": map[string]any{
+					"version":       "v1",
+					"resource":      "pods",
+					"labelSelector": map[string]any{"matchLabels": map[string]any{"app": "nginx"}},
+					"metadataOnly":  true,
+				},
+			},
+		}),
+	)
+	client, err := snap.Client()
+	assert.NoError(t, err)
+	var out bytes.Buffer
+	taken, err := Take(context.TODO(), &out, client, Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"nginx-pods": []any{
+			map[string]any{
+				"metadata": map[string]any{
+					"name":      "nginx",
+					"namespace": "team-a",
+					"labels":    map[string]any{"app": "nginx"},
+				},
+			},
+		},
+	}, taken.Metadata.GlobalContextEntries)
+}
+
+func TestTakeSecrets(t *testing.T) {
+	snap := newSnapshot()
+	snap.Metadata.Resources = append(snap.Metadata.Resources, Resource{Version: "v1", Kind: "Secret", Resource: "secrets", Namespaced: true})
+	snap.Objects = append(snap.Objects, newObject("v1", "Secret", "team-a", "credentials", map[string]any{
+		"data":       map[string]any{"password": "c2VjcmV0"},
+		"stringData": map[string]any{"token": "secret"},
+	}))
+	client, err := snap.Client()
+	assert.NoError(t, err)
+	secretData := func(snap *Snapshot) []any {
+		for _, object := range snap.Objects {
+			if object.GetKind() == "Secret" {
+				return []any{object.Object["data"], object.Object["stringData"]}
+			}
+		}
+		return nil
+	}
+	var out bytes.Buffer
+	taken, err := Take(context.TODO(), &out, client, Options{Kinds: []string{"Secret"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{map[string]any{"password": ""}, map[string]any{"token": ""}}, secretData(taken))
+	taken, err = Take(context.TODO(), &out, client, Options{Kinds: []string{"Secret"}, IncludeSecretData: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{map[string]any{"password": "c2VjcmV0"}, map[string]any{"token": "secret"}}, secretData(taken))
+}
@@ -2,17 +2,22 @@ package store
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/log"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/apicall"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/factories"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 )
 
-func ContextLoaderFactory(s *Store, cmResolver engineapi.ConfigmapResolver) engineapi.ContextLoaderFactory {
+func ContextLoaderFactory(s *Store, cmResolver engineapi.ConfigmapResolver, opts ...factories.ContextLoaderFactoryOptions) engineapi.ContextLoaderFactory {
 	if !s.IsLocal() {
-		return factories.DefaultContextLoaderFactory(cmResolver)
+		return factories.DefaultContextLoaderFactory(cmResolver, opts...)
 	}
 	return func(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) engineapi.ContextLoader {
 		init := func(jsonContext enginecontext.Interface) error {
@@ -34,17 +39,27 @@ func ContextLoaderFactory(s *Store, cmResolver engineapi.ConfigmapResolver) engi
 			}
 			return nil
 		}
-		factory := factories.DefaultContextLoaderFactory(cmResolver, factories.WithInitializer(init))
+		opts := append(opts, factories.WithInitializer(init))
 		return wrapper{
 			store: s,
-			inner: factory(policy, rule),
+			inner: factories.DefaultContextLoaderFactory(cmResolver, opts...)(policy, rule),
+			mocked: func(client engineapi.RawClient) engineapi.ContextLoader {
+				executor := apiCallExecutor{store: s}
+				if client != nil {
+					executor.fallback = apicall.NewExecutor(log.Log, policy.GetName()+"/"+rule.Name, client, apicall.APICallConfiguration{})
+				}
+				return factories.DefaultContextLoaderFactory(cmResolver, append(opts, factories.WithAPICallExecutor(executor))...)(policy, rule)
+			},
+			rule: s.GetPolicyRule(policy.GetName(), rule.Name),
 		}
 	}
 }
 
 type wrapper struct {
-	store *Store
-	inner engineapi.ContextLoader
+	store  *Store
+	inner  engineapi.ContextLoader
+	mocked func(engineapi.RawClient) engineapi.ContextLoader
+	rule   *Rule
 }
 
 func (w wrapper) Load(
@@ -61,5 +76,90 @@ func (w wrapper) Load(
 	if !w.store.GetRegistryAccess() {
 		rclientFactory = nil
 	}
+	if w.store.HasAPICallMocks() {
+		return w.mocked(client).Load(ctx, jp, client, rclientFactory, w.withoutValues(contextEntries), jsonContext)
+	}
 	return w.inner.Load(ctx, jp, client, rclientFactory, contextEntries, jsonContext)
 }
+
+// withoutValues removes the apiCall context entries set in the rule values, values take precedence over mocked responses
+func (w wrapper) withoutValues(contextEntries []kyvernov1.ContextEntry) []kyvernov1.ContextEntry {
+	if w.rule == nil || len(w.rule.Values) == 0 {
+		return contextEntries
+	}
+	entries := make([]kyvernov1.ContextEntry, 0, len(contextEntries))
+	for _, entry := range contextEntries {
+		if _, ok := w.rule.Values[entry.Name]; ok && entry.APICall != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// apiCallExecutor serves the mocked responses of the store to apiCall context entries,
+// calls without a mocked response are sent to the cluster when a client is available, unless strict mode is enabled
+type apiCallExecutor struct {
+	store    *Store
+	fallback apicall.Executor
+}
+
+func (e apiCallExecutor) Execute(ctx context.Context, call *kyvernov1.APICall) ([]byte, error) {
+	method := string(call.Method)
+	if method == "" {
+		method = "GET"
+	}
+	target := call.URLPath
+	if call.Service != nil {
+		target = call.Service.URL
+	}
+	data := map[string]interface{}{}
+	for _, d := range call.Data {
+		var value interface{}
+		if d.Value != nil {
+			if err := json.Unmarshal(d.Value.Raw, &value); err != nil {
+				return nil, fmt.Errorf("failed to decode data %s of %s %s (%w)", d.Key, method, target, err)
+			}
+		}
+		data[d.Key] = value
+	}
+	for _, response := range e.store.GetAPICallResponses() {
+		if response.matches(call, method, data) {
+			return json.Marshal(response.Response)
+		}
+	}
+	if e.fallback != nil && !e.store.IsStrictApiCalls() {
+		return e.fallback.Execute(ctx, call)
+	}
+	return nil, fmt.Errorf("no mocked response for %s %s", method, target)
+}
+
+func (r APICallResponse) matches(call *kyvernov1.APICall, method string, data map[string]interface{}) bool {
+	expected := r.Method
+	if expected == "" {
+		expected = "GET"
+	}
+	if expected != method {
+		return false
+	}
+	if call.Service != nil {
+		if r.Service != call.Service.URL {
+			return false
+		}
+	} else if r.Service != "" || r.URLPath != call.URLPath {
+		return false
+	}
+	return r.Data == nil || equal(r.Data, data)
+}
+
+// equal compares values using their json representation to ignore go type differences
+func equal(a, b interface{}) bool {
+	var left, right interface{}
+	if data, err := json.Marshal(a); err != nil || json.Unmarshal(data, &left) != nil {
+		return false
+	}
+	if data, err := json.Marshal(b); err != nil || json.Unmarshal(data, &right) != nil {
+		return false
+	}
+	return reflect.DeepEqual(left, right)
+}
@@ -0,0 +1,88 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+type fakeExecutor struct{}
+
+func (fakeExecutor) Execute(context.Context, *kyvernov1.APICall) ([]byte, error) {
+	return []byte(`"cluster"`), nil
+}
+
+func Test_apiCallExecutor(t *testing.T) {
+	responses := []APICallResponse{{
+		URLPath:  "/api/v1/namespaces/default/services",
+		Response: map[string]interface{}{"items": []interface{}{}},
+	}, {
+		Service:  "https://checker.kyverno.svc/check",
+		Method:   "POST",
+		Data:     map[string]interface{}{"image": "nginx"},
+		Response: map[string]interface{}{"allowed": true},
+	}}
+	tests := []struct {
+		name     string
+		strict   bool
+		fallback bool
+		call     kyvernov1.APICall
+		want     string
+		wantErr  string
+	}{{
+		name: "url path",
+		call: kyvernov1.APICall{URLPath: "/api/v1/namespaces/default/services"},
+		want: `{"items":[]}`,
+	}, {
+		name: "service with data",
+		call: kyvernov1.APICall{
+			Method:  "POST",
+			Service: &kyvernov1.ServiceCall{URL: "https://checker.kyverno.svc/check"},
+			Data:    []kyvernov1.RequestData{{Key: "image", Value: &apiextv1.JSON{Raw: []byte(`"nginx"`)}}},
+		},
+		want: `{"allowed":true}`,
+	}, {
+		name: "service with other data",
+		call: kyvernov1.APICall{
+			Method:  "POST",
+			Service: &kyvernov1.ServiceCall{URL: "https://checker.kyverno.svc/check"},
+			Data:    []kyvernov1.RequestData{{Key: "image", Value: &apiextv1.JSON{Raw: []byte(`"busybox"`)}}},
+		},
+		wantErr: "no mocked response for POST https://checker.kyverno.svc/check",
+	}, {
+		name:    "other method",
+		call:    kyvernov1.APICall{Method: "POST", URLPath: "/api/v1/namespaces/default/services"},
+		wantErr: "no mocked response for POST /api/v1/namespaces/default/services",
+	}, {
+		name:     "fallback",
+		fallback: true,
+		call:     kyvernov1.APICall{URLPath: "/api/v1/namespaces/kyverno/services"},
+		want:     `"cluster"`,
+	}, {
+		name:     "strict",
+		strict:   true,
+		fallback: true,
+		call:     kyvernov1.APICall{URLPath: "/api/v1/namespaces/kyverno/services"},
+		wantErr:  "no mocked response for GET /api/v1/namespaces/kyverno/services",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s Store
+			s.SetAPICallResponses(tt.strict, responses...)
+			executor := apiCallExecutor{store: &s}
+			if tt.fallback {
+				executor.fallback = fakeExecutor{}
+			}
+			got, err := executor.Execute(context.TODO(), &tt.call)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.JSONEq(t, tt.want, string(got))
+			}
+		})
+	}
+}
@@ -19,12 +19,23 @@ type Rule struct {
 	ForEachValues map[string][]interface{} `json:"foreachValues"`
 }
 
+// APICallResponse is a mocked response served to apiCall context entries
+type APICallResponse struct {
+	URLPath  string
+	Service  string
+	Method   string
+	Data     map[string]interface{}
+	Response interface{}
+}
+
 type Store struct {
-	local          bool
-	registryClient registryclient.Client
-	allowApiCalls  bool
-	policies       []Policy
-	foreachElement int
+	local            bool
+	registryClient   registryclient.Client
+	allowApiCalls    bool
+	policies         []Policy
+	foreachElement   int
+	apiCallResponses []APICallResponse
+	strictApiCalls   bool
 }
 
 // SetLocal sets local (clusterless) execution for the CLI
@@ -97,3 +108,23 @@ func (s *Store) AllowApiCall(allow bool) {
 func (s *Store) IsApiCallAllowed() bool {
 	return s.allowApiCalls
 }
+
+// SetAPICallResponses sets the mocked responses served to apiCall context entries,
+// in strict mode calls without a mocked response fail
+func (s *Store) SetAPICallResponses(strict bool, responses ...APICallResponse) {
+	s.strictApiCalls = strict
+	s.apiCallResponses = responses
+}
+
+// HasAPICallMocks returns 'true' if apiCall context entries are served by mocked responses
+func (s *Store) HasAPICallMocks() bool {
+	return s.strictApiCalls || len(s.apiCallResponses) != 0
+}
+
+func (s *Store) GetAPICallResponses() []APICallResponse {
+	return s.apiCallResponses
+}
+
+func (s *Store) IsStrictApiCalls() bool {
+	return s.strictApiCalls
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-git/go-billy/v5"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
 )
 
 type TestCase struct {
@@ -17,3 +18,35 @@ type TestCase struct {
 func (tc TestCase) Dir() string {
 	return filepath.Clean(filepath.Dir(tc.Path))
 }
+
+// Files returns the local files and folders the test depends on, including the test file itself
+func (tc TestCase) Files() []string {
+	files := []string{filepath.Clean(tc.Path)}
+	if tc.Test == nil {
+		return files
+	}
+	var paths []string
+	paths = append(paths, tc.Test.Policies...)
+	paths = append(paths, tc.Test.Resources...)
+	paths = append(paths, tc.Test.TargetResources...)
+	paths = append(paths, tc.Test.PolicyExceptions...)
+	paths = append(paths, tc.Test.Variables, tc.Test.UserInfo)
+	for _, result := range tc.Test.Results {
+		paths = append(paths, result.PatchedResources, result.PatchedResource, result.GeneratedResource, result.CloneSourceResource)
+	}
+	seen := map[string]struct{}{files[0]: {}}
+	for _, path := range paths {
+		if path == "" || path == "-" || source.IsHttp(path) {
+			continue
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(tc.Dir(), path)
+		}
+		path = filepath.Clean(path)
+		if _, ok := seen[path]; !ok {
+			seen[path] = struct{}{}
+			files = append(files, path)
+		}
+	}
+	return files
+}
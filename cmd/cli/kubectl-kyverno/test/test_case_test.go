@@ -1,6 +1,7 @@
 package test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/go-git/go-billy/v5"
@@ -43,3 +44,54 @@ func TestTestCase_Dir(t *testing.T) {
 		})
 	}
 }
+
+func TestTestCase_Files(t *testing.T) {
+	tests := []struct {
+		name string
+		Path string
+		Test *v1alpha1.Test
+		want []string
+	}{{
+		name: "no test",
+		Path: "foo/kyverno-test.yaml",
+		want: []string{"foo/kyverno-test.yaml"},
+	}, {
+		name: "files",
+		Path: "foo/kyverno-test.yaml",
+		Test: &v1alpha1.Test{
+			Policies:         []string{"policy.yaml", "https://example.com/policy.yaml"},
+			Resources:        []string{"resources.yaml", "/abs/resource.yaml"},
+			PolicyExceptions: []string{"exceptions"},
+			Variables:        "values.yaml",
+			Results: []v1alpha1.TestResult{{
+				TestResultDeprecated: v1alpha1.TestResultDeprecated{
+					PatchedResource: "patched.yaml",
+				},
+			}, {
+				TestResultDeprecated: v1alpha1.TestResultDeprecated{
+					PatchedResource: "patched.yaml",
+				},
+			}},
+		},
+		want: []string{
+			"foo/kyverno-test.yaml",
+			"foo/policy.yaml",
+			"foo/resources.yaml",
+			"/abs/resource.yaml",
+			"foo/exceptions",
+			"foo/values.yaml",
+			"foo/patched.yaml",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := TestCase{
+				Path: tt.Path,
+				Test: tt.Test,
+			}
+			if got := tc.Files(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TestCase.Files() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
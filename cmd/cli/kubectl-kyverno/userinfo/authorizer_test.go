@@ -0,0 +1,50 @@
+package userinfo
+
+import (
+	"context"
+	"testing"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/stretchr/testify/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+)
+
+func TestNewAuthorizer(t *testing.T) {
+	admin := &kyvernov2.RequestInfo{
+		ClusterRoles:      []string{"cluster-admin"},
+		AdmissionUserInfo: authenticationv1.UserInfo{Username: "alice"},
+	}
+	tests := []struct {
+		name string
+		info *kyvernov2.RequestInfo
+		user user.Info
+		want authorizer.Decision
+	}{{
+		name: "no user",
+		info: admin,
+		want: authorizer.DecisionNoOpinion,
+	}, {
+		name: "system masters",
+		user: &user.DefaultInfo{Name: "bob", Groups: []string{"system:masters"}},
+		want: authorizer.DecisionAllow,
+	}, {
+		name: "cluster admin",
+		info: admin,
+		user: &user.DefaultInfo{Name: "alice"},
+		want: authorizer.DecisionAllow,
+	}, {
+		name: "other user",
+		info: admin,
+		user: &user.DefaultInfo{Name: "bob"},
+		want: authorizer.DecisionNoOpinion,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, _, err := NewAuthorizer(tt.info).Authorize(context.TODO(), authorizer.AttributesRecord{User: tt.user, Verb: "get"})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, decision)
+		})
+	}
+}
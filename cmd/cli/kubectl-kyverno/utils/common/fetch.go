@@ -115,7 +115,7 @@ func whenClusterIsFalse(out io.Writer, resourcePaths []string, policyReport bool
 			continue
 		}
 
-		getResources, err := resource.GetUnstructuredResources(resourceBytes)
+		getResources, err := resource.GetUnstructuredResourcesFromFile(resourcePath, resourceBytes)
 		if err != nil {
 			return nil, err
 		}
@@ -155,7 +155,7 @@ func GetResourcesWithTest(out io.Writer, fs billy.Filesystem, policies []kyverno
 				continue
 			}
 
-			getResources, err := resource.GetUnstructuredResources(resourceBytes)
+			getResources, err := resource.GetUnstructuredResourcesFromFile(resourcePath, resourceBytes)
 			if err != nil {
 				return nil, err
 			}
@@ -5,8 +5,12 @@ import (
 
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
+	httplib "github.com/kyverno/kyverno/pkg/cel/libs/http"
+	"github.com/kyverno/kyverno/pkg/imagedataloader"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
@@ -70,6 +74,69 @@ func (v Variables) Namespace(name string) *corev1.Namespace {
 	return nil
 }
 
+func (v Variables) GlobalContextEntries() map[string]interface{} {
+	if v.values == nil {
+		return nil
+	}
+	if len(v.values.GlobalContextEntries) == 0 {
+		return nil
+	}
+	return v.values.GlobalContextEntries
+}
+
+// HTTPStub returns an http implementation serving the recorded responses, or nil if there are none
+func (v Variables) HTTPStub() httplib.HTTPInterface {
+	if v.values == nil {
+		return nil
+	}
+	if len(v.values.HTTPResponses) == 0 {
+		return nil
+	}
+	responses := make([]httplib.Response, 0, len(v.values.HTTPResponses))
+	for _, r := range v.values.HTTPResponses {
+		responses = append(responses, httplib.Response{
+			Method:   r.Method,
+			URL:      r.URL,
+			Body:     r.Body,
+			Response: r.Response,
+		})
+	}
+	return httplib.NewStub(responses...)
+}
+
+// ContextResources returns the resources served to CEL context functions
+func (v Variables) ContextResources() ([]*unstructured.Unstructured, error) {
+	if v.values == nil {
+		return nil, nil
+	}
+	resources := make([]*unstructured.Unstructured, 0, len(v.values.Resources))
+	for i, raw := range v.values.Resources {
+		var resource unstructured.Unstructured
+		if err := resource.UnmarshalJSON(raw.Raw); err != nil {
+			return nil, fmt.Errorf("failed to decode context resource %d (%w)", i, err)
+		}
+		resources = append(resources, &resource)
+	}
+	return resources, nil
+}
+
+// ImageData returns the image data served to CEL context.GetImageData calls
+func (v Variables) ImageData() ([]imagedataloader.ImageData, error) {
+	if v.values == nil {
+		return nil, nil
+	}
+	images := make([]imagedataloader.ImageData, 0, len(v.values.Images))
+	for _, image := range v.values.Images {
+		var data imagedataloader.ImageData
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(image.Data, &data); err != nil {
+			return nil, fmt.Errorf("failed to decode image data for %s (%w)", image.Image, err)
+		}
+		data.Image = image.Image
+		images = append(images, data)
+	}
+	return images, nil
+}
+
 func (v Variables) ComputeVariables(s *store.Store, policy, resource, kind string, kindMap sets.Set[string], variables ...string) (map[string]interface{}, error) {
 	resourceValues := map[string]interface{}{}
 	// first apply global values
@@ -129,4 +196,19 @@ func (v Variables) SetInStore(s *store.Store) {
 		}
 	}
 	s.SetPolicies(storePolicies...)
+	var apiCallResponses []store.APICallResponse
+	var strictApiCalls bool
+	if v.values != nil {
+		for _, r := range v.values.APICallResponses {
+			apiCallResponses = append(apiCallResponses, store.APICallResponse{
+				URLPath:  r.URLPath,
+				Service:  r.Service,
+				Method:   r.Method,
+				Data:     r.Data,
+				Response: r.Response,
+			})
+		}
+		strictApiCalls = v.values.StrictAPICalls
+	}
+	s.SetAPICallResponses(strictApiCalls, apiCallResponses...)
 }
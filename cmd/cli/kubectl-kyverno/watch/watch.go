@@ -0,0 +1,154 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultDebounce is the time to wait for more events before reporting changes,
+// editors often write a file in several steps (truncate, write, rename...)
+const DefaultDebounce = 200 * time.Millisecond
+
+// Watcher reports changes to a set of files and folders.
+// Files are watched through their parent folder so that atomic saves (write then rename) are not missed,
+// folders are watched recursively and new sub folders are picked up automatically.
+type Watcher struct {
+	watcher  *fsnotify.Watcher
+	debounce time.Duration
+	files    map[string]struct{}
+	dirs     map[string]struct{}
+}
+
+func New(debounce time.Duration) (*Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	return &Watcher{
+		watcher:  watcher,
+		debounce: debounce,
+		files:    map[string]struct{}{},
+		dirs:     map[string]struct{}{},
+	}, nil
+}
+
+// Add watches the given files and folders, paths that don't exist are watched from their closest existing parent
+func (w *Watcher) Add(paths ...string) error {
+	for _, path := range paths {
+		path, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		info, err := os.Stat(path)
+		if err == nil && info.IsDir() {
+			if err := w.addDir(path); err != nil {
+				return err
+			}
+			continue
+		}
+		w.files[path] = struct{}{}
+		parent := filepath.Dir(path)
+		for {
+			if _, err := os.Stat(parent); err == nil || parent == filepath.Dir(parent) {
+				break
+			}
+			parent = filepath.Dir(parent)
+		}
+		if err := w.watcher.Add(parent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *Watcher) addDir(root string) error {
+	w.dirs[root] = struct{}{}
+	return filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if path != root && strings.HasPrefix(entry.Name(), ".") {
+			return filepath.SkipDir
+		}
+		return w.watcher.Add(path)
+	})
+}
+
+// Next blocks until changes are detected and returns the sorted list of changed paths
+func (w *Watcher) Next(ctx context.Context) ([]string, error) {
+	changed := map[string]struct{}{}
+	var timer <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return nil, errors.New("watcher closed")
+			}
+			return nil, err
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return nil, errors.New("watcher closed")
+			}
+			if !w.matches(event.Name) {
+				continue
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := w.addDir(event.Name); err != nil {
+						return nil, err
+					}
+				}
+			}
+			changed[event.Name] = struct{}{}
+			timer = time.After(w.debounce)
+		case <-timer:
+			paths := make([]string, 0, len(changed))
+			for path := range changed {
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+			return paths, nil
+		}
+	}
+}
+
+func (w *Watcher) matches(path string) bool {
+	if _, ok := w.files[path]; ok {
+		return true
+	}
+	for file := range w.files {
+		// a missing parent folder of a watched file was created
+		if Contains(path, file) {
+			return true
+		}
+	}
+	for dir := range w.dirs {
+		if Contains(dir, path) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *Watcher) Close() error {
+	return w.watcher.Close()
+}
+
+// Contains returns true if path is parent or equal to child
+func Contains(parent, child string) bool {
+	parent, child = filepath.Clean(parent), filepath.Clean(child)
+	return parent == child || strings.HasPrefix(child, parent+string(filepath.Separator))
+}
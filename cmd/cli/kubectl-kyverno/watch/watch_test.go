@@ -0,0 +1,55 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatcher(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, "policy.yaml")
+	other := filepath.Join(root, "other.yaml")
+	folder := filepath.Join(root, "resources")
+	assert.NoError(t, os.WriteFile(file, []byte("a"), 0o600))
+	assert.NoError(t, os.WriteFile(other, []byte("a"), 0o600))
+	assert.NoError(t, os.Mkdir(folder, 0o755))
+	w, err := New(50 * time.Millisecond)
+	assert.NoError(t, err)
+	defer w.Close()
+	assert.NoError(t, w.Add(file, folder))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	// changes to unwatched files are ignored
+	assert.NoError(t, os.WriteFile(other, []byte("b"), 0o600))
+	assert.NoError(t, os.WriteFile(file, []byte("b"), 0o600))
+	changed, err := w.Next(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{file}, changed)
+	// files created in watched folders are reported, including nested ones
+	nested := filepath.Join(folder, "nested")
+	assert.NoError(t, os.Mkdir(nested, 0o755))
+	changed, err = w.Next(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{nested}, changed)
+	resource := filepath.Join(nested, "pod.yaml")
+	assert.NoError(t, os.WriteFile(resource, []byte("a"), 0o600))
+	changed, err = w.Next(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{resource}, changed)
+	cancel()
+	_, err = w.Next(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestContains(t *testing.T) {
+	assert.True(t, Contains("foo", "foo"))
+	assert.True(t, Contains("foo", "foo/bar.yaml"))
+	assert.True(t, Contains("foo/", "foo/bar/baz.yaml"))
+	assert.False(t, Contains("foo", "foobar/baz.yaml"))
+	assert.False(t, Contains("foo/bar.yaml", "foo"))
+}
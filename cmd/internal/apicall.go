@@ -0,0 +1,42 @@
+package internal
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	"github.com/kyverno/kyverno/pkg/engine/apicall"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NewAPICallConfiguration returns the API call configuration, when the feature is enabled the secrets used to
+// authenticate service calls are read from an informer caching the secrets labelled with kyverno.LabelAPICallAuth
+func NewAPICallConfiguration(
+	ctx context.Context,
+	logger logr.Logger,
+	kubeClient kubernetes.Interface,
+	maxAPICallResponseLength int64,
+) apicall.APICallConfiguration {
+	config := apicall.NewAPICallConfiguration(maxAPICallResponseLength)
+	logger = logger.WithName("apicall-auth-secrets").WithValues("apiCallAuthSecrets", enableAPICallAuthSecrets)
+	logger.Info("setup api call auth secrets...")
+	if !enableAPICallAuthSecrets {
+		return config
+	}
+	factory := kubeinformers.NewSharedInformerFactoryWithOptions(
+		kubeClient,
+		resyncPeriod,
+		kubeinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = kyverno.LabelAPICallAuth
+		}),
+	)
+	secretLister := factory.Core().V1().Secrets().Lister()
+	// start informers and wait for cache sync
+	if !StartInformersAndWaitForCacheSync(ctx, logger, factory) {
+		checkError(logger, errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+	}
+	return config.WithSecretLister(secretLister)
+}
@@ -12,6 +12,8 @@ type Configuration interface {
 	UsesPolicyExceptions() bool
 	UsesConfigMapCaching() bool
 	UsesDeferredLoading() bool
+	UsesGlobalContextSnapshots() bool
+	UsesAPICallAuthSecrets() bool
 	UsesCosign() bool
 	UsesRegistryClient() bool
 	UsesImageVerifyCache() bool
@@ -73,6 +75,18 @@ func WithConfigMapCaching() ConfigurationOption {
 	}
 }
 
+func WithGlobalContextSnapshots() ConfigurationOption {
+	return func(c *configuration) {
+		c.usesGlobalContextSnapshots = true
+	}
+}
+
+func WithAPICallAuthSecrets() ConfigurationOption {
+	return func(c *configuration) {
+		c.usesAPICallAuthSecrets = true
+	}
+}
+
 func WithDeferredLoading() ConfigurationOption {
 	return func(c *configuration) {
 		c.usesDeferredLoading = true
@@ -160,26 +174,28 @@ func WithRestConfig() ConfigurationOption {
 }
 
 type configuration struct {
-	usesMetrics              bool
-	usesTracing              bool
-	usesProfiling            bool
-	usesKubeconfig           bool
-	usesPolicyExceptions     bool
-	usesConfigMapCaching     bool
-	usesDeferredLoading      bool
-	usesCosign               bool
-	usesRegistryClient       bool
-	usesImageVerifyCache     bool
-	usesLeaderElection       bool
-	usesKyvernoClient        bool
-	usesDynamicClient        bool
-	usesApiServerClient      bool
-	usesMetadataClient       bool
-	usesKyvernoDynamicClient bool
-	usesEventsClient         bool
-	usesReporting            bool
-	usesRestConfig           bool
-	flagSets                 []*flag.FlagSet
+	usesMetrics                bool
+	usesTracing                bool
+	usesProfiling              bool
+	usesKubeconfig             bool
+	usesPolicyExceptions       bool
+	usesConfigMapCaching       bool
+	usesDeferredLoading        bool
+	usesGlobalContextSnapshots bool
+	usesAPICallAuthSecrets     bool
+	usesCosign                 bool
+	usesRegistryClient         bool
+	usesImageVerifyCache       bool
+	usesLeaderElection         bool
+	usesKyvernoClient          bool
+	usesDynamicClient          bool
+	usesApiServerClient        bool
+	usesMetadataClient         bool
+	usesKyvernoDynamicClient   bool
+	usesEventsClient           bool
+	usesReporting              bool
+	usesRestConfig             bool
+	flagSets                   []*flag.FlagSet
 }
 
 func (c *configuration) UsesMetrics() bool {
@@ -210,6 +226,14 @@ func (c *configuration) UsesDeferredLoading() bool {
 	return c.usesDeferredLoading
 }
 
+func (c *configuration) UsesGlobalContextSnapshots() bool {
+	return c.usesGlobalContextSnapshots
+}
+
+func (c *configuration) UsesAPICallAuthSecrets() bool {
+	return c.usesAPICallAuthSecrets
+}
+
 func (c *configuration) UsesCosign() bool {
 	return c.usesCosign
 }
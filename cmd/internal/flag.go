@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/toggle"
@@ -58,7 +59,10 @@ var (
 	imageVerifyCacheTTLDuration time.Duration
 	imageVerifyCacheMaxSize     int64
 	// global context
-	enableGlobalContext bool
+	enableGlobalContext          bool
+	enableGlobalContextSnapshots bool
+	// api call auth secrets
+	enableAPICallAuthSecrets bool
 	// reporting
 	enableReporting string
 	// resync
@@ -117,6 +121,14 @@ func initDeferredLoadingFlags() {
 	flag.Func(toggle.EnableDeferredLoadingFlagName, toggle.EnableDeferredLoadingDescription, toggle.EnableDeferredLoading.Parse)
 }
 
+func initGlobalContextSnapshotsFlags() {
+	flag.BoolVar(&enableGlobalContextSnapshots, "globalContextSnapshots", false, "Share global context API call data between controllers, the admission controller leader fetches the data and publishes snapshots loaded by other controllers.")
+}
+
+func initAPICallAuthSecretsFlags() {
+	flag.BoolVar(&enableAPICallAuthSecrets, "apiCallAuthSecrets", false, "Cache the secrets labelled with "+kyverno.LabelAPICallAuth+" used to authenticate service API calls, service call authentication fails when disabled.")
+}
+
 func initCosignFlags() {
 	flag.BoolVar(&enableTUF, "enableTuf", false, "enable tuf for private sigstore deployments")
 	flag.StringVar(&tufMirror, "tufMirror", tuf.DefaultRemoteRoot, "Alternate TUF mirror for sigstore. If left blank, public sigstore one is used for cosign verification.")
@@ -221,6 +233,14 @@ func initFlags(config Configuration, opts ...Option) {
 	if config.UsesDeferredLoading() {
 		initDeferredLoadingFlags()
 	}
+	// global context snapshots
+	if config.UsesGlobalContextSnapshots() {
+		initGlobalContextSnapshotsFlags()
+	}
+	// api call auth secrets
+	if config.UsesAPICallAuthSecrets() {
+		initAPICallAuthSecretsFlags()
+	}
 	// cosign
 	if config.UsesCosign() {
 		initCosignFlags()
@@ -0,0 +1,46 @@
+package internal
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/globalcontext/snapshot"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NewGlobalContextSnapshots returns the snapshots used to share global context data between controllers,
+// nil is returned when the feature is disabled
+func NewGlobalContextSnapshots(
+	ctx context.Context,
+	logger logr.Logger,
+	kubeClient kubernetes.Interface,
+) *snapshot.Snapshots {
+	logger = logger.WithName("global-context-snapshots").WithValues("globalContextSnapshots", enableGlobalContextSnapshots)
+	logger.Info("setup global context snapshots...")
+	if !enableGlobalContextSnapshots {
+		return nil
+	}
+	factory := kubeinformers.NewSharedInformerFactoryWithOptions(
+		kubeClient,
+		resyncPeriod,
+		kubeinformers.WithNamespace(config.KyvernoNamespace()),
+		kubeinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = kyverno.LabelGlobalContext
+		}),
+	)
+	snapshots := snapshot.New(
+		kubeClient.CoreV1().ConfigMaps(config.KyvernoNamespace()),
+		factory.Core().V1().ConfigMaps(),
+		config.KyvernoNamespace(),
+	)
+	// start informers and wait for cache sync
+	if !StartInformersAndWaitForCacheSync(ctx, logger, factory) {
+		checkError(logger, errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+	}
+	return snapshots
+}
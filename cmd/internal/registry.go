@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/imagedataloader"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
@@ -39,3 +40,18 @@ func setupRegistryClient(ctx context.Context, logger logr.Logger, client kuberne
 	checkError(logger, err, "failed to create registry client")
 	return registryClient, secretLister
 }
+
+// imageDataLoaderOptions returns the image data loader options matching the registry client configuration
+func imageDataLoaderOptions() []imagedataloader.Option {
+	opts := []imagedataloader.Option{
+		imagedataloader.WithTracing(true),
+		imagedataloader.WithInsecure(allowInsecureRegistry),
+	}
+	if imagePullSecrets != "" {
+		opts = append(opts, imagedataloader.WithPullSecret(strings.Split(imagePullSecrets, ",")))
+	}
+	if len(registryCredentialHelpers) > 0 {
+		opts = append(opts, imagedataloader.WithCredentialProviders(strings.Split(registryCredentialHelpers, ",")...))
+	}
+	return opts
+}
@@ -13,6 +13,7 @@ import (
 	metadataclient "github.com/kyverno/kyverno/pkg/clients/metadata"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/imagedataloader"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/registryclient"
@@ -44,6 +45,7 @@ type SetupResult struct {
 	RegistryClient         registryclient.Client
 	ImageVerifyCacheClient imageverifycache.Client
 	RegistrySecretLister   corev1listers.SecretNamespaceLister
+	ImageDataLoaderOptions []imagedataloader.Option
 	KyvernoClient          kyvernoclient.UpstreamInterface
 	DynamicClient          dynamicclient.UpstreamInterface
 	ApiServerClient        apiserverclient.UpstreamInterface
@@ -72,8 +74,10 @@ func Setup(config Configuration, name string, skipResourceFilters bool) (context
 	sdownTracing := SetupTracing(logger, name, client)
 	var registryClient registryclient.Client
 	var registrySecretLister corev1listers.SecretNamespaceLister
+	var imageDataLoaderOpts []imagedataloader.Option
 	if config.UsesRegistryClient() {
 		registryClient, registrySecretLister = setupRegistryClient(ctx, logger, client)
+		imageDataLoaderOpts = imageDataLoaderOptions()
 	}
 	var imageVerifyCache imageverifycache.Client
 	if config.UsesImageVerifyCache() {
@@ -130,6 +134,7 @@ func Setup(config Configuration, name string, skipResourceFilters bool) (context
 			RegistryClient:         registryClient,
 			ImageVerifyCacheClient: imageVerifyCache,
 			RegistrySecretLister:   registrySecretLister,
+			ImageDataLoaderOptions: imageDataLoaderOpts,
 			KyvernoClient:          kyvernoClient,
 			DynamicClient:          dynamicClient,
 			ApiServerClient:        apiServerClient,
@@ -15,6 +15,8 @@ import (
 	"github.com/kyverno/kyverno/pkg/auth/checker"
 	"github.com/kyverno/kyverno/pkg/breaker"
 	celengine "github.com/kyverno/kyverno/pkg/cel/engine"
+	httplib "github.com/kyverno/kyverno/pkg/cel/libs/http"
+	"github.com/kyverno/kyverno/pkg/cel/libs/imageverify"
 	"github.com/kyverno/kyverno/pkg/cel/matching"
 	celpolicy "github.com/kyverno/kyverno/pkg/cel/policy"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
@@ -27,6 +29,7 @@ import (
 	globalcontextcontroller "github.com/kyverno/kyverno/pkg/controllers/globalcontext"
 	policymetricscontroller "github.com/kyverno/kyverno/pkg/controllers/metrics/policy"
 	policycachecontroller "github.com/kyverno/kyverno/pkg/controllers/policycache"
+	policystatuscontroller "github.com/kyverno/kyverno/pkg/controllers/policystatus"
 	vapcontroller "github.com/kyverno/kyverno/pkg/controllers/validatingadmissionpolicy-generate"
 	webhookcontroller "github.com/kyverno/kyverno/pkg/controllers/webhook"
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
@@ -48,6 +51,9 @@ import (
 	webhooksglobalcontext "github.com/kyverno/kyverno/pkg/webhooks/globalcontext"
 	webhookspolicy "github.com/kyverno/kyverno/pkg/webhooks/policy"
 	webhooksresource "github.com/kyverno/kyverno/pkg/webhooks/resource"
+	"github.com/kyverno/kyverno/pkg/webhooks/resource/gpol"
+	"github.com/kyverno/kyverno/pkg/webhooks/resource/ivpol"
+	"github.com/kyverno/kyverno/pkg/webhooks/resource/mpol"
 	"github.com/kyverno/kyverno/pkg/webhooks/resource/vpol"
 	webhookgenerate "github.com/kyverno/kyverno/pkg/webhooks/updaterequest"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
@@ -56,6 +62,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/admission/plugin/policy/mutating/patch"
 	kubeinformers "k8s.io/client-go/informers"
 	appsv1informers "k8s.io/client-go/informers/apps/v1"
 	corev1informers "k8s.io/client-go/informers/core/v1"
@@ -155,6 +162,9 @@ func createrLeaderControllers(
 		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
 		kyvernoInformer.Kyverno().V1().Policies(),
 		kyvernoInformer.Kyverno().V2alpha1().ValidatingPolicies(),
+		kyvernoInformer.Kyverno().V2alpha1().MutatingPolicies(),
+		kyvernoInformer.Kyverno().V2alpha1().GeneratingPolicies(),
+		kyvernoInformer.Kyverno().V2alpha1().ImageVerificationPolicies(),
 		deploymentInformer,
 		caInformer,
 		kubeKyvernoInformer.Coordination().V1().Leases(),
@@ -274,6 +284,13 @@ func createrLeaderControllers(
 	leaderControllers = append(leaderControllers, internal.NewController(exceptionWebhookControllerName, exceptionWebhookController, 1))
 	leaderControllers = append(leaderControllers, internal.NewController(celExceptionWebhookControllerName, celExceptionWebhookController, 1))
 	leaderControllers = append(leaderControllers, internal.NewController(gctxWebhookControllerName, gctxWebhookController, 1))
+	policyStatusController := policystatuscontroller.NewController(
+		kyvernoClient,
+		kyvernoInformer.Kyverno().V2alpha1().ValidatingPolicies(),
+		celpolicy.NewCompiler(),
+		policystatuscontroller.NewTypeChecker(dynamicClient.Discovery().CachedDiscoveryInterface()),
+	)
+	leaderControllers = append(leaderControllers, internal.NewController(policystatuscontroller.ControllerName, policyStatusController, policystatuscontroller.Workers))
 
 	generateVAPs := toggle.FromContext(context.TODO()).GenerateValidatingAdmissionPolicy()
 	if generateVAPs {
@@ -351,6 +368,8 @@ func main() {
 		internal.WithPolicyExceptions(),
 		internal.WithConfigMapCaching(),
 		internal.WithDeferredLoading(),
+		internal.WithGlobalContextSnapshots(),
+		internal.WithAPICallAuthSecrets(),
 		internal.WithCosign(),
 		internal.WithRegistryClient(),
 		internal.WithImageVerifyCache(),
@@ -423,15 +442,19 @@ func main() {
 			strings.Split(omitEvents, ",")...,
 		)
 		gcstore := store.New()
+		apiCallConfig := internal.NewAPICallConfiguration(signalCtx, setup.Logger, setup.KubeClient, maxAPICallResponseLength)
+		gctxSnapshots := internal.NewGlobalContextSnapshots(signalCtx, setup.Logger, setup.KubeClient)
 		gceController := internal.NewController(
 			globalcontextcontroller.ControllerName,
 			globalcontextcontroller.NewController(
 				kyvernoInformer.Kyverno().V2alpha1().GlobalContextEntries(),
 				setup.KyvernoDynamicClient,
+				setup.MetadataClient,
 				setup.KyvernoClient,
 				gcstore,
+				gctxSnapshots,
 				eventGenerator,
-				maxAPICallResponseLength,
+				apiCallConfig,
 				true,
 			),
 			globalcontextcontroller.Workers,
@@ -481,7 +504,7 @@ func main() {
 			setup.KubeClient,
 			setup.KyvernoClient,
 			setup.RegistrySecretLister,
-			apicall.NewAPICallConfiguration(maxAPICallResponseLength),
+			apiCallConfig,
 			polexCache,
 			gcstore,
 		)
@@ -513,6 +536,10 @@ func main() {
 			internal.LeaderElectionRetryPeriod(),
 			func(ctx context.Context) {
 				logger := setup.Logger.WithName("leader")
+				// the leader publishes global context snapshots
+				if gctxSnapshots != nil {
+					gctxSnapshots.SetPublisher(true)
+				}
 				// create leader factories
 				kubeInformer := kubeinformers.NewSharedInformerFactory(setup.KubeClient, setup.ResyncPeriod)
 				kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(setup.KyvernoClient, setup.ResyncPeriod)
@@ -562,7 +589,11 @@ func main() {
 				// wait all controllers shut down
 				wg.Wait()
 			},
-			nil,
+			func() {
+				if gctxSnapshots != nil {
+					gctxSnapshots.SetPublisher(false)
+				}
+			},
 		)
 		if err != nil {
 			setup.Logger.Error(err, "failed to initialize leader election")
@@ -583,14 +614,27 @@ func main() {
 		)
 		contextProvider, err := celpolicy.NewContextProvider(
 			setup.KubeClient,
-			nil,
-			// []imagedataloader.Option{imagedataloader.WithLocalCredentials(c.RegistryAccess)},
+			setup.ImageDataLoaderOptions,
+			gcstore,
+			celpolicy.NewResourceLoader(
+				signalCtx,
+				setup.KyvernoDynamicClient.GetDynamicInterface(),
+				checker.NewSelfChecker(setup.KubeClient.AuthorizationV1().SelfSubjectAccessReviews()),
+			),
+			httplib.NewHTTP(apicall.NewExecutor(setup.Logger.WithName("http"), "http", nil, apiCallConfig)),
+			checker.NewCachingAuthorizer(
+				checker.NewAuthorizer(setup.KubeClient.AuthorizationV1().SubjectAccessReviews()),
+				checker.AuthorizerCacheTTL,
+			),
 		)
 		if err != nil {
 			setup.Logger.Error(err, "failed to create cel context provider")
 			os.Exit(1)
 		}
 		var celEngine celengine.Engine
+		var celMutatingEngine celengine.MutatingEngine
+		var celGeneratingEngine celengine.GeneratingEngine
+		var celImageVerificationEngine celengine.ImageVerificationEngine
 		{
 			// create a controller manager
 			scheme := kruntime.NewScheme()
@@ -613,6 +657,30 @@ func main() {
 				setup.Logger.Error(err, "failed to create policy provider")
 				os.Exit(1)
 			}
+			mutatingProvider, err := celengine.NewKubeMutatingProvider(compiler, mgr, kyvernoInformer.Kyverno().V2alpha1().CELPolicyExceptions().Lister())
+			if err != nil {
+				setup.Logger.Error(err, "failed to create mutating policy provider")
+				os.Exit(1)
+			}
+			generatingProvider, err := celengine.NewKubeGeneratingProvider(compiler, mgr, kyvernoInformer.Kyverno().V2alpha1().CELPolicyExceptions().Lister())
+			if err != nil {
+				setup.Logger.Error(err, "failed to create generating policy provider")
+				os.Exit(1)
+			}
+			imageVerificationProvider, err := celengine.NewKubeImageVerificationProvider(
+				compiler,
+				mgr,
+				kyvernoInformer.Kyverno().V2alpha1().CELPolicyExceptions().Lister(),
+				func(policy *kyvernov2alpha1.ImageVerificationPolicy) imageverify.Verifier {
+					return imageverify.NewVerifier(setup.Logger.WithName("image-verifier"), policy, setup.RegistryClient, setup.ImageVerifyCacheClient)
+				},
+			)
+			if err != nil {
+				setup.Logger.Error(err, "failed to create image verification policy provider")
+				os.Exit(1)
+			}
+			// create a type converter manager used to apply mutations
+			typeConverter := patch.NewTypeConverterManager(nil, setup.KubeClient.Discovery().OpenAPIV3())
 			// create a cancellable context
 			ctx, cancel := context.WithCancel(signalCtx)
 			// start manager
@@ -624,21 +692,48 @@ func main() {
 					os.Exit(1)
 				}
 			})
+			// start type converter manager
+			wg.StartWithContext(ctx, typeConverter.Run)
 			if !mgr.GetCache().WaitForCacheSync(ctx) {
 				defer cancel()
 				setup.Logger.Error(err, "failed to create policy provider")
 				os.Exit(1)
 			}
+			nsResolver := func(name string) *corev1.Namespace {
+				ns, err := setup.KubeClient.CoreV1().Namespaces().Get(context.TODO(), name, metav1.GetOptions{})
+				if err != nil {
+					return nil
+				}
+				return ns
+			}
 			celEngine = celengine.NewEngine(
 				provider,
-				func(name string) *corev1.Namespace {
-					ns, err := setup.KubeClient.CoreV1().Namespaces().Get(context.TODO(), name, metav1.GetOptions{})
+				nsResolver,
+				matching.NewMatcher(),
+			)
+			celMutatingEngine = celengine.NewMutatingEngine(
+				mutatingProvider,
+				nsResolver,
+				matching.NewMatcher(),
+				typeConverter,
+			)
+			celGeneratingEngine = celengine.NewGeneratingEngine(
+				generatingProvider,
+				nsResolver,
+				matching.NewMatcher(),
+			)
+			celImageVerificationEngine = celengine.NewImageVerificationEngine(
+				imageVerificationProvider,
+				nsResolver,
+				matching.NewMatcher(),
+				func(ctx context.Context, image string) (string, error) {
+					desc, err := setup.RegistryClient.FetchImageDescriptor(ctx, image)
 					if err != nil {
-						return nil
+						return "", err
 					}
-					return ns
+					return desc.Digest.String(), nil
 				},
-				matching.NewMatcher(),
+				setup.Configuration,
 			)
 		}
 		ephrs, err := breaker.StartAdmissionReportsCounter(signalCtx, setup.MetadataClient)
@@ -681,6 +776,23 @@ func main() {
 			setup.KyvernoClient,
 			reportsBreaker,
 		)
+		mpolHandlers := mpol.New(
+			celMutatingEngine,
+			contextProvider,
+			setup.KyvernoClient,
+			reportsBreaker,
+		)
+		gpolHandlers := gpol.New(
+			celGeneratingEngine,
+			contextProvider,
+			urgen,
+		)
+		ivpolHandlers := ivpol.New(
+			celImageVerificationEngine,
+			contextProvider,
+			setup.KyvernoClient,
+			reportsBreaker,
+		)
 		exceptionHandlers := webhooksexception.NewHandlers(exception.ValidationOptions{
 			Enabled:   internal.PolicyExceptionEnabled(),
 			Namespace: internal.ExceptionNamespace(),
@@ -697,9 +809,12 @@ func main() {
 				Validation: webhooks.HandlerFunc(policyHandlers.Validate),
 			},
 			webhooks.ResourceHandlers{
-				Mutation:           webhooks.HandlerFunc(resourceHandlers.Mutate),
-				Validation:         webhooks.HandlerFunc(resourceHandlers.Validate),
-				ValidatingPolicies: webhooks.HandlerFunc(voplHandlers.Validate),
+				Mutation:                  webhooks.HandlerFunc(resourceHandlers.Mutate),
+				Validation:                webhooks.HandlerFunc(resourceHandlers.Validate),
+				ValidatingPolicies:        webhooks.HandlerFunc(voplHandlers.Validate),
+				MutatingPolicies:          webhooks.HandlerFunc(mpolHandlers.Mutate),
+				GeneratingPolicies:        webhooks.HandlerFunc(gpolHandlers.Generate),
+				ImageVerificationPolicies: webhooks.HandlerFunc(ivpolHandlers.Mutate),
 			},
 			webhooks.ExceptionHandlers{
 				Validation: webhooks.HandlerFunc(exceptionHandlers.Validate),
@@ -8,8 +8,13 @@ import (
 	"strings"
 	"time"
 
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/cmd/internal"
+	"github.com/kyverno/kyverno/pkg/auth/checker"
 	"github.com/kyverno/kyverno/pkg/breaker"
+	celengine "github.com/kyverno/kyverno/pkg/cel/engine"
+	httplib "github.com/kyverno/kyverno/pkg/cel/libs/http"
+	celpolicy "github.com/kyverno/kyverno/pkg/cel/policy"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
@@ -28,10 +33,12 @@ import (
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	apiserver "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	kubeinformers "k8s.io/client-go/informers"
 	admissionregistrationv1informers "k8s.io/client-go/informers/admissionregistration/v1"
 	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
+	ctrl "sigs.k8s.io/controller-runtime"
 	kyamlopenapi "sigs.k8s.io/kustomize/kyaml/openapi"
 )
 
@@ -64,6 +71,8 @@ func createReportControllers(
 	eventGenerator event.Interface,
 	reportsConfig reportutils.ReportingConfiguration,
 	reportsBreaker breaker.Breaker,
+	celContext celpolicy.Context,
+	celPolicies celengine.KubeProvider,
 ) ([]internal.Controller, func(context.Context) error) {
 	var ctrls []internal.Controller
 	var warmups []func(context.Context) error
@@ -103,6 +112,8 @@ func createReportControllers(
 					kyvernoV1.Policies(),
 					kyvernoV1.ClusterPolicies(),
 					kyvernoV2alpha1.ValidatingPolicies(),
+					kyvernoV2alpha1.MutatingPolicies(),
+					kyvernoV2alpha1.ImageVerificationPolicies(),
 					vapInformer,
 				),
 				aggregationWorkers,
@@ -118,6 +129,7 @@ func createReportControllers(
 				kyvernoV1.ClusterPolicies(),
 				kyvernoV2alpha1.ValidatingPolicies(),
 				kyvernoV2.PolicyExceptions(),
+				kyvernoV2alpha1.CELPolicyExceptions(),
 				vapInformer,
 				vapBindingInformer,
 				kubeInformer.Core().V1().Namespaces(),
@@ -129,6 +141,8 @@ func createReportControllers(
 				policyReports,
 				reportsConfig,
 				reportsBreaker,
+				celContext,
+				celPolicies,
 			)
 			ctrls = append(ctrls, internal.NewController(
 				backgroundscancontroller.ControllerName,
@@ -167,6 +181,8 @@ func createrLeaderControllers(
 	eventGenerator event.Interface,
 	backgroundScanInterval time.Duration,
 	reportsBreaker breaker.Breaker,
+	celContext celpolicy.Context,
+	celPolicies celengine.KubeProvider,
 ) ([]internal.Controller, func(context.Context) error, error) {
 	reportControllers, warmup := createReportControllers(
 		eng,
@@ -188,6 +204,8 @@ func createrLeaderControllers(
 		eventGenerator,
 		reportsConfig,
 		reportsBreaker,
+		celContext,
+		celPolicies,
 	)
 	return reportControllers, warmup, nil
 }
@@ -230,9 +248,12 @@ func main() {
 		internal.WithMetrics(),
 		internal.WithTracing(),
 		internal.WithKubeconfig(),
+		internal.WithRestConfig(),
 		internal.WithPolicyExceptions(),
 		internal.WithConfigMapCaching(),
 		internal.WithDeferredLoading(),
+		internal.WithGlobalContextSnapshots(),
+		internal.WithAPICallAuthSecrets(),
 		internal.WithCosign(),
 		internal.WithRegistryClient(),
 		internal.WithImageVerifyCache(),
@@ -282,15 +303,19 @@ func main() {
 			event.Workers,
 		)
 		gcstore := store.New()
+		apiCallConfig := internal.NewAPICallConfiguration(ctx, setup.Logger, setup.KubeClient, maxAPICallResponseLength)
+		gctxSnapshots := internal.NewGlobalContextSnapshots(ctx, setup.Logger, setup.KubeClient)
 		gceController := internal.NewController(
 			globalcontextcontroller.ControllerName,
 			globalcontextcontroller.NewController(
 				kyvernoInformer.Kyverno().V2alpha1().GlobalContextEntries(),
 				setup.KyvernoDynamicClient,
+				setup.MetadataClient,
 				setup.KyvernoClient,
 				gcstore,
+				gctxSnapshots,
 				eventGenerator,
-				maxAPICallResponseLength,
+				apiCallConfig,
 				false,
 			),
 			globalcontextcontroller.Workers,
@@ -308,10 +333,57 @@ func main() {
 			setup.KubeClient,
 			setup.KyvernoClient,
 			setup.RegistrySecretLister,
-			apicall.NewAPICallConfiguration(maxAPICallResponseLength),
+			apiCallConfig,
 			polexCache,
 			gcstore,
 		)
+		contextProvider, err := celpolicy.NewContextProvider(
+			setup.KubeClient,
+			setup.ImageDataLoaderOptions,
+			gcstore,
+			celpolicy.NewResourceLoader(
+				ctx,
+				setup.KyvernoDynamicClient.GetDynamicInterface(),
+				checker.NewSelfChecker(setup.KubeClient.AuthorizationV1().SelfSubjectAccessReviews()),
+			),
+			httplib.NewHTTP(apicall.NewExecutor(setup.Logger.WithName("http"), "http", nil, apiCallConfig)),
+			// scans don't run on behalf of a user, the authorizer is only available in admission
+			nil,
+		)
+		if err != nil {
+			setup.Logger.Error(err, "failed to create cel context provider")
+			os.Exit(1)
+		}
+		// create a controller manager
+		scheme := kruntime.NewScheme()
+		if err := kyvernov2alpha1.Install(scheme); err != nil {
+			setup.Logger.Error(err, "failed to initialize scheme")
+			os.Exit(1)
+		}
+		mgr, err := ctrl.NewManager(setup.RestConfig, ctrl.Options{
+			Scheme: scheme,
+		})
+		if err != nil {
+			setup.Logger.Error(err, "failed to construct manager")
+			os.Exit(1)
+		}
+		// create the compiled policies provider shared by background scans
+		celPolicies, err := celengine.NewKubeProvider(celpolicy.NewCompiler(), mgr, kyvernoInformer.Kyverno().V2alpha1().CELPolicyExceptions().Lister())
+		if err != nil {
+			setup.Logger.Error(err, "failed to create policy provider")
+			os.Exit(1)
+		}
+		// start manager
+		wg.StartWithContext(ctx, func(ctx context.Context) {
+			if err := mgr.Start(ctx); err != nil {
+				setup.Logger.Error(err, "failed to start manager")
+				os.Exit(1)
+			}
+		})
+		if !mgr.GetCache().WaitForCacheSync(ctx) {
+			setup.Logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for manager cache sync")
+			os.Exit(1)
+		}
 		// start informers and wait for cache sync
 		if !internal.StartInformersAndWaitForCacheSync(ctx, setup.Logger, kyvernoInformer) {
 			setup.Logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
@@ -366,6 +438,8 @@ func main() {
 					eventGenerator,
 					backgroundScanInterval,
 					reportsBreaker,
+					contextProvider,
+					celPolicies,
 				)
 				if err != nil {
 					logger.Error(err, "failed to create leader controllers")
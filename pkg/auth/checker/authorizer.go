@@ -0,0 +1,151 @@
+package checker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+type subjectAuthorizer struct {
+	client authorizationv1client.SubjectAccessReviewInterface
+}
+
+// NewAuthorizer returns an authorizer.Authorizer creating a SubjectAccessReview for the user
+// found in the attributes, it is used to back the CEL authorizer library
+func NewAuthorizer(client authorizationv1client.SubjectAccessReviewInterface) authorizer.Authorizer {
+	return subjectAuthorizer{
+		client: client,
+	}
+}
+
+func (a subjectAuthorizer) Authorize(ctx context.Context, attributes authorizer.Attributes) (authorizer.Decision, string, error) {
+	review := &authorizationv1.SubjectAccessReview{}
+	if user := attributes.GetUser(); user != nil {
+		review.Spec.User = user.GetName()
+		review.Spec.UID = user.GetUID()
+		review.Spec.Groups = user.GetGroups()
+		if extra := user.GetExtra(); len(extra) != 0 {
+			review.Spec.Extra = make(map[string]authorizationv1.ExtraValue, len(extra))
+			for key, value := range extra {
+				review.Spec.Extra[key] = value
+			}
+		}
+	}
+	if attributes.IsResourceRequest() {
+		review.Spec.ResourceAttributes = &authorizationv1.ResourceAttributes{
+			Group:       attributes.GetAPIGroup(),
+			Version:     attributes.GetAPIVersion(),
+			Resource:    attributes.GetResource(),
+			Subresource: attributes.GetSubresource(),
+			Namespace:   attributes.GetNamespace(),
+			Verb:        attributes.GetVerb(),
+			Name:        attributes.GetName(),
+		}
+	} else {
+		review.Spec.NonResourceAttributes = &authorizationv1.NonResourceAttributes{
+			Path: attributes.GetPath(),
+			Verb: attributes.GetVerb(),
+		}
+	}
+	resp, err := a.client.Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return authorizer.DecisionNoOpinion, "", err
+	}
+	if resp.Status.EvaluationError != "" {
+		err = fmt.Errorf("failed to evaluate access review: %s", resp.Status.EvaluationError)
+	}
+	switch {
+	case resp.Status.Allowed:
+		return authorizer.DecisionAllow, resp.Status.Reason, err
+	case resp.Status.Denied:
+		return authorizer.DecisionDeny, resp.Status.Reason, err
+	default:
+		return authorizer.DecisionNoOpinion, resp.Status.Reason, err
+	}
+}
+
+// AuthorizerCacheTTL is the default time decisions are cached by a caching authorizer
+const AuthorizerCacheTTL = 10 * time.Second
+
+const authorizerCacheSize = 4096
+
+type cachingAuthorizer struct {
+	delegate  authorizer.Authorizer
+	ttl       time.Duration
+	decisions *cache.LRUExpireCache
+}
+
+type cachedDecision struct {
+	decision authorizer.Decision
+	reason   string
+}
+
+// NewCachingAuthorizer returns an authorizer caching the decisions of the delegate for the given ttl,
+// errors are not cached
+func NewCachingAuthorizer(delegate authorizer.Authorizer, ttl time.Duration) authorizer.Authorizer {
+	return &cachingAuthorizer{
+		delegate:  delegate,
+		ttl:       ttl,
+		decisions: cache.NewLRUExpireCache(authorizerCacheSize),
+	}
+}
+
+func (a *cachingAuthorizer) Authorize(ctx context.Context, attributes authorizer.Attributes) (authorizer.Decision, string, error) {
+	key, err := authorizerCacheKey(attributes)
+	if err != nil {
+		return a.delegate.Authorize(ctx, attributes)
+	}
+	if cached, ok := a.decisions.Get(key); ok {
+		decision := cached.(cachedDecision)
+		return decision.decision, decision.reason, nil
+	}
+	decision, reason, err := a.delegate.Authorize(ctx, attributes)
+	if err == nil {
+		a.decisions.Add(key, cachedDecision{decision: decision, reason: reason}, a.ttl)
+	}
+	return decision, reason, err
+}
+
+// authorizerCacheKey identifies the user and the request of the attributes
+func authorizerCacheKey(attributes authorizer.Attributes) (string, error) {
+	key := struct {
+		User        string              `json:"user,omitempty"`
+		UID         string              `json:"uid,omitempty"`
+		Groups      []string            `json:"groups,omitempty"`
+		Extra       map[string][]string `json:"extra,omitempty"`
+		Request     bool                `json:"request"`
+		Group       string              `json:"group,omitempty"`
+		Version     string              `json:"version,omitempty"`
+		Resource    string              `json:"resource,omitempty"`
+		Subresource string              `json:"subresource,omitempty"`
+		Namespace   string              `json:"namespace,omitempty"`
+		Name        string              `json:"name,omitempty"`
+		Verb        string              `json:"verb,omitempty"`
+		Path        string              `json:"path,omitempty"`
+	}{
+		Request:     attributes.IsResourceRequest(),
+		Group:       attributes.GetAPIGroup(),
+		Version:     attributes.GetAPIVersion(),
+		Resource:    attributes.GetResource(),
+		Subresource: attributes.GetSubresource(),
+		Namespace:   attributes.GetNamespace(),
+		Name:        attributes.GetName(),
+		Verb:        attributes.GetVerb(),
+		Path:        attributes.GetPath(),
+	}
+	if user := attributes.GetUser(); user != nil {
+		key.User = user.GetName()
+		key.UID = user.GetUID()
+		key.Groups = user.GetGroups()
+		key.Extra = user.GetExtra()
+	}
+	data, err := json.Marshal(key)
+	return string(data), err
+}
@@ -0,0 +1,126 @@
+package checker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestNewAuthorizer(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes authorizer.AttributesRecord
+		status     authorizationv1.SubjectAccessReviewStatus
+		want       authorizer.Decision
+		wantSpec   authorizationv1.SubjectAccessReviewSpec
+	}{{
+		name: "resource allowed",
+		attributes: authorizer.AttributesRecord{
+			User:            &user.DefaultInfo{Name: "alice", Groups: []string{"admins"}, Extra: map[string][]string{"scopes": {"a"}}},
+			Verb:            "delete",
+			APIVersion:      "v1",
+			Resource:        "pods",
+			Namespace:       "default",
+			Name:            "nginx",
+			ResourceRequest: true,
+		},
+		status: authorizationv1.SubjectAccessReviewStatus{Allowed: true, Reason: "rbac"},
+		want:   authorizer.DecisionAllow,
+		wantSpec: authorizationv1.SubjectAccessReviewSpec{
+			User:   "alice",
+			Groups: []string{"admins"},
+			Extra:  map[string]authorizationv1.ExtraValue{"scopes": {"a"}},
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Verb:      "delete",
+				Version:   "v1",
+				Resource:  "pods",
+				Namespace: "default",
+				Name:      "nginx",
+			},
+		},
+	}, {
+		name: "non resource denied",
+		attributes: authorizer.AttributesRecord{
+			User: &user.DefaultInfo{Name: "bob"},
+			Verb: "get",
+			Path: "/healthz",
+		},
+		status: authorizationv1.SubjectAccessReviewStatus{Denied: true},
+		want:   authorizer.DecisionDeny,
+		wantSpec: authorizationv1.SubjectAccessReviewSpec{
+			User:                  "bob",
+			NonResourceAttributes: &authorizationv1.NonResourceAttributes{Path: "/healthz", Verb: "get"},
+		},
+	}, {
+		name: "no opinion",
+		attributes: authorizer.AttributesRecord{
+			User:            &user.DefaultInfo{Name: "bob"},
+			Verb:            "get",
+			Resource:        "secrets",
+			ResourceRequest: true,
+		},
+		want: authorizer.DecisionNoOpinion,
+		wantSpec: authorizationv1.SubjectAccessReviewSpec{
+			User:               "bob",
+			ResourceAttributes: &authorizationv1.ResourceAttributes{Verb: "get", Resource: "secrets"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+			var spec authorizationv1.SubjectAccessReviewSpec
+			client.PrependReactor("create", "subjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				review := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+				spec = review.Spec
+				review.Status = tt.status
+				return true, review, nil
+			})
+			authz := NewAuthorizer(client.AuthorizationV1().SubjectAccessReviews())
+			decision, _, err := authz.Authorize(context.TODO(), tt.attributes)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, decision)
+			assert.Equal(t, tt.wantSpec, spec)
+		})
+	}
+}
+
+func TestNewCachingAuthorizer(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	reviews := 0
+	client.PrependReactor("create", "subjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		reviews++
+		review := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		review.Status.Allowed = review.Spec.User == "alice"
+		return true, review, nil
+	})
+	authz := NewCachingAuthorizer(NewAuthorizer(client.AuthorizationV1().SubjectAccessReviews()), time.Minute)
+	attributes := func(name string) authorizer.AttributesRecord {
+		return authorizer.AttributesRecord{
+			User:            &user.DefaultInfo{Name: name},
+			Verb:            "get",
+			APIVersion:      "v1",
+			Resource:        "pods",
+			Namespace:       "default",
+			ResourceRequest: true,
+		}
+	}
+	for range 3 {
+		decision, _, err := authz.Authorize(context.TODO(), attributes("alice"))
+		assert.NoError(t, err)
+		assert.Equal(t, authorizer.DecisionAllow, decision)
+	}
+	assert.Equal(t, 1, reviews)
+	// decisions are cached per user
+	decision, _, err := authz.Authorize(context.TODO(), attributes("bob"))
+	assert.NoError(t, err)
+	assert.Equal(t, authorizer.DecisionNoOpinion, decision)
+	assert.Equal(t, 2, reviews)
+}
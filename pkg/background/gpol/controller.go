@@ -0,0 +1,455 @@
+package gpol
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	celpolicy "github.com/kyverno/kyverno/pkg/cel/policy"
+	celutils "github.com/kyverno/kyverno/pkg/cel/utils"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/event"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	"github.com/kyverno/kyverno/pkg/utils/generator"
+	"go.uber.org/multierr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// CELGenerateController processes update requests created for generating policies
+type CELGenerateController struct {
+	// clients
+	client        dclient.Interface
+	statusControl common.StatusControlInterface
+
+	// listers
+	gpolLister  kyvernov2alpha1listers.GeneratingPolicyLister
+	polexLister kyvernov2alpha1listers.CELPolicyExceptionLister
+	nsLister    corev1listers.NamespaceLister
+
+	compiler celpolicy.Compiler
+	context  celpolicy.Context
+	eventGen event.Interface
+
+	// compiled policies indexed by name, entries are valid for the recorded policy resource version
+	// and the whole cache is invalidated when exceptions change
+	lock       sync.Mutex
+	compiled   map[string]compiledPolicy
+	generation uint64
+
+	watcher *downstreamWatcher
+
+	log logr.Logger
+}
+
+type compiledPolicy struct {
+	resourceVersion string
+	compiled        celpolicy.CompiledGeneratingPolicy
+}
+
+// NewCELGenerateController returns an instance of the generating policy controller,
+// it is meant to be shared by all update requests to reuse compiled policies and downstream watches
+func NewCELGenerateController(
+	client dclient.Interface,
+	kyvernoClient versioned.Interface,
+	statusControl common.StatusControlInterface,
+	gpolLister kyvernov2alpha1listers.GeneratingPolicyLister,
+	polexInformer kyvernov2alpha1informers.CELPolicyExceptionInformer,
+	nsLister corev1listers.NamespaceLister,
+	urGenerator generator.UpdateRequestGenerator,
+	context celpolicy.Context,
+	eventGen event.Interface,
+	log logr.Logger,
+) *CELGenerateController {
+	c := &CELGenerateController{
+		client:        client,
+		statusControl: statusControl,
+		gpolLister:    gpolLister,
+		polexLister:   polexInformer.Lister(),
+		nsLister:      nsLister,
+		compiler:      celpolicy.NewCompiler(),
+		context:       context,
+		eventGen:      eventGen,
+		compiled:      map[string]compiledPolicy{},
+		watcher:       newDownstreamWatcher(client, kyvernoClient, gpolLister, urGenerator, log.WithName("downstream")),
+		log:           log,
+	}
+	_, _ = polexInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(any) { c.invalidate() },
+		UpdateFunc: func(any, any) { c.invalidate() },
+		DeleteFunc: func(any) { c.invalidate() },
+	})
+	return c
+}
+
+// Stop stops watching downstream resources
+func (c *CELGenerateController) Stop() {
+	c.watcher.stop()
+}
+
+func (c *CELGenerateController) ProcessUR(ur *kyvernov2.UpdateRequest) error {
+	logger := c.log.WithValues("name", ur.GetName(), "policy", ur.Spec.Policy)
+	logger.Info("start processing UR", "ur", ur.Name, "resourceVersion", ur.GetResourceVersion())
+	policy, err := c.gpolLister.Get(ur.Spec.Policy)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// downstream resources of synchronized policies are garbage collected through their owner reference
+			logger.V(4).Info("generating policy no longer exists, skipping")
+			_, err := c.statusControl.Success(ur.GetName(), nil)
+			return err
+		}
+		return fmt.Errorf("error in fetching policy: %v", err)
+	}
+	compiled, err := c.compile(policy)
+	if err != nil {
+		return updateStatus(c.statusControl, *ur, err, nil)
+	}
+	var failures []error
+	var genResources []kyvernov1.ResourceSpec
+	for i, ruleContext := range ur.Spec.RuleContext {
+		if ruleContext.DeleteDownstream {
+			if err := c.deleteDownstream(policy, compiled, ur.Spec, ruleContext); err != nil {
+				failures = append(failures, fmt.Errorf("failed to delete downstream resources: %v", err))
+			}
+			continue
+		}
+		trigger, err := c.getTrigger(ur.Spec, i)
+		if err != nil || trigger == nil {
+			logger.V(4).Info("the trigger resource does not exist or is pending creation")
+			failures = append(failures, fmt.Errorf("failed to fetch trigger resource: %v", err))
+			continue
+		}
+		generated, err := c.generate(policy, compiled, ur.Spec, trigger)
+		if err != nil {
+			failures = append(failures, err)
+		}
+		genResources = append(genResources, generated...)
+	}
+	return updateStatus(c.statusControl, *ur, multierr.Combine(failures...), genResources)
+}
+
+// invalidate drops all compiled policies
+func (c *CELGenerateController) invalidate() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.compiled = map[string]compiledPolicy{}
+	c.generation++
+}
+
+// compile returns the compiled policy, policies are compiled again only when they or exceptions change
+func (c *CELGenerateController) compile(policy *kyvernov2alpha1.GeneratingPolicy) (celpolicy.CompiledGeneratingPolicy, error) {
+	c.lock.Lock()
+	entry, ok := c.compiled[policy.GetName()]
+	generation := c.generation
+	c.lock.Unlock()
+	if ok && entry.resourceVersion == policy.GetResourceVersion() {
+		return entry.compiled, nil
+	}
+	compiled, err := c.compileWithExceptions(policy)
+	if err != nil {
+		return nil, err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	// exceptions changed while compiling, the result must not be cached
+	if generation == c.generation {
+		c.compiled[policy.GetName()] = compiledPolicy{
+			resourceVersion: policy.GetResourceVersion(),
+			compiled:        compiled,
+		}
+	}
+	return compiled, nil
+}
+
+func (c *CELGenerateController) compileWithExceptions(policy *kyvernov2alpha1.GeneratingPolicy) (celpolicy.CompiledGeneratingPolicy, error) {
+	polexList, err := c.polexLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var exceptions []kyvernov2alpha1.CELPolicyException
+	for _, polex := range polexList {
+		for _, ref := range polex.Spec.PolicyRefs {
+			if ref.Name == policy.GetName() && ref.Kind == "GeneratingPolicy" {
+				exceptions = append(exceptions, *polex)
+			}
+		}
+	}
+	compiled, errs := c.compiler.CompileGenerating(policy, exceptions)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to compile policy: %w", errs.ToAggregate())
+	}
+	return compiled, nil
+}
+
+func (c *CELGenerateController) getTrigger(spec kyvernov2.UpdateRequestSpec, i int) (*unstructured.Unstructured, error) {
+	resourceSpec := spec.RuleContext[i].Trigger
+	admissionRequest := spec.Context.AdmissionRequestInfo.AdmissionRequest
+	trigger, err := common.GetResource(c.client, resourceSpec, spec, c.log)
+	if (err != nil || trigger == nil) && admissionRequest != nil && admissionRequest.SubResource != "" {
+		c.log.V(4).Info("trigger resource not found for subresource, reverting to resource in AdmissionReviewRequest", "subresource", admissionRequest.SubResource)
+		newResource, _, err := admissionutils.ExtractResources(nil, *admissionRequest)
+		if err != nil {
+			return nil, err
+		}
+		return &newResource, nil
+	}
+	return trigger, err
+}
+
+// evaluate returns the resources to generate for the given trigger or nil if the policy doesn't apply
+func (c *CELGenerateController) evaluate(compiled celpolicy.CompiledGeneratingPolicy, spec kyvernov2.UpdateRequestSpec, trigger *unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	request := spec.Context.AdmissionRequestInfo.AdmissionRequest
+	var gvr schema.GroupVersionResource
+	var userInfo user.Info
+	if request != nil {
+		gvr = schema.GroupVersionResource(request.Resource)
+		userInfo = celutils.ConvertUserInfo(request.UserInfo)
+	}
+	attr := admission.NewAttributesRecord(
+		trigger,
+		nil,
+		trigger.GroupVersionKind(),
+		trigger.GetNamespace(),
+		trigger.GetName(),
+		gvr,
+		"",
+		admission.Create,
+		nil,
+		false,
+		userInfo,
+	)
+	var namespace runtime.Object
+	if ns := trigger.GetNamespace(); ns != "" {
+		if namespaceObj, err := c.nsLister.Get(ns); err == nil {
+			namespace = namespaceObj
+		}
+	}
+	ctx := context.TODO()
+	matches, err := compiled.Match(ctx, attr, request, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if !matches {
+		return nil, nil
+	}
+	return compiled.Generate(ctx, attr, request, namespace, c.context)
+}
+
+func (c *CELGenerateController) generate(
+	policy *kyvernov2alpha1.GeneratingPolicy,
+	compiled celpolicy.CompiledGeneratingPolicy,
+	spec kyvernov2.UpdateRequestSpec,
+	trigger *unstructured.Unstructured,
+) ([]kyvernov1.ResourceSpec, error) {
+	logger := c.log.WithValues("policy", policy.GetName(), "trigger", trigger.GetNamespace()+"/"+trigger.GetName())
+	resources, err := c.evaluate(compiled, spec, trigger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate policy: %w", err)
+	}
+	if len(resources) == 0 {
+		logger.V(4).Info("policy does not apply to resource")
+		return nil, nil
+	}
+	var errs []error
+	genResources := make([]kyvernov1.ResourceSpec, 0, len(resources))
+	for _, resource := range resources {
+		if err := c.prepare(policy, trigger, resource); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		created, err := c.apply(policy, resource)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to generate %s %s/%s: %w", resource.GetKind(), resource.GetNamespace(), resource.GetName(), err))
+			continue
+		}
+		if policy.GetSynchronize() {
+			c.watchDownstream(resource.GroupVersionKind())
+		}
+		spec := common.ResourceSpecFromUnstructured(*resource)
+		genResources = append(genResources, spec)
+		if created {
+			c.eventGen.Add(event.NewResourceGenerationEvent(policy.GetName(), "generate", event.GeneratePolicyController, spec))
+		}
+	}
+	return genResources, multierr.Combine(errs...)
+}
+
+// prepare sets the namespace, labels and owner of a generated resource
+func (c *CELGenerateController) prepare(policy *kyvernov2alpha1.GeneratingPolicy, trigger *unstructured.Unstructured, resource *unstructured.Unstructured) error {
+	if resource.GetNamespace() == "" && trigger.GetNamespace() != "" {
+		namespaced, err := c.isNamespaced(resource.GroupVersionKind())
+		if err != nil {
+			return err
+		}
+		if namespaced {
+			resource.SetNamespace(trigger.GetNamespace())
+		}
+	}
+	resourceLabels := resource.GetLabels()
+	if resourceLabels == nil {
+		resourceLabels = map[string]string{}
+	}
+	resourceLabels[kyverno.LabelAppManagedBy] = kyverno.ValueKyvernoApp
+	resourceLabels[common.GeneratePolicyLabel] = policy.GetName()
+	resourceLabels[common.GeneratePolicyNamespaceLabel] = ""
+	common.TriggerInfo(resourceLabels, *trigger)
+	resource.SetLabels(resourceLabels)
+	// downstream resources of synchronized policies are deleted with the policy unless they are orphaned
+	if policy.GetSynchronize() && !policy.GetOrphanDownstreamOnPolicyDelete() {
+		resource.SetOwnerReferences(append(resource.GetOwnerReferences(), metav1.OwnerReference{
+			APIVersion: kyvernov2alpha1.SchemeGroupVersion.String(),
+			Kind:       "GeneratingPolicy",
+			Name:       policy.GetName(),
+			UID:        policy.GetUID(),
+		}))
+	}
+	return nil
+}
+
+// apply creates the resource if it doesn't exist, existing resources are updated only for synchronized policies
+func (c *CELGenerateController) apply(policy *kyvernov2alpha1.GeneratingPolicy, resource *unstructured.Unstructured) (bool, error) {
+	ctx := context.TODO()
+	existing, err := c.client.GetResource(ctx, resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource.GetName())
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		_, err := c.client.CreateResource(ctx, resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource, false)
+		return err == nil, err
+	}
+	if !policy.GetSynchronize() {
+		return false, nil
+	}
+	resource.SetResourceVersion(existing.GetResourceVersion())
+	resource.SetUID(existing.GetUID())
+	// compare with the desired state defaulted by the api server, defaults must not cause updates
+	desired, err := c.client.UpdateResource(ctx, resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource, true)
+	if err != nil {
+		return false, err
+	}
+	if !needsUpdate(existing, desired) {
+		return false, nil
+	}
+	_, err = c.client.UpdateResource(ctx, resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource, false)
+	return false, err
+}
+
+// watchDownstream makes sure changes to downstream resources of the given kind are reverted
+func (c *CELGenerateController) watchDownstream(gvk schema.GroupVersionKind) {
+	resources, err := c.client.Discovery().FindResources(gvk.Group, gvk.Version, gvk.Kind, "")
+	if err != nil {
+		c.log.Error(err, "failed to find downstream resource", "gvk", gvk.String())
+		return
+	}
+	for api := range resources {
+		c.watcher.watch(api.GroupVersionResource())
+	}
+}
+
+// deleteDownstream deletes the resources generated for a trigger that was deleted or doesn't match anymore,
+// the generated resources are computed from the previous state of the trigger
+func (c *CELGenerateController) deleteDownstream(policy *kyvernov2alpha1.GeneratingPolicy, compiled celpolicy.CompiledGeneratingPolicy, spec kyvernov2.UpdateRequestSpec, ruleContext kyvernov2.RuleContext) error {
+	request := spec.Context.AdmissionRequestInfo.AdmissionRequest
+	if request == nil {
+		return nil
+	}
+	_, oldObject, err := admissionutils.ExtractResources(nil, *request)
+	if err != nil {
+		return err
+	}
+	if oldObject.Object == nil {
+		return nil
+	}
+	resources, err := c.evaluate(compiled, spec, &oldObject)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, resource := range resources {
+		namespace := resource.GetNamespace()
+		if namespace == "" && oldObject.GetNamespace() != "" {
+			if namespaced, err := c.isNamespaced(resource.GroupVersionKind()); err == nil && namespaced {
+				namespace = oldObject.GetNamespace()
+			}
+		}
+		downstream, err := c.client.GetResource(context.TODO(), resource.GetAPIVersion(), resource.GetKind(), namespace, resource.GetName())
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		// only delete resources generated by this policy for this trigger
+		downstreamLabels := downstream.GetLabels()
+		if downstreamLabels[common.GeneratePolicyLabel] != policy.GetName() || downstreamLabels[common.GenerateTriggerUIDLabel] != string(ruleContext.Trigger.UID) {
+			continue
+		}
+		if err := c.client.DeleteResource(context.TODO(), downstream.GetAPIVersion(), downstream.GetKind(), downstream.GetNamespace(), downstream.GetName(), false, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, err)
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
+func (c *CELGenerateController) isNamespaced(gvk schema.GroupVersionKind) (bool, error) {
+	resources, err := c.client.Discovery().FindResources(gvk.Group, gvk.Version, gvk.Kind, "")
+	if err != nil {
+		return false, err
+	}
+	for _, resource := range resources {
+		return resource.Namespaced, nil
+	}
+	return false, fmt.Errorf("resource %s not found", gvk.String())
+}
+
+// needsUpdate returns true if the desired resource content differs from the existing one,
+// the whole content is compared except status and metadata, labels and annotations added to the existing resource are kept
+func needsUpdate(existing, desired *unstructured.Unstructured) bool {
+	for _, key := range contentKeys(existing, desired) {
+		if !datautils.DeepEqual(existing.Object[key], desired.Object[key]) {
+			return true
+		}
+	}
+	for key, value := range desired.GetLabels() {
+		if existing.GetLabels()[key] != value {
+			return true
+		}
+	}
+	for key, value := range desired.GetAnnotations() {
+		if existing.GetAnnotations()[key] != value {
+			return true
+		}
+	}
+	return false
+}
+
+func updateStatus(statusControl common.StatusControlInterface, ur kyvernov2.UpdateRequest, err error, genResources []kyvernov1.ResourceSpec) error {
+	if err != nil {
+		if _, err := statusControl.Failed(ur.GetName(), err.Error(), genResources); err != nil {
+			return err
+		}
+	} else {
+		if _, err := statusControl.Success(ur.GetName(), genResources); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package gpol
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	versionedfake "github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernoinformers "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_needsUpdate(t *testing.T) {
+	newConfigMap := func(data map[string]any, labels map[string]any) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"name":            "foo",
+				"resourceVersion": "42",
+				"labels":          labels,
+			},
+			"data": data,
+		}}
+	}
+	tests := []struct {
+		name     string
+		existing *unstructured.Unstructured
+		desired  *unstructured.Unstructured
+		want     bool
+	}{{
+		name:     "same content",
+		existing: newConfigMap(map[string]any{"key": "value"}, map[string]any{"app": "foo"}),
+		desired:  newConfigMap(map[string]any{"key": "value"}, map[string]any{"app": "foo"}),
+		want:     false,
+	}, {
+		name:     "extra labels on existing resource",
+		existing: newConfigMap(map[string]any{"key": "value"}, map[string]any{"app": "foo", "extra": "bar"}),
+		desired:  newConfigMap(map[string]any{"key": "value"}, map[string]any{"app": "foo"}),
+		want:     false,
+	}, {
+		name:     "data changed",
+		existing: newConfigMap(map[string]any{"key": "value"}, nil),
+		desired:  newConfigMap(map[string]any{"key": "other"}, nil),
+		want:     true,
+	}, {
+		name: "field removed from desired resource",
+		existing: func() *unstructured.Unstructured {
+			cm := newConfigMap(map[string]any{"key": "value"}, nil)
+			cm.Object["binaryData"] = map[string]any{"key": "dmFsdWU="}
+			return cm
+		}(),
+		desired: newConfigMap(map[string]any{"key": "value"}, nil),
+		want:    true,
+	}, {
+		name: "status ignored",
+		existing: func() *unstructured.Unstructured {
+			cm := newConfigMap(map[string]any{"key": "value"}, nil)
+			cm.Object["status"] = map[string]any{"ready": true}
+			return cm
+		}(),
+		desired: newConfigMap(map[string]any{"key": "value"}, nil),
+		want:    false,
+	}, {
+		name:     "label changed",
+		existing: newConfigMap(map[string]any{"key": "value"}, map[string]any{"app": "foo"}),
+		desired:  newConfigMap(map[string]any{"key": "value"}, map[string]any{"app": "bar"}),
+		want:     true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, needsUpdate(tt.existing, tt.desired))
+		})
+	}
+}
+
+func Test_contentChanged(t *testing.T) {
+	newSecret := func(data map[string]any, labels map[string]any, resourceVersion string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata": map[string]any{
+				"name":            "foo",
+				"resourceVersion": resourceVersion,
+				"labels":          labels,
+			},
+			"data": data,
+		}}
+	}
+	assert.False(t, contentChanged(newSecret(map[string]any{"key": "dmFsdWU="}, nil, "1"), newSecret(map[string]any{"key": "dmFsdWU="}, nil, "2")))
+	assert.True(t, contentChanged(newSecret(map[string]any{"key": "dmFsdWU="}, nil, "1"), newSecret(map[string]any{"key": "b3RoZXI="}, nil, "2")))
+	assert.True(t, contentChanged(newSecret(map[string]any{"key": "dmFsdWU="}, nil, "1"), newSecret(nil, nil, "2")))
+	assert.True(t, contentChanged(newSecret(nil, map[string]any{"app": "foo"}, "1"), newSecret(nil, nil, "2")))
+}
+
+func TestCELGenerateController_compile(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kyvernoClient := versionedfake.NewSimpleClientset()
+	informers := kyvernoinformers.NewSharedInformerFactory(kyvernoClient, 0)
+	c := NewCELGenerateController(
+		nil,
+		kyvernoClient,
+		nil,
+		informers.Kyverno().V2alpha1().GeneratingPolicies().Lister(),
+		informers.Kyverno().V2alpha1().CELPolicyExceptions(),
+		nil,
+		nil,
+		nil,
+		nil,
+		logr.Discard(),
+	)
+	informers.Start(ctx.Done())
+	informers.WaitForCacheSync(ctx.Done())
+	policy := &kyvernov2alpha1.GeneratingPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", ResourceVersion: "1"},
+	}
+	first, err := c.compile(policy)
+	assert.NoError(t, err)
+	// same resource version, the compiled policy is reused
+	second, err := c.compile(policy)
+	assert.NoError(t, err)
+	assert.Same(t, first, second)
+	// new resource version, the policy is compiled again
+	policy.ResourceVersion = "2"
+	third, err := c.compile(policy)
+	assert.NoError(t, err)
+	assert.NotSame(t, first, third)
+	// exceptions changed, the cache is invalidated
+	_, err = kyvernoClient.KyvernoV2alpha1().CELPolicyExceptions("default").Create(ctx, &kyvernov2alpha1.CELPolicyException{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+	}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		return len(c.compiled) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
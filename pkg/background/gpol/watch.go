@@ -0,0 +1,204 @@
+package gpol
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/background/generate"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	"github.com/kyverno/kyverno/pkg/utils/generator"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+// downstreamWatcher watches the resources generated by generating policies, modified or deleted
+// downstream resources of synchronized policies are restored through update requests,
+// the background controller must be allowed to list and watch the generated kinds
+type downstreamWatcher struct {
+	client        dclient.Interface
+	kyvernoClient versioned.Interface
+	gpolLister    kyvernov2alpha1listers.GeneratingPolicyLister
+	urGenerator   generator.UpdateRequestGenerator
+	log           logr.Logger
+
+	lock     sync.Mutex
+	watchers map[schema.GroupVersionResource]context.CancelFunc
+}
+
+func newDownstreamWatcher(
+	client dclient.Interface,
+	kyvernoClient versioned.Interface,
+	gpolLister kyvernov2alpha1listers.GeneratingPolicyLister,
+	urGenerator generator.UpdateRequestGenerator,
+	log logr.Logger,
+) *downstreamWatcher {
+	return &downstreamWatcher{
+		client:        client,
+		kyvernoClient: kyvernoClient,
+		gpolLister:    gpolLister,
+		urGenerator:   urGenerator,
+		log:           log,
+		watchers:      map[schema.GroupVersionResource]context.CancelFunc{},
+	}
+}
+
+// selector matches resources generated by generating policies, downstream resources of v1 generate rules carry the rule label
+func selector() labels.Selector {
+	managedBy, _ := labels.NewRequirement(kyverno.LabelAppManagedBy, selection.Equals, []string{kyverno.ValueKyvernoApp})
+	policy, _ := labels.NewRequirement(common.GeneratePolicyLabel, selection.Exists, nil)
+	rule, _ := labels.NewRequirement(common.GenerateRuleLabel, selection.DoesNotExist, nil)
+	return labels.NewSelector().Add(*managedBy, *policy, *rule)
+}
+
+// watch starts watching the downstream resources of the given type, if not already watched
+func (w *downstreamWatcher) watch(gvr schema.GroupVersionResource) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if _, ok := w.watchers[gvr]; ok {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	informer := dynamicinformer.NewFilteredDynamicInformer(
+		w.client.GetDynamicInterface(),
+		gvr,
+		metav1.NamespaceAll,
+		0,
+		cache.Indexers{},
+		func(options *metav1.ListOptions) {
+			options.LabelSelector = selector().String()
+		},
+	)
+	_, err := informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: w.update,
+		DeleteFunc: w.delete,
+	})
+	if err != nil {
+		cancel()
+		w.log.Error(err, "failed to watch downstream resources", "gvr", gvr.String())
+		return
+	}
+	go informer.Informer().Run(ctx.Done())
+	w.watchers[gvr] = cancel
+	w.log.V(4).Info("watching downstream resources", "gvr", gvr.String())
+}
+
+// stop stops all watches
+func (w *downstreamWatcher) stop() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for gvr, cancel := range w.watchers {
+		cancel()
+		delete(w.watchers, gvr)
+	}
+}
+
+func (w *downstreamWatcher) update(oldObj, newObj any) {
+	old, ok := oldObj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	new, ok := newObj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	// status changes and resyncs don't need to be reverted
+	if !contentChanged(old, new) {
+		return
+	}
+	w.enqueue(new)
+}
+
+func (w *downstreamWatcher) delete(obj any) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if downstream, ok := obj.(*unstructured.Unstructured); ok {
+		w.enqueue(downstream)
+	}
+}
+
+// enqueue creates an update request regenerating the downstream resources of the policy for the trigger of the resource
+func (w *downstreamWatcher) enqueue(downstream *unstructured.Unstructured) {
+	downstreamLabels := downstream.GetLabels()
+	policy, err := w.gpolLister.Get(downstreamLabels[common.GeneratePolicyLabel])
+	if err != nil || !policy.GetSynchronize() {
+		return
+	}
+	logger := w.log.WithValues("policy", policy.GetName(), "downstream", downstream.GetNamespace()+"/"+downstream.GetName())
+	trigger := generate.TriggerFromLabels(downstreamLabels)
+	// downstream resources of deleted triggers are deleted on purpose
+	if resource, err := common.GetResource(w.client, trigger, kyvernov2.UpdateRequestSpec{}, logger); err != nil || resource == nil {
+		logger.V(4).Info("trigger resource no longer exists, skipping")
+		return
+	}
+	ur := &kyvernov2.UpdateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "ur-",
+			Namespace:    config.KyvernoNamespace(),
+			Labels:       common.GenerateLabelsSet(policy.GetName()),
+		},
+		Spec: kyvernov2.UpdateRequestSpec{
+			Type:   kyvernov2.CELGenerate,
+			Policy: policy.GetName(),
+			RuleContext: []kyvernov2.RuleContext{{
+				Trigger:     trigger,
+				Synchronize: true,
+			}},
+		},
+	}
+	ctx := context.TODO()
+	created, err := w.urGenerator.Generate(ctx, w.kyvernoClient, ur, logger)
+	if err != nil {
+		logger.Error(err, "failed to create update request for downstream resource")
+		return
+	}
+	if created == nil {
+		return
+	}
+	updated := created.DeepCopy()
+	updated.Status.State = kyvernov2.Pending
+	if _, err := w.kyvernoClient.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(ctx, updated, metav1.UpdateOptions{}); err != nil {
+		logger.Error(err, "failed to update the status of the update request for downstream resource")
+	}
+}
+
+// contentChanged returns true if the content of the resource changed, status and server managed metadata are ignored
+func contentChanged(old, new *unstructured.Unstructured) bool {
+	for _, key := range contentKeys(old, new) {
+		if !datautils.DeepEqual(old.Object[key], new.Object[key]) {
+			return true
+		}
+	}
+	return !datautils.DeepEqual(old.GetLabels(), new.GetLabels()) || !datautils.DeepEqual(old.GetAnnotations(), new.GetAnnotations())
+}
+
+// contentKeys returns the top level keys of both resources, except metadata and status
+func contentKeys(resources ...*unstructured.Unstructured) []string {
+	var keys []string
+	seen := map[string]struct{}{}
+	for _, resource := range resources {
+		for key := range resource.Object {
+			if key == "metadata" || key == "status" {
+				continue
+			}
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
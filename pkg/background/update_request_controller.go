@@ -9,11 +9,14 @@ import (
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	common "github.com/kyverno/kyverno/pkg/background/common"
 	"github.com/kyverno/kyverno/pkg/background/generate"
+	"github.com/kyverno/kyverno/pkg/background/gpol"
 	"github.com/kyverno/kyverno/pkg/background/mutate"
 	"github.com/kyverno/kyverno/pkg/breaker"
+	celpolicy "github.com/kyverno/kyverno/pkg/cel/policy"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
 	kyvernov2informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
 	kyvernov2listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
@@ -21,6 +24,7 @@ import (
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/utils/generator"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -64,6 +68,9 @@ type controller struct {
 	jp             jmespath.Interface
 	reportsConfig  reportutils.ReportingConfiguration
 	reportsBreaker breaker.Breaker
+
+	// generating policies controller, shared across update requests
+	gpolController *gpol.CELGenerateController
 }
 
 // NewController returns an instance of the Generate-Request Controller
@@ -75,11 +82,15 @@ func NewController(
 	polInformer kyvernov1informers.PolicyInformer,
 	urInformer kyvernov2informers.UpdateRequestInformer,
 	namespaceInformer corev1informers.NamespaceInformer,
+	gpolInformer kyvernov2alpha1informers.GeneratingPolicyInformer,
+	polexInformer kyvernov2alpha1informers.CELPolicyExceptionInformer,
 	eventGen event.Interface,
 	configuration config.Configuration,
 	jp jmespath.Interface,
 	reportsConfig reportutils.ReportingConfiguration,
 	reportsBreaker breaker.Breaker,
+	celContext celpolicy.Context,
+	urGenerator generator.UpdateRequestGenerator,
 ) Controller {
 	urLister := urInformer.Lister().UpdateRequests(config.KyvernoNamespace())
 	c := controller{
@@ -100,12 +111,24 @@ func NewController(
 		reportsConfig:  reportsConfig,
 		reportsBreaker: reportsBreaker,
 	}
+	c.gpolController = gpol.NewCELGenerateController(
+		client,
+		kyvernoClient,
+		common.NewStatusControl(kyvernoClient, urLister),
+		gpolInformer.Lister(),
+		polexInformer,
+		namespaceInformer.Lister(),
+		urGenerator,
+		celContext,
+		eventGen,
+		logger,
+	)
 	_, _ = urInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.addUR,
 		UpdateFunc: c.updateUR,
 	})
 
-	c.informersSynced = []cache.InformerSynced{cpolInformer.Informer().HasSynced, polInformer.Informer().HasSynced, urInformer.Informer().HasSynced, namespaceInformer.Informer().HasSynced}
+	c.informersSynced = []cache.InformerSynced{cpolInformer.Informer().HasSynced, polInformer.Informer().HasSynced, urInformer.Informer().HasSynced, namespaceInformer.Informer().HasSynced, gpolInformer.Informer().HasSynced, polexInformer.Informer().HasSynced}
 
 	return &c
 }
@@ -113,6 +136,7 @@ func NewController(
 func (c *controller) Run(ctx context.Context, workers int) {
 	defer runtime.HandleCrash()
 	defer c.queue.ShutDown()
+	defer c.gpolController.Stop()
 
 	logger.Info("starting")
 	defer logger.Info("shutting down")
@@ -236,6 +260,8 @@ func (c *controller) processUR(ur *kyvernov2.UpdateRequest) error {
 	case kyvernov2.Generate:
 		ctrl := generate.NewGenerateController(c.client, c.kyvernoClient, statusControl, c.engine, c.cpolLister, c.polLister, c.urLister, c.nsLister, c.configuration, c.eventGen, logger, c.jp, c.reportsConfig, c.reportsBreaker)
 		return ctrl.ProcessUR(ur)
+	case kyvernov2.CELGenerate:
+		return c.gpolController.ProcessUR(ur)
 	}
 	return nil
 }
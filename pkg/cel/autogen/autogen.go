@@ -8,6 +8,13 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
+const (
+	// AutogenRuleName is the name of the rule generated for pod controllers except cronjobs
+	AutogenRuleName = "autogen"
+	// AutogenCronJobRuleName is the name of the rule generated for cronjobs
+	AutogenCronJobRuleName = "autogen-cronjobs"
+)
+
 var podControllers = sets.New("daemonsets", "deployments", "jobs", "statefulsets", "replicasets", "cronjobs")
 
 // canAutoGen checks whether the policy can be applied to Pod controllers
@@ -19,6 +26,9 @@ var podControllers = sets.New("daemonsets", "deployments", "jobs", "statefulsets
 // Otherwise it returns all pod controllers
 func canAutoGen(spec *kyvernov2alpha1.ValidatingPolicySpec) (bool, sets.Set[string]) {
 	match := spec.MatchConstraints
+	if match == nil || len(match.ResourceRules) == 0 {
+		return false, sets.New[string]()
+	}
 	if match.NamespaceSelector != nil {
 		if len(match.NamespaceSelector.MatchLabels) > 0 || len(match.NamespaceSelector.MatchExpressions) > 0 {
 			return false, sets.New[string]()
@@ -35,7 +45,7 @@ func canAutoGen(spec *kyvernov2alpha1.ValidatingPolicySpec) (bool, sets.Set[stri
 		if len(rule.ResourceNames) > 0 {
 			return false, sets.New[string]()
 		}
-		if len(rule.Resources) > 1 {
+		if len(rule.Resources) != 1 {
 			return false, sets.New[string]()
 		}
 		if rule.Resources[0] != "pods" {
@@ -49,14 +59,19 @@ func generateRules(spec *kyvernov2alpha1.ValidatingPolicySpec, controllers strin
 	var genRules []AutogenRule
 	// strip cronjobs from controllers if exist
 	isRemoved, controllers := stripCronJob(controllers)
-	// generate rule for pod controllers
-	if genRule, err := generateRuleForControllers(spec, controllers); err == nil {
-		genRules = append(genRules, *genRule)
+	// generate rule for pod controllers, rules are generated from a copy of the spec
+	// because the conversion rewrites the expressions in place
+	if controllers != "" {
+		if genRule, err := generateRuleForControllers(spec.DeepCopy(), controllers); err == nil {
+			genRule.Name = AutogenRuleName
+			genRules = append(genRules, *genRule)
+		}
 	}
 
 	// generate rule for cronjobs if exist
 	if isRemoved {
-		if genRule, err := generateCronJobRule(spec, "cronjobs"); err == nil {
+		if genRule, err := generateCronJobRule(spec.DeepCopy(), "cronjobs"); err == nil {
+			genRule.Name = AutogenCronJobRuleName
 			genRules = append(genRules, *genRule)
 		}
 	}
@@ -82,22 +97,44 @@ func stripCronJob(controllers string) (bool, string) {
 	return isRemoved, strings.Join(newControllers, ",")
 }
 
+// ComputeRules returns the rules generated for pod controllers, the controllers are taken from the policy
+// autogen configuration, the pod-policies.kyverno.io/autogen-controllers annotation or default to all pod controllers
 func ComputeRules(policy *kyvernov2alpha1.ValidatingPolicy) []AutogenRule {
 	applyAutoGen, desiredControllers := canAutoGen(&policy.Spec)
 	if !applyAutoGen {
 		return []AutogenRule{}
 	}
 
-	var actualControllers sets.Set[string]
-	ann := policy.GetAnnotations()
-	actualControllersString, ok := ann[kyverno.AnnotationAutogenControllers]
-	if !ok {
-		actualControllers = desiredControllers
-	} else {
-		actualControllers = sets.New(strings.Split(actualControllersString, ",")...)
+	actualControllers := desiredControllers
+	if config := policy.Spec.AutogenConfiguration; config != nil && config.PodControllers != nil {
+		actualControllers = toControllers(config.PodControllers.Controllers...)
+	} else if value, ok := policy.GetAnnotations()[kyverno.AnnotationAutogenControllers]; ok {
+		if value == "none" {
+			return []AutogenRule{}
+		}
+		actualControllers = toControllers(strings.Split(value, ",")...)
+	}
+	actualControllers = actualControllers.Intersection(desiredControllers)
+	if actualControllers.Len() == 0 {
+		return []AutogenRule{}
 	}
 
-	resources := strings.Join(actualControllers.UnsortedList(), ",")
-	genRules := generateRules(policy.Spec.DeepCopy(), resources)
-	return genRules
+	resources := strings.Join(sets.List(actualControllers), ",")
+	return generateRules(&policy.Spec, resources)
+}
+
+// toControllers converts the given controllers to resources, both kinds (Deployment) and resources (deployments) are supported
+func toControllers(values ...string) sets.Set[string] {
+	controllers := sets.New[string]()
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			continue
+		}
+		if !strings.HasSuffix(value, "s") {
+			value += "s"
+		}
+		controllers.Insert(value)
+	}
+	return controllers
 }
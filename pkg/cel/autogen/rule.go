@@ -10,13 +10,8 @@ import (
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 )
 
-type AutogenRule struct {
-	MatchConstraints *admissionregistrationv1.MatchResources   `json:"matchConstraints,omitempty"`
-	MatchConditions  []admissionregistrationv1.MatchCondition  `json:"matchConditions,omitempty"`
-	Validations      []admissionregistrationv1.Validation      `json:"validations,omitempty"`
-	AuditAnnotation  []admissionregistrationv1.AuditAnnotation `json:"auditAnnotations,omitempty"`
-	Variables        []admissionregistrationv1.Variable        `json:"variables,omitempty"`
-}
+// AutogenRule is a rule generated for pod controllers, it is reported in the policy status
+type AutogenRule = kyvernov2alpha1.ValidatingPolicyAutogen
 
 func generateCronJobRule(spec *kyvernov2alpha1.ValidatingPolicySpec, controllers string) (*AutogenRule, error) {
 	operations := spec.MatchConstraints.ResourceRules[0].Operations
@@ -136,6 +131,24 @@ func generateRuleForControllers(spec *kyvernov2alpha1.ValidatingPolicySpec, cont
 	}, nil
 }
 
+// ConvertMatchConditions rewrites match conditions written for pods to the pod template of the controllers matched
+// by the autogen rule with the given name, it is used to apply policy exceptions to autogen rules
+func ConvertMatchConditions(ruleName string, matchConditions []admissionregistrationv1.MatchCondition) ([]admissionregistrationv1.MatchCondition, error) {
+	resource := "pods"
+	if ruleName == AutogenCronJobRuleName {
+		resource = "cronjobs"
+	}
+	bytes, err := json.Marshal(matchConditions)
+	if err != nil {
+		return nil, err
+	}
+	var converted []admissionregistrationv1.MatchCondition
+	if err := json.Unmarshal(updateFields(bytes, resource), &converted); err != nil {
+		return nil, err
+	}
+	return converted, nil
+}
+
 func createMatchConstraints(controllers string, operations []admissionregistrationv1.OperationType) *admissionregistrationv1.MatchResources {
 	resources := strings.Split(controllers, ",")
 
@@ -460,3 +460,31 @@ func TestUpdateGenRuleByte(t *testing.T) {
 		}
 	}
 }
+
+func TestConvertMatchConditions(t *testing.T) {
+	matchConditions := []admissionregistrationv1.MatchCondition{{
+		Name:       "exempt",
+		Expression: "object.metadata.labels.exempt == 'true'",
+	}}
+	tests := []struct {
+		ruleName string
+		want     string
+	}{{
+		ruleName: AutogenRuleName,
+		want:     "object.spec.template.metadata.labels.exempt == 'true'",
+	}, {
+		ruleName: AutogenCronJobRuleName,
+		want:     "object.spec.jobTemplate.spec.template.metadata.labels.exempt == 'true'",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.ruleName, func(t *testing.T) {
+			got, err := ConvertMatchConditions(tt.ruleName, matchConditions)
+			assert.NilError(t, err)
+			assert.Equal(t, len(got), 1)
+			assert.Equal(t, got[0].Name, "exempt")
+			assert.Equal(t, got[0].Expression, tt.want)
+			// the given conditions are left unchanged
+			assert.Equal(t, matchConditions[0].Expression, "object.metadata.labels.exempt == 'true'")
+		})
+	}
+}
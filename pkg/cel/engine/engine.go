@@ -3,6 +3,7 @@ package engine
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	contextlib "github.com/kyverno/kyverno/pkg/cel/libs/context"
@@ -13,6 +14,7 @@ import (
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	admissionv1 "k8s.io/api/admission/v1"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +45,7 @@ func Request(
 	name string,
 	namespace string,
 	operation admissionv1.Operation,
-	// userInfo authenticationv1.UserInfo,
+	userInfo authenticationv1.UserInfo,
 	object runtime.Object,
 	oldObject runtime.Object,
 	dryRun bool,
@@ -59,11 +61,11 @@ func Request(
 		Name:               name,
 		Namespace:          namespace,
 		Operation:          operation,
-		// UserInfo: userInfo,
-		Object:    runtime.RawExtension{Object: object},
-		OldObject: runtime.RawExtension{Object: oldObject},
-		DryRun:    &dryRun,
-		Options:   runtime.RawExtension{Object: options},
+		UserInfo:           userInfo,
+		Object:             runtime.RawExtension{Object: object},
+		OldObject:          runtime.RawExtension{Object: oldObject},
+		DryRun:             &dryRun,
+		Options:            runtime.RawExtension{Object: options},
 	}
 	return RequestFromAdmission(context, request)
 }
@@ -136,8 +138,7 @@ func (e *engine) Handle(ctx context.Context, request EngineRequest) (EngineRespo
 		admission.Operation(request.request.Operation),
 		nil,
 		dryRun,
-		// TODO
-		nil,
+		utils.ConvertUserInfo(request.request.UserInfo),
 	)
 	// resolve namespace
 	var namespace runtime.Object
@@ -156,24 +157,54 @@ func (e *engine) handlePolicy(ctx context.Context, policy CompiledPolicy, attr a
 		Actions: policy.Actions,
 		Policy:  policy.Policy,
 	}
+	compiled, rulePrefix := policy.CompiledPolicy, ""
 	if e.matcher != nil {
 		criteria := matchCriteria{constraints: policy.Policy.Spec.MatchConstraints}
-		if matches, err := e.matcher.Match(&criteria, attr, namespace); err != nil {
+		matches, err := e.matcher.Match(&criteria, attr, namespace)
+		if err != nil {
 			response.Rules = handlers.WithResponses(engineapi.RuleError("match", engineapi.Validation, "failed to execute matching", err, nil))
 			return response
-		} else if !matches {
+		}
+		// fallback on the rules generated for pod controllers
+		if !matches {
+			for _, autogen := range policy.CompiledPolicy.Autogen() {
+				criteria := matchCriteria{constraints: autogen.MatchConstraints}
+				if matches, err = e.matcher.Match(&criteria, attr, namespace); err != nil {
+					response.Rules = handlers.WithResponses(engineapi.RuleError("match", engineapi.Validation, "failed to execute matching", err, nil))
+					return response
+				} else if matches {
+					compiled, rulePrefix = autogen.CompiledPolicy, autogen.Name+"-"
+					break
+				}
+			}
+		}
+		if !matches {
 			return response
 		}
 	}
-	results, err := policy.CompiledPolicy.Evaluate(ctx, attr, request, namespace, context)
+	exceptions, err := compiled.MatchExceptions(ctx, attr, request, namespace)
+	if err != nil {
+		response.Rules = handlers.WithResponses(engineapi.RuleError("exception", engineapi.Validation, "failed to match exceptions", err, nil))
+		return response
+	}
+	if len(exceptions) > 0 {
+		names := make([]string, 0, len(exceptions))
+		for _, exception := range exceptions {
+			names = append(names, exception.GetName())
+		}
+		message := fmt.Sprintf("rule is skipped due to policy exception %s", strings.Join(names, ", "))
+		response.Rules = handlers.WithResponses(engineapi.RuleSkip("exception", engineapi.Validation, message, nil).WithCELExceptions(exceptions))
+		return response
+	}
+	results, err := compiled.Evaluate(ctx, attr, request, namespace, context)
 	// TODO: error is about match conditions here ?
 	if err != nil {
 		response.Rules = handlers.WithResponses(engineapi.RuleError("evaluation", engineapi.Validation, "failed to load context", err, nil))
 	} else {
 		for index, validationResult := range results {
-			ruleName := fmt.Sprintf("rule-%d", index)
+			ruleName := fmt.Sprintf("%srule-%d", rulePrefix, index)
 			if validationResult.Error != nil {
-				response.Rules = append(response.Rules, *engineapi.RuleError(ruleName, engineapi.Validation, "error", err, nil))
+				response.Rules = append(response.Rules, *engineapi.RuleError(ruleName, engineapi.Validation, "error", validationResult.Error, nil))
 			} else if result, err := utils.ConvertToNative[bool](validationResult.Result); err != nil {
 				response.Rules = append(response.Rules, *engineapi.RuleError(ruleName, engineapi.Validation, "conversion error", err, nil))
 			} else if result {
@@ -0,0 +1,185 @@
+package engine
+
+import (
+	"context"
+	"sort"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/cel/matching"
+	"github.com/kyverno/kyverno/pkg/cel/utils"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/handlers"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+)
+
+type GeneratingEngineResponse struct {
+	Resource *unstructured.Unstructured
+	// Policies contains the policies that matched the request or failed to evaluate
+	Policies []GeneratingPolicyResponse
+}
+
+type GeneratingPolicyResponse struct {
+	Policy kyvernov2alpha1.GeneratingPolicy
+	// DeleteDownstream is true when the resources generated for the trigger must be deleted,
+	// this happens with synchronized policies when the trigger is deleted or doesn't match anymore
+	DeleteDownstream bool
+	Rules            []engineapi.RuleResponse
+}
+
+type GeneratingEngine interface {
+	Handle(context.Context, EngineRequest) (GeneratingEngineResponse, error)
+}
+
+type generatingEngine struct {
+	provider   GeneratingProvider
+	nsResolver NamespaceResolver
+	matcher    matching.Matcher
+}
+
+// NewGeneratingEngine creates an engine matching generating policies against admission requests,
+// resources are not generated by the engine but by the background controller.
+func NewGeneratingEngine(provider GeneratingProvider, nsResolver NamespaceResolver, matcher matching.Matcher) GeneratingEngine {
+	return &generatingEngine{
+		provider:   provider,
+		nsResolver: nsResolver,
+		matcher:    matcher,
+	}
+}
+
+func (e *generatingEngine) Handle(ctx context.Context, request EngineRequest) (GeneratingEngineResponse, error) {
+	var response GeneratingEngineResponse
+	// fetch compiled policies
+	policies, err := e.provider.CompiledGeneratingPolicies(ctx)
+	if err != nil {
+		return response, err
+	}
+	sort.SliceStable(policies, func(i, j int) bool {
+		return policies[i].Policy.GetName() < policies[j].Policy.GetName()
+	})
+	// load objects
+	object, oldObject, err := admissionutils.ExtractResources(nil, request.request)
+	if err != nil {
+		return response, err
+	}
+	response.Resource = &object
+	if response.Resource.Object == nil {
+		response.Resource = &oldObject
+	}
+	// resolve namespace
+	var namespace runtime.Object
+	if ns := request.request.Namespace; ns != "" {
+		namespace = e.nsResolver(ns)
+	}
+	for _, policy := range policies {
+		if policyResponse := e.handlePolicy(ctx, policy, &request.request, &object, &oldObject, namespace); policyResponse != nil {
+			response.Policies = append(response.Policies, *policyResponse)
+		}
+	}
+	return response, nil
+}
+
+func (e *generatingEngine) handlePolicy(
+	ctx context.Context,
+	policy CompiledGeneratingPolicy,
+	request *admissionv1.AdmissionRequest,
+	object *unstructured.Unstructured,
+	oldObject *unstructured.Unstructured,
+	namespace runtime.Object,
+) *GeneratingPolicyResponse {
+	response := &GeneratingPolicyResponse{
+		Policy: policy.Policy,
+	}
+	operation := admission.Operation(request.Operation)
+	if policy.Policy.GetSynchronize() && (operation == admission.Update || operation == admission.Delete) {
+		// synchronized policies evaluate the trigger as if it was created, it either still
+		// matches and downstream resources are updated, or it stopped matching and they are deleted
+		if operation == admission.Update {
+			matches, err := e.match(ctx, policy, request, e.attributes(request, admission.Create, object, nil), namespace)
+			if err != nil {
+				return response.withError(err)
+			}
+			if matches {
+				return response.withMatch()
+			}
+		}
+		matches, err := e.match(ctx, policy, request, e.attributes(request, admission.Create, oldObject, nil), namespace)
+		if err != nil {
+			return response.withError(err)
+		}
+		if matches {
+			response.DeleteDownstream = true
+			return response.withMatch()
+		}
+		return nil
+	}
+	matches, err := e.match(ctx, policy, request, e.attributes(request, operation, object, oldObject), namespace)
+	if err != nil {
+		return response.withError(err)
+	}
+	if matches {
+		return response.withMatch()
+	}
+	return nil
+}
+
+func (e *generatingEngine) match(
+	ctx context.Context,
+	policy CompiledGeneratingPolicy,
+	request *admissionv1.AdmissionRequest,
+	attr admission.Attributes,
+	namespace runtime.Object,
+) (bool, error) {
+	if attr.GetObject() == nil {
+		return false, nil
+	}
+	if e.matcher != nil {
+		criteria := matchCriteria{constraints: policy.Policy.Spec.MatchConstraints}
+		if matches, err := e.matcher.Match(&criteria, attr, namespace); err != nil || !matches {
+			return false, err
+		}
+	}
+	return policy.CompiledPolicy.Match(ctx, attr, request, namespace)
+}
+
+func (e *generatingEngine) attributes(request *admissionv1.AdmissionRequest, operation admission.Operation, object, oldObject *unstructured.Unstructured) admission.Attributes {
+	// default dry run
+	dryRun := false
+	if request.DryRun != nil {
+		dryRun = *request.DryRun
+	}
+	var obj, oldObj runtime.Object
+	if object != nil && object.Object != nil {
+		obj = object
+	}
+	if oldObject != nil && oldObject.Object != nil {
+		oldObj = oldObject
+	}
+	return admission.NewAttributesRecord(
+		obj,
+		oldObj,
+		schema.GroupVersionKind(request.Kind),
+		request.Namespace,
+		request.Name,
+		schema.GroupVersionResource(request.Resource),
+		request.SubResource,
+		operation,
+		nil,
+		dryRun,
+		utils.ConvertUserInfo(request.UserInfo),
+	)
+}
+
+func (r *GeneratingPolicyResponse) withMatch() *GeneratingPolicyResponse {
+	r.Rules = handlers.WithResponses(engineapi.RulePass("generate", engineapi.Generation, "policy matched", nil))
+	return r
+}
+
+func (r *GeneratingPolicyResponse) withError(err error) *GeneratingPolicyResponse {
+	r.Rules = handlers.WithResponses(engineapi.RuleError("generate", engineapi.Generation, "failed to evaluate policy", err, nil))
+	return r
+}
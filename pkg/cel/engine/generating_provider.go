@@ -0,0 +1,140 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/cel/policy"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"golang.org/x/exp/maps"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type CompiledGeneratingPolicy struct {
+	Policy         kyvernov2alpha1.GeneratingPolicy
+	CompiledPolicy policy.CompiledGeneratingPolicy
+}
+
+type GeneratingProvider interface {
+	CompiledGeneratingPolicies(context.Context) ([]CompiledGeneratingPolicy, error)
+}
+
+type GeneratingProviderFunc func(context.Context) ([]CompiledGeneratingPolicy, error)
+
+func (f GeneratingProviderFunc) CompiledGeneratingPolicies(ctx context.Context) ([]CompiledGeneratingPolicy, error) {
+	return f(ctx)
+}
+
+func NewGeneratingProvider(compiler policy.Compiler, policies ...kyvernov2alpha1.GeneratingPolicy) (GeneratingProviderFunc, error) {
+	compiled := make([]CompiledGeneratingPolicy, 0, len(policies))
+	for _, gp := range policies {
+		policy, err := compiler.CompileGenerating(&gp, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile policy %s (%w)", gp.GetName(), err.ToAggregate())
+		}
+		compiled = append(compiled, CompiledGeneratingPolicy{
+			Policy:         gp,
+			CompiledPolicy: policy,
+		})
+	}
+	provider := func(context.Context) ([]CompiledGeneratingPolicy, error) {
+		return compiled, nil
+	}
+	return provider, nil
+}
+
+func NewKubeGeneratingProvider(
+	compiler policy.Compiler,
+	mgr ctrl.Manager,
+	polexLister kyvernov2alpha1listers.CELPolicyExceptionLister,
+) (GeneratingProvider, error) {
+	r := newGeneratingPolicyReconciler(compiler, mgr.GetClient(), polexLister)
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&kyvernov2alpha1.GeneratingPolicy{}).
+		Watches(&kyvernov2alpha1.CELPolicyException{}, exceptionHandler()).
+		Complete(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct manager: %w", err)
+	}
+	return r, nil
+}
+
+type generatingPolicyReconciler struct {
+	client      client.Client
+	compiler    policy.Compiler
+	lock        *sync.RWMutex
+	policies    map[string]CompiledGeneratingPolicy
+	polexLister kyvernov2alpha1listers.CELPolicyExceptionLister
+}
+
+func newGeneratingPolicyReconciler(
+	compiler policy.Compiler,
+	client client.Client,
+	polexLister kyvernov2alpha1listers.CELPolicyExceptionLister,
+) *generatingPolicyReconciler {
+	return &generatingPolicyReconciler{
+		client:      client,
+		compiler:    compiler,
+		lock:        &sync.RWMutex{},
+		policies:    map[string]CompiledGeneratingPolicy{},
+		polexLister: polexLister,
+	}
+}
+
+func (r *generatingPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var policy kyvernov2alpha1.GeneratingPolicy
+	err := r.client.Get(ctx, req.NamespacedName, &policy)
+	if errors.IsNotFound(err) {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		delete(r.policies, req.NamespacedName.String())
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// get exceptions that match the policy
+	exceptions, err := r.ListExceptions(policy.GetName())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	compiled, errs := r.compiler.CompileGenerating(&policy, exceptions)
+	if len(errs) > 0 {
+		// No need to retry it
+		return ctrl.Result{}, nil
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.policies[req.NamespacedName.String()] = CompiledGeneratingPolicy{
+		Policy:         policy,
+		CompiledPolicy: compiled,
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *generatingPolicyReconciler) CompiledGeneratingPolicies(ctx context.Context) ([]CompiledGeneratingPolicy, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return maps.Values(r.policies), nil
+}
+
+func (r *generatingPolicyReconciler) ListExceptions(policyName string) ([]kyvernov2alpha1.CELPolicyException, error) {
+	polexList, err := r.polexLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var exceptions []kyvernov2alpha1.CELPolicyException
+	for _, polex := range polexList {
+		for _, ref := range polex.Spec.PolicyRefs {
+			if ref.Name == policyName && ref.Kind == "GeneratingPolicy" {
+				exceptions = append(exceptions, *polex)
+			}
+		}
+	}
+	return exceptions, nil
+}
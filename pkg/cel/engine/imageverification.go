@@ -0,0 +1,276 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	contextlib "github.com/kyverno/kyverno/pkg/cel/libs/context"
+	"github.com/kyverno/kyverno/pkg/cel/matching"
+	"github.com/kyverno/kyverno/pkg/cel/utils"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/handlers"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
+	"go.uber.org/multierr"
+	"gomodules.xyz/jsonpatch/v2"
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/admission"
+)
+
+type ImageVerificationEngineResponse struct {
+	Resource *unstructured.Unstructured
+	Policies []ImageVerificationPolicyResponse
+}
+
+type ImageVerificationPolicyResponse struct {
+	Actions sets.Set[admissionregistrationv1.ValidationAction]
+	Policy  kyvernov2alpha1.ImageVerificationPolicy
+	Rules   []engineapi.RuleResponse
+	// Patches contains the json patches replacing image tags with digests
+	Patches []jsonpatch.JsonPatchOperation
+}
+
+// ImageVerificationPolicyPredicate selects the policies evaluated by the engine, nil selects all of them.
+type ImageVerificationPolicyPredicate = func(kyvernov2alpha1.ImageVerificationPolicy) bool
+
+type ImageVerificationEngine interface {
+	Handle(context.Context, EngineRequest, ImageVerificationPolicyPredicate) (ImageVerificationEngineResponse, error)
+}
+
+// DigestResolver returns the digest of an image
+type DigestResolver = func(context.Context, string) (string, error)
+
+type imageVerificationEngine struct {
+	provider       ImageVerificationProvider
+	nsResolver     NamespaceResolver
+	matcher        matching.Matcher
+	digestResolver DigestResolver
+	configuration  config.Configuration
+}
+
+// NewImageVerificationEngine creates an engine evaluating image verification policies,
+// digestResolver is used when image tags are mutated to digests.
+func NewImageVerificationEngine(
+	provider ImageVerificationProvider,
+	nsResolver NamespaceResolver,
+	matcher matching.Matcher,
+	digestResolver DigestResolver,
+	configuration config.Configuration,
+) ImageVerificationEngine {
+	return &imageVerificationEngine{
+		provider:       provider,
+		nsResolver:     nsResolver,
+		matcher:        matcher,
+		digestResolver: digestResolver,
+		configuration:  configuration,
+	}
+}
+
+func (e *imageVerificationEngine) Handle(ctx context.Context, request EngineRequest, predicate ImageVerificationPolicyPredicate) (ImageVerificationEngineResponse, error) {
+	var response ImageVerificationEngineResponse
+	// fetch compiled policies
+	compiled, err := e.provider.CompiledImageVerificationPolicies(ctx)
+	if err != nil {
+		return response, err
+	}
+	policies := make([]CompiledImageVerificationPolicy, 0, len(compiled))
+	for _, policy := range compiled {
+		if predicate == nil || predicate(policy.Policy) {
+			policies = append(policies, policy)
+		}
+	}
+	// apply policies in a deterministic order
+	sort.SliceStable(policies, func(i, j int) bool {
+		return policies[i].Policy.GetName() < policies[j].Policy.GetName()
+	})
+	// load objects
+	object, oldObject, err := admissionutils.ExtractResources(nil, request.request)
+	if err != nil {
+		return response, err
+	}
+	response.Resource = &object
+	// images of deleted resources are not verified
+	if object.Object == nil {
+		response.Resource = &oldObject
+		return response, nil
+	}
+	// default dry run
+	dryRun := false
+	if request.request.DryRun != nil {
+		dryRun = *request.request.DryRun
+	}
+	var oldObj runtime.Object
+	if oldObject.Object != nil {
+		oldObj = &oldObject
+	}
+	// create admission attributes
+	attr := admission.NewAttributesRecord(
+		&object,
+		oldObj,
+		schema.GroupVersionKind(request.request.Kind),
+		request.request.Namespace,
+		request.request.Name,
+		schema.GroupVersionResource(request.request.Resource),
+		request.request.SubResource,
+		admission.Operation(request.request.Operation),
+		nil,
+		dryRun,
+		utils.ConvertUserInfo(request.request.UserInfo),
+	)
+	// resolve namespace
+	var namespace runtime.Object
+	if ns := request.request.Namespace; ns != "" {
+		namespace = e.nsResolver(ns)
+	}
+	// evaluate policies
+	for _, policy := range policies {
+		if policyResponse := e.handlePolicy(ctx, policy, attr, &request.request, &object, namespace, request.context); policyResponse != nil {
+			response.Policies = append(response.Policies, *policyResponse)
+		}
+	}
+	return response, nil
+}
+
+func (e *imageVerificationEngine) handlePolicy(
+	ctx context.Context,
+	policy CompiledImageVerificationPolicy,
+	attr admission.Attributes,
+	request *admissionv1.AdmissionRequest,
+	object *unstructured.Unstructured,
+	namespace runtime.Object,
+	context contextlib.ContextInterface,
+) *ImageVerificationPolicyResponse {
+	response := &ImageVerificationPolicyResponse{
+		Actions: sets.New(policy.Policy.GetValidationActions()...),
+		Policy:  policy.Policy,
+	}
+	if e.matcher != nil {
+		criteria := matchCriteria{constraints: policy.Policy.Spec.MatchConstraints}
+		if matches, err := e.matcher.Match(&criteria, attr, namespace); err != nil {
+			response.Rules = handlers.WithResponses(engineapi.RuleError("match", engineapi.ImageVerify, "failed to execute matching", err, nil))
+			return response
+		} else if !matches {
+			return nil
+		}
+	}
+	if matches, err := policy.CompiledPolicy.Match(ctx, attr, request, namespace); err != nil {
+		response.Rules = handlers.WithResponses(engineapi.RuleError("match", engineapi.ImageVerify, "failed to evaluate match conditions", err, nil))
+		return response
+	} else if !matches {
+		return nil
+	}
+	// select images
+	infos, err := apiutils.ExtractImagesFromResource(*object, policy.Policy.Spec.ImageExtractors, e.configuration)
+	if err != nil {
+		response.Rules = handlers.WithResponses(engineapi.RuleError("images", engineapi.ImageVerify, "failed to extract images", err, nil))
+		return response
+	}
+	images := map[string][]string{}
+	var selected []apiutils.ImageInfo
+	for name, byPointer := range infos {
+		for _, info := range byPointer {
+			image := info.String()
+			if matches, err := policy.CompiledPolicy.MatchImage(image); err != nil {
+				response.Rules = handlers.WithResponses(engineapi.RuleError("images", engineapi.ImageVerify, "failed to evaluate image rules", err, nil))
+				return response
+			} else if matches {
+				images[name] = append(images[name], image)
+				selected = append(selected, info)
+			}
+		}
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+	for name := range images {
+		sort.Strings(images[name])
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		return selected[i].Pointer < selected[j].Pointer
+	})
+	// evaluate verifications
+	results, err := policy.CompiledPolicy.Evaluate(ctx, attr, request, namespace, context, images)
+	if err != nil {
+		response.Rules = handlers.WithResponses(engineapi.RuleError("evaluation", engineapi.ImageVerify, "failed to load context", err, nil))
+		return response
+	}
+	verified := true
+	for index, verificationResult := range results {
+		ruleName := fmt.Sprintf("rule-%d", index)
+		if verificationResult.Error != nil {
+			verified = false
+			response.Rules = append(response.Rules, *engineapi.RuleError(ruleName, engineapi.ImageVerify, "error", verificationResult.Error, nil))
+		} else if result, err := utils.ConvertToNative[bool](verificationResult.Result); err != nil {
+			verified = false
+			response.Rules = append(response.Rules, *engineapi.RuleError(ruleName, engineapi.ImageVerify, "conversion error", err, nil))
+		} else if result {
+			response.Rules = append(response.Rules, *engineapi.RulePass(ruleName, engineapi.ImageVerify, "success", nil))
+		} else {
+			verified = false
+			response.Rules = append(response.Rules, *engineapi.RuleFail(ruleName, engineapi.ImageVerify, verificationResult.Message, nil))
+		}
+	}
+	// digests are only processed for verified images
+	if verified {
+		if rule := e.handleDigests(ctx, policy.Policy, selected, response); rule != nil {
+			response.Rules = append(response.Rules, *rule)
+		}
+	}
+	return response
+}
+
+// handleDigests replaces image tags with digests, or checks images use digests when mutation is disabled
+func (e *imageVerificationEngine) handleDigests(
+	ctx context.Context,
+	policy kyvernov2alpha1.ImageVerificationPolicy,
+	selected []apiutils.ImageInfo,
+	response *ImageVerificationPolicyResponse,
+) *engineapi.RuleResponse {
+	if policy.GetMutateDigest() {
+		var errs []error
+		for _, info := range selected {
+			if info.Digest != "" {
+				continue
+			}
+			if e.digestResolver == nil {
+				errs = append(errs, fmt.Errorf("failed to resolve digest for image %s: no digest resolver configured", info.String()))
+				continue
+			}
+			digest, err := e.digestResolver(ctx, info.String())
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to resolve digest for image %s: %w", info.String(), err))
+				continue
+			}
+			response.Patches = append(response.Patches, jsonpatch.JsonPatchOperation{
+				Operation: "replace",
+				Path:      info.Pointer,
+				Value:     info.String() + "@" + digest,
+			})
+		}
+		if err := multierr.Combine(errs...); err != nil {
+			response.Patches = nil
+			return engineapi.RuleError("mutate-digest", engineapi.ImageVerify, "failed to mutate digests", err, nil)
+		}
+		return nil
+	}
+	if policy.GetVerifyDigest() {
+		var missing []string
+		for _, info := range selected {
+			if info.Digest == "" {
+				missing = append(missing, info.String())
+			}
+		}
+		if len(missing) > 0 {
+			return engineapi.RuleFail("verify-digest", engineapi.ImageVerify, fmt.Sprintf("missing digest for images %v", missing), nil)
+		}
+	}
+	return nil
+}
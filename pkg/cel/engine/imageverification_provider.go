@@ -0,0 +1,161 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/cel/libs/imageverify"
+	"github.com/kyverno/kyverno/pkg/cel/policy"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"golang.org/x/exp/maps"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type CompiledImageVerificationPolicy struct {
+	Policy         kyvernov2alpha1.ImageVerificationPolicy
+	CompiledPolicy policy.CompiledImageVerificationPolicy
+}
+
+type ImageVerificationProvider interface {
+	CompiledImageVerificationPolicies(context.Context) ([]CompiledImageVerificationPolicy, error)
+}
+
+type ImageVerificationProviderFunc func(context.Context) ([]CompiledImageVerificationPolicy, error)
+
+func (f ImageVerificationProviderFunc) CompiledImageVerificationPolicies(ctx context.Context) ([]CompiledImageVerificationPolicy, error) {
+	return f(ctx)
+}
+
+// VerifierFactory creates the image verifier used by the CEL functions of a policy
+type VerifierFactory = func(*kyvernov2alpha1.ImageVerificationPolicy) imageverify.Verifier
+
+// NewImageVerificationProvider compiles the given policies, verifierFactory is optional
+// and image verification functions fail when it is nil.
+func NewImageVerificationProvider(compiler policy.Compiler, verifierFactory VerifierFactory, policies ...kyvernov2alpha1.ImageVerificationPolicy) (ImageVerificationProviderFunc, error) {
+	compiled := make([]CompiledImageVerificationPolicy, 0, len(policies))
+	for _, ivp := range policies {
+		var verifier imageverify.Verifier
+		if verifierFactory != nil {
+			verifier = verifierFactory(&ivp)
+		}
+		policy, err := compiler.CompileImageVerification(&ivp, nil, verifier)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile policy %s (%w)", ivp.GetName(), err.ToAggregate())
+		}
+		compiled = append(compiled, CompiledImageVerificationPolicy{
+			Policy:         ivp,
+			CompiledPolicy: policy,
+		})
+	}
+	provider := func(context.Context) ([]CompiledImageVerificationPolicy, error) {
+		return compiled, nil
+	}
+	return provider, nil
+}
+
+func NewKubeImageVerificationProvider(
+	compiler policy.Compiler,
+	mgr ctrl.Manager,
+	polexLister kyvernov2alpha1listers.CELPolicyExceptionLister,
+	verifierFactory VerifierFactory,
+) (ImageVerificationProvider, error) {
+	r := newImageVerificationPolicyReconciler(compiler, mgr.GetClient(), polexLister, verifierFactory)
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&kyvernov2alpha1.ImageVerificationPolicy{}).
+		Watches(&kyvernov2alpha1.CELPolicyException{}, exceptionHandler()).
+		Complete(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct manager: %w", err)
+	}
+	return r, nil
+}
+
+type imageVerificationPolicyReconciler struct {
+	client          client.Client
+	compiler        policy.Compiler
+	lock            *sync.RWMutex
+	policies        map[string]CompiledImageVerificationPolicy
+	polexLister     kyvernov2alpha1listers.CELPolicyExceptionLister
+	verifierFactory VerifierFactory
+}
+
+func newImageVerificationPolicyReconciler(
+	compiler policy.Compiler,
+	client client.Client,
+	polexLister kyvernov2alpha1listers.CELPolicyExceptionLister,
+	verifierFactory VerifierFactory,
+) *imageVerificationPolicyReconciler {
+	return &imageVerificationPolicyReconciler{
+		client:          client,
+		compiler:        compiler,
+		lock:            &sync.RWMutex{},
+		policies:        map[string]CompiledImageVerificationPolicy{},
+		polexLister:     polexLister,
+		verifierFactory: verifierFactory,
+	}
+}
+
+func (r *imageVerificationPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var policy kyvernov2alpha1.ImageVerificationPolicy
+	err := r.client.Get(ctx, req.NamespacedName, &policy)
+	if errors.IsNotFound(err) {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		delete(r.policies, req.NamespacedName.String())
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// get exceptions that match the policy
+	exceptions, err := r.ListExceptions(policy.GetName())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	var verifier imageverify.Verifier
+	if r.verifierFactory != nil {
+		verifier = r.verifierFactory(&policy)
+	}
+	compiled, errs := r.compiler.CompileImageVerification(&policy, exceptions, verifier)
+	if len(errs) > 0 {
+		// drop the previously compiled version, no need to retry it
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		delete(r.policies, req.NamespacedName.String())
+		return ctrl.Result{}, nil
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.policies[req.NamespacedName.String()] = CompiledImageVerificationPolicy{
+		Policy:         policy,
+		CompiledPolicy: compiled,
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *imageVerificationPolicyReconciler) CompiledImageVerificationPolicies(ctx context.Context) ([]CompiledImageVerificationPolicy, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return maps.Values(r.policies), nil
+}
+
+func (r *imageVerificationPolicyReconciler) ListExceptions(policyName string) ([]kyvernov2alpha1.CELPolicyException, error) {
+	polexList, err := r.polexLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var exceptions []kyvernov2alpha1.CELPolicyException
+	for _, polex := range polexList {
+		for _, ref := range polex.Spec.PolicyRefs {
+			if ref.Name == policyName && ref.Kind == "ImageVerificationPolicy" {
+				exceptions = append(exceptions, *polex)
+			}
+		}
+	}
+	return exceptions, nil
+}
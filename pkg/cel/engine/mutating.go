@@ -0,0 +1,180 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	contextlib "github.com/kyverno/kyverno/pkg/cel/libs/context"
+	"github.com/kyverno/kyverno/pkg/cel/matching"
+	"github.com/kyverno/kyverno/pkg/cel/policy"
+	"github.com/kyverno/kyverno/pkg/cel/utils"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/handlers"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/managedfields"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/admission/plugin/policy/mutating/patch"
+)
+
+type MutatingEngineResponse struct {
+	Resource        *unstructured.Unstructured
+	PatchedResource *unstructured.Unstructured
+	Policies        []MutatingPolicyResponse
+}
+
+type MutatingPolicyResponse struct {
+	Policy kyvernov2alpha1.MutatingPolicy
+	Rules  []engineapi.RuleResponse
+}
+
+// MutatingPolicyPredicate selects the policies evaluated by the engine, nil selects all of them.
+type MutatingPolicyPredicate = func(kyvernov2alpha1.MutatingPolicy) bool
+
+type MutatingEngine interface {
+	Handle(context.Context, EngineRequest, MutatingPolicyPredicate) (MutatingEngineResponse, error)
+}
+
+type mutatingEngine struct {
+	provider      MutatingProvider
+	nsResolver    NamespaceResolver
+	matcher       matching.Matcher
+	typeConverter patch.TypeConverterManager
+}
+
+// NewMutatingEngine creates an engine evaluating mutating policies,
+// typeConverter is optional and structural types are deduced from the resource when nil.
+func NewMutatingEngine(provider MutatingProvider, nsResolver NamespaceResolver, matcher matching.Matcher, typeConverter patch.TypeConverterManager) MutatingEngine {
+	return &mutatingEngine{
+		provider:      provider,
+		nsResolver:    nsResolver,
+		matcher:       matcher,
+		typeConverter: typeConverter,
+	}
+}
+
+func (e *mutatingEngine) Handle(ctx context.Context, request EngineRequest, predicate MutatingPolicyPredicate) (MutatingEngineResponse, error) {
+	var response MutatingEngineResponse
+	// fetch compiled policies
+	compiled, err := e.provider.CompiledMutatingPolicies(ctx)
+	if err != nil {
+		return response, err
+	}
+	policies := make([]CompiledMutatingPolicy, 0, len(compiled))
+	for _, policy := range compiled {
+		if predicate == nil || predicate(policy.Policy) {
+			policies = append(policies, policy)
+		}
+	}
+	// apply policies in a deterministic order
+	sort.SliceStable(policies, func(i, j int) bool {
+		return policies[i].Policy.GetName() < policies[j].Policy.GetName()
+	})
+	// load objects
+	object, oldObject, err := admissionutils.ExtractResources(nil, request.request)
+	if err != nil {
+		return response, err
+	}
+	response.Resource = &object
+	if response.Resource.Object == nil {
+		response.Resource = &oldObject
+	}
+	// resolve namespace
+	var namespace runtime.Object
+	if ns := request.request.Namespace; ns != "" {
+		namespace = e.nsResolver(ns)
+	}
+	var typeConverter managedfields.TypeConverter
+	if e.typeConverter != nil {
+		typeConverter = e.typeConverter.GetTypeConverter(schema.GroupVersionKind(request.request.Kind))
+	}
+	// mutations are chained, each policy receives the output of the previous one
+	patched := object.DeepCopy()
+	for _, policy := range policies {
+		attr := e.attributes(&request.request, patched, &oldObject)
+		policyResponse, result := e.handlePolicy(ctx, policy, attr, &request.request, namespace, request.context, typeConverter)
+		if result != nil && result.PatchedResource != nil {
+			patched = result.PatchedResource
+		}
+		response.Policies = append(response.Policies, policyResponse)
+	}
+	if patched.Object != nil {
+		response.PatchedResource = patched
+	}
+	return response, nil
+}
+
+func (e *mutatingEngine) attributes(request *admissionv1.AdmissionRequest, object, oldObject *unstructured.Unstructured) admission.Attributes {
+	// default dry run
+	dryRun := false
+	if request.DryRun != nil {
+		dryRun = *request.DryRun
+	}
+	var obj, oldObj runtime.Object
+	if object.Object != nil {
+		obj = object
+	}
+	if oldObject.Object != nil {
+		oldObj = oldObject
+	}
+	return admission.NewAttributesRecord(
+		obj,
+		oldObj,
+		schema.GroupVersionKind(request.Kind),
+		request.Namespace,
+		request.Name,
+		schema.GroupVersionResource(request.Resource),
+		request.SubResource,
+		admission.Operation(request.Operation),
+		nil,
+		dryRun,
+		utils.ConvertUserInfo(request.UserInfo),
+	)
+}
+
+func (e *mutatingEngine) handlePolicy(
+	ctx context.Context,
+	policy CompiledMutatingPolicy,
+	attr admission.Attributes,
+	request *admissionv1.AdmissionRequest,
+	namespace runtime.Object,
+	context contextlib.ContextInterface,
+	typeConverter managedfields.TypeConverter,
+) (MutatingPolicyResponse, *policy.MutatingEvaluationResult) {
+	response := MutatingPolicyResponse{
+		Policy: policy.Policy,
+	}
+	if e.matcher != nil {
+		criteria := matchCriteria{constraints: policy.Policy.Spec.MatchConstraints}
+		if matches, err := e.matcher.Match(&criteria, attr, namespace); err != nil {
+			response.Rules = handlers.WithResponses(engineapi.RuleError("match", engineapi.Mutation, "failed to execute matching", err, nil))
+			return response, nil
+		} else if !matches {
+			return response, nil
+		}
+	}
+	result, err := policy.CompiledPolicy.Evaluate(ctx, attr, request, namespace, context, typeConverter)
+	if err != nil {
+		response.Rules = handlers.WithResponses(engineapi.RuleError("evaluation", engineapi.Mutation, "failed to evaluate policy", err, nil))
+		return response, nil
+	}
+	if result == nil {
+		return response, nil
+	}
+	for index, mutationResult := range result.Results {
+		ruleName := fmt.Sprintf("rule-%d", index)
+		if mutationResult.Error != nil {
+			response.Rules = append(response.Rules, *engineapi.RuleError(ruleName, engineapi.Mutation, "failed to apply mutation", mutationResult.Error, nil))
+		} else if mutationResult.Modified {
+			response.Rules = append(response.Rules, *engineapi.RulePass(ruleName, engineapi.Mutation, "mutation applied", nil))
+		} else {
+			response.Rules = append(response.Rules, *engineapi.RuleSkip(ruleName, engineapi.Mutation, "mutation did not change the resource", nil))
+		}
+	}
+	return response, result
+}
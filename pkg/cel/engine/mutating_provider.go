@@ -0,0 +1,143 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/cel/policy"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"golang.org/x/exp/maps"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type CompiledMutatingPolicy struct {
+	Policy         kyvernov2alpha1.MutatingPolicy
+	CompiledPolicy policy.CompiledMutatingPolicy
+}
+
+type MutatingProvider interface {
+	CompiledMutatingPolicies(context.Context) ([]CompiledMutatingPolicy, error)
+}
+
+type MutatingProviderFunc func(context.Context) ([]CompiledMutatingPolicy, error)
+
+func (f MutatingProviderFunc) CompiledMutatingPolicies(ctx context.Context) ([]CompiledMutatingPolicy, error) {
+	return f(ctx)
+}
+
+func NewMutatingProvider(compiler policy.Compiler, policies ...kyvernov2alpha1.MutatingPolicy) (MutatingProviderFunc, error) {
+	compiled := make([]CompiledMutatingPolicy, 0, len(policies))
+	for _, mp := range policies {
+		policy, err := compiler.CompileMutating(&mp, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile policy %s (%w)", mp.GetName(), err.ToAggregate())
+		}
+		compiled = append(compiled, CompiledMutatingPolicy{
+			Policy:         mp,
+			CompiledPolicy: policy,
+		})
+	}
+	provider := func(context.Context) ([]CompiledMutatingPolicy, error) {
+		return compiled, nil
+	}
+	return provider, nil
+}
+
+func NewKubeMutatingProvider(
+	compiler policy.Compiler,
+	mgr ctrl.Manager,
+	polexLister kyvernov2alpha1listers.CELPolicyExceptionLister,
+) (MutatingProvider, error) {
+	r := newMutatingPolicyReconciler(compiler, mgr.GetClient(), polexLister)
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&kyvernov2alpha1.MutatingPolicy{}).
+		Watches(&kyvernov2alpha1.CELPolicyException{}, exceptionHandler()).
+		Complete(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct manager: %w", err)
+	}
+	return r, nil
+}
+
+type mutatingPolicyReconciler struct {
+	client      client.Client
+	compiler    policy.Compiler
+	lock        *sync.RWMutex
+	policies    map[string]CompiledMutatingPolicy
+	polexLister kyvernov2alpha1listers.CELPolicyExceptionLister
+}
+
+func newMutatingPolicyReconciler(
+	compiler policy.Compiler,
+	client client.Client,
+	polexLister kyvernov2alpha1listers.CELPolicyExceptionLister,
+) *mutatingPolicyReconciler {
+	return &mutatingPolicyReconciler{
+		client:      client,
+		compiler:    compiler,
+		lock:        &sync.RWMutex{},
+		policies:    map[string]CompiledMutatingPolicy{},
+		polexLister: polexLister,
+	}
+}
+
+func (r *mutatingPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var policy kyvernov2alpha1.MutatingPolicy
+	err := r.client.Get(ctx, req.NamespacedName, &policy)
+	if errors.IsNotFound(err) {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		delete(r.policies, req.NamespacedName.String())
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// get exceptions that match the policy
+	exceptions, err := r.ListExceptions(policy.GetName())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	compiled, errs := r.compiler.CompileMutating(&policy, exceptions)
+	if len(errs) > 0 {
+		// drop the previously compiled version, no need to retry it
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		delete(r.policies, req.NamespacedName.String())
+		return ctrl.Result{}, nil
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.policies[req.NamespacedName.String()] = CompiledMutatingPolicy{
+		Policy:         policy,
+		CompiledPolicy: compiled,
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *mutatingPolicyReconciler) CompiledMutatingPolicies(ctx context.Context) ([]CompiledMutatingPolicy, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return maps.Values(r.policies), nil
+}
+
+func (r *mutatingPolicyReconciler) ListExceptions(policyName string) ([]kyvernov2alpha1.CELPolicyException, error) {
+	polexList, err := r.polexLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var exceptions []kyvernov2alpha1.CELPolicyException
+	for _, polex := range polexList {
+		for _, ref := range polex.Spec.PolicyRefs {
+			if ref.Name == policyName && ref.Kind == "MutatingPolicy" {
+				exceptions = append(exceptions, *polex)
+			}
+		}
+	}
+	return exceptions, nil
+}
@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/cel/policy"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func Test_mutatingEngine_Handle(t *testing.T) {
+	mutatingPolicy := func(name string, expression string) kyvernov2alpha1.MutatingPolicy {
+		return kyvernov2alpha1.MutatingPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: kyvernov2alpha1.MutatingPolicySpec{
+				Mutations: []admissionregistrationv1alpha1.Mutation{{
+					PatchType: admissionregistrationv1alpha1.PatchTypeApplyConfiguration,
+					ApplyConfiguration: &admissionregistrationv1alpha1.ApplyConfiguration{
+						Expression: expression,
+					},
+				}},
+			},
+		}
+	}
+	provider, err := NewMutatingProvider(
+		policy.NewCompiler(),
+		mutatingPolicy("add-label", "Object{metadata: Object.metadata{labels: {'foo': 'bar'}}}"),
+		// the label is already set by the previous policy, nothing changes
+		mutatingPolicy("keep-label", "Object{metadata: Object.metadata{labels: {'foo': 'bar'}}}"),
+	)
+	assert.NoError(t, err)
+	nsResolver := func(name string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	engine := NewMutatingEngine(provider, nsResolver, nil, nil)
+	request := RequestFromAdmission(nil, admissionv1.AdmissionRequest{
+		Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+		Resource:  metav1.GroupVersionResource{Version: "v1", Resource: "configmaps"},
+		Name:      "test",
+		Namespace: "default",
+		Operation: admissionv1.Create,
+		Object: runtime.RawExtension{
+			Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"test","namespace":"default"}}`),
+		},
+	})
+	response, err := engine.Handle(context.TODO(), request, nil)
+	assert.NoError(t, err)
+	assert.Len(t, response.Policies, 2)
+	assert.Equal(t, "add-label", response.Policies[0].Policy.Name)
+	assert.Len(t, response.Policies[0].Rules, 1)
+	assert.Equal(t, engineapi.RuleStatusPass, response.Policies[0].Rules[0].Status())
+	assert.Equal(t, "keep-label", response.Policies[1].Policy.Name)
+	assert.Len(t, response.Policies[1].Rules, 1)
+	assert.Equal(t, engineapi.RuleStatusSkip, response.Policies[1].Rules[0].Status())
+	assert.Equal(t, map[string]string{"foo": "bar"}, response.PatchedResource.GetLabels())
+	// the predicate filters the evaluated policies
+	response, err = engine.Handle(context.TODO(), request, func(policy kyvernov2alpha1.MutatingPolicy) bool {
+		return policy.Name == "keep-label"
+	})
+	assert.NoError(t, err)
+	assert.Len(t, response.Policies, 1)
+	assert.Equal(t, "keep-label", response.Policies[0].Policy.Name)
+	assert.Equal(t, engineapi.RuleStatusPass, response.Policies[0].Rules[0].Status())
+}
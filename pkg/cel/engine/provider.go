@@ -13,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -37,10 +38,18 @@ func (f ProviderFunc) CompiledPolicies(ctx context.Context) ([]CompiledPolicy, e
 	return f(ctx)
 }
 
-func NewProvider(compiler policy.Compiler, policies ...kyvernov2alpha1.ValidatingPolicy) (ProviderFunc, error) {
+func NewProvider(compiler policy.Compiler, policies []kyvernov2alpha1.ValidatingPolicy, exceptions []*kyvernov2alpha1.CELPolicyException) (ProviderFunc, error) {
 	compiled := make([]CompiledPolicy, 0, len(policies))
 	for _, vp := range policies {
-		policy, err := compiler.Compile(&vp, nil)
+		var matchedExceptions []kyvernov2alpha1.CELPolicyException
+		for _, polex := range exceptions {
+			for _, ref := range polex.Spec.PolicyRefs {
+				if ref.Name == vp.GetName() {
+					matchedExceptions = append(matchedExceptions, *polex)
+				}
+			}
+		}
+		policy, err := compiler.Compile(&vp, matchedExceptions)
 		if err != nil {
 			return nil, fmt.Errorf("failed to compile policy %s (%w)", vp.GetName(), err.ToAggregate())
 		}
@@ -60,58 +69,24 @@ func NewProvider(compiler policy.Compiler, policies ...kyvernov2alpha1.Validatin
 	return provider, nil
 }
 
+// KubeProvider is a provider backed by the cluster, compiled policies are cached and recompiled when policies or
+// their exceptions change
+type KubeProvider interface {
+	Provider
+	// CompiledPolicy returns the compiled version of the given policy from the cache, the policy is compiled
+	// on demand if the cache doesn't hold this version of the policy yet
+	CompiledPolicy(context.Context, *kyvernov2alpha1.ValidatingPolicy) (CompiledPolicy, error)
+}
+
 func NewKubeProvider(
 	compiler policy.Compiler,
 	mgr ctrl.Manager,
 	polexLister kyvernov2alpha1listers.CELPolicyExceptionLister,
-) (Provider, error) {
+) (KubeProvider, error) {
 	r := newPolicyReconciler(compiler, mgr.GetClient(), polexLister)
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&kyvernov2alpha1.ValidatingPolicy{}).
-		Watches(&kyvernov2alpha1.CELPolicyException{}, &handler.Funcs{
-			CreateFunc: func(
-				ctx context.Context,
-				tce event.TypedCreateEvent[client.Object],
-				trli workqueue.TypedRateLimitingInterface[reconcile.Request],
-			) {
-				polex := tce.Object.(*kyvernov2alpha1.CELPolicyException)
-				for _, ref := range polex.Spec.PolicyRefs {
-					trli.Add(reconcile.Request{
-						NamespacedName: client.ObjectKey{
-							Name: ref.Name,
-						},
-					})
-				}
-			},
-			UpdateFunc: func(
-				ctx context.Context,
-				tue event.TypedUpdateEvent[client.Object],
-				trli workqueue.TypedRateLimitingInterface[reconcile.Request],
-			) {
-				polex := tue.ObjectNew.(*kyvernov2alpha1.CELPolicyException)
-				for _, ref := range polex.Spec.PolicyRefs {
-					trli.Add(reconcile.Request{
-						NamespacedName: client.ObjectKey{
-							Name: ref.Name,
-						},
-					})
-				}
-			},
-			DeleteFunc: func(
-				ctx context.Context,
-				tde event.TypedDeleteEvent[client.Object],
-				trli workqueue.TypedRateLimitingInterface[reconcile.Request],
-			) {
-				polex := tde.Object.(*kyvernov2alpha1.CELPolicyException)
-				for _, ref := range polex.Spec.PolicyRefs {
-					trli.Add(reconcile.Request{
-						NamespacedName: client.ObjectKey{
-							Name: ref.Name,
-						},
-					})
-				}
-			},
-		}).
+		Watches(&kyvernov2alpha1.CELPolicyException{}, exceptionHandler()).
 		Complete(r)
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct manager: %w", err)
@@ -158,7 +133,7 @@ func (r *policyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	compiled, errs := r.compiler.Compile(&policy, exceptions)
+	compiled, errs := r.compile(&policy, exceptions)
 	if len(errs) > 0 {
 		fmt.Println(errs)
 		// No need to retry it
@@ -166,16 +141,24 @@ func (r *policyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	}
 	r.lock.Lock()
 	defer r.lock.Unlock()
+	r.policies[req.NamespacedName.String()] = compiled
+	return ctrl.Result{}, nil
+}
+
+func (r *policyReconciler) compile(policy *kyvernov2alpha1.ValidatingPolicy, exceptions []kyvernov2alpha1.CELPolicyException) (CompiledPolicy, field.ErrorList) {
+	compiled, errs := r.compiler.Compile(policy, exceptions)
+	if len(errs) > 0 {
+		return CompiledPolicy{}, errs
+	}
 	actions := sets.New(policy.Spec.ValidationAction...)
 	if len(actions) == 0 {
 		actions.Insert(admissionregistrationv1.Deny)
 	}
-	r.policies[req.NamespacedName.String()] = CompiledPolicy{
+	return CompiledPolicy{
 		Actions:        actions,
-		Policy:         policy,
+		Policy:         *policy,
 		CompiledPolicy: compiled,
-	}
-	return ctrl.Result{}, nil
+	}, nil
 }
 
 func (r *policyReconciler) CompiledPolicies(ctx context.Context) ([]CompiledPolicy, error) {
@@ -184,6 +167,25 @@ func (r *policyReconciler) CompiledPolicies(ctx context.Context) ([]CompiledPoli
 	return maps.Values(r.policies), nil
 }
 
+func (r *policyReconciler) CompiledPolicy(ctx context.Context, policy *kyvernov2alpha1.ValidatingPolicy) (CompiledPolicy, error) {
+	r.lock.RLock()
+	compiled, ok := r.policies[client.ObjectKeyFromObject(policy).String()]
+	r.lock.RUnlock()
+	if ok && compiled.Policy.ResourceVersion == policy.ResourceVersion {
+		return compiled, nil
+	}
+	// the reconciler didn't catch up with this version of the policy yet
+	exceptions, err := r.ListExceptions(policy.GetName())
+	if err != nil {
+		return CompiledPolicy{}, err
+	}
+	compiled, errs := r.compile(policy, exceptions)
+	if len(errs) > 0 {
+		return CompiledPolicy{}, fmt.Errorf("failed to compile policy %s (%w)", policy.GetName(), errs.ToAggregate())
+	}
+	return compiled, nil
+}
+
 func (r *policyReconciler) ListExceptions(policyName string) ([]kyvernov2alpha1.CELPolicyException, error) {
 	polexList, err := r.polexLister.List(labels.Everything())
 	if err != nil {
@@ -199,3 +201,51 @@ func (r *policyReconciler) ListExceptions(policyName string) ([]kyvernov2alpha1.
 	}
 	return exceptions, nil
 }
+
+// exceptionHandler enqueues the policies referenced by an exception
+func exceptionHandler() handler.EventHandler {
+	return &handler.Funcs{
+		CreateFunc: func(
+			ctx context.Context,
+			tce event.TypedCreateEvent[client.Object],
+			trli workqueue.TypedRateLimitingInterface[reconcile.Request],
+		) {
+			polex := tce.Object.(*kyvernov2alpha1.CELPolicyException)
+			for _, ref := range polex.Spec.PolicyRefs {
+				trli.Add(reconcile.Request{
+					NamespacedName: client.ObjectKey{
+						Name: ref.Name,
+					},
+				})
+			}
+		},
+		UpdateFunc: func(
+			ctx context.Context,
+			tue event.TypedUpdateEvent[client.Object],
+			trli workqueue.TypedRateLimitingInterface[reconcile.Request],
+		) {
+			polex := tue.ObjectNew.(*kyvernov2alpha1.CELPolicyException)
+			for _, ref := range polex.Spec.PolicyRefs {
+				trli.Add(reconcile.Request{
+					NamespacedName: client.ObjectKey{
+						Name: ref.Name,
+					},
+				})
+			}
+		},
+		DeleteFunc: func(
+			ctx context.Context,
+			tde event.TypedDeleteEvent[client.Object],
+			trli workqueue.TypedRateLimitingInterface[reconcile.Request],
+		) {
+			polex := tde.Object.(*kyvernov2alpha1.CELPolicyException)
+			for _, ref := range polex.Spec.PolicyRefs {
+				trli.Add(reconcile.Request{
+					NamespacedName: client.ObjectKey{
+						Name: ref.Name,
+					},
+				})
+			}
+		},
+	}
+}
@@ -1,9 +1,12 @@
 package context
 
 import (
+	"fmt"
+
 	"github.com/google/cel-go/common/types"
 	"github.com/google/cel-go/common/types/ref"
 	"github.com/kyverno/kyverno/pkg/cel/utils"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 type impl struct {
@@ -33,26 +36,139 @@ func (c *impl) get_globalreference_string(ctx ref.Val, name ref.Val) ref.Val {
 	} else if name, err := utils.ConvertToNative[string](name); err != nil {
 		return types.WrapErr(err)
 	} else {
-		globalRef, err := self.GetGlobalReference(name)
+		return c.get_globalreference(self, name, "")
+	}
+}
+
+func (c *impl) get_globalreference_string_string(args ...ref.Val) ref.Val {
+	if self, err := utils.ConvertToNative[Context](args[0]); err != nil {
+		return types.WrapErr(err)
+	} else if name, err := utils.ConvertToNative[string](args[1]); err != nil {
+		return types.WrapErr(err)
+	} else if projection, err := utils.ConvertToNative[string](args[2]); err != nil {
+		return types.WrapErr(err)
+	} else {
+		return c.get_globalreference(self, name, projection)
+	}
+}
+
+func (c *impl) get_globalreference(self Context, name string, projection string) ref.Val {
+	globalRef, err := self.GetGlobalReference(name, projection)
+	if err != nil {
+		// wrap the error so that callers can check if the entry is not ready yet
+		return types.WrapErr(fmt.Errorf("failed to get global reference: %w", err))
+	}
+	return c.NativeToValue(globalRef)
+}
+
+func (c *impl) get_globalreference_cel_string_string(args ...ref.Val) ref.Val {
+	if self, err := utils.ConvertToNative[Context](args[0]); err != nil {
+		return types.WrapErr(err)
+	} else if name, err := utils.ConvertToNative[string](args[1]); err != nil {
+		return types.WrapErr(err)
+	} else if expression, err := utils.ConvertToNative[string](args[2]); err != nil {
+		return types.WrapErr(err)
+	} else {
+		globalRef, err := self.GetGlobalReferenceCEL(name, expression)
 		if err != nil {
-			// Errors are not expected here since Parse is a more lenient parser than ParseRequestURI.
-			return types.NewErr("failed to get global reference: %v", err)
+			// wrap the error so that callers can check if the entry is not ready yet
+			return types.WrapErr(fmt.Errorf("failed to get global reference: %w", err))
 		}
 		return c.NativeToValue(globalRef)
 	}
 }
 
+func (c *impl) get_globalprojection_string_string(args ...ref.Val) ref.Val {
+	if self, err := utils.ConvertToNative[Context](args[0]); err != nil {
+		return types.WrapErr(err)
+	} else if name, err := utils.ConvertToNative[string](args[1]); err != nil {
+		return types.WrapErr(err)
+	} else if projection, err := utils.ConvertToNative[string](args[2]); err != nil {
+		return types.WrapErr(err)
+	} else {
+		data, err := self.GetGlobalProjection(name, projection)
+		if err != nil {
+			// wrap the error so that callers can check if the entry is not ready yet
+			return types.WrapErr(fmt.Errorf("failed to get global projection: %w", err))
+		}
+		return c.NativeToValue(data)
+	}
+}
+
 func (c *impl) get_imagedata_string(ctx ref.Val, image ref.Val) ref.Val {
 	if self, err := utils.ConvertToNative[Context](ctx); err != nil {
 		return types.WrapErr(err)
 	} else if image, err := utils.ConvertToNative[string](image); err != nil {
 		return types.WrapErr(err)
 	} else {
-		globalRef, err := self.GetImageData(image)
+		imageData, err := self.GetImageData(image)
 		if err != nil {
 			// Errors are not expected here since Parse is a more lenient parser than ParseRequestURI.
 			return types.NewErr("failed to get image data: %v", err)
 		}
-		return c.NativeToValue(globalRef)
+		// convert to a map so that fields are exposed with their json names
+		data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(imageData)
+		if err != nil {
+			return types.WrapErr(err)
+		}
+		return c.NativeToValue(data)
+	}
+}
+
+func (c *impl) get_resource_string_string_string_string(args ...ref.Val) ref.Val {
+	if self, err := utils.ConvertToNative[Context](args[0]); err != nil {
+		return types.WrapErr(err)
+	} else if apiVersion, err := utils.ConvertToNative[string](args[1]); err != nil {
+		return types.WrapErr(err)
+	} else if resource, err := utils.ConvertToNative[string](args[2]); err != nil {
+		return types.WrapErr(err)
+	} else if namespace, err := utils.ConvertToNative[string](args[3]); err != nil {
+		return types.WrapErr(err)
+	} else if name, err := utils.ConvertToNative[string](args[4]); err != nil {
+		return types.WrapErr(err)
+	} else {
+		res, err := self.GetResource(apiVersion, resource, namespace, name)
+		if err != nil {
+			return types.WrapErr(fmt.Errorf("failed to get resource: %w", err))
+		}
+		return c.NativeToValue(res.UnstructuredContent())
+	}
+}
+
+func (c *impl) list_resources_string_string_string(args ...ref.Val) ref.Val {
+	if self, err := utils.ConvertToNative[Context](args[0]); err != nil {
+		return types.WrapErr(err)
+	} else if apiVersion, err := utils.ConvertToNative[string](args[1]); err != nil {
+		return types.WrapErr(err)
+	} else if resource, err := utils.ConvertToNative[string](args[2]); err != nil {
+		return types.WrapErr(err)
+	} else if namespace, err := utils.ConvertToNative[string](args[3]); err != nil {
+		return types.WrapErr(err)
+	} else {
+		return c.list_resources(self, apiVersion, resource, namespace, "")
+	}
+}
+
+func (c *impl) list_resources_string_string_string_string(args ...ref.Val) ref.Val {
+	if self, err := utils.ConvertToNative[Context](args[0]); err != nil {
+		return types.WrapErr(err)
+	} else if apiVersion, err := utils.ConvertToNative[string](args[1]); err != nil {
+		return types.WrapErr(err)
+	} else if resource, err := utils.ConvertToNative[string](args[2]); err != nil {
+		return types.WrapErr(err)
+	} else if namespace, err := utils.ConvertToNative[string](args[3]); err != nil {
+		return types.WrapErr(err)
+	} else if labelSelector, err := utils.ConvertToNative[string](args[4]); err != nil {
+		return types.WrapErr(err)
+	} else {
+		return c.list_resources(self, apiVersion, resource, namespace, labelSelector)
+	}
+}
+
+func (c *impl) list_resources(self Context, apiVersion, resource, namespace, labelSelector string) ref.Val {
+	list, err := self.ListResources(apiVersion, resource, namespace, labelSelector)
+	if err != nil {
+		return types.WrapErr(fmt.Errorf("failed to list resources: %w", err))
 	}
+	return c.NativeToValue(list.UnstructuredContent())
 }
@@ -2,33 +2,56 @@ package context
 
 import (
 	"context"
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/google/cel-go/cel"
+	"github.com/kyverno/kyverno/pkg/globalcontext/store"
 	"github.com/kyverno/kyverno/pkg/imagedataloader"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 type ctx struct {
-	GetConfigMapFunc       func(string, string) (unstructured.Unstructured, error)
-	GetGlobalReferenceFunc func(string) (any, error)
-	GetImageDataFunc       func(string) (*imagedataloader.ImageData, error)
+	GetConfigMapFunc          func(string, string) (unstructured.Unstructured, error)
+	GetGlobalReferenceFunc    func(string, string) (any, error)
+	GetGlobalReferenceCELFunc func(string, string) (any, error)
+	GetGlobalProjectionFunc   func(string, string) (any, error)
+	GetImageDataFunc          func(string) (*imagedataloader.ImageData, error)
+	GetResourceFunc           func(string, string, string, string) (*unstructured.Unstructured, error)
+	ListResourcesFunc         func(string, string, string, string) (*unstructured.UnstructuredList, error)
 }
 
 func (mock *ctx) GetConfigMap(ns string, n string) (unstructured.Unstructured, error) {
 	return mock.GetConfigMapFunc(ns, n)
 }
 
-func (mock *ctx) GetGlobalReference(n string) (any, error) {
-	return mock.GetGlobalReferenceFunc(n)
+func (mock *ctx) GetGlobalReference(n string, p string) (any, error) {
+	return mock.GetGlobalReferenceFunc(n, p)
+}
+
+func (mock *ctx) GetGlobalReferenceCEL(n string, e string) (any, error) {
+	return mock.GetGlobalReferenceCELFunc(n, e)
+}
+
+func (mock *ctx) GetGlobalProjection(n string, p string) (any, error) {
+	return mock.GetGlobalProjectionFunc(n, p)
 }
 
 func (mock *ctx) GetImageData(n string) (*imagedataloader.ImageData, error) {
 	return mock.GetImageDataFunc(n)
 }
 
+func (mock *ctx) GetResource(apiVersion, resource, namespace, name string) (*unstructured.Unstructured, error) {
+	return mock.GetResourceFunc(apiVersion, resource, namespace, name)
+}
+
+func (mock *ctx) ListResources(apiVersion, resource, namespace, labelSelector string) (*unstructured.UnstructuredList, error) {
+	return mock.ListResourcesFunc(apiVersion, resource, namespace, labelSelector)
+}
+
 func Test_impl_get_configmap_string_string(t *testing.T) {
 	opts := Lib()
 	base, err := cel.NewEnv(opts)
@@ -81,7 +104,7 @@ func Test_impl_get_globalreference_string(t *testing.T) {
 	called := false
 	data := map[string]any{
 		"context": Context{&ctx{
-			GetGlobalReferenceFunc: func(string) (any, error) {
+			GetGlobalReferenceFunc: func(string, string) (any, error) {
 				type foo struct {
 					s string
 				}
@@ -96,6 +119,130 @@ func Test_impl_get_globalreference_string(t *testing.T) {
 	assert.True(t, called)
 }
 
+func Test_impl_get_globalreference_string_string(t *testing.T) {
+	opts := Lib()
+	base, err := cel.NewEnv(opts)
+	assert.NoError(t, err)
+	assert.NotNil(t, base)
+	options := []cel.EnvOption{
+		cel.Variable("context", ContextType),
+	}
+	env, err := base.Extend(options...)
+	assert.NoError(t, err)
+	assert.NotNil(t, env)
+	ast, issues := env.Compile(`context.GetGlobalReference("foo", "bar")`)
+	assert.Nil(t, issues)
+	assert.NotNil(t, ast)
+	prog, err := env.Program(ast)
+	assert.NoError(t, err)
+	assert.NotNil(t, prog)
+	data := map[string]any{
+		"context": Context{&ctx{
+			GetGlobalReferenceFunc: func(name string, projection string) (any, error) {
+				assert.Equal(t, "foo", name)
+				assert.Equal(t, "bar", projection)
+				return "baz", nil
+			},
+		}},
+	}
+	out, _, err := prog.Eval(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "baz", out.Value())
+}
+
+func Test_impl_get_globalreference_not_ready(t *testing.T) {
+	opts := Lib()
+	base, err := cel.NewEnv(opts)
+	assert.NoError(t, err)
+	assert.NotNil(t, base)
+	options := []cel.EnvOption{
+		cel.Variable("context", ContextType),
+	}
+	env, err := base.Extend(options...)
+	assert.NoError(t, err)
+	assert.NotNil(t, env)
+	ast, issues := env.Compile(`context.GetGlobalReference("foo")`)
+	assert.Nil(t, issues)
+	assert.NotNil(t, ast)
+	prog, err := env.Program(ast)
+	assert.NoError(t, err)
+	assert.NotNil(t, prog)
+	data := map[string]any{
+		"context": Context{&ctx{
+			GetGlobalReferenceFunc: func(string, string) (any, error) {
+				return nil, store.ErrEntryNotReady
+			},
+		}},
+	}
+	_, _, err = prog.Eval(data)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, store.ErrEntryNotReady))
+}
+
+func Test_impl_get_globalreference_cel_string_string(t *testing.T) {
+	opts := Lib()
+	base, err := cel.NewEnv(opts)
+	assert.NoError(t, err)
+	assert.NotNil(t, base)
+	options := []cel.EnvOption{
+		cel.Variable("context", ContextType),
+	}
+	env, err := base.Extend(options...)
+	assert.NoError(t, err)
+	assert.NotNil(t, env)
+	ast, issues := env.Compile(`context.GetGlobalReferenceCEL("foo", "data.bar")`)
+	assert.Nil(t, issues)
+	assert.NotNil(t, ast)
+	prog, err := env.Program(ast)
+	assert.NoError(t, err)
+	assert.NotNil(t, prog)
+	data := map[string]any{
+		"context": Context{&ctx{
+			GetGlobalReferenceCELFunc: func(name string, expression string) (any, error) {
+				assert.Equal(t, "foo", name)
+				assert.Equal(t, "data.bar", expression)
+				return "baz", nil
+			},
+		}},
+	}
+	out, _, err := prog.Eval(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "baz", out.Value())
+}
+
+func Test_impl_get_globalprojection_string_string(t *testing.T) {
+	opts := Lib()
+	base, err := cel.NewEnv(opts)
+	assert.NoError(t, err)
+	assert.NotNil(t, base)
+	options := []cel.EnvOption{
+		cel.Variable("context", ContextType),
+	}
+	env, err := base.Extend(options...)
+	assert.NoError(t, err)
+	assert.NotNil(t, env)
+	ast, issues := env.Compile(`context.GetGlobalProjection("foo", "bar")`)
+	assert.Nil(t, issues)
+	assert.NotNil(t, ast)
+	prog, err := env.Program(ast)
+	assert.NoError(t, err)
+	assert.NotNil(t, prog)
+	data := map[string]any{
+		"context": Context{&ctx{
+			GetGlobalProjectionFunc: func(name string, projection string) (any, error) {
+				assert.Equal(t, "foo", name)
+				assert.Equal(t, "bar", projection)
+				return []any{"baz"}, nil
+			},
+		}},
+	}
+	out, _, err := prog.Eval(data)
+	assert.NoError(t, err)
+	value, err := out.ConvertToNative(reflect.TypeFor[[]string]())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"baz"}, value)
+}
+
 func Test_impl_get_imagedata_string(t *testing.T) {
 	opts := Lib()
 	base, err := cel.NewEnv(opts)
@@ -124,7 +271,123 @@ func Test_impl_get_imagedata_string(t *testing.T) {
 	}
 	out, _, err := prog.Eval(data)
 	assert.NoError(t, err)
-	img := out.Value().(*imagedataloader.ImageData)
-	assert.Equal(t, img.Tag, "latest")
-	assert.True(t, strings.HasPrefix(img.ResolvedImage, "ghcr.io/kyverno/kyverno:latest@sha256:"))
+	img := out.Value().(map[string]any)
+	assert.Equal(t, img["tag"], "latest")
+	assert.True(t, strings.HasPrefix(img["resolvedImage"].(string), "ghcr.io/kyverno/kyverno:latest@sha256:"))
+}
+
+func Test_impl_get_resource_string_string_string_string(t *testing.T) {
+	opts := Lib()
+	base, err := cel.NewEnv(opts)
+	assert.NoError(t, err)
+	assert.NotNil(t, base)
+	options := []cel.EnvOption{
+		cel.Variable("context", ContextType),
+	}
+	env, err := base.Extend(options...)
+	assert.NoError(t, err)
+	assert.NotNil(t, env)
+	ast, issues := env.Compile(`context.GetResource("apps/v1", "deployments", "default", "nginx").spec.replicas`)
+	assert.Nil(t, issues)
+	assert.NotNil(t, ast)
+	prog, err := env.Program(ast)
+	assert.NoError(t, err)
+	assert.NotNil(t, prog)
+	data := map[string]any{
+		"context": Context{&ctx{
+			GetResourceFunc: func(apiVersion, resource, namespace, name string) (*unstructured.Unstructured, error) {
+				assert.Equal(t, "apps/v1", apiVersion)
+				assert.Equal(t, "deployments", resource)
+				assert.Equal(t, "default", namespace)
+				assert.Equal(t, "nginx", name)
+				return &unstructured.Unstructured{
+					Object: map[string]any{
+						"spec": map[string]any{
+							"replicas": int64(3),
+						},
+					},
+				}, nil
+			},
+		}},
+	}
+	out, _, err := prog.Eval(data)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), out.Value())
+}
+
+func Test_impl_list_resources(t *testing.T) {
+	opts := Lib()
+	base, err := cel.NewEnv(opts)
+	assert.NoError(t, err)
+	assert.NotNil(t, base)
+	options := []cel.EnvOption{
+		cel.Variable("context", ContextType),
+	}
+	env, err := base.Extend(options...)
+	assert.NoError(t, err)
+	assert.NotNil(t, env)
+	tests := []struct {
+		name          string
+		expression    string
+		labelSelector string
+	}{{
+		name:       "without selector",
+		expression: `size(context.ListResources("v1", "pods", "default").items)`,
+	}, {
+		name:          "with selector",
+		expression:    `size(context.ListResources("v1", "pods", "default", "app=nginx").items)`,
+		labelSelector: "app=nginx",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ast, issues := env.Compile(tt.expression)
+			assert.Nil(t, issues)
+			assert.NotNil(t, ast)
+			prog, err := env.Program(ast)
+			assert.NoError(t, err)
+			assert.NotNil(t, prog)
+			data := map[string]any{
+				"context": Context{&ctx{
+					ListResourcesFunc: func(apiVersion, resource, namespace, labelSelector string) (*unstructured.UnstructuredList, error) {
+						assert.Equal(t, tt.labelSelector, labelSelector)
+						return &unstructured.UnstructuredList{
+							Object: map[string]any{},
+							Items:  []unstructured.Unstructured{{Object: map[string]any{}}, {Object: map[string]any{}}},
+						}, nil
+					},
+				}},
+			}
+			out, _, err := prog.Eval(data)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(2), out.Value())
+		})
+	}
+}
+
+func Test_impl_get_resource_error(t *testing.T) {
+	opts := Lib()
+	base, err := cel.NewEnv(opts)
+	assert.NoError(t, err)
+	assert.NotNil(t, base)
+	options := []cel.EnvOption{
+		cel.Variable("context", ContextType),
+	}
+	env, err := base.Extend(options...)
+	assert.NoError(t, err)
+	assert.NotNil(t, env)
+	ast, issues := env.Compile(`context.GetResource("v1", "secrets", "default", "foo")`)
+	assert.Nil(t, issues)
+	assert.NotNil(t, ast)
+	prog, err := env.Program(ast)
+	assert.NoError(t, err)
+	assert.NotNil(t, prog)
+	data := map[string]any{
+		"context": Context{&ctx{
+			GetResourceFunc: func(string, string, string, string) (*unstructured.Unstructured, error) {
+				return nil, errors.New("not allowed")
+			},
+		}},
+	}
+	_, _, err = prog.Eval(data)
+	assert.Error(t, err)
 }
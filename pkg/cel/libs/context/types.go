@@ -15,8 +15,12 @@ var (
 
 type ContextInterface interface {
 	GetConfigMap(string, string) (unstructured.Unstructured, error)
-	GetGlobalReference(string) (any, error)
+	GetGlobalReference(string, string) (any, error)
+	GetGlobalReferenceCEL(string, string) (any, error)
+	GetGlobalProjection(string, string) (any, error)
 	GetImageData(string) (*imagedataloader.ImageData, error)
+	GetResource(string, string, string, string) (*unstructured.Unstructured, error)
+	ListResources(string, string, string, string) (*unstructured.UnstructuredList, error)
 }
 
 type Context struct {
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/apicall"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// requestTimeout bounds the duration of a call, evaluation must not hang on a slow service
+const requestTimeout = 10 * time.Second
+
+type client struct {
+	ctx      context.Context
+	executor apicall.Executor
+	caBundle string
+	headers  map[string]string
+}
+
+// NewHTTP returns an implementation performing calls through the given apicall executor,
+// response size limits and tracing are the ones configured in the executor.
+func NewHTTP(executor apicall.Executor) HTTPInterface {
+	return &client{
+		executor: executor,
+	}
+}
+
+// WithContext returns a client performing calls within the given context,
+// calls are cancelled with the admission request or the background scan they are made for.
+func (c *client) WithContext(ctx context.Context) HTTPInterface {
+	bound := *c
+	bound.ctx = ctx
+	return &bound
+}
+
+func (c *client) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+func (c *client) Get(url string) (any, error) {
+	return c.execute("GET", url, nil)
+}
+
+func (c *client) Post(url string, body map[string]any) (any, error) {
+	keys := make([]string, 0, len(body))
+	for key := range body {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	data := make([]kyvernov1.RequestData, 0, len(keys))
+	for _, key := range keys {
+		raw, err := json.Marshal(body[key])
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, kyvernov1.RequestData{Key: key, Value: &apiextv1.JSON{Raw: raw}})
+	}
+	return c.execute("POST", url, data)
+}
+
+func (c *client) Client(caBundle string, headers map[string]string) (HTTPInterface, error) {
+	if caBundle != "" {
+		if ok := x509.NewCertPool().AppendCertsFromPEM([]byte(caBundle)); !ok {
+			return nil, errors.New("failed to parse PEM CA bundle")
+		}
+	}
+	return &client{
+		ctx:      c.ctx,
+		executor: c.executor,
+		caBundle: caBundle,
+		headers:  headers,
+	}, nil
+}
+
+func (c *client) execute(method kyvernov1.Method, url string, data []kyvernov1.RequestData) (any, error) {
+	keys := make([]string, 0, len(c.headers))
+	for key := range c.headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	headers := make([]kyvernov1.HTTPHeader, 0, len(keys))
+	for _, key := range keys {
+		headers = append(headers, kyvernov1.HTTPHeader{Key: key, Value: c.headers[key]})
+	}
+	ctx, cancel := context.WithTimeout(c.context(), requestTimeout)
+	defer cancel()
+	raw, err := c.executor.Execute(ctx, &kyvernov1.APICall{
+		Method: method,
+		Data:   data,
+		Service: &kyvernov1.ServiceCall{
+			URL:      url,
+			Headers:  headers,
+			CABundle: c.caBundle,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		// not a json response, return the raw body
+		return string(raw), nil
+	}
+	return out, nil
+}
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/kyverno/kyverno/pkg/cel/utils"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var errNotConfigured = errors.New("http calls are not configured")
+
+type impl struct {
+	types.Adapter
+}
+
+func (c *impl) get_string(http ref.Val, url ref.Val) ref.Val {
+	if self, err := utils.ConvertToNative[HTTP](http); err != nil {
+		return types.WrapErr(err)
+	} else if self.HTTPInterface == nil {
+		return types.WrapErr(errNotConfigured)
+	} else if url, err := utils.ConvertToNative[string](url); err != nil {
+		return types.WrapErr(err)
+	} else {
+		data, err := self.Get(url)
+		if err != nil {
+			return types.WrapErr(fmt.Errorf("failed to execute GET request: %w", err))
+		}
+		return c.NativeToValue(data)
+	}
+}
+
+func (c *impl) post_string_dyn(args ...ref.Val) ref.Val {
+	if self, err := utils.ConvertToNative[HTTP](args[0]); err != nil {
+		return types.WrapErr(err)
+	} else if self.HTTPInterface == nil {
+		return types.WrapErr(errNotConfigured)
+	} else if url, err := utils.ConvertToNative[string](args[1]); err != nil {
+		return types.WrapErr(err)
+	} else if body, err := convert[map[string]any](args[2]); err != nil {
+		return types.WrapErr(err)
+	} else {
+		data, err := self.Post(url, body)
+		if err != nil {
+			return types.WrapErr(fmt.Errorf("failed to execute POST request: %w", err))
+		}
+		return c.NativeToValue(data)
+	}
+}
+
+func (c *impl) client_string_map(args ...ref.Val) ref.Val {
+	if self, err := utils.ConvertToNative[HTTP](args[0]); err != nil {
+		return types.WrapErr(err)
+	} else if self.HTTPInterface == nil {
+		return types.WrapErr(errNotConfigured)
+	} else if caBundle, err := utils.ConvertToNative[string](args[1]); err != nil {
+		return types.WrapErr(err)
+	} else if headers, err := utils.ConvertToNative[map[string]string](args[2]); err != nil {
+		return types.WrapErr(err)
+	} else {
+		client, err := self.Client(caBundle, headers)
+		if err != nil {
+			return types.WrapErr(fmt.Errorf("failed to create http client: %w", err))
+		}
+		return c.NativeToValue(HTTP{HTTPInterface: client})
+	}
+}
+
+// convert turns a cel value into the equivalent go type using its json representation
+func convert[T any](value ref.Val) (T, error) {
+	var out T
+	native, err := utils.ConvertToNative[*structpb.Value](value)
+	if err != nil {
+		return out, err
+	}
+	data, err := protojson.Marshal(native)
+	if err != nil {
+		return out, err
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return out, err
+	}
+	return out, nil
+}
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"reflect"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/ext"
+)
+
+const libraryName = "kyverno.http"
+
+type lib struct{}
+
+func Lib() cel.EnvOption {
+	// create the cel lib env option
+	return cel.Lib(&lib{})
+}
+
+func (*lib) LibraryName() string {
+	return libraryName
+}
+
+func (c *lib) CompileOptions() []cel.EnvOption {
+	return []cel.EnvOption{
+		ext.NativeTypes(reflect.TypeFor[HTTP]()),
+		c.extendEnv,
+	}
+}
+
+func (*lib) ProgramOptions() []cel.ProgramOption {
+	return []cel.ProgramOption{}
+}
+
+func (c *lib) extendEnv(env *cel.Env) (*cel.Env, error) {
+	// create implementation, recording the envoy types aware adapter
+	impl := impl{
+		Adapter: env.CELTypeAdapter(),
+	}
+	// build our function overloads
+	libraryDecls := map[string][]cel.FunctionOpt{
+		"Get": {
+			cel.MemberOverload("http_get_string", []*cel.Type{HTTPType, types.StringType}, types.DynType, cel.BinaryBinding(impl.get_string)),
+		},
+		"Post": {
+			cel.MemberOverload("http_post_string_dyn", []*cel.Type{HTTPType, types.StringType, types.DynType}, types.DynType, cel.FunctionBinding(impl.post_string_dyn)),
+		},
+		"Client": {
+			cel.MemberOverload("http_client_string_map", []*cel.Type{HTTPType, types.StringType, types.NewMapType(types.StringType, types.StringType)}, HTTPType, cel.FunctionBinding(impl.client_string_map)),
+		},
+	}
+	// create env options corresponding to our function overloads
+	options := []cel.EnvOption{}
+	for name, overloads := range libraryDecls {
+		options = append(options, cel.Function(name, overloads...))
+	}
+	// extend environment with our function overloads
+	return env.Extend(options...)
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/cel-go/cel"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeExecutor struct {
+	calls []*kyvernov1.APICall
+}
+
+func (e *fakeExecutor) Execute(_ context.Context, call *kyvernov1.APICall) ([]byte, error) {
+	e.calls = append(e.calls, call)
+	if call.Service.URL == "https://error.svc" {
+		return nil, errors.New("HTTP 500 Internal Server Error")
+	}
+	body := map[string]any{}
+	for _, data := range call.Data {
+		var value any
+		if err := json.Unmarshal(data.Value.Raw, &value); err != nil {
+			return nil, err
+		}
+		body[data.Key] = value
+	}
+	return json.Marshal(map[string]any{
+		"method": call.Method,
+		"body":   body,
+	})
+}
+
+func TestLib(t *testing.T) {
+	opts := Lib()
+	env, err := cel.NewEnv(opts)
+	assert.NoError(t, err)
+	assert.NotNil(t, env)
+}
+
+func Test_lib_LibraryName(t *testing.T) {
+	var l lib
+	assert.Equal(t, libraryName, l.LibraryName())
+}
+
+func Test_lib_functions(t *testing.T) {
+	tests := []struct {
+		name       string
+		http       HTTPInterface
+		expression string
+		want       any
+		wantErr    bool
+	}{{
+		name:       "get",
+		http:       NewHTTP(&fakeExecutor{}),
+		expression: `http.Get("https://svc").method`,
+		want:       "GET",
+	}, {
+		name:       "post",
+		http:       NewHTTP(&fakeExecutor{}),
+		expression: `http.Post("https://svc", {"foo": "bar"}).body.foo`,
+		want:       "bar",
+	}, {
+		name:       "client",
+		http:       NewHTTP(&fakeExecutor{}),
+		expression: `http.Client("", {"X-Key": "value"}).Get("https://svc").method`,
+		want:       "GET",
+	}, {
+		name:       "invalid ca bundle",
+		http:       NewHTTP(&fakeExecutor{}),
+		expression: `http.Client("invalid", {}).Get("https://svc")`,
+		wantErr:    true,
+	}, {
+		name:       "error",
+		http:       NewHTTP(&fakeExecutor{}),
+		expression: `http.Get("https://error.svc")`,
+		wantErr:    true,
+	}, {
+		name: "stub",
+		http: NewStub(Response{
+			URL:      "https://svc",
+			Response: map[string]any{"allowed": true},
+		}),
+		expression: `http.Get("https://svc").allowed`,
+		want:       true,
+	}, {
+		name: "stub with body",
+		http: NewStub(Response{
+			Method:   "POST",
+			URL:      "https://svc",
+			Body:     map[string]any{"foo": "bar"},
+			Response: "recorded",
+		}),
+		expression: `http.Post("https://svc", {"foo": "bar"})`,
+		want:       "recorded",
+	}, {
+		name: "stub without matching response",
+		http: NewStub(Response{
+			Method: "POST",
+			URL:    "https://svc",
+			Body:   map[string]any{"foo": "bar"},
+		}),
+		expression: `http.Post("https://svc", {"foo": "baz"})`,
+		wantErr:    true,
+	}, {
+		name:       "not configured",
+		expression: `http.Get("https://svc")`,
+		wantErr:    true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, err := cel.NewEnv(Lib(), cel.Variable("http", HTTPType))
+			assert.NoError(t, err)
+			ast, issues := env.Compile(tt.expression)
+			assert.Nil(t, issues)
+			prog, err := env.Program(ast)
+			assert.NoError(t, err)
+			out, _, err := prog.Eval(map[string]any{"http": HTTP{tt.http}})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, out.Value())
+		})
+	}
+}
+
+func Test_client_headers(t *testing.T) {
+	executor := &fakeExecutor{}
+	client, err := NewHTTP(executor).Client("", map[string]string{"b": "2", "a": "1"})
+	assert.NoError(t, err)
+	_, err = client.Get("https://svc")
+	assert.NoError(t, err)
+	assert.Len(t, executor.calls, 1)
+	assert.Equal(t, []kyvernov1.HTTPHeader{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}}, executor.calls[0].Service.Headers)
+}
+
+type contextExecutor struct {
+	ctx context.Context
+}
+
+func (e *contextExecutor) Execute(ctx context.Context, _ *kyvernov1.APICall) ([]byte, error) {
+	e.ctx = ctx
+	return nil, ctx.Err()
+}
+
+func Test_client_context(t *testing.T) {
+	executor := &contextExecutor{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client, err := WithContext(ctx, NewHTTP(executor)).Client("", nil)
+	assert.NoError(t, err)
+	// calls are made within the bound context and its timeout
+	_, err = client.Get("https://svc")
+	assert.ErrorIs(t, err, context.Canceled)
+	_, ok := executor.ctx.Deadline()
+	assert.True(t, ok)
+	// implementations not performing calls are left unchanged
+	stub := NewStub()
+	assert.Equal(t, stub, WithContext(ctx, stub))
+}
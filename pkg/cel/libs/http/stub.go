@@ -0,0 +1,68 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Response is a recorded response, Method defaults to GET and Body is only compared when set
+type Response struct {
+	Method   string
+	URL      string
+	Body     map[string]any
+	Response any
+}
+
+type stub struct {
+	responses []Response
+}
+
+// NewStub returns an implementation serving recorded responses instead of calling services,
+// it is used by the CLI to test policies offline.
+func NewStub(responses ...Response) HTTPInterface {
+	return &stub{
+		responses: responses,
+	}
+}
+
+func (s *stub) Get(url string) (any, error) {
+	return s.find("GET", url, nil)
+}
+
+func (s *stub) Post(url string, body map[string]any) (any, error) {
+	return s.find("POST", url, body)
+}
+
+func (s *stub) Client(string, map[string]string) (HTTPInterface, error) {
+	return s, nil
+}
+
+func (s *stub) find(method string, url string, body map[string]any) (any, error) {
+	for _, response := range s.responses {
+		expected := response.Method
+		if expected == "" {
+			expected = "GET"
+		}
+		if expected != method || response.URL != url {
+			continue
+		}
+		if response.Body != nil && !equal(response.Body, body) {
+			continue
+		}
+		return response.Response, nil
+	}
+	return nil, fmt.Errorf("no recorded response for %s %s", method, url)
+}
+
+// equal compares values using their json representation to ignore go type differences
+func equal(a, b any) bool {
+	var left, right any
+	if data, err := json.Marshal(a); err != nil || json.Unmarshal(data, &left) != nil {
+		return false
+	}
+	if data, err := json.Marshal(b); err != nil || json.Unmarshal(data, &right) != nil {
+		return false
+	}
+	return reflect.DeepEqual(left, right)
+}
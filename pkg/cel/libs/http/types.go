@@ -0,0 +1,30 @@
+package http
+
+import (
+	"context"
+
+	"github.com/google/cel-go/common/types"
+)
+
+var HTTPType = types.NewOpaqueType("http.HTTP")
+
+type HTTPInterface interface {
+	Get(string) (any, error)
+	Post(string, map[string]any) (any, error)
+	Client(string, map[string]string) (HTTPInterface, error)
+}
+
+type HTTP struct {
+	HTTPInterface
+}
+
+// WithContext binds the implementation to the context of an evaluation when it supports it,
+// implementations not performing calls are returned unchanged
+func WithContext(ctx context.Context, http HTTPInterface) HTTPInterface {
+	if bindable, ok := http.(interface {
+		WithContext(context.Context) HTTPInterface
+	}); ok {
+		return bindable.WithContext(ctx)
+	}
+	return http
+}
@@ -0,0 +1,92 @@
+package imageverify
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/cel/utils"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var errNotConfigured = errors.New("image verification is not configured")
+
+type impl struct {
+	types.Adapter
+}
+
+func (c *impl) verify_image_signatures_string_list(args ...ref.Val) ref.Val {
+	if self, err := utils.ConvertToNative[ImageVerifier](args[0]); err != nil {
+		return types.WrapErr(err)
+	} else if self.verifier == nil {
+		return types.WrapErr(errNotConfigured)
+	} else if image, err := utils.ConvertToNative[string](args[1]); err != nil {
+		return types.WrapErr(err)
+	} else if attestors, err := convert[[]kyvernov2alpha1.Attestor](args[2]); err != nil {
+		return types.WrapErr(err)
+	} else {
+		count, err := self.verifier.VerifyImageSignatures(self.context(), image, attestors)
+		if err != nil {
+			return types.NewErr("failed to verify image signatures: %v", err)
+		}
+		return types.Int(count)
+	}
+}
+
+func (c *impl) verify_attestation_signatures_string_dyn_list(args ...ref.Val) ref.Val {
+	if self, err := utils.ConvertToNative[ImageVerifier](args[0]); err != nil {
+		return types.WrapErr(err)
+	} else if self.verifier == nil {
+		return types.WrapErr(errNotConfigured)
+	} else if image, err := utils.ConvertToNative[string](args[1]); err != nil {
+		return types.WrapErr(err)
+	} else if attestation, err := convert[kyvernov2alpha1.Attestation](args[2]); err != nil {
+		return types.WrapErr(err)
+	} else if attestors, err := convert[[]kyvernov2alpha1.Attestor](args[3]); err != nil {
+		return types.WrapErr(err)
+	} else {
+		count, err := self.verifier.VerifyAttestationSignatures(self.context(), image, attestation, attestors)
+		if err != nil {
+			return types.NewErr("failed to verify attestation signatures: %v", err)
+		}
+		return types.Int(count)
+	}
+}
+
+func (c *impl) extract_payload_string_dyn(args ...ref.Val) ref.Val {
+	if self, err := utils.ConvertToNative[ImageVerifier](args[0]); err != nil {
+		return types.WrapErr(err)
+	} else if self.verifier == nil {
+		return types.WrapErr(errNotConfigured)
+	} else if image, err := utils.ConvertToNative[string](args[1]); err != nil {
+		return types.WrapErr(err)
+	} else if attestation, err := convert[kyvernov2alpha1.Attestation](args[2]); err != nil {
+		return types.WrapErr(err)
+	} else {
+		payload, err := self.verifier.ExtractPayload(self.context(), image, attestation)
+		if err != nil {
+			return types.NewErr("failed to extract payload: %v", err)
+		}
+		return c.NativeToValue(payload)
+	}
+}
+
+// convert turns a cel value into the equivalent api type using its json representation
+func convert[T any](value ref.Val) (T, error) {
+	var out T
+	native, err := utils.ConvertToNative[*structpb.Value](value)
+	if err != nil {
+		return out, err
+	}
+	data, err := protojson.Marshal(native)
+	if err != nil {
+		return out, err
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return out, err
+	}
+	return out, nil
+}
@@ -0,0 +1,76 @@
+package imageverify
+
+import (
+	"reflect"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/ast"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/ext"
+)
+
+const libraryName = "kyverno.imageverify"
+
+type lib struct{}
+
+// Lib returns the image verification library, functions are bound to the ImageVerifier
+// provided in the activation under VerifierKey.
+func Lib() cel.EnvOption {
+	// create the cel lib env option
+	return cel.Lib(&lib{})
+}
+
+func (*lib) LibraryName() string {
+	return libraryName
+}
+
+func (c *lib) CompileOptions() []cel.EnvOption {
+	return []cel.EnvOption{
+		ext.NativeTypes(reflect.TypeFor[ImageVerifier]()),
+		cel.Variable(VerifierKey, ImageVerifierType),
+		cel.Macros(
+			bindVerifier("verifyImageSignatures", 2),
+			bindVerifier("verifyAttestationSignatures", 3),
+			bindVerifier("extractPayload", 2),
+		),
+		c.extendEnv,
+	}
+}
+
+func (*lib) ProgramOptions() []cel.ProgramOption {
+	return []cel.ProgramOption{}
+}
+
+func (c *lib) extendEnv(env *cel.Env) (*cel.Env, error) {
+	// create implementation, recording the envoy types aware adapter
+	impl := impl{
+		Adapter: env.CELTypeAdapter(),
+	}
+	// build our function overloads
+	libraryDecls := map[string][]cel.FunctionOpt{
+		"verifyImageSignatures": {
+			cel.MemberOverload("verify_image_signatures_string_list", []*cel.Type{ImageVerifierType, types.StringType, types.NewListType(types.DynType)}, types.IntType, cel.FunctionBinding(impl.verify_image_signatures_string_list)),
+		},
+		"verifyAttestationSignatures": {
+			cel.MemberOverload("verify_attestation_signatures_string_dyn_list", []*cel.Type{ImageVerifierType, types.StringType, types.DynType, types.NewListType(types.DynType)}, types.IntType, cel.FunctionBinding(impl.verify_attestation_signatures_string_dyn_list)),
+		},
+		"extractPayload": {
+			cel.MemberOverload("extract_payload_string_dyn", []*cel.Type{ImageVerifierType, types.StringType, types.DynType}, types.DynType, cel.FunctionBinding(impl.extract_payload_string_dyn)),
+		},
+	}
+	// create env options corresponding to our function overloads
+	options := []cel.EnvOption{}
+	for name, overloads := range libraryDecls {
+		options = append(options, cel.Function(name, overloads...))
+	}
+	// extend environment with our function overloads
+	return env.Extend(options...)
+}
+
+// bindVerifier rewrites global calls to the function into member calls on the verifier variable,
+// this way the verifier and the evaluation context are provided at evaluation time
+func bindVerifier(function string, argCount int) cel.Macro {
+	return cel.GlobalMacro(function, argCount, func(eh cel.MacroExprFactory, _ ast.Expr, args []ast.Expr) (ast.Expr, *cel.Error) {
+		return eh.NewMemberCall(function, eh.NewIdent(VerifierKey), args...), nil
+	})
+}
@@ -0,0 +1,132 @@
+package imageverify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/cel-go/cel"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeVerifier struct {
+	signed  map[string][]string
+	payload any
+}
+
+func (v *fakeVerifier) VerifyImageSignatures(ctx context.Context, image string, attestors []kyvernov2alpha1.Attestor) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, attestor := range attestors {
+		for _, signer := range v.signed[image] {
+			if signer == attestor.Name {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+func (v *fakeVerifier) VerifyAttestationSignatures(ctx context.Context, image string, attestation kyvernov2alpha1.Attestation, attestors []kyvernov2alpha1.Attestor) (int, error) {
+	if attestation.GetType() == "" {
+		return 0, errors.New("attestation has no type")
+	}
+	return v.VerifyImageSignatures(ctx, image, attestors)
+}
+
+func (v *fakeVerifier) ExtractPayload(_ context.Context, _ string, attestation kyvernov2alpha1.Attestation) (any, error) {
+	if v.payload == nil {
+		return nil, errors.New("no payload")
+	}
+	return v.payload, nil
+}
+
+func TestLib(t *testing.T) {
+	opts := Lib()
+	env, err := cel.NewEnv(opts)
+	assert.NoError(t, err)
+	assert.NotNil(t, env)
+}
+
+func Test_lib_LibraryName(t *testing.T) {
+	var l lib
+	assert.Equal(t, libraryName, l.LibraryName())
+}
+
+func Test_lib_functions(t *testing.T) {
+	verifier := &fakeVerifier{
+		signed: map[string][]string{
+			"ghcr.io/kyverno/test:v1": {"cosign", "notary"},
+		},
+		payload: map[string]any{"builder": map[string]any{"id": "github"}},
+	}
+	tests := []struct {
+		name       string
+		verifier   Verifier
+		canceled   bool
+		expression string
+		want       any
+		wantErr    bool
+	}{{
+		name:       "image signed by both attestors",
+		verifier:   verifier,
+		expression: `verifyImageSignatures("ghcr.io/kyverno/test:v1", [{"name": "cosign"}, {"name": "notary"}])`,
+		want:       int64(2),
+	}, {
+		name:       "image not signed",
+		verifier:   verifier,
+		expression: `verifyImageSignatures("ghcr.io/kyverno/other:v1", [{"name": "cosign"}])`,
+		want:       int64(0),
+	}, {
+		name:       "attestation signed",
+		verifier:   verifier,
+		expression: `verifyAttestationSignatures("ghcr.io/kyverno/test:v1", {"name": "sbom", "intoto": {"type": "cyclonedx"}}, [{"name": "cosign"}])`,
+		want:       int64(1),
+	}, {
+		name:       "attestation without type",
+		verifier:   verifier,
+		expression: `verifyAttestationSignatures("ghcr.io/kyverno/test:v1", {"name": "sbom"}, [{"name": "cosign"}])`,
+		wantErr:    true,
+	}, {
+		name:       "extract payload",
+		verifier:   verifier,
+		expression: `extractPayload("ghcr.io/kyverno/test:v1", {"name": "provenance", "intoto": {"type": "slsa"}}).builder.id`,
+		want:       "github",
+	}, {
+		name:       "canceled context",
+		verifier:   verifier,
+		canceled:   true,
+		expression: `verifyImageSignatures("ghcr.io/kyverno/test:v1", [{"name": "cosign"}])`,
+		wantErr:    true,
+	}, {
+		name:       "not configured",
+		expression: `verifyImageSignatures("ghcr.io/kyverno/test:v1", [{"name": "cosign"}])`,
+		wantErr:    true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, err := cel.NewEnv(Lib())
+			assert.NoError(t, err)
+			ast, issues := env.Compile(tt.expression)
+			assert.Nil(t, issues)
+			prog, err := env.Program(ast)
+			assert.NoError(t, err)
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.canceled {
+				cancel()
+			} else {
+				defer cancel()
+			}
+			out, _, err := prog.Eval(map[string]any{VerifierKey: NewImageVerifier(ctx, tt.verifier)})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, out.Value())
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package imageverify
+
+import (
+	"context"
+
+	"github.com/google/cel-go/common/types"
+)
+
+// VerifierKey is the variable holding the verifier, library functions are bound to it at parse time
+const VerifierKey = "imageVerifier"
+
+var ImageVerifierType = types.NewOpaqueType("imageverify.ImageVerifier")
+
+// ImageVerifier binds a verifier to the context of the evaluation using it
+type ImageVerifier struct {
+	ctx      context.Context
+	verifier Verifier
+}
+
+func NewImageVerifier(ctx context.Context, verifier Verifier) ImageVerifier {
+	return ImageVerifier{
+		ctx:      ctx,
+		verifier: verifier,
+	}
+}
+
+func (v ImageVerifier) context() context.Context {
+	if v.ctx == nil {
+		return context.Background()
+	}
+	return v.ctx
+}
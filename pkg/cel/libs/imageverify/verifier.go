@@ -0,0 +1,238 @@
+package imageverify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/cosign"
+	"github.com/kyverno/kyverno/pkg/images"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	"github.com/kyverno/kyverno/pkg/notary"
+	"go.uber.org/multierr"
+)
+
+const defaultRekorURL = "https://rekor.sigstore.dev"
+
+type Verifier interface {
+	// VerifyImageSignatures returns the number of attestors that signed the image
+	VerifyImageSignatures(ctx context.Context, image string, attestors []kyvernov2alpha1.Attestor) (int, error)
+	// VerifyAttestationSignatures returns the number of attestors that signed the attestation attached to the image
+	VerifyAttestationSignatures(ctx context.Context, image string, attestation kyvernov2alpha1.Attestation, attestors []kyvernov2alpha1.Attestor) (int, error)
+	// ExtractPayload returns the predicate of an attestation attached to the image,
+	// the attestation must be signed by one of the policy attestors
+	ExtractPayload(ctx context.Context, image string, attestation kyvernov2alpha1.Attestation) (any, error)
+}
+
+type verifier struct {
+	logger  logr.Logger
+	policy  *kyvernov2alpha1.ImageVerificationPolicy
+	client  images.Client
+	ivCache imageverifycache.Client
+}
+
+// NewVerifier creates a verifier backed by cosign and notary, verified images are recorded in the cache
+// using the policy and attestor names as keys.
+func NewVerifier(logger logr.Logger, policy *kyvernov2alpha1.ImageVerificationPolicy, client images.Client, ivCache imageverifycache.Client) Verifier {
+	if ivCache == nil {
+		ivCache = imageverifycache.DisabledImageVerifyCache()
+	}
+	return &verifier{
+		logger:  logger,
+		policy:  policy,
+		client:  client,
+		ivCache: ivCache,
+	}
+}
+
+func (v *verifier) VerifyImageSignatures(ctx context.Context, image string, attestors []kyvernov2alpha1.Attestor) (int, error) {
+	count := 0
+	for _, attestor := range attestors {
+		cacheKey := "attestors/" + attestor.Name
+		if v.cached(ctx, cacheKey, image) {
+			count++
+			continue
+		}
+		if err := v.verify(ctx, image, attestor, nil); err != nil {
+			v.logger.V(4).Info("image signature verification failed", "image", image, "attestor", attestor.Name, "error", err.Error())
+			continue
+		}
+		v.cache(ctx, cacheKey, image)
+		count++
+	}
+	return count, nil
+}
+
+func (v *verifier) VerifyAttestationSignatures(ctx context.Context, image string, attestation kyvernov2alpha1.Attestation, attestors []kyvernov2alpha1.Attestor) (int, error) {
+	if attestation.GetType() == "" {
+		return 0, fmt.Errorf("attestation %s has no type", attestation.Name)
+	}
+	count := 0
+	for _, attestor := range attestors {
+		cacheKey := "attestations/" + attestation.Name + "/" + attestor.Name
+		if v.cached(ctx, cacheKey, image) {
+			count++
+			continue
+		}
+		if err := v.verify(ctx, image, attestor, &attestation); err != nil {
+			v.logger.V(4).Info("attestation signature verification failed", "image", image, "attestation", attestation.Name, "attestor", attestor.Name, "error", err.Error())
+			continue
+		}
+		v.cache(ctx, cacheKey, image)
+		count++
+	}
+	return count, nil
+}
+
+func (v *verifier) ExtractPayload(ctx context.Context, image string, attestation kyvernov2alpha1.Attestation) (any, error) {
+	if attestation.GetType() == "" {
+		return nil, fmt.Errorf("attestation %s has no type", attestation.Name)
+	}
+	var errs []error
+	for _, attestor := range v.policy.Spec.Attestors {
+		// in-toto attestations are produced by cosign and referrers by notary
+		if (attestation.InToto != nil && !attestor.IsCosign()) || (attestation.Referrer != nil && !attestor.IsNotary()) {
+			continue
+		}
+		response, err := v.fetchAttestations(ctx, image, attestor, attestation)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", attestor.Name, err))
+			continue
+		}
+		for _, statement := range response.Statements {
+			if statement["type"] == attestation.GetType() {
+				return statement["predicate"], nil
+			}
+		}
+	}
+	if err := multierr.Combine(errs...); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("no signed attestation of type %s found for image %s", attestation.GetType(), image)
+}
+
+func (v *verifier) verify(ctx context.Context, image string, attestor kyvernov2alpha1.Attestor, attestation *kyvernov2alpha1.Attestation) error {
+	if attestation != nil {
+		response, err := v.fetchAttestations(ctx, image, attestor, *attestation)
+		if err != nil {
+			return err
+		}
+		if len(response.Statements) == 0 {
+			return fmt.Errorf("no attestation of type %s found", attestation.GetType())
+		}
+		return nil
+	}
+	return v.try(attestor, func(verifier images.ImageVerifier, opts images.Options) error {
+		opts.ImageRef = image
+		_, err := verifier.VerifySignature(ctx, opts)
+		return err
+	})
+}
+
+func (v *verifier) fetchAttestations(ctx context.Context, image string, attestor kyvernov2alpha1.Attestor, attestation kyvernov2alpha1.Attestation) (*images.Response, error) {
+	var response *images.Response
+	err := v.try(attestor, func(verifier images.ImageVerifier, opts images.Options) error {
+		opts.ImageRef = image
+		opts.FetchAttestations = true
+		opts.Type = attestation.GetType()
+		resp, err := verifier.FetchAttestations(ctx, opts)
+		if err == nil {
+			response = resp
+		}
+		return err
+	})
+	return response, err
+}
+
+// try runs the given verification with the options of each identity accepted by the attestor,
+// it succeeds as soon as one of them succeeds
+func (v *verifier) try(attestor kyvernov2alpha1.Attestor, fn func(images.ImageVerifier, images.Options) error) error {
+	verifier, options, err := v.build(attestor)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, opts := range options {
+		if err := fn(verifier, opts); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return nil
+	}
+	return multierr.Combine(errs...)
+}
+
+func (v *verifier) build(attestor kyvernov2alpha1.Attestor) (images.ImageVerifier, []images.Options, error) {
+	switch {
+	case attestor.IsNotary():
+		return notary.NewVerifier(), []images.Options{{
+			Client:       v.client,
+			Cert:         attestor.Notary.Certs,
+			TSACertChain: attestor.Notary.TSACerts,
+		}}, nil
+	case attestor.IsCosign():
+		return cosign.NewVerifier(), buildCosignOptions(v.client, *attestor.Cosign), nil
+	}
+	return nil, nil, fmt.Errorf("attestor %s must define either cosign or notary", attestor.Name)
+}
+
+func buildCosignOptions(client images.Client, attestor kyvernov2alpha1.Cosign) []images.Options {
+	base := images.Options{
+		Client:      client,
+		Annotations: attestor.Annotations,
+		RekorURL:    defaultRekorURL,
+	}
+	if attestor.Source != nil {
+		base.Repository = attestor.Source.Repository
+	}
+	if attestor.CTLog != nil {
+		if attestor.CTLog.URL != "" {
+			base.RekorURL = attestor.CTLog.URL
+		}
+		base.RekorPubKey = attestor.CTLog.RekorPubKey
+		base.CTLogsPubKey = attestor.CTLog.CTLogPubKey
+		base.TSACertChain = attestor.CTLog.TSACertChain
+		base.IgnoreTlog = attestor.CTLog.InsecureIgnoreTlog
+		base.IgnoreSCT = attestor.CTLog.InsecureIgnoreSCT
+	}
+	switch {
+	case attestor.Key != nil:
+		base.Key = attestor.Key.Data
+		if base.Key == "" {
+			base.Key = attestor.Key.KMS
+		}
+		base.SignatureAlgorithm = attestor.Key.HashAlgorithm
+	case attestor.Certificate != nil:
+		base.Cert = attestor.Certificate.Certificate
+		base.CertChain = attestor.Certificate.CertificateChain
+	case attestor.Keyless != nil:
+		base.Roots = attestor.Keyless.Roots
+		options := make([]images.Options, 0, len(attestor.Keyless.Identities))
+		for _, identity := range attestor.Keyless.Identities {
+			opts := base
+			opts.Issuer = identity.Issuer
+			opts.IssuerRegExp = identity.IssuerRegExp
+			opts.Subject = identity.Subject
+			opts.SubjectRegExp = identity.SubjectRegExp
+			options = append(options, opts)
+		}
+		return options
+	}
+	return []images.Options{base}
+}
+
+func (v *verifier) cached(ctx context.Context, key string, image string) bool {
+	found, err := v.ivCache.Get(ctx, v.policy, key, image, true)
+	if err != nil {
+		v.logger.Error(err, "error occurred during cache get", "image", image)
+		return false
+	}
+	return found
+}
+
+func (v *verifier) cache(ctx context.Context, key string, image string) {
+	if _, err := v.ivCache.Set(ctx, v.policy, key, image, true); err != nil {
+		v.logger.Error(err, "error occurred during cache set", "image", image)
+	}
+}
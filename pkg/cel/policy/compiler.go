@@ -7,23 +7,41 @@ import (
 	"github.com/google/cel-go/common/types"
 	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	engine "github.com/kyverno/kyverno/pkg/cel"
+	"github.com/kyverno/kyverno/pkg/cel/autogen"
 	"github.com/kyverno/kyverno/pkg/cel/libs/context"
+	"github.com/kyverno/kyverno/pkg/cel/libs/http"
+	"github.com/kyverno/kyverno/pkg/cel/libs/imageverify"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	apiservercel "k8s.io/apiserver/pkg/cel"
+	"k8s.io/apiserver/pkg/cel/common"
+	"k8s.io/apiserver/pkg/cel/library"
+	"k8s.io/apiserver/pkg/cel/mutation"
 )
 
 const (
+	AttestationsKey    = "attestations"
+	AttestorsKey       = "attestors"
+	AuthorizerKey      = "authorizer"
 	ContextKey         = "context"
+	HTTPKey            = "http"
+	ImageRefKey        = "ref"
+	ImagesKey          = "images"
 	NamespaceObjectKey = "namespaceObject"
 	ObjectKey          = "object"
 	OldObjectKey       = "oldObject"
 	RequestKey         = "request"
+	RequestResourceKey = "authorizer.requestResource"
 	VariablesKey       = "variables"
 )
 
 type Compiler interface {
 	Compile(*kyvernov2alpha1.ValidatingPolicy, []kyvernov2alpha1.CELPolicyException) (CompiledPolicy, field.ErrorList)
+	CompileMutating(*kyvernov2alpha1.MutatingPolicy, []kyvernov2alpha1.CELPolicyException) (CompiledMutatingPolicy, field.ErrorList)
+	CompileGenerating(*kyvernov2alpha1.GeneratingPolicy, []kyvernov2alpha1.CELPolicyException) (CompiledGeneratingPolicy, field.ErrorList)
+	CompileImageVerification(*kyvernov2alpha1.ImageVerificationPolicy, []kyvernov2alpha1.CELPolicyException, imageverify.Verifier) (CompiledImageVerificationPolicy, field.ErrorList)
 }
 
 func NewCompiler() Compiler {
@@ -33,76 +51,59 @@ func NewCompiler() Compiler {
 type compiler struct{}
 
 func (c *compiler) Compile(policy *kyvernov2alpha1.ValidatingPolicy, exceptions []kyvernov2alpha1.CELPolicyException) (CompiledPolicy, field.ErrorList) {
-	var allErrs field.ErrorList
-	base, err := engine.NewEnv()
-	if err != nil {
-		return nil, append(allErrs, field.InternalError(nil, err))
-	}
-	var declTypes []*apiservercel.DeclType
-	declTypes = append(declTypes, namespaceType, requestType)
-	declTypes = append(declTypes, context.Types()...)
-	options := []cel.EnvOption{
-		cel.Variable(ContextKey, context.ContextType),
-		cel.Variable(NamespaceObjectKey, namespaceType.CelType()),
-		cel.Variable(ObjectKey, cel.DynType),
-		cel.Variable(OldObjectKey, cel.DynType),
-		cel.Variable(RequestKey, requestType.CelType()),
-		cel.Variable(VariablesKey, VariablesType),
+	compiled, errs := c.compileValidating(policy, exceptions)
+	if errs != nil {
+		return nil, errs
 	}
-	for _, declType := range declTypes {
-		options = append(options, cel.Types(declType.CelType()))
-	}
-	variablesProvider := NewVariablesProvider(base.CELTypeProvider())
-	declProvider := apiservercel.NewDeclTypeProvider(declTypes...)
-	declOptions, err := declProvider.EnvOptions(variablesProvider)
-	if err != nil {
-		// TODO: proper error handling
-		panic(err)
+	// compile the rules generated for pod controllers, the exceptions of the policy are converted
+	// the same way as the rules so that conditions written for pods apply to the pod templates
+	for _, rule := range autogen.ComputeRules(policy) {
+		path := field.NewPath("status", "autogen", "rules").Key(rule.Name)
+		generated := policy.DeepCopy()
+		generated.Spec.MatchConstraints = rule.MatchConstraints
+		generated.Spec.MatchConditions = rule.MatchConditions
+		generated.Spec.Validations = rule.Validations
+		generated.Spec.AuditAnnotations = rule.AuditAnnotation
+		generated.Spec.Variables = rule.Variables
+		ruleExceptions, err := autogenExceptions(rule.Name, exceptions)
+		if err != nil {
+			return nil, field.ErrorList{field.InternalError(path, err)}
+		}
+		compiledRule, errs := c.compileValidating(generated, ruleExceptions)
+		if errs != nil {
+			var allErrs field.ErrorList
+			for _, err := range errs {
+				allErrs = append(allErrs, field.Invalid(path, err.BadValue, err.Error()))
+			}
+			return nil, allErrs
+		}
+		// matched exceptions are reported as they were defined
+		for i := range compiledRule.exceptions {
+			compiledRule.exceptions[i].exception = exceptions[i]
+		}
+		compiled.autogen = append(compiled.autogen, AutogenPolicy{
+			Name:             rule.Name,
+			MatchConstraints: rule.MatchConstraints,
+			CompiledPolicy:   compiledRule,
+		})
 	}
-	options = append(options, declOptions...)
-	options = append(options, context.Lib())
-	// TODO: params, authorizer, authorizer.requestResource ?
-	env, err := base.Extend(options...)
+	return compiled, nil
+}
+
+func (c *compiler) compileValidating(policy *kyvernov2alpha1.ValidatingPolicy, exceptions []kyvernov2alpha1.CELPolicyException) (*compiledPolicy, field.ErrorList) {
+	var allErrs field.ErrorList
+	env, variablesProvider, err := c.createEnv()
 	if err != nil {
 		return nil, append(allErrs, field.InternalError(nil, err))
 	}
 	path := field.NewPath("spec")
-	matchConditions := make([]cel.Program, 0, len(policy.Spec.MatchConditions))
-	{
-		path := path.Child("matchConditions")
-		for i, matchCondition := range policy.Spec.MatchConditions {
-			path := path.Index(i).Child("expression")
-			ast, issues := env.Compile(matchCondition.Expression)
-			if err := issues.Err(); err != nil {
-				return nil, append(allErrs, field.Invalid(path, matchCondition.Expression, err.Error()))
-			}
-			if !ast.OutputType().IsExactType(types.BoolType) {
-				msg := fmt.Sprintf("output is expected to be of type %s", types.BoolType.TypeName())
-				return nil, append(allErrs, field.Invalid(path, matchCondition.Expression, msg))
-			}
-			prog, err := env.Program(ast)
-			if err != nil {
-				return nil, append(allErrs, field.Invalid(path, matchCondition.Expression, err.Error()))
-			}
-			matchConditions = append(matchConditions, prog)
-		}
+	matchConditions, errs := compileMatchConditions(path.Child("matchConditions"), policy.Spec.MatchConditions, env)
+	if errs != nil {
+		return nil, append(allErrs, errs...)
 	}
-	variables := map[string]cel.Program{}
-	{
-		path := path.Child("variables")
-		for i, variable := range policy.Spec.Variables {
-			path := path.Index(i).Child("expression")
-			ast, issues := env.Compile(variable.Expression)
-			if err := issues.Err(); err != nil {
-				return nil, append(allErrs, field.Invalid(path, variable.Expression, err.Error()))
-			}
-			variablesProvider.RegisterField(variable.Name, ast.OutputType())
-			prog, err := env.Program(ast)
-			if err != nil {
-				return nil, append(allErrs, field.Invalid(path, variable.Expression, err.Error()))
-			}
-			variables[variable.Name] = prog
-		}
+	variables, errs := compileVariables(path.Child("variables"), policy.Spec.Variables, variablesProvider, env)
+	if errs != nil {
+		return nil, append(allErrs, errs...)
 	}
 	validations := make([]compiledValidation, 0, len(policy.Spec.Validations))
 	{
@@ -136,41 +137,338 @@ func (c *compiler) Compile(policy *kyvernov2alpha1.ValidatingPolicy, exceptions
 			auditAnnotations[auditAnnotation.Key] = prog
 		}
 	}
+	compiledExceptions, errs := compileExceptions(exceptions, env)
+	if errs != nil {
+		return nil, append(allErrs, errs...)
+	}
+	return &compiledPolicy{
+		failurePolicy:    policy.GetFailurePolicy(),
+		matchConditions:  matchConditions,
+		variables:        variables,
+		validations:      validations,
+		auditAnnotations: auditAnnotations,
+		exceptions:       compiledExceptions,
+	}, nil
+}
 
-	// exceptions' match conditions
-	var polexMatchConditions []cel.Program
-	if len(exceptions) > 0 {
-		for _, polex := range exceptions {
-			path := field.NewPath("spec").Child("matchConditions")
-			for i, matchCondition := range polex.Spec.MatchConditions {
-				path := path.Index(i).Child("expression")
-				ast, issues := env.Compile(matchCondition.Expression)
-				if err := issues.Err(); err != nil {
-					return nil, append(allErrs, field.Invalid(path, matchCondition.Expression, err.Error()))
-				}
-				if !ast.OutputType().IsExactType(types.BoolType) {
-					msg := fmt.Sprintf("output is expected to be of type %s", types.BoolType.TypeName())
-					return nil, append(allErrs, field.Invalid(path, matchCondition.Expression, msg))
-				}
-				prog, err := env.Program(ast)
-				if err != nil {
-					return nil, append(allErrs, field.Invalid(path, matchCondition.Expression, err.Error()))
-				}
-				polexMatchConditions = append(polexMatchConditions, prog)
+func (c *compiler) CompileMutating(policy *kyvernov2alpha1.MutatingPolicy, exceptions []kyvernov2alpha1.CELPolicyException) (CompiledMutatingPolicy, field.ErrorList) {
+	var allErrs field.ErrorList
+	env, variablesProvider, err := c.createEnv(
+		// patch types and the jsonpatch library are only available to mutating policies
+		common.ResolverEnvOption(&mutation.DynamicTypeResolver{}),
+		library.JSONPatch(),
+	)
+	if err != nil {
+		return nil, append(allErrs, field.InternalError(nil, err))
+	}
+	path := field.NewPath("spec")
+	matchConditions, errs := compileMatchConditions(path.Child("matchConditions"), policy.Spec.MatchConditions, env)
+	if errs != nil {
+		return nil, append(allErrs, errs...)
+	}
+	variables, errs := compileVariables(path.Child("variables"), policy.Spec.Variables, variablesProvider, env)
+	if errs != nil {
+		return nil, append(allErrs, errs...)
+	}
+	mutations := make([]compiledMutation, 0, len(policy.Spec.Mutations))
+	{
+		path := path.Child("mutations")
+		for i, rule := range policy.Spec.Mutations {
+			path := path.Index(i)
+			program, errs := compileMutation(path, rule, env)
+			if errs != nil {
+				return nil, append(allErrs, errs...)
 			}
+			mutations = append(mutations, program)
 		}
 	}
+	compiledExceptions, errs := compileExceptions(exceptions, env)
+	if errs != nil {
+		return nil, append(allErrs, errs...)
+	}
+	return &compiledMutatingPolicy{
+		failurePolicy:   policy.GetFailurePolicy(),
+		matchConditions: matchConditions,
+		variables:       variables,
+		mutations:       mutations,
+		exceptions:      compiledExceptions,
+	}, nil
+}
 
-	return &compiledPolicy{
-		failurePolicy:        policy.GetFailurePolicy(),
-		matchConditions:      matchConditions,
-		variables:            variables,
-		validations:          validations,
-		auditAnnotations:     auditAnnotations,
-		polexMatchConditions: polexMatchConditions,
+func (c *compiler) CompileGenerating(policy *kyvernov2alpha1.GeneratingPolicy, exceptions []kyvernov2alpha1.CELPolicyException) (CompiledGeneratingPolicy, field.ErrorList) {
+	var allErrs field.ErrorList
+	env, variablesProvider, err := c.createEnv()
+	if err != nil {
+		return nil, append(allErrs, field.InternalError(nil, err))
+	}
+	path := field.NewPath("spec")
+	matchConditions, errs := compileMatchConditions(path.Child("matchConditions"), policy.Spec.MatchConditions, env)
+	if errs != nil {
+		return nil, append(allErrs, errs...)
+	}
+	variables, errs := compileVariables(path.Child("variables"), policy.Spec.Variables, variablesProvider, env)
+	if errs != nil {
+		return nil, append(allErrs, errs...)
+	}
+	generations := make([]cel.Program, 0, len(policy.Spec.Generation))
+	{
+		path := path.Child("generate")
+		for i, generation := range policy.Spec.Generation {
+			path := path.Index(i).Child("expression")
+			ast, issues := env.Compile(generation.Expression)
+			if err := issues.Err(); err != nil {
+				return nil, append(allErrs, field.Invalid(path, generation.Expression, err.Error()))
+			}
+			program, err := env.Program(ast)
+			if err != nil {
+				return nil, append(allErrs, field.Invalid(path, generation.Expression, err.Error()))
+			}
+			generations = append(generations, program)
+		}
+	}
+	compiledExceptions, errs := compileExceptions(exceptions, env)
+	if errs != nil {
+		return nil, append(allErrs, errs...)
+	}
+	return &compiledGeneratingPolicy{
+		failurePolicy:   policy.GetFailurePolicy(),
+		matchConditions: matchConditions,
+		variables:       variables,
+		generations:     generations,
+		exceptions:      compiledExceptions,
 	}, nil
 }
 
+func (c *compiler) CompileImageVerification(policy *kyvernov2alpha1.ImageVerificationPolicy, exceptions []kyvernov2alpha1.CELPolicyException, verifier imageverify.Verifier) (CompiledImageVerificationPolicy, field.ErrorList) {
+	var allErrs field.ErrorList
+	env, variablesProvider, err := c.createEnv(
+		// images, attestors and attestations are only available to image verification policies
+		cel.Variable(ImagesKey, types.NewMapType(types.StringType, types.NewListType(types.StringType))),
+		cel.Variable(AttestorsKey, types.NewMapType(types.StringType, types.DynType)),
+		cel.Variable(AttestationsKey, types.NewMapType(types.StringType, types.DynType)),
+		imageverify.Lib(),
+	)
+	if err != nil {
+		return nil, append(allErrs, field.InternalError(nil, err))
+	}
+	path := field.NewPath("spec")
+	matchConditions, errs := compileMatchConditions(path.Child("matchConditions"), policy.Spec.MatchConditions, env)
+	if errs != nil {
+		return nil, append(allErrs, errs...)
+	}
+	variables, errs := compileVariables(path.Child("variables"), policy.Spec.Variables, variablesProvider, env)
+	if errs != nil {
+		return nil, append(allErrs, errs...)
+	}
+	imageRules, errs := compileImageRules(path.Child("imageRules"), policy.Spec.ImageRules)
+	if errs != nil {
+		return nil, append(allErrs, errs...)
+	}
+	verifications := make([]compiledValidation, 0, len(policy.Spec.Verifications))
+	{
+		path := path.Child("verifications")
+		for i, rule := range policy.Spec.Verifications {
+			path := path.Index(i)
+			program, errs := compileValidation(path, rule, env)
+			if errs != nil {
+				return nil, append(allErrs, errs...)
+			}
+			verifications = append(verifications, program)
+		}
+	}
+	attestors := map[string]any{}
+	for i, attestor := range policy.Spec.Attestors {
+		if (attestor.Cosign == nil) == (attestor.Notary == nil) {
+			return nil, append(allErrs, field.Invalid(path.Child("attestors").Index(i), attestor.Name, "exactly one of cosign or notary must be specified"))
+		}
+		data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&attestor)
+		if err != nil {
+			return nil, append(allErrs, field.InternalError(path.Child("attestors").Index(i), err))
+		}
+		attestors[attestor.Name] = data
+	}
+	attestations := map[string]any{}
+	for i, attestation := range policy.Spec.Attestations {
+		if (attestation.InToto == nil) == (attestation.Referrer == nil) {
+			return nil, append(allErrs, field.Invalid(path.Child("attestations").Index(i), attestation.Name, "exactly one of intoto or referrer must be specified"))
+		}
+		data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&attestation)
+		if err != nil {
+			return nil, append(allErrs, field.InternalError(path.Child("attestations").Index(i), err))
+		}
+		attestations[attestation.Name] = data
+	}
+	compiledExceptions, errs := compileExceptions(exceptions, env)
+	if errs != nil {
+		return nil, append(allErrs, errs...)
+	}
+	return &compiledImageVerificationPolicy{
+		failurePolicy:   policy.GetFailurePolicy(),
+		matchConditions: matchConditions,
+		variables:       variables,
+		imageRules:      imageRules,
+		verifications:   verifications,
+		attestors:       attestors,
+		attestations:    attestations,
+		exceptions:      compiledExceptions,
+		verifier:        verifier,
+	}, nil
+}
+
+func (c *compiler) createEnv(extraOptions ...cel.EnvOption) (*cel.Env, *variablesProvider, error) {
+	base, err := engine.NewEnv()
+	if err != nil {
+		return nil, nil, err
+	}
+	var declTypes []*apiservercel.DeclType
+	declTypes = append(declTypes, namespaceType, requestType)
+	declTypes = append(declTypes, context.Types()...)
+	options := []cel.EnvOption{
+		cel.Variable(AuthorizerKey, library.AuthorizerType),
+		cel.Variable(ContextKey, context.ContextType),
+		cel.Variable(HTTPKey, http.HTTPType),
+		cel.Variable(NamespaceObjectKey, namespaceType.CelType()),
+		cel.Variable(ObjectKey, cel.DynType),
+		cel.Variable(OldObjectKey, cel.DynType),
+		cel.Variable(RequestKey, requestType.CelType()),
+		cel.Variable(RequestResourceKey, library.ResourceCheckType),
+		cel.Variable(VariablesKey, VariablesType),
+	}
+	for _, declType := range declTypes {
+		options = append(options, cel.Types(declType.CelType()))
+	}
+	variablesProvider := NewVariablesProvider(base.CELTypeProvider())
+	declProvider := apiservercel.NewDeclTypeProvider(declTypes...)
+	declOptions, err := declProvider.EnvOptions(variablesProvider)
+	if err != nil {
+		return nil, nil, err
+	}
+	options = append(options, declOptions...)
+	// extra options and the authorizer libraries must be registered before libraries extending the env
+	options = append(options, extraOptions...)
+	options = append(options, library.Authz(), library.AuthzSelectors(), context.Lib(), http.Lib())
+	// TODO: params ?
+	env, err := base.Extend(options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return env, variablesProvider, nil
+}
+
+func compileMatchConditions(path *field.Path, matchConditions []admissionregistrationv1.MatchCondition, env *cel.Env) ([]cel.Program, field.ErrorList) {
+	var allErrs field.ErrorList
+	programs := make([]cel.Program, 0, len(matchConditions))
+	for i, matchCondition := range matchConditions {
+		path := path.Index(i).Child("expression")
+		ast, issues := env.Compile(matchCondition.Expression)
+		if err := issues.Err(); err != nil {
+			return nil, append(allErrs, field.Invalid(path, matchCondition.Expression, err.Error()))
+		}
+		if !ast.OutputType().IsExactType(types.BoolType) {
+			msg := fmt.Sprintf("output is expected to be of type %s", types.BoolType.TypeName())
+			return nil, append(allErrs, field.Invalid(path, matchCondition.Expression, msg))
+		}
+		prog, err := env.Program(ast)
+		if err != nil {
+			return nil, append(allErrs, field.Invalid(path, matchCondition.Expression, err.Error()))
+		}
+		programs = append(programs, prog)
+	}
+	return programs, nil
+}
+
+// compileImageRules compiles image rules in a dedicated environment where only the image reference is available
+func compileImageRules(path *field.Path, imageRules []kyvernov2alpha1.ImageRule) ([]compiledImageRule, field.ErrorList) {
+	var allErrs field.ErrorList
+	if len(imageRules) == 0 {
+		return nil, nil
+	}
+	base, err := engine.NewEnv()
+	if err != nil {
+		return nil, append(allErrs, field.InternalError(nil, err))
+	}
+	env, err := base.Extend(cel.Variable(ImageRefKey, types.StringType))
+	if err != nil {
+		return nil, append(allErrs, field.InternalError(nil, err))
+	}
+	rules := make([]compiledImageRule, 0, len(imageRules))
+	for i, rule := range imageRules {
+		path := path.Index(i)
+		if (rule.Glob == "") == (rule.CELExpression == "") {
+			return nil, append(allErrs, field.Invalid(path, rule, "exactly one of glob or cel must be specified"))
+		}
+		if rule.Glob != "" {
+			rules = append(rules, compiledImageRule{glob: rule.Glob})
+			continue
+		}
+		path = path.Child("cel")
+		ast, issues := env.Compile(rule.CELExpression)
+		if err := issues.Err(); err != nil {
+			return nil, append(allErrs, field.Invalid(path, rule.CELExpression, err.Error()))
+		}
+		if !ast.OutputType().IsExactType(types.BoolType) {
+			msg := fmt.Sprintf("output is expected to be of type %s", types.BoolType.TypeName())
+			return nil, append(allErrs, field.Invalid(path, rule.CELExpression, msg))
+		}
+		program, err := env.Program(ast)
+		if err != nil {
+			return nil, append(allErrs, field.Invalid(path, rule.CELExpression, err.Error()))
+		}
+		rules = append(rules, compiledImageRule{program: program})
+	}
+	return rules, nil
+}
+
+func compileVariables(path *field.Path, variables []admissionregistrationv1.Variable, variablesProvider *variablesProvider, env *cel.Env) (map[string]cel.Program, field.ErrorList) {
+	var allErrs field.ErrorList
+	programs := map[string]cel.Program{}
+	for i, variable := range variables {
+		path := path.Index(i).Child("expression")
+		ast, issues := env.Compile(variable.Expression)
+		if err := issues.Err(); err != nil {
+			return nil, append(allErrs, field.Invalid(path, variable.Expression, err.Error()))
+		}
+		variablesProvider.RegisterField(variable.Name, ast.OutputType())
+		prog, err := env.Program(ast)
+		if err != nil {
+			return nil, append(allErrs, field.Invalid(path, variable.Expression, err.Error()))
+		}
+		programs[variable.Name] = prog
+	}
+	return programs, nil
+}
+
+// autogenExceptions returns copies of the exceptions with their match conditions converted for the autogen rule
+func autogenExceptions(ruleName string, exceptions []kyvernov2alpha1.CELPolicyException) ([]kyvernov2alpha1.CELPolicyException, error) {
+	converted := make([]kyvernov2alpha1.CELPolicyException, 0, len(exceptions))
+	for _, polex := range exceptions {
+		polex := *polex.DeepCopy()
+		matchConditions, err := autogen.ConvertMatchConditions(ruleName, polex.Spec.MatchConditions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert exception %s (%w)", polex.Name, err)
+		}
+		polex.Spec.MatchConditions = matchConditions
+		converted = append(converted, polex)
+	}
+	return converted, nil
+}
+
+// compileExceptions compiles the match conditions of each exception
+func compileExceptions(exceptions []kyvernov2alpha1.CELPolicyException, env *cel.Env) ([]compiledException, field.ErrorList) {
+	compiled := make([]compiledException, 0, len(exceptions))
+	for _, polex := range exceptions {
+		matchConditions, errs := compileMatchConditions(field.NewPath("spec").Child("matchConditions"), polex.Spec.MatchConditions, env)
+		if errs != nil {
+			return nil, errs
+		}
+		compiled = append(compiled, compiledException{
+			exception:       polex,
+			matchConditions: matchConditions,
+		})
+	}
+	return compiled, nil
+}
+
 func compileValidation(path *field.Path, rule admissionregistrationv1.Validation, env *cel.Env) (compiledValidation, field.ErrorList) {
 	var allErrs field.ErrorList
 	compiled := compiledValidation{
@@ -210,3 +508,46 @@ func compileValidation(path *field.Path, rule admissionregistrationv1.Validation
 	}
 	return compiled, nil
 }
+
+func compileMutation(path *field.Path, rule admissionregistrationv1alpha1.Mutation, env *cel.Env) (compiledMutation, field.ErrorList) {
+	var allErrs field.ErrorList
+	var expression string
+	var expected *cel.Type
+	switch rule.PatchType {
+	case admissionregistrationv1alpha1.PatchTypeApplyConfiguration:
+		if rule.ApplyConfiguration == nil {
+			return compiledMutation{}, append(allErrs, field.Required(path.Child("applyConfiguration"), "must be specified when patchType is ApplyConfiguration"))
+		}
+		path = path.Child("applyConfiguration", "expression")
+		expression = rule.ApplyConfiguration.Expression
+		expected = types.NewObjectType(mutation.ObjectTypeName)
+	case admissionregistrationv1alpha1.PatchTypeJSONPatch:
+		if rule.JSONPatch == nil {
+			return compiledMutation{}, append(allErrs, field.Required(path.Child("jsonPatch"), "must be specified when patchType is JSONPatch"))
+		}
+		path = path.Child("jsonPatch", "expression")
+		expression = rule.JSONPatch.Expression
+		expected = types.NewListType(types.NewObjectType(mutation.JSONPatchTypeName))
+	default:
+		return compiledMutation{}, append(allErrs, field.NotSupported(path.Child("patchType"), rule.PatchType, []string{
+			string(admissionregistrationv1alpha1.PatchTypeApplyConfiguration),
+			string(admissionregistrationv1alpha1.PatchTypeJSONPatch),
+		}))
+	}
+	ast, issues := env.Compile(expression)
+	if err := issues.Err(); err != nil {
+		return compiledMutation{}, append(allErrs, field.Invalid(path, expression, err.Error()))
+	}
+	if !ast.OutputType().IsExactType(expected) && !ast.OutputType().IsExactType(types.DynType) {
+		msg := fmt.Sprintf("output is expected to be of type %s", expected.String())
+		return compiledMutation{}, append(allErrs, field.Invalid(path, expression, msg))
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		return compiledMutation{}, append(allErrs, field.Invalid(path, expression, err.Error()))
+	}
+	return compiledMutation{
+		patchType: rule.PatchType,
+		program:   program,
+	}, nil
+}
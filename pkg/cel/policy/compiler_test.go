@@ -72,3 +72,79 @@ func Test_compiler_Compile(t *testing.T) {
 		})
 	}
 }
+
+func Test_compiler_Compile_Autogen(t *testing.T) {
+	podPolicy := func(annotations map[string]string, config *kyvernov2alpha1.AutogenConfiguration) *kyvernov2alpha1.ValidatingPolicy {
+		return &kyvernov2alpha1.ValidatingPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "foo",
+				Annotations: annotations,
+			},
+			Spec: kyvernov2alpha1.ValidatingPolicySpec{
+				AutogenConfiguration: config,
+				ValidatingAdmissionPolicySpec: admissionregistrationv1.ValidatingAdmissionPolicySpec{
+					MatchConstraints: &admissionregistrationv1.MatchResources{
+						ResourceRules: []admissionregistrationv1.NamedRuleWithOperations{{
+							RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+								Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+								Rule: admissionregistrationv1.Rule{
+									APIGroups:   []string{""},
+									APIVersions: []string{"v1"},
+									Resources:   []string{"pods"},
+								},
+							},
+						}},
+					},
+					Validations: []admissionregistrationv1.Validation{{
+						Expression: "object.spec.containers.all(c, c.image != 'nginx')",
+					}},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name   string
+		policy *kyvernov2alpha1.ValidatingPolicy
+		want   []string
+	}{{
+		name:   "default",
+		policy: podPolicy(nil, nil),
+		want:   []string{"autogen", "autogen-cronjobs"},
+	}, {
+		name:   "disabled by annotation",
+		policy: podPolicy(map[string]string{"pod-policies.kyverno.io/autogen-controllers": "none"}, nil),
+	}, {
+		name:   "annotation",
+		policy: podPolicy(map[string]string{"pod-policies.kyverno.io/autogen-controllers": "Deployment"}, nil),
+		want:   []string{"autogen"},
+	}, {
+		name: "spec takes precedence over annotation",
+		policy: podPolicy(
+			map[string]string{"pod-policies.kyverno.io/autogen-controllers": "Deployment"},
+			&kyvernov2alpha1.AutogenConfiguration{
+				PodControllers: &kyvernov2alpha1.PodControllersGenerationConfiguration{
+					Controllers: []string{"cronjobs"},
+				},
+			},
+		),
+		want: []string{"autogen-cronjobs"},
+	}, {
+		name: "disabled by spec",
+		policy: podPolicy(nil, &kyvernov2alpha1.AutogenConfiguration{
+			PodControllers: &kyvernov2alpha1.PodControllersGenerationConfiguration{},
+		}),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiled, errs := NewCompiler().Compile(tt.policy, nil)
+			assert.NoError(t, errs.ToAggregate())
+			var names []string
+			for _, autogen := range compiled.Autogen() {
+				assert.NotNil(t, autogen.MatchConstraints)
+				assert.NotNil(t, autogen.CompiledPolicy)
+				names = append(names, autogen.Name)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
@@ -2,35 +2,91 @@ package policy
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 
 	contextlib "github.com/kyverno/kyverno/pkg/cel/libs/context"
+	httplib "github.com/kyverno/kyverno/pkg/cel/libs/http"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/globalcontext/projection"
+	"github.com/kyverno/kyverno/pkg/globalcontext/store"
 	"github.com/kyverno/kyverno/pkg/imagedataloader"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
 	"k8s.io/client-go/kubernetes"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 )
 
 type Context = contextlib.ContextInterface
 
+// GlobalContextStore provides access to the global context entries
+type GlobalContextStore interface {
+	Get(key string) (store.Entry, bool)
+}
+
 type contextProvider struct {
 	client    kubernetes.Interface
 	imagedata imagedataloader.Fetcher
+	gctxStore GlobalContextStore
+	resources ResourceLoader
+	http      httplib.HTTPInterface
+	authz     authorizer.Authorizer
+	jp        jmespath.Interface
+
+	lock        sync.Mutex
+	expressions map[string]projection.Projections
 }
 
-func NewContextProvider(client kubernetes.Interface, imageOpts []imagedataloader.Option) (Context, error) {
-	idl, err := imagedataloader.New(client.CoreV1().Secrets(config.KyvernoNamespace()), imageOpts...)
+// NewContextProvider creates the context available to CEL policies, the client, the resource loader, the http
+// implementation and the authorizer are optional and global references are resolved from the given store.
+func NewContextProvider(
+	client kubernetes.Interface,
+	imageOpts []imagedataloader.Option,
+	gctxStore GlobalContextStore,
+	resources ResourceLoader,
+	http httplib.HTTPInterface,
+	authz authorizer.Authorizer,
+) (Context, error) {
+	var secrets corev1.SecretInterface
+	if client != nil {
+		secrets = client.CoreV1().Secrets(config.KyvernoNamespace())
+	}
+	idl, err := imagedataloader.New(secrets, imageOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return &contextProvider{
-		client:    client,
-		imagedata: idl,
+		client:      client,
+		imagedata:   idl,
+		gctxStore:   gctxStore,
+		resources:   resources,
+		http:        http,
+		authz:       authz,
+		jp:          jmespath.New(config.NewDefaultConfiguration(false)),
+		expressions: map[string]projection.Projections{},
 	}, nil
 }
 
 func (cp *contextProvider) GetConfigMap(namespace string, name string) (unstructured.Unstructured, error) {
+	if cp.client == nil {
+		// without a cluster connection configmaps can still be served by the resource loader
+		if cp.resources != nil {
+			cm, err := cp.resources.GetResource(context.TODO(), schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}, namespace, name)
+			if err != nil {
+				return unstructured.Unstructured{}, err
+			}
+			return *cm, nil
+		}
+		return unstructured.Unstructured{}, errors.New("configmaps are not available without a cluster connection")
+	}
 	cm, err := cp.client.CoreV1().ConfigMaps(namespace).Get(context.TODO(), name, metav1.GetOptions{})
 	if err != nil {
 		return unstructured.Unstructured{}, err
@@ -42,11 +98,141 @@ func (cp *contextProvider) GetConfigMap(namespace string, name string) (unstruct
 	return *out, nil
 }
 
-func (cp *contextProvider) GetGlobalReference(string) (any, error) {
-	return nil, nil
+func (cp *contextProvider) GetGlobalReference(name string, jmesPath string) (any, error) {
+	data, err := cp.getGlobalData(name)
+	if err != nil {
+		return nil, err
+	}
+	if jmesPath == "" {
+		return data, nil
+	}
+	result, err := cp.jp.Search(jmesPath, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply jmespath %s to global context entry %s: %w", jmesPath, name, err)
+	}
+	return result, nil
+}
+
+func (cp *contextProvider) GetGlobalReferenceCEL(name string, expression string) (any, error) {
+	data, err := cp.getGlobalData(name)
+	if err != nil {
+		return nil, err
+	}
+	projections, err := cp.compileExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+	result, err := projections.Apply(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply expression %s to global context entry %s: %w", expression, name, err)
+	}
+	return result[expression], nil
+}
+
+func (cp *contextProvider) GetGlobalProjection(name string, projection string) (any, error) {
+	if projection == "" {
+		return nil, fmt.Errorf("global context entry %s: projection name must not be empty", name)
+	}
+	entry, err := cp.getGlobalEntry(name)
+	if err != nil {
+		return nil, err
+	}
+	// named projections are precomputed by the entry and already hold json compatible values
+	data, err := entry.Get(projection)
+	if err != nil {
+		return nil, fmt.Errorf("global context entry %s: %w", name, err)
+	}
+	return data, nil
+}
+
+// getGlobalData returns the data of the entry as json compatible values
+func (cp *contextProvider) getGlobalData(name string) (any, error) {
+	entry, err := cp.getGlobalEntry(name)
+	if err != nil {
+		return nil, err
+	}
+	var data any
+	// entries normalizing their data when it changes don't need to be converted on every read
+	if normalized, ok := entry.(store.Normalized); ok {
+		data, err = normalized.GetNormalized()
+	} else if data, err = entry.Get(""); err == nil {
+		data, err = projection.Normalize(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("global context entry %s: %w", name, err)
+	}
+	return data, nil
+}
+
+// compileExpression compiles a cel expression applied to the data of global context entries, expressions are compiled once
+func (cp *contextProvider) compileExpression(expression string) (projection.Projections, error) {
+	cp.lock.Lock()
+	defer cp.lock.Unlock()
+	if projections, ok := cp.expressions[expression]; ok {
+		return projections, nil
+	}
+	projections, err := projection.New(cp.jp, kyvernov2alpha1.GlobalContextEntryProjection{
+		Name: expression,
+		CEL:  expression,
+	})
+	if err != nil {
+		return nil, err
+	}
+	cp.expressions[expression] = projections
+	return projections, nil
+}
+
+func (cp *contextProvider) getGlobalEntry(name string) (store.Entry, error) {
+	if cp.gctxStore == nil {
+		return nil, fmt.Errorf("global context entry %s not found", name)
+	}
+	entry, ok := cp.gctxStore.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("global context entry %s not found", name)
+	}
+	return entry, nil
+}
+
+func (cp *contextProvider) GetResource(apiVersion, resource, namespace, name string) (*unstructured.Unstructured, error) {
+	if cp.resources == nil {
+		return nil, errors.New("resources are not available without a cluster connection")
+	}
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	return cp.resources.GetResource(context.TODO(), gv.WithResource(resource), namespace, name)
+}
+
+func (cp *contextProvider) ListResources(apiVersion, resource, namespace, labelSelector string) (*unstructured.UnstructuredList, error) {
+	if cp.resources == nil {
+		return nil, errors.New("resources are not available without a cluster connection")
+	}
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector %s: %w", labelSelector, err)
+	}
+	return cp.resources.ListResources(context.TODO(), gv.WithResource(resource), namespace, selector)
+}
+
+// HTTP returns the implementation bound to the http variable of CEL policies
+func (cp *contextProvider) HTTP() httplib.HTTPInterface {
+	return cp.http
+}
+
+// Authorizer returns the authorizer bound to the authorizer variable, it may be nil
+func (cp *contextProvider) Authorizer() authorizer.Authorizer {
+	return cp.authz
 }
 
 func (cp *contextProvider) GetImageData(image string) (*imagedataloader.ImageData, error) {
+	if cp.imagedata == nil {
+		return nil, errors.New("image data is not available")
+	}
 	// TODO: get image credentials from image verification policies?
 	return cp.imagedata.FetchImageData(context.TODO(), image)
 }
@@ -0,0 +1,59 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	httplib "github.com/kyverno/kyverno/pkg/cel/libs/http"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/imagedataloader"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+)
+
+type fakeImageDataLoader struct {
+	images   map[string]imagedataloader.ImageData
+	fallback imagedataloader.Fetcher
+}
+
+// NewFakeImageDataLoader returns a fetcher serving the given image data, images that are not declared are fetched
+// with the fallback fetcher when one is given.
+func NewFakeImageDataLoader(fallback imagedataloader.Fetcher, images ...imagedataloader.ImageData) imagedataloader.Fetcher {
+	loader := &fakeImageDataLoader{
+		images:   map[string]imagedataloader.ImageData{},
+		fallback: fallback,
+	}
+	for _, image := range images {
+		loader.images[image.Image] = image
+	}
+	return loader
+}
+
+func (l *fakeImageDataLoader) FetchImageData(ctx context.Context, image string, options ...imagedataloader.Option) (*imagedataloader.ImageData, error) {
+	if data, ok := l.images[image]; ok {
+		return &data, nil
+	}
+	if l.fallback != nil {
+		return l.fallback.FetchImageData(ctx, image, options...)
+	}
+	return nil, fmt.Errorf("image data not found for %s", image)
+}
+
+// NewFakeContextProvider creates a context served from the given data only, it is used by the CLI to unit test
+// CEL policies: configmaps are looked up in the resource loader and image data comes from the given fetcher.
+func NewFakeContextProvider(
+	gctxStore GlobalContextStore,
+	resources ResourceLoader,
+	imagedata imagedataloader.Fetcher,
+	http httplib.HTTPInterface,
+	authz authorizer.Authorizer,
+) Context {
+	return &contextProvider{
+		imagedata: imagedata,
+		gctxStore: gctxStore,
+		resources: resources,
+		http:      http,
+		authz:     authz,
+		jp:        jmespath.New(config.NewDefaultConfiguration(false)),
+	}
+}
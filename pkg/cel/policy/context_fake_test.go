@@ -0,0 +1,44 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/imagedataloader"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_fakeImageDataLoader(t *testing.T) {
+	fallback := NewFakeImageDataLoader(nil, imagedataloader.ImageData{Image: "ghcr.io/kyverno/fallback:v1"})
+	loader := NewFakeImageDataLoader(
+		fallback,
+		imagedataloader.ImageData{Image: "ghcr.io/kyverno/kyverno:v1", Registry: "ghcr.io"},
+	)
+	data, err := loader.FetchImageData(context.TODO(), "ghcr.io/kyverno/kyverno:v1")
+	assert.NoError(t, err)
+	assert.Equal(t, "ghcr.io", data.Registry)
+	data, err = loader.FetchImageData(context.TODO(), "ghcr.io/kyverno/fallback:v1")
+	assert.NoError(t, err)
+	assert.Equal(t, "ghcr.io/kyverno/fallback:v1", data.Image)
+	_, err = loader.FetchImageData(context.TODO(), "ghcr.io/kyverno/missing:v1")
+	assert.Error(t, err)
+}
+
+func Test_fakeContextProvider(t *testing.T) {
+	cm := &unstructured.Unstructured{}
+	cm.SetAPIVersion("v1")
+	cm.SetKind("ConfigMap")
+	cm.SetNamespace("default")
+	cm.SetName("config")
+	assert.NoError(t, unstructured.SetNestedField(cm.Object, "bar", "data", "foo"))
+	provider := NewFakeContextProvider(nil, NewFakeResourceLoader(cm), NewFakeImageDataLoader(nil), nil, nil)
+	got, err := provider.GetConfigMap("default", "config")
+	assert.NoError(t, err)
+	value, _, _ := unstructured.NestedString(got.Object, "data", "foo")
+	assert.Equal(t, "bar", value)
+	_, err = provider.GetConfigMap("default", "missing")
+	assert.Error(t, err)
+	_, err = provider.GetImageData("ghcr.io/kyverno/kyverno:v1")
+	assert.Error(t, err)
+}
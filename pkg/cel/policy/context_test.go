@@ -0,0 +1,178 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/globalcontext/projection"
+	"github.com/kyverno/kyverno/pkg/globalcontext/static"
+	"github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"github.com/stretchr/testify/assert"
+)
+
+type notReadyEntry struct{}
+
+func (notReadyEntry) Get(string) (any, error) {
+	return nil, store.ErrEntryNotReady
+}
+
+func (notReadyEntry) Stop() {}
+
+func Test_contextProvider_GetGlobalReference(t *testing.T) {
+	gctxStore := store.New()
+	gctxStore.Set("deployments", static.New([]byte(`{"items":[{"name":"foo"},{"name":"bar"}]}`)))
+	gctxStore.Set("replicas", static.New(map[string]int{"foo": 3}))
+	gctxStore.Set("pending", notReadyEntry{})
+	projections, err := projection.New(jmespath.New(config.NewDefaultConfiguration(false)), kyvernov2alpha1.GlobalContextEntryProjection{
+		Name:     "names",
+		JMESPath: "items[].name",
+	})
+	assert.NoError(t, err)
+	gctxStore.Set("projected", static.NewWithProjections([]byte(`{"items":[{"name":"foo"},{"name":"bar"}]}`), projections))
+	provider, err := NewContextProvider(nil, nil, gctxStore, nil, nil, nil)
+	assert.NoError(t, err)
+	tests := []struct {
+		name       string
+		entry      string
+		projection string
+		want       any
+		wantErr    bool
+		notReady   bool
+	}{{
+		name:  "raw json",
+		entry: "deployments",
+		want: map[string]any{
+			"items": []any{
+				map[string]any{"name": "foo"},
+				map[string]any{"name": "bar"},
+			},
+		},
+	}, {
+		name:  "go value",
+		entry: "replicas",
+		want:  map[string]any{"foo": float64(3)},
+	}, {
+		name:       "projection",
+		entry:      "deployments",
+		projection: "items[].name",
+		want:       []any{"foo", "bar"},
+	}, {
+		name:       "projection of entry with named projections",
+		entry:      "projected",
+		projection: "items[0].name",
+		want:       "foo",
+	}, {
+		name:     "not ready",
+		entry:    "pending",
+		wantErr:  true,
+		notReady: true,
+	}, {
+		name:    "not found",
+		entry:   "missing",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := provider.GetGlobalReference(tt.entry, tt.projection)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.notReady, errors.Is(err, store.ErrEntryNotReady))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_contextProvider_GetGlobalProjection(t *testing.T) {
+	gctxStore := store.New()
+	projections, err := projection.New(jmespath.New(config.NewDefaultConfiguration(false)), kyvernov2alpha1.GlobalContextEntryProjection{
+		Name:     "names",
+		JMESPath: "items[].name",
+	})
+	assert.NoError(t, err)
+	gctxStore.Set("projected", static.NewWithProjections([]byte(`{"items":[{"name":"foo"},{"name":"bar"}]}`), projections))
+	gctxStore.Set("pending", notReadyEntry{})
+	provider, err := NewContextProvider(nil, nil, gctxStore, nil, nil, nil)
+	assert.NoError(t, err)
+	tests := []struct {
+		name       string
+		entry      string
+		projection string
+		want       any
+		wantErr    error
+	}{{
+		name:       "named projection",
+		entry:      "projected",
+		projection: "names",
+		want:       []any{"foo", "bar"},
+	}, {
+		name:       "jmespath is not a named projection",
+		entry:      "projected",
+		projection: "items[0].name",
+		wantErr:    store.ErrProjectionNotFound,
+	}, {
+		name:       "not ready",
+		entry:      "pending",
+		projection: "names",
+		wantErr:    store.ErrEntryNotReady,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := provider.GetGlobalProjection(tt.entry, tt.projection)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_contextProvider_GetGlobalReferenceCEL(t *testing.T) {
+	gctxStore := store.New()
+	gctxStore.Set("deployments", static.New([]byte(`{"items":[{"name":"foo","replicas":3},{"name":"bar","replicas":1}]}`)))
+	gctxStore.Set("pending", notReadyEntry{})
+	provider, err := NewContextProvider(nil, nil, gctxStore, nil, nil, nil)
+	assert.NoError(t, err)
+	tests := []struct {
+		name       string
+		entry      string
+		expression string
+		want       any
+		wantErr    error
+	}{{
+		name:       "expression",
+		entry:      "deployments",
+		expression: "data.items.filter(i, i.replicas > 1).map(i, i.name)",
+		want:       []any{"foo"},
+	}, {
+		name:       "same expression",
+		entry:      "deployments",
+		expression: "data.items.filter(i, i.replicas > 1).map(i, i.name)",
+		want:       []any{"foo"},
+	}, {
+		name:       "not ready",
+		entry:      "pending",
+		expression: "data",
+		wantErr:    store.ErrEntryNotReady,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := provider.GetGlobalReferenceCEL(tt.entry, tt.expression)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+	_, err = provider.GetGlobalReferenceCEL("deployments", "data.")
+	assert.Error(t, err)
+}
@@ -0,0 +1,119 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types/ref"
+	contextlib "github.com/kyverno/kyverno/pkg/cel/libs/context"
+	"github.com/kyverno/kyverno/pkg/cel/utils"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apiserver/pkg/admission"
+)
+
+type CompiledGeneratingPolicy interface {
+	// Match returns true if the policy applies to the request, requests matching an exception are not matched
+	Match(context.Context, admission.Attributes, *admissionv1.AdmissionRequest, runtime.Object) (bool, error)
+	// Generate evaluates the generate expressions and returns the resources to generate
+	Generate(context.Context, admission.Attributes, *admissionv1.AdmissionRequest, runtime.Object, contextlib.ContextInterface) ([]*unstructured.Unstructured, error)
+}
+
+type compiledGeneratingPolicy struct {
+	failurePolicy   admissionregistrationv1.FailurePolicyType
+	matchConditions []cel.Program
+	variables       map[string]cel.Program
+	generations     []cel.Program
+	exceptions      []compiledException
+}
+
+func (p *compiledGeneratingPolicy) Match(
+	ctx context.Context,
+	attr admission.Attributes,
+	request *admissionv1.AdmissionRequest,
+	namespace runtime.Object,
+) (bool, error) {
+	// check if the resource matches an exception
+	if len(p.exceptions) > 0 {
+		matched, err := matchExceptions(ctx, attr, request, namespace, p.failurePolicy, p.exceptions)
+		if err != nil {
+			return false, err
+		}
+		if len(matched) > 0 {
+			return false, nil
+		}
+	}
+	return match(ctx, attr.GetObject(), attr.GetOldObject(), request, namespace, p.failurePolicy, p.matchConditions)
+}
+
+func (p *compiledGeneratingPolicy) Generate(
+	ctx context.Context,
+	attr admission.Attributes,
+	request *admissionv1.AdmissionRequest,
+	namespace runtime.Object,
+	context contextlib.ContextInterface,
+) ([]*unstructured.Unstructured, error) {
+	data, err := prepareData(ctx, attr.GetObject(), attr.GetOldObject(), request, namespace, context, p.variables)
+	if err != nil {
+		return nil, err
+	}
+	var resources []*unstructured.Unstructured
+	for i, generation := range p.generations {
+		out, _, err := generation.ContextEval(ctx, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate generate expression %d: %w", i, err)
+		}
+		generated, err := convertToResources(out)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert generate expression %d output: %w", i, err)
+		}
+		resources = append(resources, generated...)
+	}
+	return resources, nil
+}
+
+// convertToResources converts the output of a generate expression, either a single object or a list of objects
+func convertToResources(out ref.Val) ([]*unstructured.Unstructured, error) {
+	value, err := utils.ConvertToNative[*structpb.Value](out)
+	if err != nil {
+		return nil, err
+	}
+	// round trip through json so that numbers are decoded as int64 when possible
+	raw, err := protojson.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var decoded any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, err
+	}
+	var objects []any
+	switch typed := decoded.(type) {
+	case []any:
+		objects = typed
+	default:
+		objects = []any{typed}
+	}
+	resources := make([]*unstructured.Unstructured, 0, len(objects))
+	for _, object := range objects {
+		typed, ok := object.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected an object but got %T", object)
+		}
+		resource := &unstructured.Unstructured{Object: typed}
+		if resource.GetAPIVersion() == "" || resource.GetKind() == "" {
+			return nil, fmt.Errorf("generated resource must specify apiVersion and kind")
+		}
+		if resource.GetName() == "" {
+			return nil, fmt.Errorf("generated resource %s must specify a name", resource.GetKind())
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
@@ -0,0 +1,121 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+)
+
+func Test_compiler_CompileGenerating(t *testing.T) {
+	tests := []struct {
+		name        string
+		generations []kyvernov2alpha1.Generation
+		wantErr     bool
+	}{{
+		name: "single object",
+		generations: []kyvernov2alpha1.Generation{{
+			Expression: "{'apiVersion': dyn('v1'), 'kind': dyn('ConfigMap'), 'metadata': dyn({'name': 'foo'})}",
+		}},
+	}, {
+		name: "list of objects",
+		generations: []kyvernov2alpha1.Generation{{
+			Expression: "[{'apiVersion': dyn('v1'), 'kind': dyn('ConfigMap'), 'metadata': dyn({'name': 'foo'})}]",
+		}},
+	}, {
+		name: "invalid expression",
+		generations: []kyvernov2alpha1.Generation{{
+			Expression: "{'apiVersion': ",
+		}},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &kyvernov2alpha1.GeneratingPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: kyvernov2alpha1.GeneratingPolicySpec{
+					Generation: tt.generations,
+				},
+			}
+			compiled, errs := NewCompiler().CompileGenerating(policy, nil)
+			if tt.wantErr {
+				assert.Error(t, errs.ToAggregate())
+			} else {
+				assert.NoError(t, errs.ToAggregate())
+				assert.NotNil(t, compiled)
+			}
+		})
+	}
+}
+
+func Test_compiledGeneratingPolicy(t *testing.T) {
+	policy := &kyvernov2alpha1.GeneratingPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+		Spec: kyvernov2alpha1.GeneratingPolicySpec{
+			MatchConditions: []admissionregistrationv1.MatchCondition{{
+				Name:       "labelled",
+				Expression: "has(object.metadata.labels) && 'generate' in object.metadata.labels",
+			}},
+			Variables: []admissionregistrationv1.Variable{{
+				Name:       "name",
+				Expression: "object.metadata.name + '-copy'",
+			}},
+			Generation: []kyvernov2alpha1.Generation{{
+				Expression: "{'apiVersion': dyn('v1'), 'kind': dyn('ConfigMap'), 'metadata': dyn({'name': variables.name}), 'data': dyn({'replicas': string(1)})}",
+			}, {
+				Expression: "[{'apiVersion': dyn('v1'), 'kind': dyn('Secret'), 'metadata': dyn({'name': 'a'})}, {'apiVersion': dyn('v1'), 'kind': dyn('Secret'), 'metadata': dyn({'name': 'b'})}]",
+			}},
+		},
+	}
+	compiled, errs := NewCompiler().CompileGenerating(policy, nil)
+	assert.NoError(t, errs.ToAggregate())
+	attributes := func(labels map[string]any) admission.Attributes {
+		object := &unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Namespace",
+				"metadata": map[string]any{
+					"name":   "test",
+					"labels": labels,
+				},
+			},
+		}
+		return admission.NewAttributesRecord(
+			object,
+			nil,
+			schema.GroupVersionKind{Version: "v1", Kind: "Namespace"},
+			"",
+			"test",
+			schema.GroupVersionResource{Version: "v1", Resource: "namespaces"},
+			"",
+			admission.Create,
+			nil,
+			false,
+			nil,
+		)
+	}
+	matched, err := compiled.Match(context.TODO(), attributes(map[string]any{"foo": "bar"}), nil, nil)
+	assert.NoError(t, err)
+	assert.False(t, matched)
+	attr := attributes(map[string]any{"generate": "true"})
+	matched, err = compiled.Match(context.TODO(), attr, nil, nil)
+	assert.NoError(t, err)
+	assert.True(t, matched)
+	resources, err := compiled.Generate(context.TODO(), attr, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, resources, 3)
+	assert.Equal(t, "ConfigMap", resources[0].GetKind())
+	assert.Equal(t, "test-copy", resources[0].GetName())
+	assert.Equal(t, "a", resources[1].GetName())
+	assert.Equal(t, "b", resources[2].GetName())
+}
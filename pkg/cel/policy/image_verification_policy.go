@@ -0,0 +1,129 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"github.com/kyverno/kyverno/ext/wildcard"
+	contextlib "github.com/kyverno/kyverno/pkg/cel/libs/context"
+	"github.com/kyverno/kyverno/pkg/cel/libs/imageverify"
+	"github.com/kyverno/kyverno/pkg/cel/utils"
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+)
+
+type CompiledImageVerificationPolicy interface {
+	// Match returns true if the request matches the policy match conditions and no exception applies
+	Match(context.Context, admission.Attributes, *admissionv1.AdmissionRequest, runtime.Object) (bool, error)
+	// MatchImage returns true if the image is selected by the policy image rules
+	MatchImage(string) (bool, error)
+	// Evaluate evaluates the policy verifications, images contains the selected images indexed by extractor name
+	Evaluate(context.Context, admission.Attributes, *admissionv1.AdmissionRequest, runtime.Object, contextlib.ContextInterface, map[string][]string) ([]EvaluationResult, error)
+}
+
+type compiledImageRule struct {
+	glob    string
+	program cel.Program
+}
+
+type compiledImageVerificationPolicy struct {
+	failurePolicy   admissionregistrationv1.FailurePolicyType
+	matchConditions []cel.Program
+	variables       map[string]cel.Program
+	imageRules      []compiledImageRule
+	verifications   []compiledValidation
+	attestors       map[string]any
+	attestations    map[string]any
+	exceptions      []compiledException
+	verifier        imageverify.Verifier
+}
+
+func (p *compiledImageVerificationPolicy) Match(
+	ctx context.Context,
+	attr admission.Attributes,
+	request *admissionv1.AdmissionRequest,
+	namespace runtime.Object,
+) (bool, error) {
+	// check if the resource matches an exception
+	if len(p.exceptions) > 0 {
+		matched, err := matchExceptions(ctx, attr, request, namespace, p.failurePolicy, p.exceptions)
+		if err != nil {
+			return false, err
+		}
+		if len(matched) > 0 {
+			return false, nil
+		}
+	}
+	return match(ctx, attr.GetObject(), attr.GetOldObject(), request, namespace, p.failurePolicy, p.matchConditions)
+}
+
+func (p *compiledImageVerificationPolicy) MatchImage(image string) (bool, error) {
+	if len(p.imageRules) == 0 {
+		return true, nil
+	}
+	for _, rule := range p.imageRules {
+		if rule.program == nil {
+			if wildcard.Match(rule.glob, image) {
+				return true, nil
+			}
+			continue
+		}
+		out, _, err := rule.program.Eval(map[string]any{ImageRefKey: image})
+		if err != nil {
+			return false, err
+		}
+		result, err := utils.ConvertToNative[bool](out)
+		if err != nil {
+			return false, err
+		}
+		if result {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (p *compiledImageVerificationPolicy) Evaluate(
+	ctx context.Context,
+	attr admission.Attributes,
+	request *admissionv1.AdmissionRequest,
+	namespace runtime.Object,
+	context contextlib.ContextInterface,
+	images map[string][]string,
+) ([]EvaluationResult, error) {
+	data, err := prepareData(ctx, attr.GetObject(), attr.GetOldObject(), request, namespace, context, p.variables)
+	if err != nil {
+		return nil, err
+	}
+	data[ImagesKey] = images
+	data[AttestorsKey] = p.attestors
+	data[AttestationsKey] = p.attestations
+	data[imageverify.VerifierKey] = imageverify.NewImageVerifier(ctx, p.verifier)
+	results := make([]EvaluationResult, 0, len(p.verifications))
+	for _, verification := range p.verifications {
+		out, _, err := verification.program.ContextEval(ctx, data)
+		// evaluate only when rule fails
+		var message string
+		if outcome, err := utils.ConvertToNative[bool](out); err == nil && !outcome {
+			message = verification.message
+			if verification.messageExpression != nil {
+				if out, _, err := verification.messageExpression.ContextEval(ctx, data); err != nil {
+					message = fmt.Sprintf("failed to evaluate message expression: %s", err)
+				} else if msg, err := utils.ConvertToNative[string](out); err != nil {
+					message = fmt.Sprintf("failed to convert message expression to string: %s", err)
+				} else {
+					message = msg
+				}
+			}
+		}
+		results = append(results, EvaluationResult{
+			Result:  out,
+			Message: message,
+			Error:   err,
+		})
+	}
+	return results, nil
+}
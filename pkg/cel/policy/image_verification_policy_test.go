@@ -0,0 +1,207 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/cel/utils"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+)
+
+type fakeVerifier struct {
+	signed map[string][]string
+}
+
+func (v *fakeVerifier) VerifyImageSignatures(_ context.Context, image string, attestors []kyvernov2alpha1.Attestor) (int, error) {
+	count := 0
+	for _, attestor := range attestors {
+		for _, signer := range v.signed[image] {
+			if signer == attestor.Name {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+func (v *fakeVerifier) VerifyAttestationSignatures(ctx context.Context, image string, _ kyvernov2alpha1.Attestation, attestors []kyvernov2alpha1.Attestor) (int, error) {
+	return v.VerifyImageSignatures(ctx, image, attestors)
+}
+
+func (v *fakeVerifier) ExtractPayload(context.Context, string, kyvernov2alpha1.Attestation) (any, error) {
+	return nil, nil
+}
+
+func Test_compiler_CompileImageVerification(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    kyvernov2alpha1.ImageVerificationPolicySpec
+		wantErr bool
+	}{{
+		name: "valid",
+		spec: kyvernov2alpha1.ImageVerificationPolicySpec{
+			ImageRules: []kyvernov2alpha1.ImageRule{{Glob: "ghcr.io/*"}, {CELExpression: "ref.startsWith('docker.io/')"}},
+			Attestors: []kyvernov2alpha1.Attestor{{
+				Name:   "notary",
+				Notary: &kyvernov2alpha1.Notary{Certs: "cert"},
+			}},
+			Attestations: []kyvernov2alpha1.Attestation{{
+				Name:   "sbom",
+				InToto: &kyvernov2alpha1.InToto{Type: "cyclonedx"},
+			}},
+			Verifications: []admissionregistrationv1.Validation{{
+				Expression: "images.containers.all(image, verifyImageSignatures(image, [attestors.notary]) > 0)",
+			}},
+		},
+	}, {
+		name: "image rule without glob or cel",
+		spec: kyvernov2alpha1.ImageVerificationPolicySpec{
+			ImageRules: []kyvernov2alpha1.ImageRule{{}},
+		},
+		wantErr: true,
+	}, {
+		name: "image rule with non boolean cel",
+		spec: kyvernov2alpha1.ImageVerificationPolicySpec{
+			ImageRules: []kyvernov2alpha1.ImageRule{{CELExpression: "ref"}},
+		},
+		wantErr: true,
+	}, {
+		name: "attestor with cosign and notary",
+		spec: kyvernov2alpha1.ImageVerificationPolicySpec{
+			Attestors: []kyvernov2alpha1.Attestor{{
+				Name:   "both",
+				Cosign: &kyvernov2alpha1.Cosign{},
+				Notary: &kyvernov2alpha1.Notary{},
+			}},
+		},
+		wantErr: true,
+	}, {
+		name: "attestation without type",
+		spec: kyvernov2alpha1.ImageVerificationPolicySpec{
+			Attestations: []kyvernov2alpha1.Attestation{{
+				Name: "none",
+			}},
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &kyvernov2alpha1.ImageVerificationPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: tt.spec,
+			}
+			compiled, errs := NewCompiler().CompileImageVerification(policy, nil, nil)
+			if tt.wantErr {
+				assert.Error(t, errs.ToAggregate())
+			} else {
+				assert.NoError(t, errs.ToAggregate())
+				assert.NotNil(t, compiled)
+			}
+		})
+	}
+}
+
+func Test_compiledImageVerificationPolicy_MatchImage(t *testing.T) {
+	policy := &kyvernov2alpha1.ImageVerificationPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+		Spec: kyvernov2alpha1.ImageVerificationPolicySpec{
+			ImageRules: []kyvernov2alpha1.ImageRule{{Glob: "ghcr.io/kyverno/*"}, {CELExpression: "ref.startsWith('docker.io/')"}},
+		},
+	}
+	compiled, errs := NewCompiler().CompileImageVerification(policy, nil, nil)
+	assert.NoError(t, errs.ToAggregate())
+	for image, want := range map[string]bool{
+		"ghcr.io/kyverno/kyverno:latest": true,
+		"docker.io/nginx:latest":         true,
+		"quay.io/nginx:latest":           false,
+	} {
+		matches, err := compiled.MatchImage(image)
+		assert.NoError(t, err)
+		assert.Equal(t, want, matches, image)
+	}
+}
+
+func Test_compiledImageVerificationPolicy_Evaluate(t *testing.T) {
+	policy := &kyvernov2alpha1.ImageVerificationPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+		Spec: kyvernov2alpha1.ImageVerificationPolicySpec{
+			Attestors: []kyvernov2alpha1.Attestor{{
+				Name:   "notary",
+				Notary: &kyvernov2alpha1.Notary{Certs: "cert"},
+			}},
+			Verifications: []admissionregistrationv1.Validation{{
+				Expression: "images.containers.all(image, verifyImageSignatures(image, [attestors.notary]) > 0)",
+				Message:    "images must be signed",
+			}},
+		},
+	}
+	verifier := &fakeVerifier{
+		signed: map[string][]string{
+			"ghcr.io/kyverno/signed:v1": {"notary"},
+		},
+	}
+	compiled, errs := NewCompiler().CompileImageVerification(policy, nil, verifier)
+	assert.NoError(t, errs.ToAggregate())
+	object := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"name":      "test",
+				"namespace": "default",
+			},
+		},
+	}
+	attr := admission.NewAttributesRecord(
+		object,
+		nil,
+		schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+		"default",
+		"test",
+		schema.GroupVersionResource{Version: "v1", Resource: "pods"},
+		"",
+		admission.Create,
+		nil,
+		false,
+		nil,
+	)
+	tests := []struct {
+		name    string
+		images  []string
+		want    bool
+		message string
+	}{{
+		name:   "signed",
+		images: []string{"ghcr.io/kyverno/signed:v1"},
+		want:   true,
+	}, {
+		name:    "unsigned",
+		images:  []string{"ghcr.io/kyverno/signed:v1", "ghcr.io/kyverno/unsigned:v1"},
+		want:    false,
+		message: "images must be signed",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := compiled.Evaluate(context.TODO(), attr, nil, nil, nil, map[string][]string{"containers": tt.images})
+			assert.NoError(t, err)
+			assert.Len(t, results, 1)
+			assert.NoError(t, results[0].Error)
+			result, err := utils.ConvertToNative[bool](results[0].Result)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+			assert.Equal(t, tt.message, results[0].Message)
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	contextlib "github.com/kyverno/kyverno/pkg/cel/libs/context"
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/managedfields"
+	"k8s.io/apiserver/pkg/admission"
+	plugincel "k8s.io/apiserver/pkg/admission/plugin/cel"
+	"k8s.io/apiserver/pkg/admission/plugin/policy/mutating/patch"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+)
+
+type MutatingEvaluationResult struct {
+	// PatchedResource is the resource after all mutations have been applied
+	PatchedResource *unstructured.Unstructured
+	// Results contains one entry per mutation that was evaluated
+	Results []MutationResult
+}
+
+type MutationResult struct {
+	Error error
+	// Modified is true when the mutation changed the resource
+	Modified bool
+}
+
+type CompiledMutatingPolicy interface {
+	Evaluate(context.Context, admission.Attributes, *admissionv1.AdmissionRequest, runtime.Object, contextlib.ContextInterface, managedfields.TypeConverter) (*MutatingEvaluationResult, error)
+}
+
+type compiledMutation struct {
+	patchType admissionregistrationv1alpha1.PatchType
+	program   cel.Program
+}
+
+type compiledMutatingPolicy struct {
+	failurePolicy   admissionregistrationv1.FailurePolicyType
+	matchConditions []cel.Program
+	variables       map[string]cel.Program
+	mutations       []compiledMutation
+	exceptions      []compiledException
+}
+
+func (p *compiledMutatingPolicy) Evaluate(
+	ctx context.Context,
+	attr admission.Attributes,
+	request *admissionv1.AdmissionRequest,
+	namespace runtime.Object,
+	context contextlib.ContextInterface,
+	typeConverter managedfields.TypeConverter,
+) (*MutatingEvaluationResult, error) {
+	// check if the resource matches an exception
+	if len(p.exceptions) > 0 {
+		matched, err := matchExceptions(ctx, attr, request, namespace, p.failurePolicy, p.exceptions)
+		if err != nil {
+			return nil, err
+		}
+		if len(matched) > 0 {
+			return nil, nil
+		}
+	}
+	match, err := match(ctx, attr.GetObject(), attr.GetOldObject(), request, namespace, p.failurePolicy, p.matchConditions)
+	if err != nil {
+		return nil, err
+	}
+	if !match {
+		return nil, nil
+	}
+	versionedAttributes, err := admission.NewVersionedAttributes(attr, attr.GetKind(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var ns *corev1.Namespace
+	if namespace != nil {
+		if n, ok := namespace.(*corev1.Namespace); ok {
+			ns = n
+		}
+	}
+	if typeConverter == nil {
+		typeConverter = managedfields.NewDeducedTypeConverter()
+	}
+	result := &MutatingEvaluationResult{
+		Results: make([]MutationResult, 0, len(p.mutations)),
+	}
+	for _, mutation := range p.mutations {
+		evaluator := &mutationEvaluator{
+			program:   mutation.program,
+			variables: p.variables,
+			request:   request,
+			namespace: namespace,
+			context:   context,
+		}
+		var patcher patch.Patcher
+		switch mutation.patchType {
+		case admissionregistrationv1alpha1.PatchTypeJSONPatch:
+			patcher = patch.NewJSONPatcher(evaluator)
+		case admissionregistrationv1alpha1.PatchTypeApplyConfiguration:
+			patcher = patch.NewApplyConfigurationPatcher(evaluator)
+		}
+		patched, err := patcher.Patch(ctx, patch.Request{
+			MatchedResource:     attr.GetResource(),
+			VersionedAttributes: versionedAttributes,
+			ObjectInterfaces:    admission.NewObjectInterfacesFromScheme(runtime.NewScheme()),
+			Namespace:           ns,
+			TypeConverter:       typeConverter,
+		}, celconfig.RuntimeCELCostBudget)
+		if err != nil {
+			// stop applying mutations, the resource would be left in an intermediate state
+			result.Results = append(result.Results, MutationResult{Error: err})
+			return result, nil
+		}
+		modified := !equality.Semantic.DeepEqual(versionedAttributes.VersionedObject, patched)
+		versionedAttributes.Dirty = true
+		versionedAttributes.VersionedObject = patched
+		result.Results = append(result.Results, MutationResult{Modified: modified})
+	}
+	patchedResource, err := convertObjectToUnstructured(versionedAttributes.VersionedObject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert patched resource: %w", err)
+	}
+	result.PatchedResource = patchedResource
+	return result, nil
+}
+
+// mutationEvaluator implements plugincel.MutatingEvaluator on top of a kyverno compiled program,
+// this allows reusing the upstream patchers while keeping the kyverno specific variables available.
+type mutationEvaluator struct {
+	program   cel.Program
+	variables map[string]cel.Program
+	request   *admissionv1.AdmissionRequest
+	namespace runtime.Object
+	context   contextlib.ContextInterface
+}
+
+func (e *mutationEvaluator) ForInput(
+	ctx context.Context,
+	versionedAttr *admission.VersionedAttributes,
+	_ *admissionv1.AdmissionRequest,
+	_ plugincel.OptionalVariableBindings,
+	_ *corev1.Namespace,
+	runtimeCELCostBudget int64,
+) (plugincel.EvaluationResult, int64, error) {
+	// object is the result of the previous mutations
+	data, err := prepareData(ctx, versionedAttr.VersionedObject, versionedAttr.VersionedOldObject, e.request, e.namespace, e.context, e.variables)
+	if err != nil {
+		return plugincel.EvaluationResult{}, -1, err
+	}
+	out, _, err := e.program.ContextEval(ctx, data)
+	return plugincel.EvaluationResult{
+		EvalResult: out,
+		Error:      err,
+	}, runtimeCELCostBudget, nil
+}
+
+func (e *mutationEvaluator) CompilationErrors() []error {
+	return nil
+}
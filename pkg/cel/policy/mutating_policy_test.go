@@ -0,0 +1,149 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+)
+
+func Test_compiler_CompileMutating(t *testing.T) {
+	tests := []struct {
+		name      string
+		mutations []admissionregistrationv1alpha1.Mutation
+		wantErr   bool
+	}{{
+		name: "apply configuration",
+		mutations: []admissionregistrationv1alpha1.Mutation{{
+			PatchType: admissionregistrationv1alpha1.PatchTypeApplyConfiguration,
+			ApplyConfiguration: &admissionregistrationv1alpha1.ApplyConfiguration{
+				Expression: "Object{metadata: Object.metadata{labels: {'foo': 'bar'}}}",
+			},
+		}},
+	}, {
+		name: "json patch",
+		mutations: []admissionregistrationv1alpha1.Mutation{{
+			PatchType: admissionregistrationv1alpha1.PatchTypeJSONPatch,
+			JSONPatch: &admissionregistrationv1alpha1.JSONPatch{
+				Expression: "[JSONPatch{op: 'add', path: '/metadata/labels', value: {'foo': 'bar'}}]",
+			},
+		}},
+	}, {
+		name: "json patch with wrong output type",
+		mutations: []admissionregistrationv1alpha1.Mutation{{
+			PatchType: admissionregistrationv1alpha1.PatchTypeJSONPatch,
+			JSONPatch: &admissionregistrationv1alpha1.JSONPatch{
+				Expression: "'foo'",
+			},
+		}},
+		wantErr: true,
+	}, {
+		name: "missing apply configuration",
+		mutations: []admissionregistrationv1alpha1.Mutation{{
+			PatchType: admissionregistrationv1alpha1.PatchTypeApplyConfiguration,
+		}},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &kyvernov2alpha1.MutatingPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: kyvernov2alpha1.MutatingPolicySpec{
+					Mutations: tt.mutations,
+				},
+			}
+			c := NewCompiler()
+			compiled, errs := c.CompileMutating(policy, nil)
+			if tt.wantErr {
+				assert.Error(t, errs.ToAggregate())
+			} else {
+				assert.NoError(t, errs.ToAggregate())
+				assert.NotNil(t, compiled)
+			}
+		})
+	}
+}
+
+func Test_compiledMutatingPolicy_Evaluate(t *testing.T) {
+	policy := &kyvernov2alpha1.MutatingPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+		Spec: kyvernov2alpha1.MutatingPolicySpec{
+			Mutations: []admissionregistrationv1alpha1.Mutation{{
+				PatchType: admissionregistrationv1alpha1.PatchTypeApplyConfiguration,
+				ApplyConfiguration: &admissionregistrationv1alpha1.ApplyConfiguration{
+					Expression: "Object{metadata: Object.metadata{labels: {'foo': 'bar'}}}",
+				},
+			}, {
+				PatchType: admissionregistrationv1alpha1.PatchTypeJSONPatch,
+				JSONPatch: &admissionregistrationv1alpha1.JSONPatch{
+					Expression: "[JSONPatch{op: 'add', path: '/metadata/labels/copy', value: object.metadata.labels.foo}]",
+				},
+			}},
+		},
+	}
+	compiled, errs := NewCompiler().CompileMutating(policy, nil)
+	assert.NoError(t, errs.ToAggregate())
+	object := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"name":      "test",
+				"namespace": "default",
+			},
+		},
+	}
+	attr := admission.NewAttributesRecord(
+		object,
+		nil,
+		schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+		"default",
+		"test",
+		schema.GroupVersionResource{Version: "v1", Resource: "configmaps"},
+		"",
+		admission.Create,
+		nil,
+		false,
+		nil,
+	)
+	result, err := compiled.Evaluate(context.TODO(), attr, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Len(t, result.Results, 2)
+	for _, r := range result.Results {
+		assert.NoError(t, r.Error)
+		assert.True(t, r.Modified)
+	}
+	assert.Equal(t, map[string]string{"foo": "bar", "copy": "bar"}, result.PatchedResource.GetLabels())
+	// the mutations don't change the patched resource
+	attr = admission.NewAttributesRecord(
+		result.PatchedResource,
+		nil,
+		schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+		"default",
+		"test",
+		schema.GroupVersionResource{Version: "v1", Resource: "configmaps"},
+		"",
+		admission.Create,
+		nil,
+		false,
+		nil,
+	)
+	result, err = compiled.Evaluate(context.TODO(), attr, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, result.Results, 2)
+	for _, r := range result.Results {
+		assert.NoError(t, r.Error)
+		assert.False(t, r.Modified)
+	}
+}
@@ -8,15 +8,20 @@ import (
 	"github.com/google/cel-go/cel"
 	"github.com/google/cel-go/common/types"
 	"github.com/google/cel-go/common/types/ref"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	contextlib "github.com/kyverno/kyverno/pkg/cel/libs/context"
+	httplib "github.com/kyverno/kyverno/pkg/cel/libs/http"
 	"github.com/kyverno/kyverno/pkg/cel/utils"
 	"go.uber.org/multierr"
 	admissionv1 "k8s.io/api/admission/v1"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
 	"k8s.io/apiserver/pkg/cel/lazy"
+	"k8s.io/apiserver/pkg/cel/library"
 )
 
 type EvaluationResult struct {
@@ -26,7 +31,18 @@ type EvaluationResult struct {
 }
 
 type CompiledPolicy interface {
+	// MatchExceptions returns the exceptions matching the request, validations should not be evaluated when any matched
+	MatchExceptions(context.Context, admission.Attributes, *admissionv1.AdmissionRequest, runtime.Object) ([]kyvernov2alpha1.CELPolicyException, error)
 	Evaluate(context.Context, admission.Attributes, *admissionv1.AdmissionRequest, runtime.Object, contextlib.ContextInterface) ([]EvaluationResult, error)
+	// Autogen returns the policies generated for pod controllers
+	Autogen() []AutogenPolicy
+}
+
+// AutogenPolicy is a compiled policy generated from a pod policy to apply on pod controllers
+type AutogenPolicy struct {
+	Name             string
+	MatchConstraints *admissionregistrationv1.MatchResources
+	CompiledPolicy   CompiledPolicy
 }
 
 type compiledValidation struct {
@@ -35,13 +51,23 @@ type compiledValidation struct {
 	program           cel.Program
 }
 
+type compiledException struct {
+	exception       kyvernov2alpha1.CELPolicyException
+	matchConditions []cel.Program
+}
+
 type compiledPolicy struct {
-	failurePolicy        admissionregistrationv1.FailurePolicyType
-	matchConditions      []cel.Program
-	variables            map[string]cel.Program
-	validations          []compiledValidation
-	auditAnnotations     map[string]cel.Program
-	polexMatchConditions []cel.Program
+	failurePolicy    admissionregistrationv1.FailurePolicyType
+	matchConditions  []cel.Program
+	variables        map[string]cel.Program
+	validations      []compiledValidation
+	auditAnnotations map[string]cel.Program
+	exceptions       []compiledException
+	autogen          []AutogenPolicy
+}
+
+func (p *compiledPolicy) Autogen() []AutogenPolicy {
+	return p.autogen
 }
 
 func (p *compiledPolicy) Evaluate(
@@ -51,60 +77,16 @@ func (p *compiledPolicy) Evaluate(
 	namespace runtime.Object,
 	context contextlib.ContextInterface,
 ) ([]EvaluationResult, error) {
-	// check if the resource matches an exception
-	if len(p.polexMatchConditions) > 0 {
-		match, err := p.match(ctx, attr, request, namespace, true)
-		if err != nil {
-			return nil, err
-		}
-		if match {
-			return nil, nil
-		}
-	}
-
-	match, err := p.match(ctx, attr, request, namespace, false)
+	match, err := p.match(ctx, attr, request, namespace)
 	if err != nil {
 		return nil, err
 	}
 	if !match {
 		return nil, nil
 	}
-	namespaceVal, err := objectToResolveVal(namespace)
-	if err != nil {
-		return nil, fmt.Errorf("failed to prepare namespace variable for evaluation: %w", err)
-	}
-	objectVal, err := objectToResolveVal(attr.GetObject())
+	data, err := prepareData(ctx, attr.GetObject(), attr.GetOldObject(), request, namespace, context, p.variables)
 	if err != nil {
-		return nil, fmt.Errorf("failed to prepare object variable for evaluation: %w", err)
-	}
-	oldObjectVal, err := objectToResolveVal(attr.GetOldObject())
-	if err != nil {
-		return nil, fmt.Errorf("failed to prepare oldObject variable for evaluation: %w", err)
-	}
-	requestVal, err := convertObjectToUnstructured(request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to prepare request variable for evaluation: %w", err)
-	}
-	vars := lazy.NewMapValue(VariablesType)
-	data := map[string]any{
-		ContextKey:         contextlib.Context{ContextInterface: context},
-		NamespaceObjectKey: namespaceVal,
-		ObjectKey:          objectVal,
-		OldObjectKey:       oldObjectVal,
-		RequestKey:         requestVal.Object,
-		VariablesKey:       vars,
-	}
-	for name, variable := range p.variables {
-		vars.Append(name, func(*lazy.MapValue) ref.Val {
-			out, _, err := variable.ContextEval(ctx, data)
-			if out != nil {
-				return out
-			}
-			if err != nil {
-				return types.WrapErr(err)
-			}
-			return nil
-		})
+		return nil, err
 	}
 	results := make([]EvaluationResult, 0, len(p.validations))
 	for _, validation := range p.validations {
@@ -132,22 +114,65 @@ func (p *compiledPolicy) Evaluate(
 	return results, nil
 }
 
+func (p *compiledPolicy) MatchExceptions(
+	ctx context.Context,
+	attr admission.Attributes,
+	request *admissionv1.AdmissionRequest,
+	namespace runtime.Object,
+) ([]kyvernov2alpha1.CELPolicyException, error) {
+	return matchExceptions(ctx, attr, request, namespace, p.failurePolicy, p.exceptions)
+}
+
 func (p *compiledPolicy) match(
 	ctx context.Context,
 	attr admission.Attributes,
 	request *admissionv1.AdmissionRequest,
 	namespace runtime.Object,
-	isException bool,
+) (bool, error) {
+	return match(ctx, attr.GetObject(), attr.GetOldObject(), request, namespace, p.failurePolicy, p.matchConditions)
+}
+
+// matchExceptions returns the exceptions matching the request, an exception matches when all its match conditions are true
+func matchExceptions(
+	ctx context.Context,
+	attr admission.Attributes,
+	request *admissionv1.AdmissionRequest,
+	namespace runtime.Object,
+	failurePolicy admissionregistrationv1.FailurePolicyType,
+	exceptions []compiledException,
+) ([]kyvernov2alpha1.CELPolicyException, error) {
+	var matched []kyvernov2alpha1.CELPolicyException
+	for _, polex := range exceptions {
+		match, err := match(ctx, attr.GetObject(), attr.GetOldObject(), request, namespace, failurePolicy, polex.matchConditions)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			matched = append(matched, polex.exception)
+		}
+	}
+	return matched, nil
+}
+
+// match evaluates the given match conditions, errors are ignored if the failure policy is Ignore
+func match(
+	ctx context.Context,
+	object runtime.Object,
+	oldObject runtime.Object,
+	request *admissionv1.AdmissionRequest,
+	namespace runtime.Object,
+	failurePolicy admissionregistrationv1.FailurePolicyType,
+	matchConditions []cel.Program,
 ) (bool, error) {
 	namespaceVal, err := objectToResolveVal(namespace)
 	if err != nil {
 		return false, fmt.Errorf("failed to prepare namespace variable for evaluation: %w", err)
 	}
-	objectVal, err := objectToResolveVal(attr.GetObject())
+	objectVal, err := objectToResolveVal(object)
 	if err != nil {
 		return false, fmt.Errorf("failed to prepare object variable for evaluation: %w", err)
 	}
-	oldObjectVal, err := objectToResolveVal(attr.GetOldObject())
+	oldObjectVal, err := objectToResolveVal(oldObject)
 	if err != nil {
 		return false, fmt.Errorf("failed to prepare oldObject variable for evaluation: %w", err)
 	}
@@ -162,11 +187,6 @@ func (p *compiledPolicy) match(
 		RequestKey:         requestVal.Object,
 	}
 	var errs []error
-
-	matchConditions := p.matchConditions
-	if isException {
-		matchConditions = p.polexMatchConditions
-	}
 	for _, matchCondition := range matchConditions {
 		// evaluate the condition
 		out, _, err := matchCondition.ContextEval(ctx, data)
@@ -189,13 +209,107 @@ func (p *compiledPolicy) match(
 	}
 	if err := multierr.Combine(errs...); err == nil {
 		return true, nil
-	} else if p.failurePolicy == admissionregistrationv1.Ignore {
+	} else if failurePolicy == admissionregistrationv1.Ignore {
 		return false, nil
 	} else {
 		return false, err
 	}
 }
 
+// prepareData builds the activation used to evaluate policy expressions, variables are evaluated lazily
+func prepareData(
+	ctx context.Context,
+	object runtime.Object,
+	oldObject runtime.Object,
+	request *admissionv1.AdmissionRequest,
+	namespace runtime.Object,
+	context contextlib.ContextInterface,
+	variables map[string]cel.Program,
+) (map[string]any, error) {
+	namespaceVal, err := objectToResolveVal(namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare namespace variable for evaluation: %w", err)
+	}
+	objectVal, err := objectToResolveVal(object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare object variable for evaluation: %w", err)
+	}
+	oldObjectVal, err := objectToResolveVal(oldObject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare oldObject variable for evaluation: %w", err)
+	}
+	requestVal, err := convertObjectToUnstructured(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare request variable for evaluation: %w", err)
+	}
+	vars := lazy.NewMapValue(VariablesType)
+	data := map[string]any{
+		ContextKey:         contextlib.Context{ContextInterface: context},
+		HTTPKey:            httplib.HTTP{HTTPInterface: httplib.WithContext(ctx, httpOf(context))},
+		NamespaceObjectKey: namespaceVal,
+		ObjectKey:          objectVal,
+		OldObjectKey:       oldObjectVal,
+		RequestKey:         requestVal.Object,
+		VariablesKey:       vars,
+	}
+	// access reviews need a user, requests built for background scans have none
+	if authz := authorizerOf(context); authz != nil && request != nil && request.UserInfo.Username != "" {
+		userInfo := utils.ConvertUserInfo(request.UserInfo)
+		data[AuthorizerKey] = library.NewAuthorizerVal(userInfo, authz)
+		data[RequestResourceKey] = library.NewResourceAuthorizerVal(userInfo, authz, requestResource{request})
+	}
+	for name, variable := range variables {
+		vars.Append(name, func(*lazy.MapValue) ref.Val {
+			out, _, err := variable.ContextEval(ctx, data)
+			if out != nil {
+				return out
+			}
+			if err != nil {
+				return types.WrapErr(err)
+			}
+			return nil
+		})
+	}
+	return data, nil
+}
+
+// httpOf returns the http implementation exposed by the context, if any
+func httpOf(context contextlib.ContextInterface) httplib.HTTPInterface {
+	if provider, ok := context.(interface{ HTTP() httplib.HTTPInterface }); ok {
+		return provider.HTTP()
+	}
+	return nil
+}
+
+// authorizerOf returns the authorizer exposed by the context, if any
+func authorizerOf(context contextlib.ContextInterface) authorizer.Authorizer {
+	if provider, ok := context.(interface{ Authorizer() authorizer.Authorizer }); ok {
+		return provider.Authorizer()
+	}
+	return nil
+}
+
+// requestResource exposes the resource targeted by an admission request to the authorizer library
+type requestResource struct {
+	request *admissionv1.AdmissionRequest
+}
+
+func (r requestResource) GetName() string {
+	return r.request.Name
+}
+
+func (r requestResource) GetNamespace() string {
+	return r.request.Namespace
+}
+
+func (r requestResource) GetResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource(r.request.Resource)
+}
+
+func (r requestResource) GetSubresource() string {
+	return r.request.SubResource
+}
+
 func convertObjectToUnstructured(obj interface{}) (*unstructured.Unstructured, error) {
 	if obj == nil || reflect.ValueOf(obj).IsNil() {
 		return &unstructured.Unstructured{Object: nil}, nil
@@ -0,0 +1,345 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+)
+
+func Test_compiledPolicy_Authorizer(t *testing.T) {
+	policy := &kyvernov2alpha1.ValidatingPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+		Spec: kyvernov2alpha1.ValidatingPolicySpec{
+			ValidatingAdmissionPolicySpec: admissionregistrationv1.ValidatingAdmissionPolicySpec{
+				Validations: []admissionregistrationv1.Validation{{
+					Expression: "authorizer.group('').resource('pods').namespace(object.metadata.namespace).check('delete').allowed()",
+				}, {
+					Expression: "authorizer.requestResource.check('create').allowed()",
+				}, {
+					Expression: "'admins' in request.userInfo.groups",
+				}},
+			},
+		},
+	}
+	compiled, errs := NewCompiler().Compile(policy, nil)
+	assert.NoError(t, errs.ToAggregate())
+	object := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"name":      "nginx",
+				"namespace": "default",
+			},
+		},
+	}
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	attr := admission.NewAttributesRecord(object, nil, object.GroupVersionKind(), "default", "nginx", gvr, "", admission.Create, nil, false, nil)
+	var checked []authorizer.Attributes
+	authz := authorizer.AuthorizerFunc(func(_ context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+		checked = append(checked, a)
+		if a.GetUser().GetName() == "alice" {
+			return authorizer.DecisionAllow, "", nil
+		}
+		return authorizer.DecisionNoOpinion, "", nil
+	})
+	provider, err := NewContextProvider(nil, nil, nil, nil, nil, authz)
+	assert.NoError(t, err)
+	tests := []struct {
+		name     string
+		userInfo authenticationv1.UserInfo
+		want     []bool
+	}{{
+		name:     "allowed",
+		userInfo: authenticationv1.UserInfo{Username: "alice", Groups: []string{"admins"}},
+		want:     []bool{true, true, true},
+	}, {
+		name:     "not allowed",
+		userInfo: authenticationv1.UserInfo{Username: "bob", Groups: []string{"devs"}},
+		want:     []bool{false, false, false},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checked = nil
+			request := &admissionv1.AdmissionRequest{
+				Name:      "nginx",
+				Namespace: "default",
+				Resource:  metav1.GroupVersionResource(gvr),
+				Operation: admissionv1.Create,
+				UserInfo:  tt.userInfo,
+			}
+			results, err := compiled.Evaluate(context.TODO(), attr, request, nil, provider)
+			assert.NoError(t, err)
+			assert.Len(t, results, len(tt.want))
+			for i, result := range results {
+				assert.NoError(t, result.Error)
+				assert.Equal(t, tt.want[i], result.Result.Value())
+			}
+			assert.Len(t, checked, 2)
+			assert.Equal(t, "delete", checked[0].GetVerb())
+			assert.Equal(t, "default", checked[0].GetNamespace())
+			assert.Equal(t, "create", checked[1].GetVerb())
+			assert.Equal(t, "nginx", checked[1].GetName())
+			assert.Equal(t, "pods", checked[1].GetResource())
+		})
+	}
+	t.Run("no user", func(t *testing.T) {
+		checked = nil
+		// background scans have no user, the authorizer is not bound and no access review is sent
+		request := &admissionv1.AdmissionRequest{
+			Name:      "nginx",
+			Namespace: "default",
+			Resource:  metav1.GroupVersionResource(gvr),
+			Operation: admissionv1.Create,
+		}
+		results, err := compiled.Evaluate(context.TODO(), attr, request, nil, provider)
+		assert.NoError(t, err)
+		assert.Len(t, results, 3)
+		assert.Error(t, results[0].Error)
+		assert.Error(t, results[1].Error)
+		assert.Empty(t, checked)
+	})
+}
+
+func Test_compiledPolicy_MatchExceptions(t *testing.T) {
+	policy := &kyvernov2alpha1.ValidatingPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+		Spec: kyvernov2alpha1.ValidatingPolicySpec{
+			ValidatingAdmissionPolicySpec: admissionregistrationv1.ValidatingAdmissionPolicySpec{
+				Validations: []admissionregistrationv1.Validation{{
+					Expression: "false",
+				}},
+			},
+		},
+	}
+	exception := func(name string, expressions ...string) kyvernov2alpha1.CELPolicyException {
+		polex := kyvernov2alpha1.CELPolicyException{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: kyvernov2alpha1.CELPolicyExceptionSpec{
+				PolicyRefs: []kyvernov2alpha1.PolicyRef{{Name: "foo", Kind: "ValidatingPolicy"}},
+			},
+		}
+		for i, expression := range expressions {
+			polex.Spec.MatchConditions = append(polex.Spec.MatchConditions, admissionregistrationv1.MatchCondition{
+				Name:       fmt.Sprintf("condition-%d", i),
+				Expression: expression,
+			})
+		}
+		return polex
+	}
+	object := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"name":      "nginx",
+				"namespace": "default",
+			},
+		},
+	}
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	attr := admission.NewAttributesRecord(object, nil, object.GroupVersionKind(), "default", "nginx", gvr, "", admission.Create, nil, false, nil)
+	request := &admissionv1.AdmissionRequest{
+		Name:      "nginx",
+		Namespace: "default",
+		Resource:  metav1.GroupVersionResource(gvr),
+		Operation: admissionv1.Create,
+	}
+	tests := []struct {
+		name       string
+		exceptions []kyvernov2alpha1.CELPolicyException
+		want       []string
+	}{{
+		name: "no exceptions",
+	}, {
+		name: "matching exception",
+		exceptions: []kyvernov2alpha1.CELPolicyException{
+			exception("by-name", "object.metadata.name == 'nginx'"),
+		},
+		want: []string{"by-name"},
+	}, {
+		name: "all conditions must match",
+		exceptions: []kyvernov2alpha1.CELPolicyException{
+			exception("partial", "object.metadata.name == 'nginx'", "object.metadata.namespace == 'kube-system'"),
+			exception("by-namespace", "object.metadata.namespace == 'default'"),
+		},
+		want: []string{"by-namespace"},
+	}, {
+		name: "no matching exception",
+		exceptions: []kyvernov2alpha1.CELPolicyException{
+			exception("other", "object.metadata.name == 'other'"),
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiled, errs := NewCompiler().Compile(policy, tt.exceptions)
+			assert.NoError(t, errs.ToAggregate())
+			matched, err := compiled.MatchExceptions(context.TODO(), attr, request, nil)
+			assert.NoError(t, err)
+			var names []string
+			for _, polex := range matched {
+				names = append(names, polex.Name)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func Test_compiledPolicy_Autogen_Evaluate(t *testing.T) {
+	policy := &kyvernov2alpha1.ValidatingPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+		Spec: kyvernov2alpha1.ValidatingPolicySpec{
+			ValidatingAdmissionPolicySpec: admissionregistrationv1.ValidatingAdmissionPolicySpec{
+				MatchConstraints: &admissionregistrationv1.MatchResources{
+					ResourceRules: []admissionregistrationv1.NamedRuleWithOperations{{
+						RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+							Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+							Rule: admissionregistrationv1.Rule{
+								APIGroups:   []string{""},
+								APIVersions: []string{"v1"},
+								Resources:   []string{"pods"},
+							},
+						},
+					}},
+				},
+				Validations: []admissionregistrationv1.Validation{{
+					Expression: "object.spec.containers.all(c, c.image != 'nginx')",
+				}},
+			},
+		},
+	}
+	compiled, errs := NewCompiler().Compile(policy, nil)
+	assert.NoError(t, errs.ToAggregate())
+	autogen := compiled.Autogen()
+	assert.Len(t, autogen, 2)
+	object := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]any{
+				"name":      "nginx",
+				"namespace": "default",
+			},
+			"spec": map[string]any{
+				"template": map[string]any{
+					"spec": map[string]any{
+						"containers": []any{
+							map[string]any{"name": "nginx", "image": "nginx"},
+						},
+					},
+				},
+			},
+		},
+	}
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	attr := admission.NewAttributesRecord(object, nil, object.GroupVersionKind(), "default", "nginx", gvr, "", admission.Create, nil, false, nil)
+	request := &admissionv1.AdmissionRequest{
+		Name:      "nginx",
+		Namespace: "default",
+		Resource:  metav1.GroupVersionResource(gvr),
+		Operation: admissionv1.Create,
+	}
+	results, err := autogen[0].CompiledPolicy.Evaluate(context.TODO(), attr, request, nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.NoError(t, results[0].Error)
+	assert.Equal(t, false, results[0].Result.Value())
+}
+
+func Test_compiledPolicy_Autogen_MatchExceptions(t *testing.T) {
+	policy := &kyvernov2alpha1.ValidatingPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+		Spec: kyvernov2alpha1.ValidatingPolicySpec{
+			ValidatingAdmissionPolicySpec: admissionregistrationv1.ValidatingAdmissionPolicySpec{
+				MatchConstraints: &admissionregistrationv1.MatchResources{
+					ResourceRules: []admissionregistrationv1.NamedRuleWithOperations{{
+						RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+							Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+							Rule: admissionregistrationv1.Rule{
+								APIGroups:   []string{""},
+								APIVersions: []string{"v1"},
+								Resources:   []string{"pods"},
+							},
+						},
+					}},
+				},
+				Validations: []admissionregistrationv1.Validation{{
+					Expression: "object.spec.containers.all(c, c.image != 'nginx')",
+				}},
+			},
+		},
+	}
+	// the exception is written for pods, it applies to the pod template of controllers
+	exceptions := []kyvernov2alpha1.CELPolicyException{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "exempt",
+		},
+		Spec: kyvernov2alpha1.CELPolicyExceptionSpec{
+			PolicyRefs: []kyvernov2alpha1.PolicyRef{{Name: "foo", Kind: "ValidatingPolicy"}},
+			MatchConditions: []admissionregistrationv1.MatchCondition{{
+				Name:       "exempt",
+				Expression: "has(object.metadata.labels) && object.metadata.labels.exempt == 'true'",
+			}},
+		},
+	}}
+	compiled, errs := NewCompiler().Compile(policy, exceptions)
+	assert.NoError(t, errs.ToAggregate())
+	autogen := compiled.Autogen()
+	assert.Len(t, autogen, 2)
+	object := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]any{
+				"name":      "nginx",
+				"namespace": "default",
+			},
+			"spec": map[string]any{
+				"template": map[string]any{
+					"metadata": map[string]any{
+						"labels": map[string]any{"exempt": "true"},
+					},
+					"spec": map[string]any{
+						"containers": []any{
+							map[string]any{"name": "nginx", "image": "nginx"},
+						},
+					},
+				},
+			},
+		},
+	}
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	attr := admission.NewAttributesRecord(object, nil, object.GroupVersionKind(), "default", "nginx", gvr, "", admission.Create, nil, false, nil)
+	request := &admissionv1.AdmissionRequest{
+		Name:      "nginx",
+		Namespace: "default",
+		Resource:  metav1.GroupVersionResource(gvr),
+		Operation: admissionv1.Create,
+	}
+	matched, err := autogen[0].CompiledPolicy.MatchExceptions(context.TODO(), attr, request, nil)
+	assert.NoError(t, err)
+	assert.Len(t, matched, 1)
+	// matched exceptions are reported unchanged
+	assert.Equal(t, exceptions[0], matched[0])
+}
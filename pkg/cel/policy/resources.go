@@ -0,0 +1,238 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/auth/checker"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	toolscache "k8s.io/client-go/tools/cache"
+)
+
+// ResourceLoader fetches kubernetes resources on behalf of CEL policies
+type ResourceLoader interface {
+	GetResource(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error)
+	ListResources(ctx context.Context, gvr schema.GroupVersionResource, namespace string, selector labels.Selector) (*unstructured.UnstructuredList, error)
+}
+
+const (
+	// maxInformers caps the number of informers started by a loader, other resources are fetched from the api server
+	maxInformers = 32
+	// informerIdleTimeout is the time after which an unused informer is stopped
+	informerIdleTimeout = 10 * time.Minute
+	// decisionsSize and decisionsTTL configure the cache of access checks
+	decisionsSize = 1024
+	decisionsTTL  = time.Minute
+)
+
+// DeniedResources are never loaded for CEL policies, whatever kyverno is allowed to access
+var DeniedResources = []schema.GroupResource{
+	{Group: "", Resource: "secrets"},
+}
+
+type resourceLoader struct {
+	client    dynamic.Interface
+	checker   checker.AuthChecker
+	ctx       context.Context
+	denied    sets.Set[schema.GroupResource]
+	decisions *cache.LRUExpireCache
+	lock      sync.Mutex
+	// informers holds the informers started so far, a nil informer means
+	// kyverno is not allowed to watch the resource and the api server is queried directly
+	informers map[schema.GroupVersionResource]*loaderInformer
+}
+
+type loaderInformer struct {
+	informer informers.GenericInformer
+	stop     context.CancelFunc
+	lastUsed time.Time
+}
+
+// NewResourceLoader returns a loader backed by informers, an informer is started the first time a resource
+// is requested if kyverno is allowed to list and watch it cluster wide. The api server is queried directly
+// until the informer is synced. At most maxInformers informers run at once, informers unused for
+// informerIdleTimeout are stopped, all informers are stopped when the context is done.
+func NewResourceLoader(ctx context.Context, client dynamic.Interface, checker checker.AuthChecker) ResourceLoader {
+	return &resourceLoader{
+		client:    client,
+		checker:   checker,
+		ctx:       ctx,
+		denied:    sets.New(DeniedResources...),
+		decisions: cache.NewLRUExpireCache(decisionsSize),
+		informers: map[schema.GroupVersionResource]*loaderInformer{},
+	}
+}
+
+// NewDirectResourceLoader returns a loader always querying the api server, it is suited to short lived processes
+func NewDirectResourceLoader(client dynamic.Interface, checker checker.AuthChecker) ResourceLoader {
+	return &resourceLoader{
+		client:    client,
+		checker:   checker,
+		denied:    sets.New(DeniedResources...),
+		decisions: cache.NewLRUExpireCache(decisionsSize),
+	}
+}
+
+func (l *resourceLoader) GetResource(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+	// access is checked for cached reads too, kyverno may not be allowed to read the resource in this namespace
+	if err := l.check(ctx, gvr, namespace, name, "get"); err != nil {
+		return nil, err
+	}
+	if informer := l.informer(ctx, gvr); informer != nil {
+		var obj runtime.Object
+		var err error
+		if namespace == "" {
+			obj, err = informer.Lister().Get(name)
+		} else {
+			obj, err = informer.Lister().ByNamespace(namespace).Get(name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		resource, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return nil, fmt.Errorf("unexpected object type %T", obj)
+		}
+		return resource.DeepCopy(), nil
+	}
+	return l.client.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (l *resourceLoader) ListResources(ctx context.Context, gvr schema.GroupVersionResource, namespace string, selector labels.Selector) (*unstructured.UnstructuredList, error) {
+	if selector == nil {
+		selector = labels.Everything()
+	}
+	if err := l.check(ctx, gvr, namespace, "", "list"); err != nil {
+		return nil, err
+	}
+	if informer := l.informer(ctx, gvr); informer != nil {
+		var objs []runtime.Object
+		var err error
+		if namespace == "" {
+			objs, err = informer.Lister().List(selector)
+		} else {
+			objs, err = informer.Lister().ByNamespace(namespace).List(selector)
+		}
+		if err != nil {
+			return nil, err
+		}
+		out := &unstructured.UnstructuredList{Object: map[string]any{}}
+		for _, obj := range objs {
+			resource, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				return nil, fmt.Errorf("unexpected object type %T", obj)
+			}
+			out.Items = append(out.Items, *resource.DeepCopy())
+		}
+		return out, nil
+	}
+	return l.client.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+}
+
+// informer returns a synced informer for the given resource, or nil if the api server must be queried directly
+func (l *resourceLoader) informer(ctx context.Context, gvr schema.GroupVersionResource) informers.GenericInformer {
+	if l.ctx == nil {
+		return nil
+	}
+	if cached, ok := l.getInformer(gvr); ok {
+		if cached.informer == nil || !cached.informer.Informer().HasSynced() {
+			return nil
+		}
+		return cached.informer
+	}
+	// access reviews are done without holding the lock, they are api calls
+	allowed := l.check(ctx, gvr, "", "", "list") == nil && l.check(ctx, gvr, "", "", "watch") == nil
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if _, ok := l.informers[gvr]; ok {
+		return nil
+	}
+	cached := &loaderInformer{lastUsed: time.Now()}
+	if allowed {
+		if l.running() >= maxInformers {
+			// retried once an idle informer is stopped
+			return nil
+		}
+		ctx, cancel := context.WithCancel(l.ctx)
+		cached.informer = dynamicinformer.NewFilteredDynamicInformer(l.client, gvr, metav1.NamespaceAll, 0, toolscache.Indexers{toolscache.NamespaceIndex: toolscache.MetaNamespaceIndexFunc}, nil)
+		cached.stop = cancel
+		go cached.informer.Informer().Run(ctx.Done())
+	}
+	l.informers[gvr] = cached
+	return nil
+}
+
+// getInformer returns the informer of a resource and stops the informers that were not used recently
+func (l *resourceLoader) getInformer(gvr schema.GroupVersionResource) (*loaderInformer, bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := time.Now()
+	for key, cached := range l.informers {
+		if key != gvr && now.Sub(cached.lastUsed) > informerIdleTimeout {
+			if cached.stop != nil {
+				cached.stop()
+			}
+			delete(l.informers, key)
+		}
+	}
+	cached, ok := l.informers[gvr]
+	if ok {
+		cached.lastUsed = now
+	}
+	return cached, ok
+}
+
+// running returns the number of running informers, the lock must be held
+func (l *resourceLoader) running() int {
+	count := 0
+	for _, cached := range l.informers {
+		if cached.informer != nil {
+			count++
+		}
+	}
+	return count
+}
+
+func (l *resourceLoader) check(ctx context.Context, gvr schema.GroupVersionResource, namespace, name, verb string) error {
+	if l.denied.Has(gvr.GroupResource()) {
+		return fmt.Errorf("%s can not be loaded by policies", gvr.GroupResource().String())
+	}
+	if l.checker == nil {
+		return nil
+	}
+	key := decisionKey{gvr: gvr, namespace: namespace, name: name, verb: verb}
+	if err, ok := l.decisions.Get(key); ok {
+		if err == nil {
+			return nil
+		}
+		return err.(error)
+	}
+	result, err := l.checker.Check(ctx, gvr.Group, gvr.Version, gvr.Resource, "", namespace, name, verb)
+	if err != nil {
+		// errors are not cached, the next call reviews the access again
+		return err
+	}
+	if !result.Allowed {
+		err = fmt.Errorf("not allowed to %s %s in namespace %q: %s", verb, gvr.String(), namespace, result.Reason)
+	}
+	l.decisions.Add(key, err, decisionsTTL)
+	return err
+}
+
+type decisionKey struct {
+	gvr       schema.GroupVersionResource
+	namespace string
+	name      string
+	verb      string
+}
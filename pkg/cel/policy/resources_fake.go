@@ -0,0 +1,54 @@
+package policy
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type fakeResourceLoader struct {
+	resources map[schema.GroupVersionResource][]*unstructured.Unstructured
+}
+
+// NewFakeResourceLoader returns a loader serving the given resources, it is used by the CLI when no cluster is available.
+// Without discovery, resource names are guessed from kinds.
+func NewFakeResourceLoader(resources ...*unstructured.Unstructured) ResourceLoader {
+	loader := &fakeResourceLoader{
+		resources: map[schema.GroupVersionResource][]*unstructured.Unstructured{},
+	}
+	for _, resource := range resources {
+		gvr, _ := meta.UnsafeGuessKindToResource(resource.GroupVersionKind())
+		loader.resources[gvr] = append(loader.resources[gvr], resource)
+	}
+	return loader
+}
+
+func (l *fakeResourceLoader) GetResource(_ context.Context, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+	for _, resource := range l.resources[gvr] {
+		if resource.GetNamespace() == namespace && resource.GetName() == name {
+			return resource.DeepCopy(), nil
+		}
+	}
+	return nil, apierrors.NewNotFound(gvr.GroupResource(), name)
+}
+
+func (l *fakeResourceLoader) ListResources(_ context.Context, gvr schema.GroupVersionResource, namespace string, selector labels.Selector) (*unstructured.UnstructuredList, error) {
+	if selector == nil {
+		selector = labels.Everything()
+	}
+	out := &unstructured.UnstructuredList{Object: map[string]any{}}
+	for _, resource := range l.resources[gvr] {
+		if namespace != "" && resource.GetNamespace() != namespace {
+			continue
+		}
+		if !selector.Matches(labels.Set(resource.GetLabels())) {
+			continue
+		}
+		out.Items = append(out.Items, *resource.DeepCopy())
+	}
+	return out, nil
+}
@@ -0,0 +1,153 @@
+package policy
+
+import (
+	"context"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/auth/checker"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+type fakeChecker struct {
+	allowed bool
+	// denied namespaces
+	denied []string
+	calls  *atomic.Int32
+}
+
+func (c fakeChecker) Check(_ context.Context, _, _, _, _, namespace, _, _ string) (*checker.AuthResult, error) {
+	if c.calls != nil {
+		c.calls.Add(1)
+	}
+	return &checker.AuthResult{Allowed: c.allowed && !slices.Contains(c.denied, namespace)}, nil
+}
+
+func pod(namespace, name string, labels map[string]string) *unstructured.Unstructured {
+	pod := &unstructured.Unstructured{}
+	pod.SetAPIVersion("v1")
+	pod.SetKind("Pod")
+	pod.SetNamespace(namespace)
+	pod.SetName(name)
+	pod.SetLabels(labels)
+	return pod
+}
+
+var podsGVR = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+func Test_resourceLoader(t *testing.T) {
+	newClient := func() *fake.FakeDynamicClient {
+		return fake.NewSimpleDynamicClientWithCustomListKinds(
+			runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{podsGVR: "PodList"},
+			pod("default", "nginx", map[string]string{"app": "nginx"}),
+			pod("default", "redis", map[string]string{"app": "redis"}),
+		)
+	}
+	t.Run("allowed", func(t *testing.T) {
+		loader := NewDirectResourceLoader(newClient(), fakeChecker{allowed: true})
+		resource, err := loader.GetResource(context.TODO(), podsGVR, "default", "nginx")
+		assert.NoError(t, err)
+		assert.Equal(t, "nginx", resource.GetName())
+		selector, err := labels.Parse("app=redis")
+		assert.NoError(t, err)
+		list, err := loader.ListResources(context.TODO(), podsGVR, "default", selector)
+		assert.NoError(t, err)
+		assert.Len(t, list.Items, 1)
+		assert.Equal(t, "redis", list.Items[0].GetName())
+	})
+	t.Run("denied", func(t *testing.T) {
+		loader := NewDirectResourceLoader(newClient(), fakeChecker{allowed: false})
+		_, err := loader.GetResource(context.TODO(), podsGVR, "default", "nginx")
+		assert.Error(t, err)
+		_, err = loader.ListResources(context.TODO(), podsGVR, "default", nil)
+		assert.Error(t, err)
+	})
+	t.Run("informer", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+		defer cancel()
+		loader := NewResourceLoader(ctx, newClient(), fakeChecker{allowed: true})
+		// the first call is served by the api server while the informer syncs
+		list, err := loader.ListResources(ctx, podsGVR, "", nil)
+		assert.NoError(t, err)
+		assert.Len(t, list.Items, 2)
+		assert.Eventually(t, func() bool {
+			return loader.(*resourceLoader).informer(ctx, podsGVR) != nil
+		}, 5*time.Second, 10*time.Millisecond)
+		resource, err := loader.GetResource(ctx, podsGVR, "default", "redis")
+		assert.NoError(t, err)
+		assert.Equal(t, "redis", resource.GetName())
+	})
+	t.Run("cached reads check the namespace", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+		defer cancel()
+		loader := NewResourceLoader(ctx, newClient(), fakeChecker{allowed: true, denied: []string{"default"}})
+		assert.Eventually(t, func() bool {
+			return loader.(*resourceLoader).informer(ctx, podsGVR) != nil
+		}, 5*time.Second, 10*time.Millisecond)
+		_, err := loader.GetResource(ctx, podsGVR, "default", "redis")
+		assert.Error(t, err)
+		_, err = loader.ListResources(ctx, podsGVR, "default", nil)
+		assert.Error(t, err)
+	})
+	t.Run("access decisions are cached", func(t *testing.T) {
+		var calls atomic.Int32
+		loader := NewDirectResourceLoader(newClient(), fakeChecker{allowed: true, calls: &calls})
+		for range 3 {
+			_, err := loader.GetResource(context.TODO(), podsGVR, "default", "nginx")
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, int32(1), calls.Load())
+	})
+	t.Run("secrets are denied", func(t *testing.T) {
+		secretsGVR := schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+		loader := NewDirectResourceLoader(newClient(), fakeChecker{allowed: true})
+		_, err := loader.GetResource(context.TODO(), secretsGVR, "default", "token")
+		assert.EqualError(t, err, "secrets can not be loaded by policies")
+		_, err = loader.ListResources(context.TODO(), secretsGVR, "", nil)
+		assert.EqualError(t, err, "secrets can not be loaded by policies")
+	})
+	t.Run("idle informers are stopped", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+		defer cancel()
+		loader := NewResourceLoader(ctx, newClient(), fakeChecker{allowed: true}).(*resourceLoader)
+		assert.Eventually(t, func() bool {
+			return loader.informer(ctx, podsGVR) != nil
+		}, 5*time.Second, 10*time.Millisecond)
+		otherGVR := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+		loader.lock.Lock()
+		loader.informers[podsGVR].lastUsed = time.Now().Add(-2 * informerIdleTimeout)
+		loader.lock.Unlock()
+		loader.getInformer(otherGVR)
+		loader.lock.Lock()
+		defer loader.lock.Unlock()
+		assert.NotContains(t, loader.informers, podsGVR)
+	})
+}
+
+func Test_fakeResourceLoader(t *testing.T) {
+	loader := NewFakeResourceLoader(
+		pod("default", "nginx", map[string]string{"app": "nginx"}),
+		pod("kube-system", "coredns", map[string]string{"app": "coredns"}),
+	)
+	resource, err := loader.GetResource(context.TODO(), podsGVR, "default", "nginx")
+	assert.NoError(t, err)
+	assert.Equal(t, "nginx", resource.GetName())
+	_, err = loader.GetResource(context.TODO(), podsGVR, "default", "coredns")
+	assert.Error(t, err)
+	list, err := loader.ListResources(context.TODO(), podsGVR, "", nil)
+	assert.NoError(t, err)
+	assert.Len(t, list.Items, 2)
+	selector, err := labels.Parse("app=coredns")
+	assert.NoError(t, err)
+	list, err = loader.ListResources(context.TODO(), podsGVR, "", selector)
+	assert.NoError(t, err)
+	assert.Len(t, list.Items, 1)
+}
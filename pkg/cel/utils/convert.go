@@ -4,8 +4,10 @@ import (
 	"reflect"
 
 	"github.com/google/cel-go/common/types/ref"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authentication/user"
 )
 
 func ConvertToNative[T any](value ref.Val) (T, error) {
@@ -30,3 +32,20 @@ func ConvertObjectToUnstructured(obj any) (*unstructured.Unstructured, error) {
 	}
 	return &unstructured.Unstructured{Object: ret}, nil
 }
+
+// ConvertUserInfo converts an admission request user info to the type expected by admission attributes and authorizers
+func ConvertUserInfo(info authenticationv1.UserInfo) user.Info {
+	var extra map[string][]string
+	if len(info.Extra) != 0 {
+		extra = make(map[string][]string, len(info.Extra))
+		for key, value := range info.Extra {
+			extra[key] = value
+		}
+	}
+	return &user.DefaultInfo{
+		Name:   info.Username,
+		UID:    info.UID,
+		Groups: info.Groups,
+		Extra:  extra,
+	}
+}
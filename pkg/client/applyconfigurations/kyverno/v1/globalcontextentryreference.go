@@ -21,8 +21,10 @@ package v1
 // GlobalContextEntryReferenceApplyConfiguration represents an declarative configuration of the GlobalContextEntryReference type for use
 // with apply.
 type GlobalContextEntryReferenceApplyConfiguration struct {
-	Name     *string `json:"name,omitempty"`
-	JMESPath *string `json:"jmesPath,omitempty"`
+	Name       *string `json:"name,omitempty"`
+	Projection *string `json:"projection,omitempty"`
+	Key        *string `json:"key,omitempty"`
+	JMESPath   *string `json:"jmesPath,omitempty"`
 }
 
 // GlobalContextEntryReferenceApplyConfiguration constructs an declarative configuration of the GlobalContextEntryReference type for use with
@@ -39,6 +41,22 @@ func (b *GlobalContextEntryReferenceApplyConfiguration) WithName(value string) *
 	return b
 }
 
+// WithProjection sets the Projection field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Projection field is set to the value of the last call.
+func (b *GlobalContextEntryReferenceApplyConfiguration) WithProjection(value string) *GlobalContextEntryReferenceApplyConfiguration {
+	b.Projection = &value
+	return b
+}
+
+// WithKey sets the Key field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Key field is set to the value of the last call.
+func (b *GlobalContextEntryReferenceApplyConfiguration) WithKey(value string) *GlobalContextEntryReferenceApplyConfiguration {
+	b.Key = &value
+	return b
+}
+
 // WithJMESPath sets the JMESPath field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the JMESPath field is set to the value of the last call.
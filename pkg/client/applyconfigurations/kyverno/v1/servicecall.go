@@ -21,9 +21,10 @@ package v1
 // ServiceCallApplyConfiguration represents an declarative configuration of the ServiceCall type for use
 // with apply.
 type ServiceCallApplyConfiguration struct {
-	URL      *string                        `json:"url,omitempty"`
-	Headers  []HTTPHeaderApplyConfiguration `json:"headers,omitempty"`
-	CABundle *string                        `json:"caBundle,omitempty"`
+	URL      *string                            `json:"url,omitempty"`
+	Headers  []HTTPHeaderApplyConfiguration     `json:"headers,omitempty"`
+	CABundle *string                            `json:"caBundle,omitempty"`
+	Auth     *ServiceCallAuthApplyConfiguration `json:"auth,omitempty"`
 }
 
 // ServiceCallApplyConfiguration constructs an declarative configuration of the ServiceCall type for use with
@@ -60,3 +61,11 @@ func (b *ServiceCallApplyConfiguration) WithCABundle(value string) *ServiceCallA
 	b.CABundle = &value
 	return b
 }
+
+// WithAuth sets the Auth field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Auth field is set to the value of the last call.
+func (b *ServiceCallApplyConfiguration) WithAuth(value *ServiceCallAuthApplyConfiguration) *ServiceCallApplyConfiguration {
+	b.Auth = value
+	return b
+}
@@ -30,6 +30,8 @@ type ExternalAPICallApplyConfiguration struct {
 	v1.APICallApplyConfiguration `json:",omitempty,inline"`
 	RefreshInterval              *metav1.Duration `json:"refreshInterval,omitempty"`
 	RetryLimit                   *int             `json:"retryLimit,omitempty"`
+	RetryBackoff                 *metav1.Duration `json:"retryBackoff,omitempty"`
+	MaxStaleness                 *metav1.Duration `json:"maxStaleness,omitempty"`
 }
 
 // ExternalAPICallApplyConfiguration constructs an declarative configuration of the ExternalAPICall type for use with
@@ -90,3 +92,19 @@ func (b *ExternalAPICallApplyConfiguration) WithRetryLimit(value int) *ExternalA
 	b.RetryLimit = &value
 	return b
 }
+
+// WithRetryBackoff sets the RetryBackoff field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RetryBackoff field is set to the value of the last call.
+func (b *ExternalAPICallApplyConfiguration) WithRetryBackoff(value metav1.Duration) *ExternalAPICallApplyConfiguration {
+	b.RetryBackoff = &value
+	return b
+}
+
+// WithMaxStaleness sets the MaxStaleness field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxStaleness field is set to the value of the last call.
+func (b *ExternalAPICallApplyConfiguration) WithMaxStaleness(value metav1.Duration) *ExternalAPICallApplyConfiguration {
+	b.MaxStaleness = &value
+	return b
+}
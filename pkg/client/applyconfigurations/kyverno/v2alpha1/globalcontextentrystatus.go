@@ -25,9 +25,10 @@ import (
 // GlobalContextEntryStatusApplyConfiguration represents an declarative configuration of the GlobalContextEntryStatus type for use
 // with apply.
 type GlobalContextEntryStatusApplyConfiguration struct {
-	Ready           *bool          `json:"ready,omitempty"`
-	Conditions      []v1.Condition `json:"conditions,omitempty"`
-	LastRefreshTime *v1.Time       `json:"lastRefreshTime,omitempty"`
+	Ready              *bool          `json:"ready,omitempty"`
+	Conditions         []v1.Condition `json:"conditions,omitempty"`
+	LastRefreshTime    *v1.Time       `json:"lastRefreshTime,omitempty"`
+	SnapshotGeneration *int64         `json:"snapshotGeneration,omitempty"`
 }
 
 // GlobalContextEntryStatusApplyConfiguration constructs an declarative configuration of the GlobalContextEntryStatus type for use with
@@ -61,3 +62,11 @@ func (b *GlobalContextEntryStatusApplyConfiguration) WithLastRefreshTime(value v
 	b.LastRefreshTime = &value
 	return b
 }
+
+// WithSnapshotGeneration sets the SnapshotGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SnapshotGeneration field is set to the value of the last call.
+func (b *GlobalContextEntryStatusApplyConfiguration) WithSnapshotGeneration(value int64) *GlobalContextEntryStatusApplyConfiguration {
+	b.SnapshotGeneration = &value
+	return b
+}
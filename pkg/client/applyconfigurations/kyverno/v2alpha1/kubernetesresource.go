@@ -18,13 +18,20 @@ limitations under the License.
 
 package v2alpha1
 
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // KubernetesResourceApplyConfiguration represents an declarative configuration of the KubernetesResource type for use
 // with apply.
 type KubernetesResourceApplyConfiguration struct {
-	Group     *string `json:"group,omitempty"`
-	Version   *string `json:"version,omitempty"`
-	Resource  *string `json:"resource,omitempty"`
-	Namespace *string `json:"namespace,omitempty"`
+	Group         *string           `json:"group,omitempty"`
+	Version       *string           `json:"version,omitempty"`
+	Resource      *string           `json:"resource,omitempty"`
+	Namespace     *string           `json:"namespace,omitempty"`
+	LabelSelector *v1.LabelSelector `json:"labelSelector,omitempty"`
+	FieldSelector *string           `json:"fieldSelector,omitempty"`
+	MetadataOnly  *bool             `json:"metadataOnly,omitempty"`
 }
 
 // KubernetesResourceApplyConfiguration constructs an declarative configuration of the KubernetesResource type for use with
@@ -64,3 +71,27 @@ func (b *KubernetesResourceApplyConfiguration) WithNamespace(value string) *Kube
 	b.Namespace = &value
 	return b
 }
+
+// WithLabelSelector sets the LabelSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LabelSelector field is set to the value of the last call.
+func (b *KubernetesResourceApplyConfiguration) WithLabelSelector(value v1.LabelSelector) *KubernetesResourceApplyConfiguration {
+	b.LabelSelector = &value
+	return b
+}
+
+// WithFieldSelector sets the FieldSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FieldSelector field is set to the value of the last call.
+func (b *KubernetesResourceApplyConfiguration) WithFieldSelector(value string) *KubernetesResourceApplyConfiguration {
+	b.FieldSelector = &value
+	return b
+}
+
+// WithMetadataOnly sets the MetadataOnly field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MetadataOnly field is set to the value of the last call.
+func (b *KubernetesResourceApplyConfiguration) WithMetadataOnly(value bool) *KubernetesResourceApplyConfiguration {
+	b.MetadataOnly = &value
+	return b
+}
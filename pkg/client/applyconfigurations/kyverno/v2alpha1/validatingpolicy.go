@@ -29,8 +29,8 @@ import (
 type ValidatingPolicyApplyConfiguration struct {
 	v1.TypeMetaApplyConfiguration    `json:",inline"`
 	*v1.ObjectMetaApplyConfiguration `json:"metadata,omitempty"`
-	Spec                             *ValidatingPolicySpecApplyConfiguration `json:"spec,omitempty"`
-	Status                           *PolicyStatusApplyConfiguration         `json:"status,omitempty"`
+	Spec                             *ValidatingPolicySpecApplyConfiguration   `json:"spec,omitempty"`
+	Status                           *ValidatingPolicyStatusApplyConfiguration `json:"status,omitempty"`
 }
 
 // ValidatingPolicy constructs an declarative configuration of the ValidatingPolicy type for use with
@@ -212,7 +212,7 @@ func (b *ValidatingPolicyApplyConfiguration) WithSpec(value *ValidatingPolicySpe
 // WithStatus sets the Status field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Status field is set to the value of the last call.
-func (b *ValidatingPolicyApplyConfiguration) WithStatus(value *PolicyStatusApplyConfiguration) *ValidatingPolicyApplyConfiguration {
+func (b *ValidatingPolicyApplyConfiguration) WithStatus(value *ValidatingPolicyStatusApplyConfiguration) *ValidatingPolicyApplyConfiguration {
 	b.Status = value
 	return b
 }
@@ -28,6 +28,7 @@ type ValidatingPolicySpecApplyConfiguration struct {
 	v1.ValidatingAdmissionPolicySpec `json:",inline"`
 	ValidationAction                 []v1.ValidationAction                   `json:"validationActions,omitempty"`
 	WebhookConfiguration             *WebhookConfigurationApplyConfiguration `json:"webhookConfiguration,omitempty"`
+	AutogenConfiguration             *AutogenConfigurationApplyConfiguration `json:"autogen,omitempty"`
 }
 
 // ValidatingPolicySpecApplyConfiguration constructs an declarative configuration of the ValidatingPolicySpec type for use with
@@ -117,3 +118,11 @@ func (b *ValidatingPolicySpecApplyConfiguration) WithWebhookConfiguration(value
 	b.WebhookConfiguration = value
 	return b
 }
+
+// WithAutogenConfiguration sets the AutogenConfiguration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AutogenConfiguration field is set to the value of the last call.
+func (b *ValidatingPolicySpecApplyConfiguration) WithAutogenConfiguration(value *AutogenConfigurationApplyConfiguration) *ValidatingPolicySpecApplyConfiguration {
+	b.AutogenConfiguration = value
+	return b
+}
@@ -107,6 +107,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.MatchResourcesApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Mutation"):
 		return &kyvernov1.MutationApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OAuth2ClientCredentials"):
+		return &kyvernov1.OAuth2ClientCredentialsApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ObjectFieldBinding"):
 		return &kyvernov1.ObjectFieldBindingApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("PodSecurity"):
@@ -135,6 +137,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.SecretReferenceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ServiceCall"):
 		return &kyvernov1.ServiceCallApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ServiceCallAuth"):
+		return &kyvernov1.ServiceCallAuthApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ServiceCallSecret"):
+		return &kyvernov1.ServiceCallSecretApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Spec"):
 		return &kyvernov1.SpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("StaticKeyAttestor"):
@@ -207,28 +213,86 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov2.UpdateRequestStatusApplyConfiguration{}
 
 		// Group=kyverno.io, Version=v2alpha1
+	case v2alpha1.SchemeGroupVersion.WithKind("Attestation"):
+		return &kyvernov2alpha1.AttestationApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("Attestor"):
+		return &kyvernov2alpha1.AttestorApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("AutogenConfiguration"):
+		return &kyvernov2alpha1.AutogenConfigurationApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("CELPolicyException"):
 		return &kyvernov2alpha1.CELPolicyExceptionApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("CELPolicyExceptionSpec"):
 		return &kyvernov2alpha1.CELPolicyExceptionSpecApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("Certificate"):
+		return &kyvernov2alpha1.CertificateApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("Cosign"):
+		return &kyvernov2alpha1.CosignApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("CTLog"):
+		return &kyvernov2alpha1.CTLogApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("ExpressionMessage"):
+		return &kyvernov2alpha1.ExpressionMessageApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("ExternalAPICall"):
 		return &kyvernov2alpha1.ExternalAPICallApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("GeneratingPolicy"):
+		return &kyvernov2alpha1.GeneratingPolicyApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("GeneratingPolicySpec"):
+		return &kyvernov2alpha1.GeneratingPolicySpecApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("Generation"):
+		return &kyvernov2alpha1.GenerationApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("GlobalContextEntry"):
 		return &kyvernov2alpha1.GlobalContextEntryApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("GlobalContextEntryIndex"):
+		return &kyvernov2alpha1.GlobalContextEntryIndexApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("GlobalContextEntryProjection"):
+		return &kyvernov2alpha1.GlobalContextEntryProjectionApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("GlobalContextEntrySpec"):
 		return &kyvernov2alpha1.GlobalContextEntrySpecApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("GlobalContextEntryStatus"):
 		return &kyvernov2alpha1.GlobalContextEntryStatusApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("Identity"):
+		return &kyvernov2alpha1.IdentityApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("ImageRule"):
+		return &kyvernov2alpha1.ImageRuleApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("ImageVerificationPolicy"):
+		return &kyvernov2alpha1.ImageVerificationPolicyApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("ImageVerificationPolicySpec"):
+		return &kyvernov2alpha1.ImageVerificationPolicySpecApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("InToto"):
+		return &kyvernov2alpha1.InTotoApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("Key"):
+		return &kyvernov2alpha1.KeyApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("Keyless"):
+		return &kyvernov2alpha1.KeylessApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("KubernetesResource"):
 		return &kyvernov2alpha1.KubernetesResourceApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("MutatingPolicy"):
+		return &kyvernov2alpha1.MutatingPolicyApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("MutatingPolicySpec"):
+		return &kyvernov2alpha1.MutatingPolicySpecApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("Notary"):
+		return &kyvernov2alpha1.NotaryApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("PodControllersGenerationConfiguration"):
+		return &kyvernov2alpha1.PodControllersGenerationConfigurationApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("PolicyRef"):
 		return &kyvernov2alpha1.PolicyRefApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("PolicyStatus"):
 		return &kyvernov2alpha1.PolicyStatusApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("Referrer"):
+		return &kyvernov2alpha1.ReferrerApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("Source"):
+		return &kyvernov2alpha1.SourceApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("TypeChecking"):
+		return &kyvernov2alpha1.TypeCheckingApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("ValidatingPolicy"):
 		return &kyvernov2alpha1.ValidatingPolicyApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("ValidatingPolicyAutogen"):
+		return &kyvernov2alpha1.ValidatingPolicyAutogenApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("ValidatingPolicyAutogenStatus"):
+		return &kyvernov2alpha1.ValidatingPolicyAutogenStatusApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("ValidatingPolicySpec"):
 		return &kyvernov2alpha1.ValidatingPolicySpecApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("ValidatingPolicyStatus"):
+		return &kyvernov2alpha1.ValidatingPolicyStatusApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("WebhookConfiguration"):
 		return &kyvernov2alpha1.WebhookConfigurationApplyConfiguration{}
 
@@ -32,10 +32,22 @@ func (c *FakeKyvernoV2alpha1) CELPolicyExceptions(namespace string) v2alpha1.CEL
 	return &FakeCELPolicyExceptions{c, namespace}
 }
 
+func (c *FakeKyvernoV2alpha1) GeneratingPolicies() v2alpha1.GeneratingPolicyInterface {
+	return &FakeGeneratingPolicies{c}
+}
+
 func (c *FakeKyvernoV2alpha1) GlobalContextEntries() v2alpha1.GlobalContextEntryInterface {
 	return &FakeGlobalContextEntries{c}
 }
 
+func (c *FakeKyvernoV2alpha1) ImageVerificationPolicies() v2alpha1.ImageVerificationPolicyInterface {
+	return &FakeImageVerificationPolicies{c}
+}
+
+func (c *FakeKyvernoV2alpha1) MutatingPolicies() v2alpha1.MutatingPolicyInterface {
+	return &FakeMutatingPolicies{c}
+}
+
 func (c *FakeKyvernoV2alpha1) ValidatingPolicies() v2alpha1.ValidatingPolicyInterface {
 	return &FakeValidatingPolicies{c}
 }
@@ -20,6 +20,12 @@ package v2alpha1
 
 type CELPolicyExceptionExpansion interface{}
 
+type GeneratingPolicyExpansion interface{}
+
 type GlobalContextEntryExpansion interface{}
 
+type ImageVerificationPolicyExpansion interface{}
+
+type MutatingPolicyExpansion interface{}
+
 type ValidatingPolicyExpansion interface{}
@@ -29,7 +29,10 @@ import (
 type KyvernoV2alpha1Interface interface {
 	RESTClient() rest.Interface
 	CELPolicyExceptionsGetter
+	GeneratingPoliciesGetter
 	GlobalContextEntriesGetter
+	ImageVerificationPoliciesGetter
+	MutatingPoliciesGetter
 	ValidatingPoliciesGetter
 }
 
@@ -42,10 +45,22 @@ func (c *KyvernoV2alpha1Client) CELPolicyExceptions(namespace string) CELPolicyE
 	return newCELPolicyExceptions(c, namespace)
 }
 
+func (c *KyvernoV2alpha1Client) GeneratingPolicies() GeneratingPolicyInterface {
+	return newGeneratingPolicies(c)
+}
+
 func (c *KyvernoV2alpha1Client) GlobalContextEntries() GlobalContextEntryInterface {
 	return newGlobalContextEntries(c)
 }
 
+func (c *KyvernoV2alpha1Client) ImageVerificationPolicies() ImageVerificationPolicyInterface {
+	return newImageVerificationPolicies(c)
+}
+
+func (c *KyvernoV2alpha1Client) MutatingPolicies() MutatingPolicyInterface {
+	return newMutatingPolicies(c)
+}
+
 func (c *KyvernoV2alpha1Client) ValidatingPolicies() ValidatingPolicyInterface {
 	return newValidatingPolicies(c)
 }
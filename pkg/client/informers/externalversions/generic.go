@@ -75,8 +75,14 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		// Group=kyverno.io, Version=v2alpha1
 	case v2alpha1.SchemeGroupVersion.WithResource("celpolicyexceptions"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().CELPolicyExceptions().Informer()}, nil
+	case v2alpha1.SchemeGroupVersion.WithResource("generatingpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().GeneratingPolicies().Informer()}, nil
 	case v2alpha1.SchemeGroupVersion.WithResource("globalcontextentries"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().GlobalContextEntries().Informer()}, nil
+	case v2alpha1.SchemeGroupVersion.WithResource("imageverificationpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().ImageVerificationPolicies().Informer()}, nil
+	case v2alpha1.SchemeGroupVersion.WithResource("mutatingpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().MutatingPolicies().Informer()}, nil
 	case v2alpha1.SchemeGroupVersion.WithResource("validatingpolicies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().ValidatingPolicies().Informer()}, nil
 
@@ -26,8 +26,14 @@ import (
 type Interface interface {
 	// CELPolicyExceptions returns a CELPolicyExceptionInformer.
 	CELPolicyExceptions() CELPolicyExceptionInformer
+	// GeneratingPolicies returns a GeneratingPolicyInformer.
+	GeneratingPolicies() GeneratingPolicyInformer
 	// GlobalContextEntries returns a GlobalContextEntryInformer.
 	GlobalContextEntries() GlobalContextEntryInformer
+	// ImageVerificationPolicies returns a ImageVerificationPolicyInformer.
+	ImageVerificationPolicies() ImageVerificationPolicyInformer
+	// MutatingPolicies returns a MutatingPolicyInformer.
+	MutatingPolicies() MutatingPolicyInformer
 	// ValidatingPolicies returns a ValidatingPolicyInformer.
 	ValidatingPolicies() ValidatingPolicyInformer
 }
@@ -48,11 +54,26 @@ func (v *version) CELPolicyExceptions() CELPolicyExceptionInformer {
 	return &cELPolicyExceptionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// GeneratingPolicies returns a GeneratingPolicyInformer.
+func (v *version) GeneratingPolicies() GeneratingPolicyInformer {
+	return &generatingPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // GlobalContextEntries returns a GlobalContextEntryInformer.
 func (v *version) GlobalContextEntries() GlobalContextEntryInformer {
 	return &globalContextEntryInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ImageVerificationPolicies returns a ImageVerificationPolicyInformer.
+func (v *version) ImageVerificationPolicies() ImageVerificationPolicyInformer {
+	return &imageVerificationPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// MutatingPolicies returns a MutatingPolicyInformer.
+func (v *version) MutatingPolicies() MutatingPolicyInformer {
+	return &mutatingPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ValidatingPolicies returns a ValidatingPolicyInformer.
 func (v *version) ValidatingPolicies() ValidatingPolicyInformer {
 	return &validatingPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -26,10 +26,22 @@ type CELPolicyExceptionListerExpansion interface{}
 // CELPolicyExceptionNamespaceLister.
 type CELPolicyExceptionNamespaceListerExpansion interface{}
 
+// GeneratingPolicyListerExpansion allows custom methods to be added to
+// GeneratingPolicyLister.
+type GeneratingPolicyListerExpansion interface{}
+
 // GlobalContextEntryListerExpansion allows custom methods to be added to
 // GlobalContextEntryLister.
 type GlobalContextEntryListerExpansion interface{}
 
+// ImageVerificationPolicyListerExpansion allows custom methods to be added to
+// ImageVerificationPolicyLister.
+type ImageVerificationPolicyListerExpansion interface{}
+
+// MutatingPolicyListerExpansion allows custom methods to be added to
+// MutatingPolicyLister.
+type MutatingPolicyListerExpansion interface{}
+
 // ValidatingPolicyListerExpansion allows custom methods to be added to
 // ValidatingPolicyLister.
 type ValidatingPolicyListerExpansion interface{}
@@ -4,7 +4,10 @@ import (
 	"github.com/go-logr/logr"
 	github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1 "github.com/kyverno/kyverno/pkg/client/clientset/versioned/typed/kyverno/v2alpha1"
 	celpolicyexceptions "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/celpolicyexceptions"
+	generatingpolicies "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/generatingpolicies"
 	globalcontextentries "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/globalcontextentries"
+	imageverificationpolicies "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/imageverificationpolicies"
+	mutatingpolicies "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/mutatingpolicies"
 	validatingpolicies "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/validatingpolicies"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"k8s.io/client-go/rest"
@@ -35,10 +38,22 @@ func (c *withMetrics) CELPolicyExceptions(namespace string) github_com_kyverno_k
 	recorder := metrics.NamespacedClientQueryRecorder(c.metrics, namespace, "CELPolicyException", c.clientType)
 	return celpolicyexceptions.WithMetrics(c.inner.CELPolicyExceptions(namespace), recorder)
 }
+func (c *withMetrics) GeneratingPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.GeneratingPolicyInterface {
+	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "GeneratingPolicy", c.clientType)
+	return generatingpolicies.WithMetrics(c.inner.GeneratingPolicies(), recorder)
+}
 func (c *withMetrics) GlobalContextEntries() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.GlobalContextEntryInterface {
 	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "GlobalContextEntry", c.clientType)
 	return globalcontextentries.WithMetrics(c.inner.GlobalContextEntries(), recorder)
 }
+func (c *withMetrics) ImageVerificationPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ImageVerificationPolicyInterface {
+	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "ImageVerificationPolicy", c.clientType)
+	return imageverificationpolicies.WithMetrics(c.inner.ImageVerificationPolicies(), recorder)
+}
+func (c *withMetrics) MutatingPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.MutatingPolicyInterface {
+	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "MutatingPolicy", c.clientType)
+	return mutatingpolicies.WithMetrics(c.inner.MutatingPolicies(), recorder)
+}
 func (c *withMetrics) ValidatingPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ValidatingPolicyInterface {
 	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "ValidatingPolicy", c.clientType)
 	return validatingpolicies.WithMetrics(c.inner.ValidatingPolicies(), recorder)
@@ -55,9 +70,18 @@ func (c *withTracing) RESTClient() rest.Interface {
 func (c *withTracing) CELPolicyExceptions(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.CELPolicyExceptionInterface {
 	return celpolicyexceptions.WithTracing(c.inner.CELPolicyExceptions(namespace), c.client, "CELPolicyException")
 }
+func (c *withTracing) GeneratingPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.GeneratingPolicyInterface {
+	return generatingpolicies.WithTracing(c.inner.GeneratingPolicies(), c.client, "GeneratingPolicy")
+}
 func (c *withTracing) GlobalContextEntries() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.GlobalContextEntryInterface {
 	return globalcontextentries.WithTracing(c.inner.GlobalContextEntries(), c.client, "GlobalContextEntry")
 }
+func (c *withTracing) ImageVerificationPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ImageVerificationPolicyInterface {
+	return imageverificationpolicies.WithTracing(c.inner.ImageVerificationPolicies(), c.client, "ImageVerificationPolicy")
+}
+func (c *withTracing) MutatingPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.MutatingPolicyInterface {
+	return mutatingpolicies.WithTracing(c.inner.MutatingPolicies(), c.client, "MutatingPolicy")
+}
 func (c *withTracing) ValidatingPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ValidatingPolicyInterface {
 	return validatingpolicies.WithTracing(c.inner.ValidatingPolicies(), c.client, "ValidatingPolicy")
 }
@@ -73,9 +97,18 @@ func (c *withLogging) RESTClient() rest.Interface {
 func (c *withLogging) CELPolicyExceptions(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.CELPolicyExceptionInterface {
 	return celpolicyexceptions.WithLogging(c.inner.CELPolicyExceptions(namespace), c.logger.WithValues("resource", "CELPolicyExceptions").WithValues("namespace", namespace))
 }
+func (c *withLogging) GeneratingPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.GeneratingPolicyInterface {
+	return generatingpolicies.WithLogging(c.inner.GeneratingPolicies(), c.logger.WithValues("resource", "GeneratingPolicies"))
+}
 func (c *withLogging) GlobalContextEntries() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.GlobalContextEntryInterface {
 	return globalcontextentries.WithLogging(c.inner.GlobalContextEntries(), c.logger.WithValues("resource", "GlobalContextEntries"))
 }
+func (c *withLogging) ImageVerificationPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ImageVerificationPolicyInterface {
+	return imageverificationpolicies.WithLogging(c.inner.ImageVerificationPolicies(), c.logger.WithValues("resource", "ImageVerificationPolicies"))
+}
+func (c *withLogging) MutatingPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.MutatingPolicyInterface {
+	return mutatingpolicies.WithLogging(c.inner.MutatingPolicies(), c.logger.WithValues("resource", "MutatingPolicies"))
+}
 func (c *withLogging) ValidatingPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ValidatingPolicyInterface {
 	return validatingpolicies.WithLogging(c.inner.ValidatingPolicies(), c.logger.WithValues("resource", "ValidatingPolicies"))
 }
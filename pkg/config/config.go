@@ -54,6 +54,12 @@ const (
 	VerifyMutatingWebhookName = "monitor-webhooks.kyverno.svc"
 	// ValidatingPolicyWebhookName defines default webhook name for validatingpolicies
 	ValidatingPolicyWebhookName = "vpol.validate.kyverno.svc"
+	// MutatingPolicyWebhookName defines default webhook name for mutatingpolicies
+	MutatingPolicyWebhookName = "mpol.mutate.kyverno.svc"
+	// GeneratingPolicyWebhookName defines default webhook name for generatingpolicies
+	GeneratingPolicyWebhookName = "gpol.validate.kyverno.svc"
+	// ImageVerificationPolicyWebhookName defines default webhook name for imageverificationpolicies
+	ImageVerificationPolicyWebhookName = "ivpol.mutate.kyverno.svc"
 )
 
 // paths
@@ -64,6 +70,12 @@ const (
 	ValidatingWebhookServicePath = "/validate"
 	// ValidatingPolicyServicePath is the path for validating policies execution
 	ValidatingPolicyServicePath = "/vpol"
+	// MutatingPolicyServicePath is the path for mutating policies execution
+	MutatingPolicyServicePath = "/mpol"
+	// GeneratingPolicyServicePath is the path for generating policies execution
+	GeneratingPolicyServicePath = "/gpol"
+	// ImageVerificationPolicyServicePath is the path for image verification policies execution
+	ImageVerificationPolicyServicePath = "/ivpol"
 	// ExceptionValidatingWebhookServicePath is the path for policy exception validation webhook(used to validate policy exception resource)
 	ExceptionValidatingWebhookServicePath = "/exceptionvalidate"
 	// CELExceptionValidatingWebhookServicePath is the path for CELPolicyException validation webhook(used to validate CELPolicyException resource)
@@ -10,16 +10,29 @@ import (
 	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
 	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers"
 	"github.com/kyverno/kyverno/pkg/engine/adapters"
+	"github.com/kyverno/kyverno/pkg/engine/apicall"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/globalcontext/externalapi"
+	"github.com/kyverno/kyverno/pkg/globalcontext/invalid"
 	"github.com/kyverno/kyverno/pkg/globalcontext/k8sresource"
+	"github.com/kyverno/kyverno/pkg/globalcontext/projection"
+	"github.com/kyverno/kyverno/pkg/globalcontext/snapshot"
 	"github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 )
@@ -40,20 +53,26 @@ type controller struct {
 
 	// state
 	dclient            dclient.Interface
+	metadataClient     metadata.Interface
 	kyvernoClient      versioned.Interface
 	store              store.Store
+	snapshots          *snapshot.Snapshots
 	eventGen           event.Interface
-	maxResponseLength  int64
+	apiCallConfig      apicall.APICallConfiguration
 	shouldUpdateStatus bool
+	jp                 jmespath.Interface
+	sizeMetric         metric.Int64ObservableGauge
 }
 
 func NewController(
 	gceInformer kyvernov2alpha1informers.GlobalContextEntryInformer,
 	dclient dclient.Interface,
+	metadataClient metadata.Interface,
 	kyvernoClient versioned.Interface,
 	storage store.Store,
+	snapshots *snapshot.Snapshots,
 	eventGen event.Interface,
-	maxResponseLength int64,
+	apiCallConfig apicall.APICallConfiguration,
 	shouldUpdateStatus bool,
 ) controllers.Controller {
 	queue := workqueue.NewTypedRateLimitingQueueWithConfig(
@@ -64,20 +83,54 @@ func NewController(
 		gceLister:          gceInformer.Lister(),
 		queue:              queue,
 		dclient:            dclient,
+		metadataClient:     metadataClient,
 		kyvernoClient:      kyvernoClient,
 		store:              storage,
+		snapshots:          snapshots,
 		eventGen:           eventGen,
-		maxResponseLength:  maxResponseLength,
+		apiCallConfig:      apiCallConfig,
 		shouldUpdateStatus: shouldUpdateStatus,
+		jp:                 jmespath.New(config.NewDefaultConfiguration(false)),
 	}
 
 	if _, err := controllerutils.AddEventHandlersT(gceInformer.Informer(), c.addGTXEntry, c.updateGTXEntry, c.deleteGTXEntry); err != nil {
 		logger.Error(err, "failed to register event handlers")
 	}
 
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	sizeMetric, err := meter.Int64ObservableGauge(
+		"kyverno_global_context_entry_size_bytes",
+		metric.WithDescription("can be used to track the approximate memory used by the data and projections of global context entries"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_global_context_entry_size_bytes")
+	} else {
+		c.sizeMetric = sizeMetric
+		if _, err := meter.RegisterCallback(c.report, sizeMetric); err != nil {
+			logger.Error(err, "failed to register callback")
+		}
+	}
+
 	return c
 }
 
+func (c *controller) report(ctx context.Context, observer metric.Observer) error {
+	gces, err := c.gceLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, gce := range gces {
+		entry, ok := c.store.Get(gce.Name)
+		if !ok {
+			continue
+		}
+		if sized, ok := entry.(store.Sized); ok {
+			observer.ObserveInt64(c.sizeMetric, sized.Size(), metric.WithAttributes(attribute.String("entry_name", gce.Name)))
+		}
+	}
+	return nil
+}
+
 func (c *controller) addGTXEntry(obj *kyvernov2alpha1.GlobalContextEntry) {
 	logger.Info("globalcontextentry created", "uid", obj.GetUID(), "kind", obj.Kind, "name", obj.GetName())
 	c.enqueueGCTXEntry(obj)
@@ -134,21 +187,44 @@ func (c *controller) getEntry(name string) (*kyvernov2alpha1.GlobalContextEntry,
 }
 
 func (c *controller) makeStoreEntry(ctx context.Context, gce *kyvernov2alpha1.GlobalContextEntry) (store.Entry, error) {
-	if gce.Spec.KubernetesResource != nil {
+	projections, err := projection.New(c.jp, gce.Spec.Projections...)
+	if err != nil {
+		// invalid expressions won't compile on retry, lookups report the error instead
+		logger.Error(err, "failed to compile projections", "name", gce.Name)
+		return invalid.New(err), nil
+	}
+	if resource := gce.Spec.KubernetesResource; resource != nil {
 		gvr := schema.GroupVersionResource{
-			Group:    gce.Spec.KubernetesResource.Group,
-			Version:  gce.Spec.KubernetesResource.Version,
-			Resource: gce.Spec.KubernetesResource.Resource,
+			Group:    resource.Group,
+			Version:  resource.Version,
+			Resource: resource.Resource,
+		}
+		var labelSelector string
+		if resource.LabelSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(resource.LabelSelector)
+			if err != nil {
+				// an invalid selector won't be valid on retry, lookups report the error instead
+				logger.Error(err, "failed to parse label selector", "name", gce.Name)
+				return invalid.New(err), nil
+			}
+			labelSelector = selector.String()
 		}
 		return k8sresource.New(
 			ctx,
 			gce,
 			c.eventGen,
 			c.dclient.GetDynamicInterface(),
+			c.metadataClient,
 			c.kyvernoClient,
 			logger,
 			gvr,
-			gce.Spec.KubernetesResource.Namespace,
+			resource.Namespace,
+			func(options *metav1.ListOptions) {
+				options.LabelSelector = labelSelector
+				options.FieldSelector = resource.FieldSelector
+			},
+			resource.MetadataOnly,
+			projections,
 			c.shouldUpdateStatus,
 		)
 	}
@@ -161,8 +237,10 @@ func (c *controller) makeStoreEntry(ctx context.Context, gce *kyvernov2alpha1.Gl
 		logger,
 		adapters.Client(c.dclient),
 		gce.Spec.APICall.APICall,
+		projections,
+		c.snapshots,
 		gce.Spec.APICall.RefreshInterval.Duration,
-		c.maxResponseLength,
+		c.apiCallConfig,
 		c.shouldUpdateStatus,
 	)
 }
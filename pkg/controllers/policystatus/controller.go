@@ -0,0 +1,195 @@
+package policystatus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/cel/autogen"
+	celpolicy "github.com/kyverno/kyverno/pkg/cel/policy"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/admission/plugin/policy/validating"
+	"k8s.io/apiserver/pkg/cel/openapi/resolver"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 2
+	ControllerName = "policy-status-controller"
+	maxRetries     = 10
+)
+
+// readyConditions are the conditions a validating policy needs to be ready
+var readyConditions = []kyvernov2alpha1.PolicyConditionType{
+	kyvernov2alpha1.PolicyConditionTypePolicyCompiled,
+	kyvernov2alpha1.PolicyConditionTypeWebhookConfigured,
+}
+
+type controller struct {
+	// clients
+	client versioned.Interface
+
+	// listers
+	vpolLister kyvernov2alpha1listers.ValidatingPolicyLister
+
+	// queue
+	queue workqueue.TypedRateLimitingInterface[any]
+
+	compiler celpolicy.Compiler
+	checker  *validating.TypeChecker
+}
+
+// NewTypeChecker returns a type checker resolving resources schemas using the given discovery client
+func NewTypeChecker(client discovery.CachedDiscoveryInterface) *validating.TypeChecker {
+	return &validating.TypeChecker{
+		SchemaResolver: &resolver.ClientDiscoveryResolver{Discovery: client},
+		RestMapper:     restmapper.NewDeferredDiscoveryRESTMapper(client),
+	}
+}
+
+func NewController(
+	client versioned.Interface,
+	vpolInformer kyvernov2alpha1informers.ValidatingPolicyInformer,
+	compiler celpolicy.Compiler,
+	checker *validating.TypeChecker,
+) controllers.Controller {
+	queue := workqueue.NewTypedRateLimitingQueueWithConfig(
+		workqueue.DefaultTypedControllerRateLimiter[any](),
+		workqueue.TypedRateLimitingQueueConfig[any]{Name: ControllerName},
+	)
+	c := &controller{
+		client:     client,
+		vpolLister: vpolInformer.Lister(),
+		queue:      queue,
+		compiler:   compiler,
+		checker:    checker,
+	}
+	// status updates are watched too, the webhook controller reports the webhook configuration state
+	if _, _, err := controllerutils.AddDefaultEventHandlers(logger, vpolInformer.Informer(), queue); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, name string) error {
+	vpol, err := c.vpolLister.Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	return controllerutils.UpdateStatus(
+		ctx,
+		vpol,
+		c.client.KyvernoV2alpha1().ValidatingPolicies(),
+		func(vpol *kyvernov2alpha1.ValidatingPolicy) error {
+			c.updateStatus(vpol)
+			return nil
+		},
+		func(a *kyvernov2alpha1.ValidatingPolicy, b *kyvernov2alpha1.ValidatingPolicy) bool {
+			return datautils.DeepEqual(a.Status, b.Status)
+		},
+	)
+}
+
+func (c *controller) updateStatus(vpol *kyvernov2alpha1.ValidatingPolicy) {
+	status := &vpol.Status
+	var typeChecking kyvernov2alpha1.TypeChecking
+	if _, errs := c.compiler.Compile(vpol, nil); len(errs) != 0 {
+		for _, err := range errs {
+			typeChecking.ExpressionErrors = append(typeChecking.ExpressionErrors, kyvernov2alpha1.ExpressionMessage{
+				FieldRef: err.Field,
+				Message:  err.ErrorBody(),
+			})
+		}
+		status.SetReadyByCondition(kyvernov2alpha1.PolicyConditionTypePolicyCompiled, metav1.ConditionFalse, fmt.Sprintf("%d expression(s) failed to compile", len(errs)))
+	} else {
+		typeChecking.ExpressionWarnings = c.typeCheck(vpol)
+		status.SetReadyByCondition(kyvernov2alpha1.PolicyConditionTypePolicyCompiled, metav1.ConditionTrue, "Policy compiled")
+	}
+	if len(typeChecking.ExpressionErrors) != 0 || len(typeChecking.ExpressionWarnings) != 0 {
+		status.TypeChecking = &typeChecking
+	} else {
+		status.TypeChecking = nil
+	}
+	status.Autogen.Rules = autogen.ComputeRules(vpol)
+	status.ObservedGeneration = vpol.Generation
+	status.SetReadyFromConditions(readyConditions...)
+}
+
+// typeCheck checks the policy expressions against the schemas of the resources matched by the policy
+func (c *controller) typeCheck(vpol *kyvernov2alpha1.ValidatingPolicy) []kyvernov2alpha1.ExpressionMessage {
+	if c.checker == nil {
+		return nil
+	}
+	vap := &admissionregistrationv1.ValidatingAdmissionPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: vpol.GetName(),
+		},
+		Spec: vpol.Spec.ValidatingAdmissionPolicySpec,
+	}
+	ctx := c.checker.CreateContext(vap)
+	var warnings []kyvernov2alpha1.ExpressionMessage
+	check := func(path *field.Path, expression string) {
+		if expression == "" {
+			return
+		}
+		if message := typeErrors(c.checker.CheckExpression(ctx, expression)); message != "" {
+			warnings = append(warnings, kyvernov2alpha1.ExpressionMessage{
+				FieldRef: path.String(),
+				Message:  message,
+			})
+		}
+	}
+	path := field.NewPath("spec")
+	for i, condition := range vpol.Spec.MatchConditions {
+		check(path.Child("matchConditions").Index(i).Child("expression"), condition.Expression)
+	}
+	for i, validation := range vpol.Spec.Validations {
+		check(path.Child("validations").Index(i).Child("expression"), validation.Expression)
+		check(path.Child("validations").Index(i).Child("messageExpression"), validation.MessageExpression)
+	}
+	for i, annotation := range vpol.Spec.AuditAnnotations {
+		check(path.Child("auditAnnotations").Index(i).Child("valueExpression"), annotation.ValueExpression)
+	}
+	return warnings
+}
+
+// typeErrors returns the type errors found by the kubernetes type checker, kyverno libraries are unknown
+// to it but references were already validated by the kyverno compiler so undeclared references are ignored
+func typeErrors(results validating.TypeCheckingResults) string {
+	var messages []string
+	for _, result := range results {
+		if result.Issues == nil {
+			continue
+		}
+		for _, line := range strings.Split(result.Issues.Error(), "\n") {
+			_, issue, found := strings.Cut(line, "ERROR: ")
+			if !found || strings.Contains(issue, "undeclared reference") {
+				continue
+			}
+			messages = append(messages, fmt.Sprintf("%s: %s", result.GVK, issue))
+		}
+	}
+	return strings.Join(messages, "; ")
+}
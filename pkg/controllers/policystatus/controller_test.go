@@ -0,0 +1,95 @@
+package policystatus
+
+import (
+	"errors"
+	"testing"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	celpolicy "github.com/kyverno/kyverno/pkg/cel/policy"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission/plugin/policy/validating"
+)
+
+func Test_controller_updateStatus(t *testing.T) {
+	policy := func(expression string, webhookConfigured bool) *kyvernov2alpha1.ValidatingPolicy {
+		vpol := &kyvernov2alpha1.ValidatingPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "foo",
+				Generation: 2,
+			},
+			Spec: kyvernov2alpha1.ValidatingPolicySpec{
+				ValidatingAdmissionPolicySpec: admissionregistrationv1.ValidatingAdmissionPolicySpec{
+					Validations: []admissionregistrationv1.Validation{{
+						Expression: expression,
+					}},
+				},
+			},
+		}
+		if webhookConfigured {
+			vpol.GetStatus().SetReadyByCondition(kyvernov2alpha1.PolicyConditionTypeWebhookConfigured, metav1.ConditionTrue, "Webhook configured")
+		}
+		return vpol
+	}
+	tests := []struct {
+		name       string
+		policy     *kyvernov2alpha1.ValidatingPolicy
+		compiled   bool
+		ready      bool
+		wantErrors []string
+	}{{
+		name:     "ready",
+		policy:   policy("object.metadata.name == 'foo'", true),
+		compiled: true,
+		ready:    true,
+	}, {
+		name:     "webhook not configured",
+		policy:   policy("object.metadata.name == 'foo'", false),
+		compiled: true,
+	}, {
+		name:       "compilation error",
+		policy:     policy("object.metadata.name ==", true),
+		wantErrors: []string{"spec.validations[0].expression"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{compiler: celpolicy.NewCompiler()}
+			c.updateStatus(tt.policy)
+			status := tt.policy.Status
+			assert.Equal(t, int64(2), status.ObservedGeneration)
+			assert.Equal(t, tt.compiled, meta.IsStatusConditionTrue(status.Conditions, string(kyvernov2alpha1.PolicyConditionTypePolicyCompiled)))
+			assert.Equal(t, tt.ready, status.Ready)
+			assert.Equal(t, tt.ready, status.IsReady())
+			if len(tt.wantErrors) == 0 {
+				assert.Nil(t, status.TypeChecking)
+			} else {
+				assert.NotNil(t, status.TypeChecking)
+				var fields []string
+				for _, err := range status.TypeChecking.ExpressionErrors {
+					assert.NotEmpty(t, err.Message)
+					fields = append(fields, err.FieldRef)
+				}
+				assert.Equal(t, tt.wantErrors, fields)
+			}
+		})
+	}
+}
+
+func Test_typeErrors(t *testing.T) {
+	gvk := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	results := validating.TypeCheckingResults{{
+		GVK:    gvk,
+		Issues: errors.New("compilation failed: ERROR: <input>:1:1: undeclared reference to 'context' (in container '')\n | context.GetResource()\n | ^"),
+	}, {
+		GVK:    gvk,
+		Issues: errors.New("compilation failed: ERROR: <input>:1:16: undefined field 'foo'\n | object.spec.foo\n | ...............^"),
+	}, {
+		GVK: gvk,
+		Err: errors.New("internal error"),
+	}}
+	assert.Equal(t, "/v1, Kind=Pod: <input>:1:16: undefined field 'foo'", typeErrors(results))
+	assert.Equal(t, "", typeErrors(results[:1]))
+}
@@ -53,6 +53,8 @@ type controller struct {
 	polLister   kyvernov1listers.PolicyLister
 	cpolLister  kyvernov1listers.ClusterPolicyLister
 	vpolLister  kyvernov2alpha1listers.ValidatingPolicyLister
+	mpolLister  kyvernov2alpha1listers.MutatingPolicyLister
+	ivpolLister kyvernov2alpha1listers.ImageVerificationPolicyLister
 	vapLister   admissionregistrationv1listers.ValidatingAdmissionPolicyLister
 	ephrLister  cache.GenericLister
 	cephrLister cache.GenericLister
@@ -74,6 +76,8 @@ func NewController(
 	polInformer kyvernov1informers.PolicyInformer,
 	cpolInformer kyvernov1informers.ClusterPolicyInformer,
 	vpolInformer kyvernov2alpha1informers.ValidatingPolicyInformer,
+	mpolInformer kyvernov2alpha1informers.MutatingPolicyInformer,
+	ivpolInformer kyvernov2alpha1informers.ImageVerificationPolicyInformer,
 	vapInformer admissionregistrationv1informers.ValidatingAdmissionPolicyInformer,
 ) controllers.Controller {
 	ephrInformer := metadataFactory.ForResource(reportsv1.SchemeGroupVersion.WithResource("ephemeralreports"))
@@ -144,6 +148,28 @@ func NewController(
 			logger.Error(err, "failed to register event handlers")
 		}
 	}
+	if mpolInformer != nil {
+		c.mpolLister = mpolInformer.Lister()
+		if _, err := controllerutils.AddEventHandlersT(
+			mpolInformer.Informer(),
+			func(_ metav1.Object) { enqueueAll() },
+			func(_, _ metav1.Object) { enqueueAll() },
+			func(_ metav1.Object) { enqueueAll() },
+		); err != nil {
+			logger.Error(err, "failed to register event handlers")
+		}
+	}
+	if ivpolInformer != nil {
+		c.ivpolLister = ivpolInformer.Lister()
+		if _, err := controllerutils.AddEventHandlersT(
+			ivpolInformer.Informer(),
+			func(_ metav1.Object) { enqueueAll() },
+			func(_, _ metav1.Object) { enqueueAll() },
+			func(_ metav1.Object) { enqueueAll() },
+		); err != nil {
+			logger.Error(err, "failed to register event handlers")
+		}
+	}
 	if vapInformer != nil {
 		c.vapLister = vapInformer.Lister()
 		if _, err := controllerutils.AddEventHandlersT(
@@ -230,6 +256,34 @@ func (c *controller) createVPolMap() (sets.Set[string], error) {
 	return results, nil
 }
 
+func (c *controller) createMPolMap() (sets.Set[string], error) {
+	results := sets.New[string]()
+	if c.mpolLister != nil {
+		mpols, err := c.mpolLister.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		for _, mpol := range mpols {
+			results.Insert(cache.MetaObjectToName(mpol).String())
+		}
+	}
+	return results, nil
+}
+
+func (c *controller) createIVPolMap() (sets.Set[string], error) {
+	results := sets.New[string]()
+	if c.ivpolLister != nil {
+		ivpols, err := c.ivpolLister.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		for _, ivpol := range ivpols {
+			results.Insert(cache.MetaObjectToName(ivpol).String())
+		}
+	}
+	return results, nil
+}
+
 func (c *controller) findOwnedEphemeralReports(ctx context.Context, namespace, name string) ([]reportsv1.ReportInterface, error) {
 	selector, err := reportutils.SelectorResourceUidEquals(types.UID(name))
 	if err != nil {
@@ -454,10 +508,20 @@ func (c *controller) backReconcile(ctx context.Context, logger logr.Logger, _, n
 	if err != nil {
 		return err
 	}
+	mpolMap, err := c.createMPolMap()
+	if err != nil {
+		return err
+	}
+	ivpolMap, err := c.createIVPolMap()
+	if err != nil {
+		return err
+	}
 	maps := maps{
-		pol:  policyMap,
-		vap:  vapMap,
-		vpol: vpolMap,
+		pol:   policyMap,
+		vap:   vapMap,
+		vpol:  vpolMap,
+		mpol:  mpolMap,
+		ivpol: ivpolMap,
 	}
 	reports = append(reports, ephemeralReports...)
 	merged := map[string]policyreportv1alpha2.PolicyReportResult{}
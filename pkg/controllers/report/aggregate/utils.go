@@ -16,9 +16,11 @@ import (
 )
 
 type maps struct {
-	pol  map[string]policyMapEntry
-	vap  sets.Set[string]
-	vpol sets.Set[string]
+	pol   map[string]policyMapEntry
+	vap   sets.Set[string]
+	vpol  sets.Set[string]
+	mpol  sets.Set[string]
+	ivpol sets.Set[string]
 }
 
 func mergeReports(maps maps, accumulator map[string]policyreportv1alpha2.PolicyReportResult, uid types.UID, reports ...reportsv1.ReportInterface) {
@@ -37,6 +39,24 @@ func mergeReports(maps maps, accumulator map[string]policyreportv1alpha2.PolicyR
 						accumulator[key] = result
 					}
 				}
+			case reportutils.SourceMutatingPolicy:
+				if maps.mpol != nil && maps.mpol.Has(result.Policy) {
+					key := result.Source + "/" + result.Policy + "/" + result.Rule + "/" + string(uid)
+					if rule, exists := accumulator[key]; !exists {
+						accumulator[key] = result
+					} else if rule.Timestamp.Seconds < result.Timestamp.Seconds {
+						accumulator[key] = result
+					}
+				}
+			case reportutils.SourceImageVerificationPolicy:
+				if maps.ivpol != nil && maps.ivpol.Has(result.Policy) {
+					key := result.Source + "/" + result.Policy + "/" + result.Rule + "/" + string(uid)
+					if rule, exists := accumulator[key]; !exists {
+						accumulator[key] = result
+					} else if rule.Timestamp.Seconds < result.Timestamp.Seconds {
+						accumulator[key] = result
+					}
+				}
 			case reportutils.SourceValidatingAdmissionPolicy:
 				if maps.vap != nil && maps.vap.Has(result.Policy) {
 					key := result.Source + "/" + result.Policy + "/" + string(uid)
@@ -12,6 +12,8 @@ import (
 	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
 	"github.com/kyverno/kyverno/pkg/breaker"
+	celengine "github.com/kyverno/kyverno/pkg/cel/engine"
+	celpolicy "github.com/kyverno/kyverno/pkg/cel/policy"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
 	kyvernov2informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2"
@@ -65,6 +67,7 @@ type controller struct {
 	cpolLister       kyvernov1listers.ClusterPolicyLister
 	vpolLister       kyvernov2alpha1listers.ValidatingPolicyLister
 	polexLister      kyvernov2listers.PolicyExceptionLister
+	celpolexLister   kyvernov2alpha1listers.CELPolicyExceptionLister
 	vapLister        admissionregistrationv1listers.ValidatingAdmissionPolicyLister
 	vapBindingLister admissionregistrationv1listers.ValidatingAdmissionPolicyBindingLister
 	bgscanrLister    cache.GenericLister
@@ -85,6 +88,8 @@ type controller struct {
 	policyReports bool
 	reportsConfig reportutils.ReportingConfiguration
 	breaker       breaker.Breaker
+	celContext    celpolicy.Context
+	celPolicies   celengine.KubeProvider
 }
 
 func NewController(
@@ -96,6 +101,7 @@ func NewController(
 	cpolInformer kyvernov1informers.ClusterPolicyInformer,
 	vpolInformer kyvernov2alpha1informers.ValidatingPolicyInformer,
 	polexInformer kyvernov2informers.PolicyExceptionInformer,
+	celpolexInformer kyvernov2alpha1informers.CELPolicyExceptionInformer,
 	vapInformer admissionregistrationv1informers.ValidatingAdmissionPolicyInformer,
 	vapBindingInformer admissionregistrationv1informers.ValidatingAdmissionPolicyBindingInformer,
 	nsInformer corev1informers.NamespaceInformer,
@@ -107,6 +113,8 @@ func NewController(
 	policyReports bool,
 	reportsConfig reportutils.ReportingConfiguration,
 	breaker breaker.Breaker,
+	celContext celpolicy.Context,
+	celPolicies celengine.KubeProvider,
 ) controllers.Controller {
 	ephrInformer := metadataFactory.ForResource(reportsv1.SchemeGroupVersion.WithResource("ephemeralreports"))
 	cephrInformer := metadataFactory.ForResource(reportsv1.SchemeGroupVersion.WithResource("clusterephemeralreports"))
@@ -133,6 +141,8 @@ func NewController(
 		policyReports:  policyReports,
 		reportsConfig:  reportsConfig,
 		breaker:        breaker,
+		celContext:     celContext,
+		celPolicies:    celPolicies,
 	}
 	if vpolInformer != nil {
 		c.vpolLister = vpolInformer.Lister()
@@ -140,6 +150,12 @@ func NewController(
 			logger.Error(err, "failed to register event handlers")
 		}
 	}
+	if celpolexInformer != nil {
+		c.celpolexLister = celpolexInformer.Lister()
+		if _, err := controllerutils.AddEventHandlersT(celpolexInformer.Informer(), c.addCELException, c.updateCELException, c.deleteCELException); err != nil {
+			logger.Error(err, "failed to register event handlers")
+		}
+	}
 	if vapInformer != nil {
 		c.vapLister = vapInformer.Lister()
 		if _, err := controllerutils.AddEventHandlersT(vapInformer.Informer(), c.addVAP, c.updateVAP, c.deleteVAP); err != nil {
@@ -208,6 +224,20 @@ func (c *controller) deleteException(obj *kyvernov2.PolicyException) {
 	c.enqueueResources()
 }
 
+func (c *controller) addCELException(obj *kyvernov2alpha1.CELPolicyException) {
+	c.enqueueResources()
+}
+
+func (c *controller) updateCELException(old, obj *kyvernov2alpha1.CELPolicyException) {
+	if old.GetResourceVersion() != obj.GetResourceVersion() {
+		c.enqueueResources()
+	}
+}
+
+func (c *controller) deleteCELException(obj *kyvernov2alpha1.CELPolicyException) {
+	c.enqueueResources()
+}
+
 func (c *controller) addVP(obj *kyvernov2alpha1.ValidatingPolicy) {
 	c.enqueueResources()
 }
@@ -280,7 +310,7 @@ func (c *controller) getMeta(namespace, name string) (metav1.Object, error) {
 	}
 }
 
-func (c *controller) needsReconcile(namespace, name, hash string, exceptions []kyvernov2.PolicyException, bindings []admissionregistrationv1.ValidatingAdmissionPolicyBinding, policies ...engineapi.GenericPolicy) (bool, bool, error) {
+func (c *controller) needsReconcile(namespace, name, hash string, exceptions []kyvernov2.PolicyException, celExceptions []kyvernov2alpha1.CELPolicyException, bindings []admissionregistrationv1.ValidatingAdmissionPolicyBinding, policies ...engineapi.GenericPolicy) (bool, bool, error) {
 	// if the reportMetadata does not exist, we need a full reconcile
 	reportMetadata, err := c.getMeta(namespace, name)
 	if err != nil {
@@ -315,6 +345,9 @@ func (c *controller) needsReconcile(namespace, name, hash string, exceptions []k
 	for _, exception := range exceptions {
 		expected[reportutils.PolicyExceptionLabel(exception)] = exception.GetResourceVersion()
 	}
+	for _, exception := range celExceptions {
+		expected[reportutils.CELPolicyExceptionLabel(exception)] = exception.GetResourceVersion()
+	}
 	for _, binding := range bindings {
 		expected[reportutils.ValidatingAdmissionPolicyBindingLabel(binding)] = binding.GetResourceVersion()
 	}
@@ -341,6 +374,7 @@ func (c *controller) reconcileReport(
 	gvr schema.GroupVersionResource,
 	resource resource.Resource,
 	exceptions []kyvernov2.PolicyException,
+	celExceptions []kyvernov2alpha1.CELPolicyException,
 	bindings []admissionregistrationv1.ValidatingAdmissionPolicyBinding,
 	policies ...engineapi.GenericPolicy,
 ) error {
@@ -374,6 +408,9 @@ func (c *controller) reconcileReport(
 	for _, exception := range exceptions {
 		expected[reportutils.PolicyExceptionLabel(exception)] = exception.GetResourceVersion()
 	}
+	for _, exception := range celExceptions {
+		expected[reportutils.CELPolicyExceptionLabel(exception)] = exception.GetResourceVersion()
+	}
 	for _, binding := range bindings {
 		expected[reportutils.ValidatingAdmissionPolicyBindingLabel(binding)] = binding.GetResourceVersion()
 	}
@@ -401,6 +438,10 @@ func (c *controller) reconcileReport(
 			key := cache.MetaObjectToName(&exceptions[i]).String()
 			policyNameToLabel[key] = reportutils.PolicyExceptionLabel(exception)
 		}
+		for i, exception := range celExceptions {
+			key := cache.MetaObjectToName(&celExceptions[i]).String()
+			policyNameToLabel[key] = reportutils.CELPolicyExceptionLabel(exception)
+		}
 		for _, binding := range bindings {
 			key := cache.MetaObjectToName(&binding).String()
 			policyNameToLabel[key] = reportutils.ValidatingAdmissionPolicyBindingLabel(binding)
@@ -437,6 +478,13 @@ func (c *controller) reconcileReport(
 					break
 				}
 			}
+		} else if policy.AsValidatingPolicy() != nil {
+			for _, polex := range celExceptions {
+				if actual[reportutils.CELPolicyExceptionLabel(polex)] != polex.GetResourceVersion() {
+					reevaluate = true
+					break
+				}
+			}
 		} else {
 			for _, binding := range bindings {
 				if actual[reportutils.ValidatingAdmissionPolicyBindingLabel(binding)] != binding.GetResourceVersion() {
@@ -446,7 +494,7 @@ func (c *controller) reconcileReport(
 			}
 		}
 		if full || reevaluate || actual[reportutils.PolicyLabel(policy)] != policy.GetResourceVersion() {
-			scanner := utils.NewScanner(logger, c.engine, c.config, c.jp, c.client, c.reportsConfig)
+			scanner := utils.NewScanner(logger, c.engine, c.config, c.jp, c.client, c.reportsConfig, c.celContext, c.celPolicies)
 			for _, result := range scanner.ScanResource(ctx, *target, gvr, "", ns, bindings, policy) {
 				if result.Error != nil {
 					return result.Error
@@ -469,6 +517,9 @@ func (c *controller) reconcileReport(
 	for _, exception := range exceptions {
 		reportutils.SetPolicyExceptionLabel(desired, exception)
 	}
+	for _, exception := range celExceptions {
+		reportutils.SetCELPolicyExceptionLabel(desired, exception)
+	}
 	for _, binding := range bindings {
 		reportutils.SetValidatingAdmissionPolicyBindingLabel(desired, binding)
 	}
@@ -585,15 +636,23 @@ func (c *controller) reconcile(ctx context.Context, log logr.Logger, key, namesp
 	if err != nil {
 		return err
 	}
+	var celExceptions []kyvernov2alpha1.CELPolicyException
+	if c.celpolexLister != nil {
+		// load cel policy exceptions
+		celExceptions, err = utils.FetchCELPolicyExceptions(c.celpolexLister)
+		if err != nil {
+			return err
+		}
+	}
 	// we have the resource, check if we need to reconcile
-	if needsReconcile, full, err := c.needsReconcile(namespace, name, resource.Hash, exceptions, vapBindings, policies...); err != nil {
+	if needsReconcile, full, err := c.needsReconcile(namespace, name, resource.Hash, exceptions, celExceptions, vapBindings, policies...); err != nil {
 		return err
 	} else {
 		defer func() {
 			c.queue.AddAfter(key, c.forceDelay)
 		}()
 		if needsReconcile {
-			return c.reconcileReport(ctx, namespace, name, full, uid, gvk, gvr, resource, exceptions, vapBindings, policies...)
+			return c.reconcileReport(ctx, namespace, name, full, uid, gvk, gvr, resource, exceptions, celExceptions, vapBindings, policies...)
 		}
 	}
 	return nil
@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/pkg/admissionpolicy"
+	celautogen "github.com/kyverno/kyverno/pkg/cel/autogen"
 	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
 	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
@@ -274,6 +275,10 @@ func (c *controller) updateDynamicWatchers(ctx context.Context) error {
 		// fetch kinds from validating admission policies
 		for _, policy := range vpols {
 			kinds := admissionpolicy.GetKinds(policy.Spec.MatchConstraints)
+			// include kinds from the rules generated for pod controllers
+			for _, rule := range celautogen.ComputeRules(&policy) {
+				kinds = append(kinds, admissionpolicy.GetKinds(rule.MatchConstraints)...)
+			}
 			for _, kind := range kinds {
 				group, version, kind, subresource := kubeutils.ParseKindSelector(kind)
 				c.addGVKToGVRMapping(group, version, kind, subresource, gvkToGvr)
@@ -19,6 +19,7 @@ import (
 	"go.uber.org/multierr"
 	admissionv1 "k8s.io/api/admission/v1"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -31,6 +32,8 @@ type scanner struct {
 	jp              jmespath.Interface
 	client          dclient.Interface
 	reportingConfig reportutils.ReportingConfiguration
+	celContext      celpolicy.Context
+	celPolicies     celengine.KubeProvider
 }
 
 type ScanResult struct {
@@ -57,6 +60,8 @@ func NewScanner(
 	jp jmespath.Interface,
 	client dclient.Interface,
 	reportingConfig reportutils.ReportingConfiguration,
+	celContext celpolicy.Context,
+	celPolicies celengine.KubeProvider,
 ) Scanner {
 	return &scanner{
 		logger:          logger,
@@ -65,6 +70,8 @@ func NewScanner(
 		jp:              jp,
 		client:          client,
 		reportingConfig: reportingConfig,
+		celContext:      celContext,
+		celPolicies:     celPolicies,
 	}
 }
 
@@ -134,57 +141,58 @@ func (s *scanner) ScanResource(
 		}
 	}
 	// evaluate validating policies
-	for i, policy := range vpols {
-		if pol := policy.AsValidatingPolicy(); pol != nil {
-			// create compiler
-			compiler := celpolicy.NewCompiler()
-			// create provider
-			provider, err := celengine.NewProvider(compiler, *pol)
-			if err != nil {
-				logger.Error(err, "failed to create policy provider")
-				results[&vpols[i]] = ScanResult{nil, err}
-				continue
+	if len(vpols) > 0 {
+		var compiled []celengine.CompiledPolicy
+		for i, policy := range vpols {
+			if pol := policy.AsValidatingPolicy(); pol != nil {
+				compiledPolicy, err := s.celPolicies.CompiledPolicy(ctx, pol)
+				if err != nil {
+					logger.Error(err, "failed to compile policy")
+					results[&vpols[i]] = ScanResult{nil, err}
+					continue
+				}
+				compiled = append(compiled, compiledPolicy)
 			}
-			// create engine
-			engine := celengine.NewEngine(
-				provider,
-				func(name string) *corev1.Namespace { return ns },
-				matching.NewMatcher(),
-			)
-			// create context provider
-			context, err := celpolicy.NewContextProvider(
-				s.client.GetKubeClient(),
-				nil,
-				// TODO
-				// []imagedataloader.Option{imagedataloader.WithLocalCredentials(c.RegistryAccess)},
-			)
-			if err != nil {
-				logger.Error(err, "failed to create cel context provider")
-				results[&vpols[i]] = ScanResult{nil, err}
-				continue
+		}
+		// create engine, compiled policies are shared with the provider cache
+		engine := celengine.NewEngine(
+			celengine.ProviderFunc(func(context.Context) ([]celengine.CompiledPolicy, error) { return compiled, nil }),
+			func(name string) *corev1.Namespace { return ns },
+			matching.NewMatcher(),
+		)
+		request := celengine.Request(
+			s.celContext,
+			resource.GroupVersionKind(),
+			gvr,
+			subResource,
+			resource.GetName(),
+			resource.GetNamespace(),
+			admissionv1.Create,
+			// background scans are not attributed to a user
+			authenticationv1.UserInfo{},
+			&resource,
+			nil,
+			false,
+			nil,
+		)
+		engineResponse, err := engine.Handle(ctx, request)
+		rules := map[string][]engineapi.RuleResponse{}
+		for _, policy := range engineResponse.Policies {
+			rules[policy.Policy.GetName()] = policy.Rules
+		}
+		for i, policy := range vpols {
+			if pol := policy.AsValidatingPolicy(); pol != nil {
+				if _, done := results[&vpols[i]]; done {
+					continue
+				}
+				response := engineapi.EngineResponse{
+					Resource: resource,
+					PolicyResponse: engineapi.PolicyResponse{
+						Rules: rules[pol.GetName()],
+					},
+				}.WithPolicy(vpols[i])
+				results[&vpols[i]] = ScanResult{&response, err}
 			}
-			request := celengine.Request(
-				context,
-				resource.GroupVersionKind(),
-				gvr,
-				subResource,
-				resource.GetName(),
-				resource.GetNamespace(),
-				admissionv1.Create,
-				&resource,
-				nil,
-				false,
-				nil,
-			)
-			engineResponse, err := engine.Handle(ctx, request)
-			response := engineapi.EngineResponse{
-				Resource: resource,
-				PolicyResponse: engineapi.PolicyResponse{
-					// TODO: policies at index 0
-					Rules: engineResponse.Policies[0].Rules,
-				},
-			}.WithPolicy(vpols[i])
-			results[&vpols[i]] = ScanResult{&response, err}
 		}
 	}
 	// evaluate validating admission policies
@@ -127,6 +127,18 @@ func FetchPolicyExceptions(polexLister kyvernov2listers.PolicyExceptionLister, n
 	return exceptions, nil
 }
 
+func FetchCELPolicyExceptions(celpolexLister kyvernov2alpha1listers.CELPolicyExceptionLister) ([]kyvernov2alpha1.CELPolicyException, error) {
+	var exceptions []kyvernov2alpha1.CELPolicyException
+	if polexs, err := celpolexLister.List(labels.Everything()); err != nil {
+		return nil, err
+	} else {
+		for _, polex := range polexs {
+			exceptions = append(exceptions, *polex)
+		}
+	}
+	return exceptions, nil
+}
+
 func FetchValidatingAdmissionPolicies(vapLister admissionregistrationv1listers.ValidatingAdmissionPolicyLister) ([]admissionregistrationv1.ValidatingAdmissionPolicy, error) {
 	var policies []admissionregistrationv1.ValidatingAdmissionPolicy
 	if pols, err := vapLister.List(labels.Everything()); err != nil {
@@ -100,6 +100,9 @@ type controller struct {
 	cpolLister        kyvernov1listers.ClusterPolicyLister
 	polLister         kyvernov1listers.PolicyLister
 	vpolLister        kyvernov2alpha1listers.ValidatingPolicyLister
+	mpolLister        kyvernov2alpha1listers.MutatingPolicyLister
+	gpolLister        kyvernov2alpha1listers.GeneratingPolicyLister
+	ivpolLister       kyvernov2alpha1listers.ImageVerificationPolicyLister
 	deploymentLister  appsv1listers.DeploymentLister
 	secretLister      corev1listers.SecretLister
 	leaseLister       coordinationv1listers.LeaseLister
@@ -130,6 +133,18 @@ type controller struct {
 	// vpolState records validatingpolicies that are configured
 	// successfully in webhook object, non-thread safe
 	vpolState map[string]bool
+
+	// mpolState records mutatingpolicies that are configured
+	// successfully in webhook object, non-thread safe
+	mpolState map[string]bool
+
+	// gpolState records generatingpolicies that are configured
+	// successfully in webhook object, non-thread safe
+	gpolState map[string]bool
+
+	// ivpolState records imageverificationpolicies that are configured
+	// successfully in webhook object, non-thread safe
+	ivpolState map[string]bool
 }
 
 func NewController(
@@ -143,6 +158,9 @@ func NewController(
 	cpolInformer kyvernov1informers.ClusterPolicyInformer,
 	polInformer kyvernov1informers.PolicyInformer,
 	vpolInformer kyvernov2alpha1informers.ValidatingPolicyInformer,
+	mpolInformer kyvernov2alpha1informers.MutatingPolicyInformer,
+	gpolInformer kyvernov2alpha1informers.GeneratingPolicyInformer,
+	ivpolInformer kyvernov2alpha1informers.ImageVerificationPolicyInformer,
 	deploymentInformer appsv1informers.DeploymentInformer,
 	secretInformer corev1informers.SecretInformer,
 	leaseInformer coordinationv1informers.LeaseInformer,
@@ -176,6 +194,9 @@ func NewController(
 		cpolLister:          cpolInformer.Lister(),
 		polLister:           polInformer.Lister(),
 		vpolLister:          vpolInformer.Lister(),
+		mpolLister:          mpolInformer.Lister(),
+		gpolLister:          gpolInformer.Lister(),
+		ivpolLister:         ivpolInformer.Lister(),
 		deploymentLister:    deploymentInformer.Lister(),
 		secretLister:        secretInformer.Lister(),
 		leaseLister:         leaseInformer.Lister(),
@@ -197,7 +218,10 @@ func NewController(
 			config.MutatingWebhookConfigurationName:   sets.New[string](),
 			config.ValidatingWebhookConfigurationName: sets.New[string](),
 		},
-		vpolState: make(map[string]bool),
+		vpolState:  make(map[string]bool),
+		mpolState:  make(map[string]bool),
+		gpolState:  make(map[string]bool),
+		ivpolState: make(map[string]bool),
 	}
 	if _, _, err := controllerutils.AddDefaultEventHandlers(logger, mwcInformer.Informer(), queue); err != nil {
 		logger.Error(err, "failed to register event handlers")
@@ -260,6 +284,30 @@ func NewController(
 	); err != nil {
 		logger.Error(err, "failed to register event handlers")
 	}
+	if _, err := controllerutils.AddEventHandlers(
+		mpolInformer.Informer(),
+		func(interface{}) { c.enqueueResourceWebhooks(0) },
+		func(interface{}, interface{}) { c.enqueueResourceWebhooks(0) },
+		func(interface{}) { c.enqueueResourceWebhooks(0) },
+	); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	if _, err := controllerutils.AddEventHandlers(
+		gpolInformer.Informer(),
+		func(interface{}) { c.enqueueResourceWebhooks(0) },
+		func(interface{}, interface{}) { c.enqueueResourceWebhooks(0) },
+		func(interface{}) { c.enqueueResourceWebhooks(0) },
+	); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	if _, err := controllerutils.AddEventHandlers(
+		ivpolInformer.Informer(),
+		func(interface{}) { c.enqueueResourceWebhooks(0) },
+		func(interface{}, interface{}) { c.enqueueResourceWebhooks(0) },
+		func(interface{}) { c.enqueueResourceWebhooks(0) },
+	); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
 	configuration.OnChanged(c.enqueueAll)
 	return &c
 }
@@ -388,6 +436,27 @@ func (c *controller) recordValidatingPolicyState(validatingpolicies ...kyvernov2
 	}
 }
 
+func (c *controller) recordMutatingPolicyState(mutatingpolicies ...*kyvernov2alpha1.MutatingPolicy) {
+	c.mpolState = make(map[string]bool)
+	for _, policy := range mutatingpolicies {
+		c.mpolState[policy.GetName()] = true
+	}
+}
+
+func (c *controller) recordImageVerificationPolicyState(imageverificationpolicies ...*kyvernov2alpha1.ImageVerificationPolicy) {
+	c.ivpolState = make(map[string]bool)
+	for _, policy := range imageverificationpolicies {
+		c.ivpolState[policy.GetName()] = true
+	}
+}
+
+func (c *controller) recordGeneratingPolicyState(generatingpolicies ...*kyvernov2alpha1.GeneratingPolicy) {
+	c.gpolState = make(map[string]bool)
+	for _, policy := range generatingpolicies {
+		c.gpolState[policy.GetName()] = true
+	}
+}
+
 func (c *controller) reconcileResourceValidatingWebhookConfiguration(ctx context.Context) error {
 	if c.autoUpdateWebhooks {
 		return c.reconcileValidatingWebhookConfiguration(ctx, c.autoUpdateWebhooks, c.buildResourceValidatingWebhookConfiguration)
@@ -662,15 +731,16 @@ func (c *controller) updateValidatingPolicyStatuses(ctx context.Context) error {
 
 	updateStatusFunc := func(vpol kyvernov2alpha1.GenericPolicy) error {
 		status := vpol.GetStatus()
-		status.SetReadyByCondition(kyvernov2alpha1.PolicyConditionTypeWebhookConfigured, metav1.ConditionTrue, "Webhook configured")
+		if c.vpolState[vpol.GetName()] {
+			status.SetReadyByCondition(kyvernov2alpha1.PolicyConditionTypeWebhookConfigured, metav1.ConditionTrue, "Webhook configured")
+		} else {
+			status.SetReadyByCondition(kyvernov2alpha1.PolicyConditionTypeWebhookConfigured, metav1.ConditionFalse, "Webhook not configured")
+		}
 		return nil
 	}
 
 	var errs []error
 	for _, vpol := range vpols {
-		if !c.vpolState[vpol.GetName()] {
-			continue
-		}
 		err := controllerutils.UpdateStatus(
 			ctx,
 			vpol.(*kyvernov2alpha1.ValidatingPolicy),
@@ -689,6 +759,93 @@ func (c *controller) updateValidatingPolicyStatuses(ctx context.Context) error {
 	return multierr.Combine(errs...)
 }
 
+func (c *controller) updateMutatingPolicyStatuses(ctx context.Context) error {
+	mpols, err := c.mpolLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, mpol := range mpols {
+		if !c.mpolState[mpol.GetName()] {
+			continue
+		}
+		err := controllerutils.UpdateStatus(
+			ctx,
+			mpol,
+			c.kyvernoClient.KyvernoV2alpha1().MutatingPolicies(),
+			func(mpol *kyvernov2alpha1.MutatingPolicy) error {
+				mpol.GetStatus().SetReadyByCondition(kyvernov2alpha1.PolicyConditionTypeWebhookConfigured, metav1.ConditionTrue, "Webhook configured")
+				return nil
+			},
+			func(a *kyvernov2alpha1.MutatingPolicy, b *kyvernov2alpha1.MutatingPolicy) bool {
+				return datautils.DeepEqual(a.Status, b.Status)
+			},
+		)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", mpol.GetName(), err))
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
+func (c *controller) updateImageVerificationPolicyStatuses(ctx context.Context) error {
+	ivpols, err := c.ivpolLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, ivpol := range ivpols {
+		if !c.ivpolState[ivpol.GetName()] {
+			continue
+		}
+		err := controllerutils.UpdateStatus(
+			ctx,
+			ivpol,
+			c.kyvernoClient.KyvernoV2alpha1().ImageVerificationPolicies(),
+			func(ivpol *kyvernov2alpha1.ImageVerificationPolicy) error {
+				ivpol.GetStatus().SetReadyByCondition(kyvernov2alpha1.PolicyConditionTypeWebhookConfigured, metav1.ConditionTrue, "Webhook configured")
+				return nil
+			},
+			func(a *kyvernov2alpha1.ImageVerificationPolicy, b *kyvernov2alpha1.ImageVerificationPolicy) bool {
+				return datautils.DeepEqual(a.Status, b.Status)
+			},
+		)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", ivpol.GetName(), err))
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
+func (c *controller) updateGeneratingPolicyStatuses(ctx context.Context) error {
+	gpols, err := c.gpolLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, gpol := range gpols {
+		if !c.gpolState[gpol.GetName()] {
+			continue
+		}
+		err := controllerutils.UpdateStatus(
+			ctx,
+			gpol,
+			c.kyvernoClient.KyvernoV2alpha1().GeneratingPolicies(),
+			func(gpol *kyvernov2alpha1.GeneratingPolicy) error {
+				gpol.GetStatus().SetReadyByCondition(kyvernov2alpha1.PolicyConditionTypeWebhookConfigured, metav1.ConditionTrue, "Webhook configured")
+				return nil
+			},
+			func(a *kyvernov2alpha1.GeneratingPolicy, b *kyvernov2alpha1.GeneratingPolicy) bool {
+				return datautils.DeepEqual(a.Status, b.Status)
+			},
+		)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", gpol.GetName(), err))
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
 func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
 	if c.autoDeleteWebhooks && c.runtime.IsGoingDown() {
 		return c.reconcileWebhookDeletion(ctx)
@@ -702,9 +859,20 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 			if err := c.reconcileResourceMutatingWebhookConfiguration(ctx); err != nil {
 				return err
 			}
+
+			var errs []error
 			if err := c.updatePolicyStatuses(ctx, config.MutatingWebhookConfigurationName); err != nil {
-				return err
+				errs = append(errs, fmt.Errorf("failed to update policy statuses: %w", err))
+			}
+
+			if err := c.updateMutatingPolicyStatuses(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("failed to update mutating policy statuses: %w", err))
+			}
+
+			if err := c.updateImageVerificationPolicyStatuses(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("failed to update image verification policy statuses: %w", err))
 			}
+			return multierr.Combine(errs...)
 		}
 	case config.ValidatingWebhookConfigurationName:
 		if c.runtime.IsRollingUpdate() {
@@ -722,6 +890,10 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 			if err := c.updateValidatingPolicyStatuses(ctx); err != nil {
 				errs = append(errs, fmt.Errorf("failed to update validating policy statuses: %w", err))
 			}
+
+			if err := c.updateGeneratingPolicyStatuses(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("failed to update generating policy statuses: %w", err))
+			}
 			return multierr.Combine(errs...)
 		}
 	case config.PolicyValidatingWebhookConfigurationName:
@@ -905,9 +1077,49 @@ func (c *controller) buildResourceMutatingWebhookConfiguration(ctx context.Conte
 	} else {
 		c.recordPolicyState(config.MutatingWebhookConfigurationName)
 	}
+	if err := c.buildForMutatingPolicies(cfg, caBundle, &result); err != nil {
+		return nil, fmt.Errorf("failed to build webhook rules for mutatingpolicies: %v", err)
+	}
+	if err := c.buildForImageVerificationPolicies(cfg, caBundle, &result); err != nil {
+		return nil, fmt.Errorf("failed to build webhook rules for imageverificationpolicies: %v", err)
+	}
 	return &result, nil
 }
 
+func (c *controller) buildForMutatingPolicies(cfg config.Configuration, caBundle []byte, result *admissionregistrationv1.MutatingWebhookConfiguration) error {
+	if !c.watchdogCheck() {
+		c.recordMutatingPolicyState()
+		return nil
+	}
+
+	mpols, err := c.mpolLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	webhooks := buildMutatingWebhookRules(cfg, c.server, c.servicePort, caBundle, mpols)
+	result.Webhooks = append(result.Webhooks, webhooks...)
+	c.recordMutatingPolicyState(mpols...)
+	return nil
+}
+
+func (c *controller) buildForImageVerificationPolicies(cfg config.Configuration, caBundle []byte, result *admissionregistrationv1.MutatingWebhookConfiguration) error {
+	if !c.watchdogCheck() {
+		c.recordImageVerificationPolicyState()
+		return nil
+	}
+
+	ivpols, err := c.ivpolLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	webhooks := buildImageVerificationWebhookRules(cfg, c.server, c.servicePort, caBundle, ivpols)
+	result.Webhooks = append(result.Webhooks, webhooks...)
+	c.recordImageVerificationPolicyState(ivpols...)
+	return nil
+}
+
 func (c *controller) buildResourceMutatingWebhookRules(caBundle []byte, webhookCfg config.WebhookConfig, sideEffects *admissionregistrationv1.SideEffectClass, webhooks []*webhook) []admissionregistrationv1.MutatingWebhook {
 	var mutatingWebhooks []admissionregistrationv1.MutatingWebhook //nolint:prealloc
 	objectSelector := webhookCfg.ObjectSelector
@@ -1011,11 +1223,16 @@ func (c *controller) buildResourceValidatingWebhookConfiguration(ctx context.Con
 		errs = append(errs, fmt.Errorf("failed to build webhook rules for validatingpolicies: %v", err))
 	}
 
+	if err := c.buildForGeneratingPolicies(cfg, caBundle, webhookConfig); err != nil {
+		errs = append(errs, fmt.Errorf("failed to build webhook rules for generatingpolicies: %v", err))
+	}
+
 	return webhookConfig, multierr.Combine(errs...)
 }
 
 func (c *controller) buildForValidatingPolicies(cfg config.Configuration, caBundle []byte, result *admissionregistrationv1.ValidatingWebhookConfiguration) error {
 	if !c.watchdogCheck() {
+		c.recordValidatingPolicyState()
 		return nil
 	}
 
@@ -1030,6 +1247,23 @@ func (c *controller) buildForValidatingPolicies(cfg config.Configuration, caBund
 	return nil
 }
 
+func (c *controller) buildForGeneratingPolicies(cfg config.Configuration, caBundle []byte, result *admissionregistrationv1.ValidatingWebhookConfiguration) error {
+	if !c.watchdogCheck() {
+		c.recordGeneratingPolicyState()
+		return nil
+	}
+
+	gpols, err := c.gpolLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	webhooks := buildGeneratingWebhookRules(cfg, c.server, c.servicePort, caBundle, gpols)
+	result.Webhooks = append(result.Webhooks, webhooks...)
+	c.recordGeneratingPolicyState(gpols...)
+	return nil
+}
+
 func (c *controller) buildForPolicies(ctx context.Context, cfg config.Configuration, caBundle []byte, result *admissionregistrationv1.ValidatingWebhookConfiguration) error {
 	if c.watchdogCheck() {
 		webhookCfg := cfg.GetWebhook()
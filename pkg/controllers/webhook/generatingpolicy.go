@@ -0,0 +1,111 @@
+package webhook
+
+import (
+	"slices"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/config"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/utils/ptr"
+)
+
+func buildGeneratingWebhookRules(cfg config.Configuration, server string, servicePort int32, caBundle []byte, gpols []*kyvernov2alpha1.GeneratingPolicy) (webhooks []admissionregistrationv1.ValidatingWebhook) {
+	var (
+		webhookIgnoreList []admissionregistrationv1.ValidatingWebhook
+		webhookFailList   []admissionregistrationv1.ValidatingWebhook
+		webhookIgnore     = admissionregistrationv1.ValidatingWebhook{
+			Name:                    config.GeneratingPolicyWebhookName + "-ignore",
+			ClientConfig:            newClientConfig(server, servicePort, caBundle, config.GeneratingPolicyServicePath+"/ignore"),
+			FailurePolicy:           ptr.To(admissionregistrationv1.Ignore),
+			SideEffects:             &noneOnDryRun,
+			AdmissionReviewVersions: []string{"v1"},
+		}
+		webhookFail = admissionregistrationv1.ValidatingWebhook{
+			Name:                    config.GeneratingPolicyWebhookName + "-fail",
+			ClientConfig:            newClientConfig(server, servicePort, caBundle, config.GeneratingPolicyServicePath+"/fail"),
+			FailurePolicy:           ptr.To(admissionregistrationv1.Fail),
+			SideEffects:             &noneOnDryRun,
+			AdmissionReviewVersions: []string{"v1"},
+		}
+	)
+
+	if cfg.GetWebhook().NamespaceSelector != nil {
+		webhookIgnore.NamespaceSelector = cfg.GetWebhook().NamespaceSelector
+		webhookFail.NamespaceSelector = cfg.GetWebhook().NamespaceSelector
+	}
+	if cfg.GetWebhook().ObjectSelector != nil {
+		webhookIgnore.ObjectSelector = cfg.GetWebhook().ObjectSelector
+		webhookFail.ObjectSelector = cfg.GetWebhook().ObjectSelector
+	}
+	for _, gpol := range gpols {
+		webhook := admissionregistrationv1.ValidatingWebhook{}
+		failurePolicyIgnore := gpol.GetFailurePolicy() == admissionregistrationv1.Ignore
+		if failurePolicyIgnore {
+			webhook.FailurePolicy = ptr.To(admissionregistrationv1.Ignore)
+		} else {
+			webhook.FailurePolicy = ptr.To(admissionregistrationv1.Fail)
+		}
+		for _, match := range gpol.GetMatchConstraints().ResourceRules {
+			rule := *match.RuleWithOperations.DeepCopy()
+			// synchronized policies need to be notified when the trigger changes or goes away
+			if gpol.GetSynchronize() && !slices.Contains(rule.Operations, admissionregistrationv1.OperationAll) {
+				for _, operation := range []admissionregistrationv1.OperationType{admissionregistrationv1.Update, admissionregistrationv1.Delete} {
+					if !slices.Contains(rule.Operations, operation) {
+						rule.Operations = append(rule.Operations, operation)
+					}
+				}
+			}
+			webhook.Rules = append(webhook.Rules, rule)
+		}
+
+		fineGrainedWebhook := false
+		// match conditions of synchronized policies are evaluated by the handler,
+		// the trigger must reach it when it stops matching
+		if gpol.GetMatchConditions() != nil && !gpol.GetSynchronize() {
+			webhook.MatchConditions = gpol.GetMatchConditions()
+			fineGrainedWebhook = true
+		}
+		if gpol.GetMatchConstraints().MatchPolicy != nil && *gpol.GetMatchConstraints().MatchPolicy == admissionregistrationv1.Exact {
+			webhook.MatchPolicy = gpol.GetMatchConstraints().MatchPolicy
+			fineGrainedWebhook = true
+		}
+		if gpol.GetWebhookConfiguration() != nil && gpol.GetWebhookConfiguration().TimeoutSeconds != nil {
+			webhook.TimeoutSeconds = gpol.GetWebhookConfiguration().TimeoutSeconds
+			fineGrainedWebhook = true
+		}
+
+		if fineGrainedWebhook {
+			webhook.SideEffects = &noneOnDryRun
+			webhook.AdmissionReviewVersions = []string{"v1"}
+			if failurePolicyIgnore {
+				webhook.Name = config.GeneratingPolicyWebhookName + "-ignore-finegrained-" + gpol.GetName()
+				webhook.ClientConfig = newClientConfig(server, servicePort, caBundle, config.GeneratingPolicyServicePath+"/ignore"+config.FineGrainedWebhookPath+"/"+gpol.GetName())
+				webhookIgnoreList = append(webhookIgnoreList, webhook)
+			} else {
+				webhook.Name = config.GeneratingPolicyWebhookName + "-fail-finegrained-" + gpol.GetName()
+				webhook.ClientConfig = newClientConfig(server, servicePort, caBundle, config.GeneratingPolicyServicePath+"/fail"+config.FineGrainedWebhookPath+"/"+gpol.GetName())
+				webhookFailList = append(webhookFailList, webhook)
+			}
+		} else {
+			if failurePolicyIgnore {
+				webhookIgnore.Rules = append(webhookIgnore.Rules, webhook.Rules...)
+			} else {
+				webhookFail.Rules = append(webhookFail.Rules, webhook.Rules...)
+			}
+		}
+	}
+
+	if webhookFailList != nil {
+		webhooks = append(webhooks, webhookFailList...)
+	}
+	if webhookIgnoreList != nil {
+		webhooks = append(webhooks, webhookIgnoreList...)
+	}
+	if webhookFail.Rules != nil {
+		webhooks = append(webhooks, webhookFail)
+	}
+	if webhookIgnore.Rules != nil {
+		webhooks = append(webhooks, webhookIgnore)
+	}
+	return
+}
@@ -0,0 +1,119 @@
+package webhook
+
+import (
+	"testing"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestBuildGeneratingWebhookRules(t *testing.T) {
+	matchConstraints := &admissionregistrationv1.MatchResources{
+		ResourceRules: []admissionregistrationv1.NamedRuleWithOperations{
+			{
+				RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+					Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+					Rule: admissionregistrationv1.Rule{
+						APIGroups:   []string{""},
+						APIVersions: []string{"v1"},
+						Resources:   []string{"namespaces"},
+					},
+				},
+			},
+		},
+	}
+	matchConditions := []admissionregistrationv1.MatchCondition{{
+		Name:       "exclude-kube-system",
+		Expression: "object.metadata.name != 'kube-system'",
+	}}
+	tests := []struct {
+		name               string
+		gpols              []*kyvernov2alpha1.GeneratingPolicy
+		expectedWebhooks   []admissionregistrationv1.ValidatingWebhook
+		expectedOperations []admissionregistrationv1.OperationType
+	}{
+		{
+			name: "Single Ignore Policy",
+			gpols: []*kyvernov2alpha1.GeneratingPolicy{
+				{
+					Spec: kyvernov2alpha1.GeneratingPolicySpec{
+						FailurePolicy:    ptr.To(admissionregistrationv1.Ignore),
+						MatchConstraints: matchConstraints,
+					},
+				},
+			},
+			expectedWebhooks: []admissionregistrationv1.ValidatingWebhook{
+				{
+					Name:          config.GeneratingPolicyWebhookName + "-ignore",
+					FailurePolicy: ptr.To(admissionregistrationv1.Ignore),
+					ClientConfig:  newClientConfig("", 0, nil, config.GeneratingPolicyServicePath+"/ignore"),
+				},
+			},
+			expectedOperations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+		},
+		{
+			name: "Fine-Grained Fail Policy",
+			gpols: []*kyvernov2alpha1.GeneratingPolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-fine-grained-fail",
+					},
+					Spec: kyvernov2alpha1.GeneratingPolicySpec{
+						MatchConstraints: matchConstraints,
+						MatchConditions:  matchConditions,
+					},
+				},
+			},
+			expectedWebhooks: []admissionregistrationv1.ValidatingWebhook{
+				{
+					Name:          config.GeneratingPolicyWebhookName + "-fail-finegrained-test-fine-grained-fail",
+					FailurePolicy: ptr.To(admissionregistrationv1.Fail),
+					ClientConfig:  newClientConfig("", 0, nil, config.GeneratingPolicyServicePath+"/fail"+config.FineGrainedWebhookPath+"/test-fine-grained-fail"),
+				},
+			},
+			expectedOperations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+		},
+		{
+			name: "Synchronized Policy",
+			gpols: []*kyvernov2alpha1.GeneratingPolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-sync",
+					},
+					Spec: kyvernov2alpha1.GeneratingPolicySpec{
+						MatchConstraints: matchConstraints,
+						MatchConditions:  matchConditions,
+						Synchronize:      true,
+					},
+				},
+			},
+			expectedWebhooks: []admissionregistrationv1.ValidatingWebhook{
+				{
+					Name:          config.GeneratingPolicyWebhookName + "-fail",
+					FailurePolicy: ptr.To(admissionregistrationv1.Fail),
+					ClientConfig:  newClientConfig("", 0, nil, config.GeneratingPolicyServicePath+"/fail"),
+				},
+			},
+			expectedOperations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhooks := buildGeneratingWebhookRules(config.NewDefaultConfiguration(false), "", 0, nil, tt.gpols)
+			assert.Equal(t, len(tt.expectedWebhooks), len(webhooks))
+			for i, expect := range tt.expectedWebhooks {
+				assert.Equal(t, expect.Name, webhooks[i].Name)
+				assert.Equal(t, expect.FailurePolicy, webhooks[i].FailurePolicy)
+				assert.Equal(t, 1, len(webhooks[i].Rules))
+				assert.Equal(t, tt.expectedOperations, webhooks[i].Rules[0].Operations)
+				assert.Equal(t, *expect.ClientConfig.Service.Path, *webhooks[i].ClientConfig.Service.Path)
+			}
+			// the policy constraints must not be modified
+			assert.Equal(t, []admissionregistrationv1.OperationType{admissionregistrationv1.Create}, matchConstraints.ResourceRules[0].Operations)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/config"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/utils/ptr"
+)
+
+func buildImageVerificationWebhookRules(cfg config.Configuration, server string, servicePort int32, caBundle []byte, ivpols []*kyvernov2alpha1.ImageVerificationPolicy) (webhooks []admissionregistrationv1.MutatingWebhook) {
+	var (
+		webhookIgnoreList []admissionregistrationv1.MutatingWebhook
+		webhookFailList   []admissionregistrationv1.MutatingWebhook
+		webhookIgnore     = admissionregistrationv1.MutatingWebhook{
+			Name:                    config.ImageVerificationPolicyWebhookName + "-ignore",
+			ClientConfig:            newClientConfig(server, servicePort, caBundle, config.ImageVerificationPolicyServicePath+"/ignore"),
+			FailurePolicy:           ptr.To(admissionregistrationv1.Ignore),
+			SideEffects:             &noneOnDryRun,
+			AdmissionReviewVersions: []string{"v1"},
+			ReinvocationPolicy:      ptr.To(admissionregistrationv1.IfNeededReinvocationPolicy),
+		}
+		webhookFail = admissionregistrationv1.MutatingWebhook{
+			Name:                    config.ImageVerificationPolicyWebhookName + "-fail",
+			ClientConfig:            newClientConfig(server, servicePort, caBundle, config.ImageVerificationPolicyServicePath+"/fail"),
+			FailurePolicy:           ptr.To(admissionregistrationv1.Fail),
+			SideEffects:             &noneOnDryRun,
+			AdmissionReviewVersions: []string{"v1"},
+			ReinvocationPolicy:      ptr.To(admissionregistrationv1.IfNeededReinvocationPolicy),
+		}
+	)
+
+	if cfg.GetWebhook().NamespaceSelector != nil {
+		webhookIgnore.NamespaceSelector = cfg.GetWebhook().NamespaceSelector
+		webhookFail.NamespaceSelector = cfg.GetWebhook().NamespaceSelector
+	}
+	if cfg.GetWebhook().ObjectSelector != nil {
+		webhookIgnore.ObjectSelector = cfg.GetWebhook().ObjectSelector
+		webhookFail.ObjectSelector = cfg.GetWebhook().ObjectSelector
+	}
+	for _, ivpol := range ivpols {
+		// images may be changed by other mutations, the webhook is reinvoked to verify them again
+		webhook := admissionregistrationv1.MutatingWebhook{
+			ReinvocationPolicy: ptr.To(admissionregistrationv1.IfNeededReinvocationPolicy),
+		}
+		failurePolicyIgnore := ivpol.GetFailurePolicy() == admissionregistrationv1.Ignore
+		if failurePolicyIgnore {
+			webhook.FailurePolicy = ptr.To(admissionregistrationv1.Ignore)
+		} else {
+			webhook.FailurePolicy = ptr.To(admissionregistrationv1.Fail)
+		}
+		for _, match := range ivpol.GetMatchConstraints().ResourceRules {
+			webhook.Rules = append(webhook.Rules, match.RuleWithOperations)
+		}
+
+		fineGrainedWebhook := false
+		if ivpol.GetMatchConditions() != nil {
+			webhook.MatchConditions = ivpol.GetMatchConditions()
+			fineGrainedWebhook = true
+		}
+		if ivpol.GetMatchConstraints().MatchPolicy != nil && *ivpol.GetMatchConstraints().MatchPolicy == admissionregistrationv1.Exact {
+			webhook.MatchPolicy = ivpol.GetMatchConstraints().MatchPolicy
+			fineGrainedWebhook = true
+		}
+		if ivpol.GetWebhookConfiguration() != nil && ivpol.GetWebhookConfiguration().TimeoutSeconds != nil {
+			webhook.TimeoutSeconds = ivpol.GetWebhookConfiguration().TimeoutSeconds
+			fineGrainedWebhook = true
+		}
+
+		if fineGrainedWebhook {
+			webhook.SideEffects = &noneOnDryRun
+			webhook.AdmissionReviewVersions = []string{"v1"}
+			if failurePolicyIgnore {
+				webhook.Name = config.ImageVerificationPolicyWebhookName + "-ignore-finegrained-" + ivpol.GetName()
+				webhook.ClientConfig = newClientConfig(server, servicePort, caBundle, config.ImageVerificationPolicyServicePath+"/ignore"+config.FineGrainedWebhookPath+"/"+ivpol.GetName())
+				webhookIgnoreList = append(webhookIgnoreList, webhook)
+			} else {
+				webhook.Name = config.ImageVerificationPolicyWebhookName + "-fail-finegrained-" + ivpol.GetName()
+				webhook.ClientConfig = newClientConfig(server, servicePort, caBundle, config.ImageVerificationPolicyServicePath+"/fail"+config.FineGrainedWebhookPath+"/"+ivpol.GetName())
+				webhookFailList = append(webhookFailList, webhook)
+			}
+		} else {
+			if failurePolicyIgnore {
+				webhookIgnore.Rules = append(webhookIgnore.Rules, webhook.Rules...)
+			} else {
+				webhookFail.Rules = append(webhookFail.Rules, webhook.Rules...)
+			}
+		}
+	}
+
+	if webhookFailList != nil {
+		webhooks = append(webhooks, webhookFailList...)
+	}
+	if webhookIgnoreList != nil {
+		webhooks = append(webhooks, webhookIgnoreList...)
+	}
+	if webhookFail.Rules != nil {
+		webhooks = append(webhooks, webhookFail)
+	}
+	if webhookIgnore.Rules != nil {
+		webhooks = append(webhooks, webhookIgnore)
+	}
+	return
+}
@@ -0,0 +1,108 @@
+package webhook
+
+import (
+	"testing"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestBuildImageVerificationWebhookRules(t *testing.T) {
+	matchConstraints := &admissionregistrationv1.MatchResources{
+		ResourceRules: []admissionregistrationv1.NamedRuleWithOperations{
+			{
+				RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+					Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+					Rule: admissionregistrationv1.Rule{
+						APIGroups:   []string{""},
+						APIVersions: []string{"v1"},
+						Resources:   []string{"pods"},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name             string
+		ivpols           []*kyvernov2alpha1.ImageVerificationPolicy
+		expectedWebhooks []admissionregistrationv1.MutatingWebhook
+	}{
+		{
+			name: "Single Ignore Policy",
+			ivpols: []*kyvernov2alpha1.ImageVerificationPolicy{
+				{
+					Spec: kyvernov2alpha1.ImageVerificationPolicySpec{
+						FailurePolicy:    ptr.To(admissionregistrationv1.Ignore),
+						MatchConstraints: matchConstraints,
+					},
+				},
+			},
+			expectedWebhooks: []admissionregistrationv1.MutatingWebhook{
+				{
+					Name:          config.ImageVerificationPolicyWebhookName + "-ignore",
+					FailurePolicy: ptr.To(admissionregistrationv1.Ignore),
+					ClientConfig:  newClientConfig("", 0, nil, config.ImageVerificationPolicyServicePath+"/ignore"),
+				},
+			},
+		},
+		{
+			name: "Single Fail Policy",
+			ivpols: []*kyvernov2alpha1.ImageVerificationPolicy{
+				{
+					Spec: kyvernov2alpha1.ImageVerificationPolicySpec{
+						MatchConstraints: matchConstraints,
+					},
+				},
+			},
+			expectedWebhooks: []admissionregistrationv1.MutatingWebhook{
+				{
+					Name:          config.ImageVerificationPolicyWebhookName + "-fail",
+					FailurePolicy: ptr.To(admissionregistrationv1.Fail),
+					ClientConfig:  newClientConfig("", 0, nil, config.ImageVerificationPolicyServicePath+"/fail"),
+				},
+			},
+		},
+		{
+			name: "Fine-Grained Ignore Policy",
+			ivpols: []*kyvernov2alpha1.ImageVerificationPolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-fine-grained-ignore",
+					},
+					Spec: kyvernov2alpha1.ImageVerificationPolicySpec{
+						FailurePolicy:    ptr.To(admissionregistrationv1.Ignore),
+						MatchConstraints: matchConstraints,
+						MatchConditions: []admissionregistrationv1.MatchCondition{{
+							Name:       "exclude-kube-system",
+							Expression: "object.metadata.namespace != 'kube-system'",
+						}},
+					},
+				},
+			},
+			expectedWebhooks: []admissionregistrationv1.MutatingWebhook{
+				{
+					Name:          config.ImageVerificationPolicyWebhookName + "-ignore-finegrained-test-fine-grained-ignore",
+					FailurePolicy: ptr.To(admissionregistrationv1.Ignore),
+					ClientConfig:  newClientConfig("", 0, nil, config.ImageVerificationPolicyServicePath+"/ignore"+config.FineGrainedWebhookPath+"/test-fine-grained-ignore"),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhooks := buildImageVerificationWebhookRules(config.NewDefaultConfiguration(false), "", 0, nil, tt.ivpols)
+			assert.Equal(t, len(tt.expectedWebhooks), len(webhooks))
+			for i, expect := range tt.expectedWebhooks {
+				assert.Equal(t, expect.Name, webhooks[i].Name)
+				assert.Equal(t, expect.FailurePolicy, webhooks[i].FailurePolicy)
+				assert.Equal(t, ptr.To(admissionregistrationv1.IfNeededReinvocationPolicy), webhooks[i].ReinvocationPolicy)
+				assert.Equal(t, 1, len(webhooks[i].Rules))
+				assert.Equal(t, *expect.ClientConfig.Service.Path, *webhooks[i].ClientConfig.Service.Path)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package webhook
+
+import (
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/config"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/utils/ptr"
+)
+
+func buildMutatingWebhookRules(cfg config.Configuration, server string, servicePort int32, caBundle []byte, mpols []*kyvernov2alpha1.MutatingPolicy) (webhooks []admissionregistrationv1.MutatingWebhook) {
+	var (
+		webhookIgnoreList []admissionregistrationv1.MutatingWebhook
+		webhookFailList   []admissionregistrationv1.MutatingWebhook
+		webhookIgnore     = admissionregistrationv1.MutatingWebhook{
+			Name:                    config.MutatingPolicyWebhookName + "-ignore",
+			ClientConfig:            newClientConfig(server, servicePort, caBundle, config.MutatingPolicyServicePath+"/ignore"),
+			FailurePolicy:           ptr.To(admissionregistrationv1.Ignore),
+			SideEffects:             &noneOnDryRun,
+			AdmissionReviewVersions: []string{"v1"},
+			ReinvocationPolicy:      ptr.To(admissionregistrationv1.NeverReinvocationPolicy),
+		}
+		webhookFail = admissionregistrationv1.MutatingWebhook{
+			Name:                    config.MutatingPolicyWebhookName + "-fail",
+			ClientConfig:            newClientConfig(server, servicePort, caBundle, config.MutatingPolicyServicePath+"/fail"),
+			FailurePolicy:           ptr.To(admissionregistrationv1.Fail),
+			SideEffects:             &noneOnDryRun,
+			AdmissionReviewVersions: []string{"v1"},
+			ReinvocationPolicy:      ptr.To(admissionregistrationv1.NeverReinvocationPolicy),
+		}
+	)
+
+	if cfg.GetWebhook().NamespaceSelector != nil {
+		webhookIgnore.NamespaceSelector = cfg.GetWebhook().NamespaceSelector
+		webhookFail.NamespaceSelector = cfg.GetWebhook().NamespaceSelector
+	}
+	if cfg.GetWebhook().ObjectSelector != nil {
+		webhookIgnore.ObjectSelector = cfg.GetWebhook().ObjectSelector
+		webhookFail.ObjectSelector = cfg.GetWebhook().ObjectSelector
+	}
+	for _, mpol := range mpols {
+		webhook := admissionregistrationv1.MutatingWebhook{
+			ReinvocationPolicy: ptr.To(mpol.GetReinvocationPolicy()),
+		}
+		failurePolicyIgnore := mpol.GetFailurePolicy() == admissionregistrationv1.Ignore
+		if failurePolicyIgnore {
+			webhook.FailurePolicy = ptr.To(admissionregistrationv1.Ignore)
+		} else {
+			webhook.FailurePolicy = ptr.To(admissionregistrationv1.Fail)
+		}
+		for _, match := range mpol.GetMatchConstraints().ResourceRules {
+			webhook.Rules = append(webhook.Rules, match.RuleWithOperations)
+		}
+
+		fineGrainedWebhook := false
+		if mpol.GetMatchConditions() != nil {
+			webhook.MatchConditions = mpol.GetMatchConditions()
+			fineGrainedWebhook = true
+		}
+		if mpol.GetMatchConstraints().MatchPolicy != nil && *mpol.GetMatchConstraints().MatchPolicy == admissionregistrationv1.Exact {
+			webhook.MatchPolicy = mpol.GetMatchConstraints().MatchPolicy
+			fineGrainedWebhook = true
+		}
+		if mpol.GetWebhookConfiguration() != nil && mpol.GetWebhookConfiguration().TimeoutSeconds != nil {
+			webhook.TimeoutSeconds = mpol.GetWebhookConfiguration().TimeoutSeconds
+			fineGrainedWebhook = true
+		}
+
+		if fineGrainedWebhook {
+			webhook.SideEffects = &noneOnDryRun
+			webhook.AdmissionReviewVersions = []string{"v1"}
+			if failurePolicyIgnore {
+				webhook.Name = config.MutatingPolicyWebhookName + "-ignore-finegrained-" + mpol.GetName()
+				webhook.ClientConfig = newClientConfig(server, servicePort, caBundle, config.MutatingPolicyServicePath+"/ignore"+config.FineGrainedWebhookPath+"/"+mpol.GetName())
+				webhookIgnoreList = append(webhookIgnoreList, webhook)
+			} else {
+				webhook.Name = config.MutatingPolicyWebhookName + "-fail-finegrained-" + mpol.GetName()
+				webhook.ClientConfig = newClientConfig(server, servicePort, caBundle, config.MutatingPolicyServicePath+"/fail"+config.FineGrainedWebhookPath+"/"+mpol.GetName())
+				webhookFailList = append(webhookFailList, webhook)
+			}
+		} else {
+			// the shared webhooks are reinvoked as soon as one of the policies asks for it
+			if failurePolicyIgnore {
+				webhookIgnore.Rules = append(webhookIgnore.Rules, webhook.Rules...)
+				if *webhook.ReinvocationPolicy == admissionregistrationv1.IfNeededReinvocationPolicy {
+					webhookIgnore.ReinvocationPolicy = webhook.ReinvocationPolicy
+				}
+			} else {
+				webhookFail.Rules = append(webhookFail.Rules, webhook.Rules...)
+				if *webhook.ReinvocationPolicy == admissionregistrationv1.IfNeededReinvocationPolicy {
+					webhookFail.ReinvocationPolicy = webhook.ReinvocationPolicy
+				}
+			}
+		}
+	}
+
+	if webhookFailList != nil {
+		webhooks = append(webhooks, webhookFailList...)
+	}
+	if webhookIgnoreList != nil {
+		webhooks = append(webhooks, webhookIgnoreList...)
+	}
+	if webhookFail.Rules != nil {
+		webhooks = append(webhooks, webhookFail)
+	}
+	if webhookIgnore.Rules != nil {
+		webhooks = append(webhooks, webhookIgnore)
+	}
+	return
+}
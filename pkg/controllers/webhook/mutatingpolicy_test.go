@@ -0,0 +1,111 @@
+package webhook
+
+import (
+	"testing"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestBuildMutatingWebhookRules(t *testing.T) {
+	matchConstraints := &admissionregistrationv1.MatchResources{
+		ResourceRules: []admissionregistrationv1.NamedRuleWithOperations{
+			{
+				RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+					Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+					Rule: admissionregistrationv1.Rule{
+						APIGroups:   []string{""},
+						APIVersions: []string{"v1"},
+						Resources:   []string{"pods"},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name             string
+		mpols            []*kyvernov2alpha1.MutatingPolicy
+		expectedWebhooks []admissionregistrationv1.MutatingWebhook
+	}{
+		{
+			name: "Single Ignore Policy",
+			mpols: []*kyvernov2alpha1.MutatingPolicy{
+				{
+					Spec: kyvernov2alpha1.MutatingPolicySpec{
+						FailurePolicy:    ptr.To(admissionregistrationv1.Ignore),
+						MatchConstraints: matchConstraints,
+					},
+				},
+			},
+			expectedWebhooks: []admissionregistrationv1.MutatingWebhook{
+				{
+					Name:               config.MutatingPolicyWebhookName + "-ignore",
+					FailurePolicy:      ptr.To(admissionregistrationv1.Ignore),
+					ReinvocationPolicy: ptr.To(admissionregistrationv1.NeverReinvocationPolicy),
+					ClientConfig:       newClientConfig("", 0, nil, config.MutatingPolicyServicePath+"/ignore"),
+				},
+			},
+		},
+		{
+			name: "Single Fail Policy Reinvoked",
+			mpols: []*kyvernov2alpha1.MutatingPolicy{
+				{
+					Spec: kyvernov2alpha1.MutatingPolicySpec{
+						MatchConstraints:   matchConstraints,
+						ReinvocationPolicy: admissionregistrationv1.IfNeededReinvocationPolicy,
+					},
+				},
+			},
+			expectedWebhooks: []admissionregistrationv1.MutatingWebhook{
+				{
+					Name:               config.MutatingPolicyWebhookName + "-fail",
+					FailurePolicy:      ptr.To(admissionregistrationv1.Fail),
+					ReinvocationPolicy: ptr.To(admissionregistrationv1.IfNeededReinvocationPolicy),
+					ClientConfig:       newClientConfig("", 0, nil, config.MutatingPolicyServicePath+"/fail"),
+				},
+			},
+		},
+		{
+			name: "Fine-Grained Fail Policy",
+			mpols: []*kyvernov2alpha1.MutatingPolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-fine-grained-fail",
+					},
+					Spec: kyvernov2alpha1.MutatingPolicySpec{
+						MatchConstraints: matchConstraints,
+						MatchConditions: []admissionregistrationv1.MatchCondition{{
+							Name:       "exclude-kube-system",
+							Expression: "object.metadata.namespace != 'kube-system'",
+						}},
+					},
+				},
+			},
+			expectedWebhooks: []admissionregistrationv1.MutatingWebhook{
+				{
+					Name:               config.MutatingPolicyWebhookName + "-fail-finegrained-test-fine-grained-fail",
+					FailurePolicy:      ptr.To(admissionregistrationv1.Fail),
+					ReinvocationPolicy: ptr.To(admissionregistrationv1.NeverReinvocationPolicy),
+					ClientConfig:       newClientConfig("", 0, nil, config.MutatingPolicyServicePath+"/fail"+config.FineGrainedWebhookPath+"/test-fine-grained-fail"),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhooks := buildMutatingWebhookRules(config.NewDefaultConfiguration(false), "", 0, nil, tt.mpols)
+			assert.Equal(t, len(tt.expectedWebhooks), len(webhooks))
+			for i, expect := range tt.expectedWebhooks {
+				assert.Equal(t, expect.Name, webhooks[i].Name)
+				assert.Equal(t, expect.FailurePolicy, webhooks[i].FailurePolicy)
+				assert.Equal(t, expect.ReinvocationPolicy, webhooks[i].ReinvocationPolicy)
+				assert.Equal(t, 1, len(webhooks[i].Rules))
+				assert.Equal(t, *expect.ClientConfig.Service.Path, *webhooks[i].ClientConfig.Service.Path)
+			}
+		})
+	}
+}
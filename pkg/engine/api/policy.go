@@ -8,7 +8,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// GenericPolicy abstracts the policy type (ClusterPolicy/Policy, ValidatingPolicy, ValidatingAdmissionPolicy and MutatingAdmissionPolicy)
+// GenericPolicy abstracts the policy type (ClusterPolicy/Policy, ValidatingPolicy, MutatingPolicy, ImageVerificationPolicy, ValidatingAdmissionPolicy and MutatingAdmissionPolicy)
 // It is intended to be used in EngineResponse
 type GenericPolicy interface {
 	metav1.Object
@@ -26,6 +26,10 @@ type GenericPolicy interface {
 	AsValidatingAdmissionPolicy() *admissionregistrationv1.ValidatingAdmissionPolicy
 	// AsValidatingPolicy returns the validating policy
 	AsValidatingPolicy() *kyvernov2alpha1.ValidatingPolicy
+	// AsMutatingPolicy returns the mutating policy
+	AsMutatingPolicy() *kyvernov2alpha1.MutatingPolicy
+	// AsImageVerificationPolicy returns the image verification policy
+	AsImageVerificationPolicy() *kyvernov2alpha1.ImageVerificationPolicy
 }
 
 type genericPolicy struct {
@@ -34,6 +38,8 @@ type genericPolicy struct {
 	ValidatingAdmissionPolicy *admissionregistrationv1.ValidatingAdmissionPolicy
 	MutatingAdmissionPolicy   *admissionregistrationv1alpha1.MutatingAdmissionPolicy
 	ValidatingPolicy          *kyvernov2alpha1.ValidatingPolicy
+	MutatingPolicy            *kyvernov2alpha1.MutatingPolicy
+	ImageVerificationPolicy   *kyvernov2alpha1.ImageVerificationPolicy
 }
 
 func (p *genericPolicy) AsObject() any {
@@ -52,6 +58,14 @@ func (p *genericPolicy) AsValidatingPolicy() *kyvernov2alpha1.ValidatingPolicy {
 	return p.ValidatingPolicy
 }
 
+func (p *genericPolicy) AsMutatingPolicy() *kyvernov2alpha1.MutatingPolicy {
+	return p.MutatingPolicy
+}
+
+func (p *genericPolicy) AsImageVerificationPolicy() *kyvernov2alpha1.ImageVerificationPolicy {
+	return p.ImageVerificationPolicy
+}
+
 func (p *genericPolicy) GetAPIVersion() string {
 	switch {
 	case p.PolicyInterface != nil:
@@ -62,6 +76,10 @@ func (p *genericPolicy) GetAPIVersion() string {
 		return admissionregistrationv1alpha1.SchemeGroupVersion.String()
 	case p.ValidatingPolicy != nil:
 		return kyvernov2alpha1.GroupVersion.String()
+	case p.MutatingPolicy != nil:
+		return kyvernov2alpha1.GroupVersion.String()
+	case p.ImageVerificationPolicy != nil:
+		return kyvernov2alpha1.GroupVersion.String()
 	}
 	return ""
 }
@@ -76,6 +94,10 @@ func (p *genericPolicy) GetKind() string {
 		return "MutatingAdmissionPolicy"
 	case p.ValidatingPolicy != nil:
 		return "ValidatingPolicy"
+	case p.MutatingPolicy != nil:
+		return "MutatingPolicy"
+	case p.ImageVerificationPolicy != nil:
+		return "ImageVerificationPolicy"
 	}
 	return ""
 }
@@ -115,3 +137,17 @@ func NewValidatingPolicy(pol *kyvernov2alpha1.ValidatingPolicy) GenericPolicy {
 		ValidatingPolicy: pol,
 	}
 }
+
+func NewMutatingPolicy(pol *kyvernov2alpha1.MutatingPolicy) GenericPolicy {
+	return &genericPolicy{
+		Object:         pol,
+		MutatingPolicy: pol,
+	}
+}
+
+func NewImageVerificationPolicy(pol *kyvernov2alpha1.ImageVerificationPolicy) GenericPolicy {
+	return &genericPolicy{
+		Object:                  pol,
+		ImageVerificationPolicy: pol,
+	}
+}
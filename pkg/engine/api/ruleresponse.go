@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	pssutils "github.com/kyverno/kyverno/pkg/pss/utils"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -45,6 +46,8 @@ type RuleResponse struct {
 	podSecurityChecks *PodSecurityChecks
 	// exceptions are the exceptions applied (if any)
 	exceptions []kyvernov2.PolicyException
+	// celExceptions are the CEL policy exceptions applied (if any)
+	celExceptions []kyvernov2alpha1.CELPolicyException
 	// binding is the validatingadmissionpolicybinding (if any)
 	binding *admissionregistrationv1.ValidatingAdmissionPolicyBinding
 	// emitWarning enable passing rule message as warning to api server warning header
@@ -96,6 +99,11 @@ func (r RuleResponse) WithExceptions(exceptions []kyvernov2.PolicyException) *Ru
 	return &r
 }
 
+func (r RuleResponse) WithCELExceptions(exceptions []kyvernov2alpha1.CELPolicyException) *RuleResponse {
+	r.celExceptions = exceptions
+	return &r
+}
+
 func (r RuleResponse) WithBinding(binding *admissionregistrationv1.ValidatingAdmissionPolicyBinding) *RuleResponse {
 	r.binding = binding
 	return &r
@@ -141,6 +149,10 @@ func (r *RuleResponse) Exceptions() []kyvernov2.PolicyException {
 	return r.exceptions
 }
 
+func (r *RuleResponse) CELExceptions() []kyvernov2alpha1.CELPolicyException {
+	return r.celExceptions
+}
+
 func (r *RuleResponse) ValidatingAdmissionPolicyBinding() *admissionregistrationv1.ValidatingAdmissionPolicyBinding {
 	return r.binding
 }
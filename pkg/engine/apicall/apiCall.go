@@ -27,13 +27,21 @@ func New(
 	jsonCtx enginecontext.Interface,
 	client ClientInterface,
 	apiCallConfig APICallConfiguration,
+) (*apiCall, error) {
+	return NewWithExecutor(logger, jp, entry, jsonCtx, NewExecutor(logger, entry.Name, client, apiCallConfig))
+}
+
+// NewWithExecutor returns an API call running the requests with the given executor
+func NewWithExecutor(
+	logger logr.Logger,
+	jp jmespath.Interface,
+	entry kyvernov1.ContextEntry,
+	jsonCtx enginecontext.Interface,
+	executor Executor,
 ) (*apiCall, error) {
 	if entry.APICall == nil {
 		return nil, fmt.Errorf("missing APICall in context entry %v", entry)
 	}
-
-	executor := NewExecutor(logger, entry.Name, client, apiCallConfig)
-
 	return &apiCall{
 		logger:   logger,
 		jp:       jp,
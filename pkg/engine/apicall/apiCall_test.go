@@ -3,6 +3,7 @@ package apicall
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -305,3 +306,42 @@ func Test_serviceHeaders(t *testing.T) {
 	assert.Equal(t, "application/json", responseHeaders["Content-Type"][0])
 	assert.Equal(t, "CustomVal", responseHeaders["Custom-Key"][0])
 }
+
+func Test_serviceConditionalRequest(t *testing.T) {
+	const etag = `"v1"`
+	const lastModified = "Sun, 18 Oct 2026 10:00:00 GMT"
+	mux := http.NewServeMux()
+	mux.HandleFunc("/resource", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag && r.Header.Get("If-Modified-Since") == lastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", lastModified)
+		w.Write([]byte(`{ "day": "Sunday" }`))
+	})
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	call := &kyvernov1.APICall{
+		Method: "GET",
+		Service: &kyvernov1.ServiceCall{
+			URL: s.URL + "/resource",
+		},
+	}
+	executor := NewExecutor(logr.Discard(), "test", nil, apiConfig)
+
+	data, validators, err := executor.ExecuteConditional(context.TODO(), call, Validators{})
+	assert.NilError(t, err)
+	assert.Equal(t, `{ "day": "Sunday" }`, string(data))
+	assert.Equal(t, Validators{ETag: etag, LastModified: lastModified}, validators)
+
+	data, next, err := executor.ExecuteConditional(context.TODO(), call, validators)
+	assert.Assert(t, errors.Is(err, ErrNotModified))
+	assert.Assert(t, data == nil)
+	assert.Equal(t, validators, next)
+
+	data, err = executor.Execute(context.TODO(), call)
+	assert.NilError(t, err)
+	assert.Equal(t, `{ "day": "Sunday" }`, string(data))
+}
@@ -0,0 +1,174 @@
+package apicall
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+const (
+	secretKeyUsername     = "username"
+	secretKeyPassword     = "password"
+	secretKeyClientID     = "client_id"
+	secretKeyClientSecret = "client_secret"
+	tokenSourcesSize      = 256
+	tokenSourcesTTL       = time.Hour
+)
+
+// tokenSources caches OAuth2 token sources by client, tokens are refreshed by the token sources when they expire
+var tokenSources = cache.NewLRUExpireCache(tokenSourcesSize)
+
+// credentials holds the credentials loaded from the secrets referenced by a service call,
+// they must never be logged or returned in errors
+type credentials struct {
+	clientCert *tls.Certificate
+	basic      *basicCredentials
+	oauth2     *clientcredentials.Config
+}
+
+type basicCredentials struct {
+	username string
+	password string
+}
+
+func (a *executor) loadCredentials(service *kyvernov1.ServiceCall) (*credentials, error) {
+	var creds credentials
+	if service.Auth == nil {
+		return &creds, nil
+	}
+	auth := service.Auth
+	if auth.ClientCert != nil {
+		data, err := a.readSecret(*auth.ClientCert, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+		if err != nil {
+			return nil, err
+		}
+		cert, err := tls.X509KeyPair(data[corev1.TLSCertKey], data[corev1.TLSPrivateKeyKey])
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate from secret %s/%s for APICall %s: %w", auth.ClientCert.Namespace, auth.ClientCert.Name, a.name, err)
+		}
+		creds.clientCert = &cert
+	}
+	if auth.Basic != nil {
+		data, err := a.readSecret(*auth.Basic, secretKeyUsername, secretKeyPassword)
+		if err != nil {
+			return nil, err
+		}
+		creds.basic = &basicCredentials{
+			username: string(data[secretKeyUsername]),
+			password: string(data[secretKeyPassword]),
+		}
+	}
+	if auth.OAuth2 != nil {
+		data, err := a.readSecret(auth.OAuth2.Secret, secretKeyClientID, secretKeyClientSecret)
+		if err != nil {
+			return nil, err
+		}
+		creds.oauth2 = &clientcredentials.Config{
+			ClientID:     string(data[secretKeyClientID]),
+			ClientSecret: string(data[secretKeyClientSecret]),
+			TokenURL:     auth.OAuth2.TokenURL,
+			Scopes:       auth.OAuth2.Scopes,
+		}
+	}
+	return &creds, nil
+}
+
+// readSecret reads a secret from the secret lister, the lister only caches secrets labelled with kyverno.LabelAPICallAuth.
+// Secrets are never read with the API client, it would let policy authors read any secret kyverno can access.
+func (a *executor) readSecret(ref kyvernov1.ServiceCallSecret, keys ...string) (map[string][]byte, error) {
+	if a.config.secretLister == nil {
+		return nil, fmt.Errorf("failed to get secret %s/%s for APICall %s: service call auth secrets are disabled", ref.Namespace, ref.Name, a.name)
+	}
+	secret, err := a.config.secretLister.Secrets(ref.Namespace).Get(ref.Name)
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get secret %s/%s for APICall %s: %w (the secret must have the %s label)", ref.Namespace, ref.Name, a.name, err, kyverno.LabelAPICallAuth)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret %s/%s for APICall %s: %w", ref.Namespace, ref.Name, a.name, err)
+	}
+	for _, key := range keys {
+		if len(secret.Data[key]) == 0 {
+			return nil, fmt.Errorf("secret %s/%s for APICall %s has no %s key", ref.Namespace, ref.Name, a.name, key)
+		}
+	}
+	return secret.Data, nil
+}
+
+// authenticate adds the credentials to the request
+func (a *executor) authenticate(req *http.Request, client *http.Client, service *kyvernov1.ServiceCall, creds *credentials) error {
+	if creds.basic != nil {
+		req.SetBasicAuth(creds.basic.username, creds.basic.password)
+	}
+	if creds.oauth2 != nil {
+		token, err := tokenSource(client, service, creds).Token()
+		if err != nil {
+			return fmt.Errorf("failed to get OAuth2 token for APICall %s: %s", a.name, tokenError(err))
+		}
+		token.SetAuthHeader(req)
+	}
+	return nil
+}
+
+// tokenSource returns the cached token source of the client, the http client is used to request tokens
+func tokenSource(client *http.Client, service *kyvernov1.ServiceCall, creds *credentials) oauth2.TokenSource {
+	key := tokenSourceKey(service, creds)
+	if ts, ok := tokenSources.Get(key); ok {
+		return ts.(oauth2.TokenSource)
+	}
+	// the token source keeps the context to refresh tokens, it must outlive the request
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, client)
+	ts := creds.oauth2.TokenSource(ctx)
+	tokenSources.Add(key, ts, tokenSourcesTTL)
+	return ts
+}
+
+// invalidateToken drops the cached token of the client, a new token is requested by the next call
+func invalidateToken(service *kyvernov1.ServiceCall, creds *credentials) {
+	if creds.oauth2 != nil {
+		tokenSources.Remove(tokenSourceKey(service, creds))
+	}
+}
+
+// tokenSourceKey hashes everything used to request tokens, secret values are not kept in clear in the cache keys
+func tokenSourceKey(service *kyvernov1.ServiceCall, creds *credentials) string {
+	hash := sha256.New()
+	write := func(values ...string) {
+		for _, value := range values {
+			hash.Write([]byte(value))
+			hash.Write([]byte{0})
+		}
+	}
+	write(creds.oauth2.TokenURL, creds.oauth2.ClientID, creds.oauth2.ClientSecret, service.CABundle)
+	write(creds.oauth2.Scopes...)
+	if creds.clientCert != nil {
+		for _, cert := range creds.clientCert.Certificate {
+			hash.Write(cert)
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// tokenError describes a token request failure without the response body, it can echo the credentials
+func tokenError(err error) string {
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) && retrieveErr.Response != nil {
+		if retrieveErr.ErrorCode != "" {
+			return fmt.Sprintf("HTTP %s: %s", retrieveErr.Response.Status, retrieveErr.ErrorCode)
+		}
+		return fmt.Sprintf("HTTP %s", retrieveErr.Response.Status)
+	}
+	return err.Error()
+}
@@ -0,0 +1,257 @@
+package apicall
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// secretsConfig returns a configuration reading the secrets from a lister, secrets are keyed by namespace/name
+func secretsConfig(t *testing.T, secrets map[string]map[string][]byte) APICallConfiguration {
+	t.Helper()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for key, data := range secrets {
+		namespace, name, _ := strings.Cut(key, "/")
+		assert.NilError(t, indexer.Add(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Data:       data,
+		}))
+	}
+	return apiConfig.WithSecretLister(corev1listers.NewSecretLister(indexer))
+}
+
+func Test_serviceBasicAuth(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/resource", func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "admin" || password != "s3cr3t" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{ "day": "Sunday" }`))
+	})
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	secrets := map[string]map[string][]byte{
+		"kyverno/basic": {
+			"username": []byte("admin"),
+			"password": []byte("s3cr3t"),
+		},
+		"kyverno/invalid": {
+			"username": []byte("admin"),
+		},
+	}
+	call := &kyvernov1.APICall{
+		Method: "GET",
+		Service: &kyvernov1.ServiceCall{
+			URL: s.URL + "/resource",
+			Auth: &kyvernov1.ServiceCallAuth{
+				Basic: &kyvernov1.ServiceCallSecret{Name: "basic", Namespace: "kyverno"},
+			},
+		},
+	}
+	executor := NewExecutor(logr.Discard(), "test", nil, secretsConfig(t, secrets))
+
+	data, err := executor.Execute(context.TODO(), call)
+	assert.NilError(t, err)
+	assert.Equal(t, `{ "day": "Sunday" }`, string(data))
+
+	call.Service.Auth.Basic.Name = "invalid"
+	_, err = executor.Execute(context.TODO(), call)
+	assert.ErrorContains(t, err, "secret kyverno/invalid for APICall test has no password key")
+
+	call.Service.Auth.Basic.Name = "missing"
+	_, err = executor.Execute(context.TODO(), call)
+	assert.ErrorContains(t, err, "failed to get secret kyverno/missing for APICall test")
+	assert.ErrorContains(t, err, "apicall.kyverno.io/auth label")
+}
+
+func Test_serviceAuthSecretsDisabled(t *testing.T) {
+	call := &kyvernov1.APICall{
+		Method: "GET",
+		Service: &kyvernov1.ServiceCall{
+			URL: "http://localhost/resource",
+			Auth: &kyvernov1.ServiceCallAuth{
+				Basic: &kyvernov1.ServiceCallSecret{Name: "basic", Namespace: "kyverno"},
+			},
+		},
+	}
+	// secrets are never read with the API client
+	executor := NewExecutor(logr.Discard(), "test", nil, apiConfig)
+	_, err := executor.Execute(context.TODO(), call)
+	assert.ErrorContains(t, err, "failed to get secret kyverno/basic for APICall test: service call auth secrets are disabled")
+}
+
+func Test_serviceOAuth2(t *testing.T) {
+	var tokens, unauthorized atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, _ := r.BasicAuth()
+		if clientID != "client" || clientSecret != "s3cr3t" {
+			// some servers echo the request in errors
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(fmt.Sprintf(`{"error":"invalid_client","error_description":"invalid secret %s"}`, clientSecret)))
+			return
+		}
+		n := tokens.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(fmt.Sprintf(`{"access_token":"token-%d","token_type":"Bearer","expires_in":3600}`, n)))
+	})
+	mux.HandleFunc("/resource", func(w http.ResponseWriter, r *http.Request) {
+		if unauthorized.Load() > 0 {
+			unauthorized.Add(-1)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer token-") {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{ "day": "Sunday" }`))
+	})
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	secrets := map[string]map[string][]byte{
+		"kyverno/client": {
+			"client_id":     []byte("client"),
+			"client_secret": []byte("s3cr3t"),
+		},
+		"kyverno/wrong": {
+			"client_id":     []byte("client"),
+			"client_secret": []byte("wr0ng"),
+		},
+	}
+	call := &kyvernov1.APICall{
+		Method: "GET",
+		Service: &kyvernov1.ServiceCall{
+			URL: s.URL + "/resource",
+			Auth: &kyvernov1.ServiceCallAuth{
+				OAuth2: &kyvernov1.OAuth2ClientCredentials{
+					TokenURL: s.URL + "/token",
+					Secret:   kyvernov1.ServiceCallSecret{Name: "client", Namespace: "kyverno"},
+				},
+			},
+		},
+	}
+	executor := NewExecutor(logr.Discard(), "test", nil, secretsConfig(t, secrets))
+
+	// the token is cached between calls
+	for range 3 {
+		data, err := executor.Execute(context.TODO(), call)
+		assert.NilError(t, err)
+		assert.Equal(t, `{ "day": "Sunday" }`, string(data))
+	}
+	assert.Equal(t, int32(1), tokens.Load())
+
+	// a rejected token is dropped
+	unauthorized.Store(1)
+	_, err := executor.Execute(context.TODO(), call)
+	assert.ErrorContains(t, err, "HTTP 401")
+	_, err = executor.Execute(context.TODO(), call)
+	assert.NilError(t, err)
+	assert.Equal(t, int32(2), tokens.Load())
+
+	// token errors don't leak the credentials
+	call.Service.Auth.OAuth2.Secret.Name = "wrong"
+	_, err = executor.Execute(context.TODO(), call)
+	assert.ErrorContains(t, err, "failed to get OAuth2 token for APICall test: HTTP 401 Unauthorized: invalid_client")
+	assert.Assert(t, !strings.Contains(err.Error(), "wr0ng"))
+}
+
+func Test_serviceClientCert(t *testing.T) {
+	certPEM, keyPEM, cert := newClientCert(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(cert)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/resource", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			http.Error(w, "unexpected authorization header", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{ "day": "Sunday" }`))
+	})
+	s := httptest.NewUnstartedServer(mux)
+	s.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+	s.StartTLS()
+	defer s.Close()
+
+	secrets := map[string]map[string][]byte{
+		"kyverno/cert": {
+			"tls.crt": certPEM,
+			"tls.key": keyPEM,
+		},
+	}
+	call := &kyvernov1.APICall{
+		Method: "GET",
+		Service: &kyvernov1.ServiceCall{
+			URL:      s.URL + "/resource",
+			CABundle: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw})),
+			Auth: &kyvernov1.ServiceCallAuth{
+				ClientCert: &kyvernov1.ServiceCallSecret{Name: "cert", Namespace: "kyverno"},
+			},
+		},
+	}
+	executor := NewExecutor(logr.Discard(), "test", nil, secretsConfig(t, secrets))
+
+	data, err := executor.Execute(context.TODO(), call)
+	assert.NilError(t, err)
+	assert.Equal(t, `{ "day": "Sunday" }`, string(data))
+
+	call.Service.Auth = nil
+	_, err = executor.Execute(context.TODO(), call)
+	assert.ErrorContains(t, err, "failed to execute HTTP request")
+}
+
+func newClientCert(t *testing.T) ([]byte, []byte, *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kyverno"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NilError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NilError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NilError(t, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, cert
+}
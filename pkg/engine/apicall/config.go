@@ -1,7 +1,12 @@
 package apicall
 
+import (
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
 type APICallConfiguration struct {
 	maxAPICallResponseLength int64
+	secretLister             corev1listers.SecretLister
 }
 
 func NewAPICallConfiguration(maxLen int64) APICallConfiguration {
@@ -9,3 +14,10 @@ func NewAPICallConfiguration(maxLen int64) APICallConfiguration {
 		maxAPICallResponseLength: maxLen,
 	}
 }
+
+// WithSecretLister returns a copy of the configuration reading service call auth secrets from the lister,
+// service call authentication fails when no lister is set
+func (c APICallConfiguration) WithSecretLister(lister corev1listers.SecretLister) APICallConfiguration {
+	c.secretLister = lister
+	return c
+}
@@ -6,6 +6,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -21,6 +22,22 @@ type Executor interface {
 	Execute(context.Context, *kyvernov1.APICall) ([]byte, error)
 }
 
+// ConditionalExecutor is implemented by executors supporting conditional requests
+type ConditionalExecutor interface {
+	Executor
+	ExecuteConditional(context.Context, *kyvernov1.APICall, Validators) ([]byte, Validators, error)
+}
+
+// ErrNotModified is returned by conditional service calls when the data did not change
+var ErrNotModified = errors.New("not modified")
+
+// Validators identify the version of the data returned by a service call,
+// they are sent back in conditional requests
+type Validators struct {
+	ETag         string
+	LastModified string
+}
+
 type executor struct {
 	logger logr.Logger
 	name   string
@@ -43,10 +60,18 @@ func NewExecutor(
 }
 
 func (a *executor) Execute(ctx context.Context, call *kyvernov1.APICall) ([]byte, error) {
+	data, _, err := a.ExecuteConditional(ctx, call, Validators{})
+	return data, err
+}
+
+// ExecuteConditional executes the call and returns the validators of the response. Service GET calls
+// send the given validators and return ErrNotModified when the data did not change.
+func (a *executor) ExecuteConditional(ctx context.Context, call *kyvernov1.APICall, validators Validators) ([]byte, Validators, error) {
 	if call.URLPath != "" {
-		return a.executeK8sAPICall(ctx, call.URLPath, call.Method, call.Data)
+		data, err := a.executeK8sAPICall(ctx, call.URLPath, call.Method, call.Data)
+		return data, Validators{}, err
 	}
-	return a.executeServiceCall(ctx, call)
+	return a.executeServiceCall(ctx, call, validators)
 }
 
 func (a *executor) executeK8sAPICall(ctx context.Context, path string, method kyvernov1.Method, data []kyvernov1.RequestData) ([]byte, error) {
@@ -62,52 +87,73 @@ func (a *executor) executeK8sAPICall(ctx context.Context, path string, method ky
 	return jsonData, nil
 }
 
-func (a *executor) executeServiceCall(ctx context.Context, apiCall *kyvernov1.APICall) ([]byte, error) {
+func (a *executor) executeServiceCall(ctx context.Context, apiCall *kyvernov1.APICall, validators Validators) ([]byte, Validators, error) {
 	if apiCall.Service == nil {
-		return nil, fmt.Errorf("missing service for APICall %s", a.name)
+		return nil, Validators{}, fmt.Errorf("missing service for APICall %s", a.name)
 	}
 
-	client, err := a.buildHTTPClient(apiCall.Service)
+	creds, err := a.loadCredentials(apiCall.Service)
 	if err != nil {
-		return nil, err
+		return nil, Validators{}, err
+	}
+
+	client, err := a.buildHTTPClient(apiCall.Service, creds.clientCert)
+	if err != nil {
+		return nil, Validators{}, err
 	}
 
 	req, err := a.buildHTTPRequest(ctx, apiCall)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build HTTP request for APICall %s: %w", a.name, err)
+		return nil, Validators{}, fmt.Errorf("failed to build HTTP request for APICall %s: %w", a.name, err)
+	}
+	if apiCall.Method == "GET" {
+		addConditionalHeaders(req, validators)
+	}
+	if err := a.authenticate(req, client, apiCall.Service, creds); err != nil {
+		return nil, Validators{}, err
 	}
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute HTTP request for APICall %s: %w", a.name, err)
+		return nil, Validators{}, fmt.Errorf("failed to execute HTTP request for APICall %s: %w", a.name, err)
 	}
 	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		// the token may have been revoked before it expired
+		invalidateToken(apiCall.Service, creds)
+	}
 	var w http.ResponseWriter
 
 	if a.config.maxAPICallResponseLength != 0 {
 		resp.Body = http.MaxBytesReader(w, resp.Body, a.config.maxAPICallResponseLength)
 	}
 
+	if resp.StatusCode == http.StatusNotModified {
+		a.logger.V(4).Info("service APICall data not modified", "name", a.name)
+		return nil, validators, ErrNotModified
+	}
+
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		b, err := io.ReadAll(resp.Body)
 		if err == nil {
-			return nil, fmt.Errorf("HTTP %s: %s", resp.Status, string(b))
+			return nil, Validators{}, fmt.Errorf("HTTP %s: %s", resp.Status, string(b))
 		}
 
-		return nil, fmt.Errorf("HTTP %s", resp.Status)
+		return nil, Validators{}, fmt.Errorf("HTTP %s", resp.Status)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		if _, ok := err.(*http.MaxBytesError); ok {
-			return nil, fmt.Errorf("response length must be less than max allowed response length of %d", a.config.maxAPICallResponseLength)
+			return nil, Validators{}, fmt.Errorf("response length must be less than max allowed response length of %d", a.config.maxAPICallResponseLength)
 		} else {
-			return nil, fmt.Errorf("failed to read data from APICall %s: %w", a.name, err)
+			return nil, Validators{}, fmt.Errorf("failed to read data from APICall %s: %w", a.name, err)
 		}
 	}
 
 	a.logger.Info("executed service APICall", "name", a.name, "len", len(body))
-	return body, nil
+	return body, Validators{ETag: resp.Header.Get("ETag"), LastModified: resp.Header.Get("Last-Modified")}, nil
 }
 
 func (a *executor) buildHTTPRequest(ctx context.Context, apiCall *kyvernov1.APICall) (*http.Request, error) {
@@ -133,19 +179,20 @@ func (a *executor) buildHTTPRequest(ctx context.Context, apiCall *kyvernov1.APIC
 		return nil, fmt.Errorf("failed to build request for APICall %s: %w", a.name, err)
 	}
 
-	if err := a.addHTTPHeaders(req, apiCall.Service.Headers); err != nil {
+	if err := a.addHTTPHeaders(req, apiCall.Service); err != nil {
 		return nil, fmt.Errorf("failed to add headers for APICall %s: %w", a.name, err)
 	}
 
 	return req, nil
 }
 
-func (a *executor) addHTTPHeaders(req *http.Request, headers []kyvernov1.HTTPHeader) error {
-	for _, header := range headers {
+func (a *executor) addHTTPHeaders(req *http.Request, service *kyvernov1.ServiceCall) error {
+	for _, header := range service.Headers {
 		req.Header.Add(header.Key, header.Value)
 	}
 
-	if req.Header.Get("Authorization") == "" {
+	// the service account token is not sent to services authenticating with other credentials
+	if service.Auth == nil && req.Header.Get("Authorization") == "" {
 		token := a.getToken()
 		if token != "" {
 			req.Header.Add("Authorization", "Bearer "+token)
@@ -155,6 +202,15 @@ func (a *executor) addHTTPHeaders(req *http.Request, headers []kyvernov1.HTTPHea
 	return nil
 }
 
+func addConditionalHeaders(req *http.Request, validators Validators) {
+	if validators.ETag != "" {
+		req.Header.Set("If-None-Match", validators.ETag)
+	}
+	if validators.LastModified != "" {
+		req.Header.Set("If-Modified-Since", validators.LastModified)
+	}
+}
+
 func (a *executor) getToken() string {
 	fileName := "/var/run/secrets/kubernetes.io/serviceaccount/token"
 	b, err := os.ReadFile(fileName)
@@ -166,19 +222,27 @@ func (a *executor) getToken() string {
 	return string(b)
 }
 
-func (a *executor) buildHTTPClient(service *kyvernov1.ServiceCall) (*http.Client, error) {
-	if service == nil || service.CABundle == "" {
-		return http.DefaultClient, nil
+func (a *executor) buildHTTPClient(service *kyvernov1.ServiceCall, clientCert *tls.Certificate) (*http.Client, error) {
+	if (service == nil || service.CABundle == "") && clientCert == nil {
+		return &http.Client{
+			Transport: tracing.Transport(http.DefaultTransport, otelhttp.WithFilter(tracing.RequestFilterIsInSpan)),
+		}, nil
+	}
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if service != nil && service.CABundle != "" {
+		caCertPool := x509.NewCertPool()
+		if ok := caCertPool.AppendCertsFromPEM([]byte(service.CABundle)); !ok {
+			return nil, fmt.Errorf("failed to parse PEM CA bundle for APICall %s", a.name)
+		}
+		tlsConfig.RootCAs = caCertPool
 	}
-	caCertPool := x509.NewCertPool()
-	if ok := caCertPool.AppendCertsFromPEM([]byte(service.CABundle)); !ok {
-		return nil, fmt.Errorf("failed to parse PEM CA bundle for APICall %s", a.name)
+	if clientCert != nil {
+		tlsConfig.Certificates = []tls.Certificate{*clientCert}
 	}
 	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			RootCAs:    caCertPool,
-			MinVersion: tls.VersionTLS12,
-		},
+		TLSClientConfig: tlsConfig,
 	}
 	return &http.Client{
 		Transport: tracing.Transport(transport, otelhttp.WithFilter(tracing.RequestFilterIsInSpan)),
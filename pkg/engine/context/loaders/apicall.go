@@ -20,6 +20,7 @@ type apiLoader struct {
 	jp        jmespath.Interface
 	client    engineapi.RawClient
 	config    apicall.APICallConfiguration
+	executor  apicall.Executor
 	data      []byte
 }
 
@@ -43,22 +44,45 @@ func NewAPILoader(
 	}
 }
 
+// NewAPILoaderWithExecutor returns a loader running the API calls with the given executor instead of a client
+func NewAPILoaderWithExecutor(
+	ctx context.Context,
+	logger logr.Logger,
+	entry kyvernov1.ContextEntry,
+	enginectx enginecontext.Interface,
+	jp jmespath.Interface,
+	executor apicall.Executor,
+) enginecontext.Loader {
+	return &apiLoader{
+		ctx:       ctx,
+		logger:    logger,
+		entry:     entry,
+		enginectx: enginectx,
+		jp:        jp,
+		executor:  executor,
+	}
+}
+
 func (a *apiLoader) HasLoaded() bool {
 	return a.data != nil
 }
 
 func (a *apiLoader) LoadData() error {
-	executor, err := apicall.New(a.logger, a.jp, a.entry, a.enginectx, a.client, a.config)
+	executor := a.executor
+	if executor == nil {
+		executor = apicall.NewExecutor(a.logger, a.entry.Name, a.client, a.config)
+	}
+	call, err := apicall.NewWithExecutor(a.logger, a.jp, a.entry, a.enginectx, executor)
 	if err != nil {
 		return fmt.Errorf("failed to initiaize APICal: %w", err)
 	}
 	if a.data == nil {
 		var err error
-		if a.data, err = executor.Fetch(a.ctx); err != nil {
+		if a.data, err = call.Fetch(a.ctx); err != nil {
 			return fmt.Errorf("failed to fetch data for APICall: %w", err)
 		}
 	}
-	if _, err := executor.Store(a.data); err != nil {
+	if _, err := call.Store(a.data); err != nil {
 		return fmt.Errorf("failed to store data for APICall: %w", err)
 	}
 	return nil
@@ -94,11 +94,24 @@ func (g *gctxLoader) loadGctxData() ([]byte, error) {
 		g.logger.Error(err, "")
 		return nil, err
 	}
-	data, err = storeEntry.Get()
+	data, err = storeEntry.Get(rc.Projection)
 	if err != nil {
 		g.logger.Error(err, "failed to fetch data from entry")
 		return nil, err
 	}
+	if rc.Key != "" {
+		index, ok := data.(map[string]any)
+		if !ok {
+			err := fmt.Errorf("projection %s of entry %s is not indexed", rc.Projection, rc.Name)
+			g.logger.Error(err, "")
+			return nil, err
+		}
+		elements, ok := index[rc.Key]
+		if !ok {
+			elements = []any{}
+		}
+		data = elements
+	}
 
 	var jsonData []byte
 	if _, ok := data.([]byte); ok {
@@ -42,6 +42,13 @@ func WithAPICallConfig(config apicall.APICallConfiguration) ContextLoaderFactory
 	}
 }
 
+// WithAPICallExecutor makes APICall context entries run with the given executor instead of the client passed to Load
+func WithAPICallExecutor(executor apicall.Executor) ContextLoaderFactoryOptions {
+	return func(cl *contextLoader) {
+		cl.apiCallExecutor = executor
+	}
+}
+
 func WithGlobalContextStore(gctxStore loaders.Store) ContextLoaderFactoryOptions {
 	return func(cl *contextLoader) {
 		cl.gctxStore = gctxStore
@@ -49,11 +56,12 @@ func WithGlobalContextStore(gctxStore loaders.Store) ContextLoaderFactoryOptions
 }
 
 type contextLoader struct {
-	logger        logr.Logger
-	cmResolver    engineapi.ConfigmapResolver
-	initializers  []engineapi.Initializer
-	apiCallConfig apicall.APICallConfiguration
-	gctxStore     loaders.Store
+	logger          logr.Logger
+	cmResolver      engineapi.ConfigmapResolver
+	initializers    []engineapi.Initializer
+	apiCallConfig   apicall.APICallConfiguration
+	apiCallExecutor apicall.Executor
+	gctxStore       loaders.Store
 }
 
 func (l *contextLoader) Load(
@@ -107,7 +115,10 @@ func (l *contextLoader) newLoader(
 			return nil, nil
 		}
 	} else if entry.APICall != nil {
-		if client != nil {
+		if l.apiCallExecutor != nil {
+			ldr := loaders.NewAPILoaderWithExecutor(ctx, l.logger, entry, jsonContext, jp, l.apiCallExecutor)
+			return enginecontext.NewDeferredLoader(entry.Name, ldr, l.logger)
+		} else if client != nil {
 			ldr := loaders.NewAPILoader(ctx, l.logger, entry, jsonContext, jp, client, l.apiCallConfig)
 			return enginecontext.NewDeferredLoader(entry.Name, ldr, l.logger)
 		} else {
@@ -1,8 +1,11 @@
 package externalapi
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	"sync"
 	"time"
 
@@ -14,9 +17,12 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
 	"github.com/kyverno/kyverno/pkg/event"
 	entryevent "github.com/kyverno/kyverno/pkg/globalcontext/event"
+	"github.com/kyverno/kyverno/pkg/globalcontext/projection"
+	"github.com/kyverno/kyverno/pkg/globalcontext/snapshot"
 	"github.com/kyverno/kyverno/pkg/globalcontext/store"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/retry"
@@ -24,11 +30,18 @@ import (
 
 type entry struct {
 	sync.Mutex
-	data any
-	err  error
-	stop func()
+	data       any
+	normalized normalized
+	size       int64
+	err        error
+	generation int64
+	validators apicall.Validators
+	refreshed  time.Time
+	stop       func()
 }
 
+const defaultRetryBackoff = time.Second
+
 func New(
 	ctx context.Context,
 	gce *kyvernov2alpha1.GlobalContextEntry,
@@ -38,8 +51,10 @@ func New(
 	logger logr.Logger,
 	client apicall.ClientInterface,
 	call kyvernov1.APICall,
+	projections projection.Projections,
+	snapshots *snapshot.Snapshots,
 	period time.Duration,
-	maxResponseLength int64,
+	apiCallConfig apicall.APICallConfiguration,
 	shouldUpdateStatus bool,
 ) (store.Entry, error) {
 	var group wait.Group
@@ -53,37 +68,85 @@ func New(
 	e := &entry{
 		stop: stop,
 	}
+	if snapshots != nil {
+		// the data is loaded from the snapshots published by the publisher, including when this controller is the publisher
+		// because a snapshot published before it became the publisher can be newer than the data it fetched
+		unwatch := snapshots.Watch(gce.Name, func() {
+			e.load(logger, snapshots, gce.Name, projections)
+		})
+		e.stop = func() {
+			unwatch()
+			stop()
+		}
+		e.load(logger, snapshots, gce.Name, projections)
+	}
 
 	group.StartWithContext(ctx, func(ctx context.Context) {
-		config := apicall.NewAPICallConfiguration(maxResponseLength)
-		caller := apicall.NewExecutor(logger, "globalcontext", client, config)
+		caller := apicall.NewExecutor(logger, "globalcontext", client, apiCallConfig)
 
 		wait.UntilWithContext(ctx, func(ctx context.Context) {
-			if data, err := doCall(ctx, caller, call, gce.Spec.APICall.RetryLimit); err != nil {
-				e.setData(nil, err)
+			if snapshots != nil && !snapshots.IsPublisher() {
+				return
+			}
+			backoff := newBackoff(gce.Spec.APICall.RetryBackoff, period)
+			data, validators, err := doCall(ctx, caller, call, e.getValidators(), gce.Spec.APICall.RetryLimit, backoff)
+			if errors.Is(err, apicall.ErrNotModified) {
+				data, generation := e.setNotModified()
+
+				logger.V(4).Info("api call data not modified")
+
+				if snapshots != nil && data != nil {
+					// a previous attempt to publish the data may have failed
+					if generation, err = e.publish(ctx, snapshots, gce, data); err != nil {
+						logger.Error(err, "failed to publish snapshot")
+
+						eventGen.Add(entryevent.NewErrorEvent(objectReference(gce), err))
+					}
+				}
+
+				if shouldUpdateStatus {
+					if updateErr := updateStatus(ctx, gce, kyvernoClient, succeeded(generation)); updateErr != nil {
+						logger.Error(updateErr, "failed to update status")
+					}
+				}
+			} else if err != nil {
+				stale := e.setError(err, maxStaleness(gce.Spec.APICall.MaxStaleness))
 
-				logger.Error(err, "failed to get data from api caller")
+				logger.Error(err, "failed to get data from api caller", "stale", stale)
 
-				eventGen.Add(entryevent.NewErrorEvent(corev1.ObjectReference{
-					APIVersion: gce.APIVersion,
-					Kind:       gce.Kind,
-					Name:       gce.Name,
-					Namespace:  gce.Namespace,
-					UID:        gce.UID,
-				}, err))
+				eventGen.Add(entryevent.NewErrorEvent(objectReference(gce), err))
 
 				if shouldUpdateStatus {
-					if updateErr := updateStatus(ctx, gce, kyvernoClient, false, entryevent.ReasonAPICallFailure); updateErr != nil {
+					update := failed(entryevent.ReasonAPICallFailure)
+					if stale {
+						update = staled(err)
+					}
+					if updateErr := updateStatus(ctx, gce, kyvernoClient, update); updateErr != nil {
 						logger.Error(updateErr, "failed to update status")
 					}
 				}
 			} else {
-				e.setData(data, nil)
+				normalized := normalize(data, projections)
+				generation := e.setProjectedData(data, validators, normalized)
 
 				logger.V(4).Info("api call success", "data", data)
 
+				if normalized.projectionErr != nil {
+					logger.Error(normalized.projectionErr, "failed to compute projections")
+
+					eventGen.Add(entryevent.NewErrorEvent(objectReference(gce), normalized.projectionErr))
+				}
+
+				if snapshots != nil {
+					if generation, err = e.publish(ctx, snapshots, gce, data); err != nil {
+						logger.Error(err, "failed to publish snapshot")
+
+						eventGen.Add(entryevent.NewErrorEvent(objectReference(gce), err))
+					}
+				}
+
 				if shouldUpdateStatus {
-					if updateErr := updateStatus(ctx, gce, kyvernoClient, true, "APICallSuccess"); updateErr != nil {
+					if updateErr := updateStatus(ctx, gce, kyvernoClient, succeeded(generation)); updateErr != nil {
 						logger.Error(updateErr, "failed to update status")
 					}
 				}
@@ -94,7 +157,7 @@ func New(
 	return e, nil
 }
 
-func (e *entry) Get() (any, error) {
+func (e *entry) Get(projection string) (any, error) {
 	e.Lock()
 	defer e.Unlock()
 
@@ -103,10 +166,45 @@ func (e *entry) Get() (any, error) {
 	}
 
 	if e.data == nil {
-		return nil, fmt.Errorf("no data available")
+		return nil, fmt.Errorf("%w: no data available", store.ErrEntryNotReady)
+	}
+
+	if projection == "" {
+		return e.data, nil
+	}
+
+	if e.normalized.projectionErr != nil {
+		return nil, e.normalized.projectionErr
+	}
+
+	data, ok := e.normalized.projected[projection]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", store.ErrProjectionNotFound, projection)
 	}
 
-	return e.data, nil
+	return data, nil
+}
+
+func (e *entry) GetNormalized() (any, error) {
+	e.Lock()
+	defer e.Unlock()
+
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	if e.data == nil {
+		return nil, fmt.Errorf("%w: no data available", store.ErrEntryNotReady)
+	}
+
+	return e.normalized.data, e.normalized.err
+}
+
+func (e *entry) Size() int64 {
+	e.Lock()
+	defer e.Unlock()
+
+	return e.size
 }
 
 func (e *entry) Stop() {
@@ -115,39 +213,216 @@ func (e *entry) Stop() {
 	e.stop()
 }
 
-func (e *entry) setData(data any, err error) {
+// setError records a failed refresh and returns true if the last data fetched keeps being served,
+// it is served while the time since its last successful refresh doesn't exceed maxStaleness
+func (e *entry) setError(err error, maxStaleness time.Duration) bool {
+	e.Lock()
+	defer e.Unlock()
+
+	if e.data != nil && maxStaleness > 0 && time.Since(e.refreshed) <= maxStaleness {
+		return true
+	}
+	e.err = err
+	return false
+}
+
+// setNotModified records a successful refresh of the data served and returns the data and its generation
+func (e *entry) setNotModified() ([]byte, int64) {
+	e.Lock()
+	defer e.Unlock()
+
+	e.err = nil
+	e.refreshed = time.Now()
+	data, _ := e.data.([]byte)
+	return data, e.generation
+}
+
+func (e *entry) getValidators() apicall.Validators {
+	e.Lock()
+	defer e.Unlock()
+
+	return e.validators
+}
+
+// setProjectedData sets the fetched data and returns the generation of the data
+func (e *entry) setProjectedData(data []byte, validators apicall.Validators, normalized normalized) int64 {
+	e.Lock()
+	defer e.Unlock()
+
+	if !e.isCurrent(data) {
+		// fetched data is not part of a snapshot until it is published
+		e.generation = 0
+	}
+	e.data = data
+	e.err = nil
+	e.validators = validators
+	e.refreshed = time.Now()
+	e.normalized = normalized
+	e.size = store.SizeOf(e.data, e.normalized.projected)
+	return e.generation
+}
+
+// isCurrent returns true if the data is the same as the last data fetched or loaded
+func (e *entry) isCurrent(data []byte) bool {
+	current, ok := e.data.([]byte)
+	return ok && bytes.Equal(current, data)
+}
+
+// publish publishes a new snapshot when the data changed and returns the generation of the snapshot
+func (e *entry) publish(ctx context.Context, snapshots *snapshot.Snapshots, gce *kyvernov2alpha1.GlobalContextEntry, data []byte) (int64, error) {
+	e.Lock()
+	current := e.generation
+	e.Unlock()
+	if current != 0 {
+		return current, nil
+	}
+	// a new generation must be greater than the generations published by previous publishers
+	latest, _, err := snapshots.Load(gce.Name)
+	if err != nil && !errors.Is(err, snapshot.ErrNotFound) {
+		return 0, err
+	}
+	generation := max(latest, gce.Status.SnapshotGeneration) + 1
+	if err := snapshots.Publish(ctx, gce, generation, data); err != nil {
+		return 0, err
+	}
 	e.Lock()
 	defer e.Unlock()
+	if e.isCurrent(data) {
+		e.generation = generation
+	}
+	return generation, nil
+}
 
+// load serves the data of the last snapshot if it is newer than the data served
+func (e *entry) load(logger logr.Logger, snapshots *snapshot.Snapshots, name string, projections projection.Projections) {
+	generation, data, err := snapshots.Load(name)
 	if err != nil {
-		e.err = err
-	} else {
-		e.data = data
-		e.err = nil
+		if !errors.Is(err, snapshot.ErrNotFound) {
+			logger.Error(err, "failed to load snapshot")
+		}
+		return
 	}
+	e.Lock()
+	current := e.generation
+	e.Unlock()
+	if generation <= current {
+		return
+	}
+	normalized := normalize(data, projections)
+	if normalized.projectionErr != nil {
+		logger.Error(normalized.projectionErr, "failed to compute projections")
+	}
+	e.Lock()
+	defer e.Unlock()
+	if generation <= e.generation {
+		return
+	}
+	e.data = data
+	e.err = nil
+	e.generation = generation
+	// validators of the data fetched by the publisher are unknown
+	e.validators = apicall.Validators{}
+	e.refreshed = time.Now()
+	e.normalized = normalized
+	e.size = store.SizeOf(e.data, e.normalized.projected)
 }
 
-func doCall(ctx context.Context, caller apicall.Executor, call kyvernov1.APICall, retryLimit int) (any, error) {
-	var result any
-	backoff := wait.Backoff{
-		Duration: retry.DefaultBackoff.Duration,
-		Factor:   retry.DefaultBackoff.Factor,
-		Jitter:   retry.DefaultBackoff.Jitter,
-		Steps:    retryLimit,
+// normalized holds the fetched data converted to json compatible values and its projections
+type normalized struct {
+	data          any
+	err           error
+	projected     map[string]any
+	projectionErr error
+}
+
+// normalize converts the fetched data once so that readers don't have to convert it on every read
+func normalize(data []byte, projections projection.Projections) normalized {
+	var out normalized
+	if out.data, out.err = projection.Normalize(data); out.err != nil {
+		out.projectionErr = out.err
+		return out
+	}
+	if len(projections) > 0 {
+		out.projected, out.projectionErr = projections.Apply(out.data)
 	}
+	return out
+}
 
-	retryError := retry.OnError(backoff, func(err error) bool {
-		return err != nil
-	}, func() error {
-		var exeErr error
-		result, exeErr = caller.Execute(ctx, &call)
-		return exeErr
-	})
+// newBackoff returns an exponential backoff with jitter starting at retryBackoff and capped by period
+func newBackoff(retryBackoff *metav1.Duration, period time.Duration) wait.Backoff {
+	duration := defaultRetryBackoff
+	if retryBackoff != nil && retryBackoff.Duration > 0 {
+		duration = retryBackoff.Duration
+	}
+	return wait.Backoff{
+		Duration: duration,
+		Factor:   2,
+		Jitter:   0.5,
+		Steps:    math.MaxInt32,
+		Cap:      period,
+	}
+}
 
-	return result, retryError
+func maxStaleness(maxStaleness *metav1.Duration) time.Duration {
+	if maxStaleness == nil {
+		return 0
+	}
+	return maxStaleness.Duration
 }
 
-func updateStatus(ctx context.Context, gce *kyvernov2alpha1.GlobalContextEntry, kyvernoClient versioned.Interface, ready bool, reason string) error {
+// doCall executes the call up to retryLimit times, waiting between attempts as defined by backoff
+func doCall(ctx context.Context, caller apicall.ConditionalExecutor, call kyvernov1.APICall, validators apicall.Validators, retryLimit int, backoff wait.Backoff) ([]byte, apicall.Validators, error) {
+	for attempt := 1; ; attempt++ {
+		data, next, err := caller.ExecuteConditional(ctx, &call, validators)
+		if err == nil || errors.Is(err, apicall.ErrNotModified) || attempt >= retryLimit {
+			return data, next, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, apicall.Validators{}, err
+		case <-time.After(backoff.Step()):
+		}
+	}
+}
+
+func objectReference(gce *kyvernov2alpha1.GlobalContextEntry) corev1.ObjectReference {
+	return corev1.ObjectReference{
+		APIVersion: gce.APIVersion,
+		Kind:       gce.Kind,
+		Name:       gce.Name,
+		Namespace:  gce.Namespace,
+		UID:        gce.UID,
+	}
+}
+
+// succeeded returns a status update for data refreshed successfully
+func succeeded(generation int64) func(*kyvernov2alpha1.GlobalContextEntryStatus) {
+	return func(status *kyvernov2alpha1.GlobalContextEntryStatus) {
+		status.SetReady(true, "APICallSuccess")
+		status.SetStale(false, "APICallSuccess")
+		status.UpdateRefreshTime()
+		if generation != 0 {
+			status.SnapshotGeneration = generation
+		}
+	}
+}
+
+// staled returns a status update for data that failed to refresh but keeps being served
+func staled(err error) func(*kyvernov2alpha1.GlobalContextEntryStatus) {
+	return func(status *kyvernov2alpha1.GlobalContextEntryStatus) {
+		status.SetStale(true, err.Error())
+	}
+}
+
+// failed returns a status update for data that is not served anymore
+func failed(reason string) func(*kyvernov2alpha1.GlobalContextEntryStatus) {
+	return func(status *kyvernov2alpha1.GlobalContextEntryStatus) {
+		status.SetReady(false, reason)
+		meta.RemoveStatusCondition(&status.Conditions, kyvernov2alpha1.GlobalContextEntryConditionStale)
+	}
+}
+
+func updateStatus(ctx context.Context, gce *kyvernov2alpha1.GlobalContextEntry, kyvernoClient versioned.Interface, update func(*kyvernov2alpha1.GlobalContextEntryStatus)) error {
 	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		latestGCE, getErr := kyvernoClient.KyvernoV2alpha1().GlobalContextEntries().Get(ctx, gce.GetName(), metav1.GetOptions{})
 		if getErr != nil {
@@ -158,10 +433,7 @@ func updateStatus(ctx context.Context, gce *kyvernov2alpha1.GlobalContextEntry,
 			if latest == nil {
 				return fmt.Errorf("failed to update status: %s", gce.GetName())
 			}
-			latest.Status.SetReady(ready, reason)
-			if ready {
-				latest.Status.UpdateRefreshTime()
-			}
+			update(&latest.Status)
 			return nil
 		}, nil)
 	})
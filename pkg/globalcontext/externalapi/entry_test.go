@@ -0,0 +1,139 @@
+package externalapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/apicall"
+	"github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type executor struct {
+	errs  []error
+	calls int
+}
+
+func (e *executor) Execute(ctx context.Context, call *kyvernov1.APICall) ([]byte, error) {
+	data, _, err := e.ExecuteConditional(ctx, call, apicall.Validators{})
+	return data, err
+}
+
+func (e *executor) ExecuteConditional(_ context.Context, _ *kyvernov1.APICall, validators apicall.Validators) ([]byte, apicall.Validators, error) {
+	e.calls++
+	if len(e.errs) != 0 {
+		err := e.errs[0]
+		e.errs = e.errs[1:]
+		return nil, validators, err
+	}
+	return []byte(`{}`), apicall.Validators{ETag: "etag"}, nil
+}
+
+func Test_doCall(t *testing.T) {
+	failure := errors.New("failure")
+	backoff := newBackoff(&metav1.Duration{Duration: time.Millisecond}, time.Second)
+	tests := []struct {
+		name       string
+		errs       []error
+		retryLimit int
+		wantCalls  int
+		wantErr    error
+	}{{
+		name:       "success",
+		retryLimit: 3,
+		wantCalls:  1,
+	}, {
+		name:       "success after retries",
+		errs:       []error{failure, failure},
+		retryLimit: 3,
+		wantCalls:  3,
+	}, {
+		name:       "retry limit reached",
+		errs:       []error{failure, failure, failure},
+		retryLimit: 3,
+		wantCalls:  3,
+		wantErr:    failure,
+	}, {
+		name:       "not modified is not retried",
+		errs:       []error{apicall.ErrNotModified},
+		retryLimit: 3,
+		wantCalls:  1,
+		wantErr:    apicall.ErrNotModified,
+	}, {
+		name:      "no retry limit",
+		errs:      []error{failure},
+		wantCalls: 1,
+		wantErr:   failure,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			caller := &executor{errs: tt.errs}
+			data, validators, err := doCall(context.TODO(), caller, kyvernov1.APICall{}, apicall.Validators{}, tt.retryLimit, backoff)
+			assert.Equal(t, tt.wantCalls, caller.calls)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, data)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, []byte(`{}`), data)
+				assert.Equal(t, "etag", validators.ETag)
+			}
+		})
+	}
+}
+
+func Test_newBackoff(t *testing.T) {
+	backoff := newBackoff(nil, time.Minute)
+	assert.Equal(t, defaultRetryBackoff, backoff.Duration)
+	backoff = newBackoff(&metav1.Duration{Duration: 10 * time.Second}, 15*time.Second)
+	for range 10 {
+		// jitter adds up to half of the capped duration
+		assert.LessOrEqual(t, backoff.Step(), 15*time.Second+15*time.Second/2)
+	}
+}
+
+func Test_entry_setError(t *testing.T) {
+	failure := errors.New("failure")
+	e := &entry{}
+	// no data to serve
+	assert.False(t, e.setError(failure, time.Hour))
+	assert.ErrorIs(t, e.err, failure)
+	e.setProjectedData([]byte(`{}`), apicall.Validators{}, normalized{})
+	assert.NoError(t, e.err)
+	// data is served while it is not older than max staleness
+	assert.True(t, e.setError(failure, time.Hour))
+	data, err := e.Get("")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`{}`), data)
+	// data is not served when staleness is not allowed
+	assert.False(t, e.setError(failure, 0))
+	_, err = e.Get("")
+	assert.ErrorIs(t, err, failure)
+	// data is served again when it did not change
+	data, _ = e.setNotModified()
+	assert.Equal(t, []byte(`{}`), data)
+	_, err = e.Get("")
+	assert.NoError(t, err)
+	// data is not served when it is too old
+	e.refreshed = time.Now().Add(-2 * time.Hour)
+	assert.False(t, e.setError(failure, time.Hour))
+}
+
+func Test_entry_GetNormalized(t *testing.T) {
+	e := &entry{}
+	_, err := e.GetNormalized()
+	assert.ErrorIs(t, err, store.ErrEntryNotReady)
+	data := []byte(`{"items":[{"name":"foo"}]}`)
+	e.setProjectedData(data, apicall.Validators{}, normalize(data, nil))
+	normalized, err := e.GetNormalized()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"items": []any{map[string]any{"name": "foo"}}}, normalized)
+	// invalid json can't be normalized
+	e.setProjectedData([]byte(`{`), apicall.Validators{}, normalize([]byte(`{`), nil))
+	_, err = e.GetNormalized()
+	assert.Error(t, err)
+}
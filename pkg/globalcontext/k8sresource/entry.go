@@ -3,30 +3,51 @@ package k8sresource
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/go-logr/logr"
 	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	"github.com/kyverno/kyverno/pkg/event"
 	entryevent "github.com/kyverno/kyverno/pkg/globalcontext/event"
+	"github.com/kyverno/kyverno/pkg/globalcontext/projection"
 	"github.com/kyverno/kyverno/pkg/globalcontext/store"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/metadata/metadatainformer"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/retry"
 )
 
 type entry struct {
-	lister   cache.GenericLister
-	stop     func()
-	gce      *kyvernov2alpha1.GlobalContextEntry
-	eventGen event.Interface
+	sync.RWMutex
+	lister        cache.GenericLister
+	stop          func()
+	gce           *kyvernov2alpha1.GlobalContextEntry
+	eventGen      event.Interface
+	logger        logr.Logger
+	metadataOnly  bool
+	data          []any
+	dataErr       error
+	projections   projection.Projections
+	projected     map[string]any
+	projectionErr error
+	normalized    any
+	version       int64
+	sizes         map[string]int64
+	objectsSize   int64
+	projectedSize int64
+	changed       chan struct{}
 }
 
 // TODO: Handle Kyverno Pod Ready State
@@ -35,10 +56,14 @@ func New(
 	gce *kyvernov2alpha1.GlobalContextEntry,
 	eventGen event.Interface,
 	client dynamic.Interface,
+	metadataClient metadata.Interface,
 	kyvernoClient versioned.Interface,
 	logger logr.Logger,
 	gvr schema.GroupVersionResource,
 	namespace string,
+	tweakListOptions func(*metav1.ListOptions),
+	metadataOnly bool,
+	projections projection.Projections,
 	shouldUpdateStatus bool,
 ) (store.Entry, error) {
 	indexers := cache.Indexers{
@@ -47,7 +72,13 @@ func New(
 	if namespace == "" {
 		namespace = metav1.NamespaceAll
 	}
-	informer := dynamicinformer.NewFilteredDynamicInformer(client, gvr, namespace, 0, indexers, nil)
+	var informer informers.GenericInformer
+	if metadataOnly {
+		// only the metadata of the resources is fetched and cached
+		informer = metadatainformer.NewFilteredMetadataInformer(metadataClient, gvr, namespace, 0, indexers, tweakListOptions)
+	} else {
+		informer = dynamicinformer.NewFilteredDynamicInformer(client, gvr, namespace, 0, indexers, tweakListOptions)
+	}
 	var group wait.Group
 	ctx, cancel := context.WithCancel(ctx)
 	stop := func() {
@@ -78,6 +109,26 @@ func New(
 		logger.Error(err, "failed to set watch error handler")
 		return nil, err
 	}
+	e := &entry{
+		lister:       informer.Lister(),
+		stop:         stop,
+		gce:          gce,
+		eventGen:     eventGen,
+		logger:       logger,
+		metadataOnly: metadataOnly,
+		projections:  projections,
+		sizes:        map[string]int64{},
+		changed:      make(chan struct{}, 1),
+	}
+	// sizes are tracked per object, events are coalesced and projections are computed again once for a burst of changes
+	if _, err := informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    e.track,
+		UpdateFunc: func(_, obj any) { e.track(obj) },
+		DeleteFunc: e.untrack,
+	}); err != nil {
+		logger.Error(err, "failed to add event handler")
+		return nil, err
+	}
 
 	group.StartWithContext(ctx, func(ctx context.Context) {
 		informer.Informer().Run(ctx.Done())
@@ -103,20 +154,47 @@ func New(
 		return nil, err
 	}
 
+	if e.needsRefresh() {
+		e.refresh()
+		group.StartWithContext(ctx, func(ctx context.Context) {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-e.changed:
+					e.refresh()
+				}
+			}
+		})
+	}
+
 	if shouldUpdateStatus {
 		if err := updateStatus(ctx, gce, kyvernoClient, true, "CacheSyncSuccess"); err != nil {
 			logger.Error(err, "failed to update status")
 		}
 	}
 
-	return &entry{
-		lister:   informer.Lister(),
-		stop:     stop,
-		eventGen: eventGen,
-	}, nil
+	return e, nil
 }
 
-func (e *entry) Get() (any, error) {
+func (e *entry) Get(projection string) (any, error) {
+	if projection != "" {
+		e.RLock()
+		defer e.RUnlock()
+		if !e.projections.Has(projection) {
+			return nil, fmt.Errorf("%w: %s", store.ErrProjectionNotFound, projection)
+		}
+		if e.projectionErr != nil {
+			return nil, e.projectionErr
+		}
+		return e.projected[projection], nil
+	}
+	if e.metadataOnly {
+		// partial objects are converted once per refresh
+		e.RLock()
+		defer e.RUnlock()
+		return e.data, e.dataErr
+	}
 	obj, err := e.lister.List(labels.Everything())
 	if err != nil {
 		e.eventGen.Add(entryevent.NewErrorEvent(corev1.ObjectReference{
@@ -131,6 +209,174 @@ func (e *entry) Get() (any, error) {
 	return obj, nil
 }
 
+func (e *entry) Size() int64 {
+	e.RLock()
+	defer e.RUnlock()
+	return e.objectsSize + e.projectedSize
+}
+
+// needsRefresh returns true if the entry holds data derived from the cached objects,
+// full objects without projections are served by the lister directly
+func (e *entry) needsRefresh() bool {
+	return e.metadataOnly || len(e.projections) > 0
+}
+
+// track records the size of an added or updated object
+func (e *entry) track(obj any) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return
+	}
+	size := store.SizeOf(obj)
+	e.Lock()
+	e.objectsSize += size - e.sizes[key]
+	e.sizes[key] = size
+	e.invalidate()
+	e.Unlock()
+	e.notify()
+}
+
+// untrack forgets the size of a deleted object
+func (e *entry) untrack(obj any) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return
+	}
+	e.Lock()
+	e.objectsSize -= e.sizes[key]
+	delete(e.sizes, key)
+	e.invalidate()
+	e.Unlock()
+	e.notify()
+}
+
+// invalidate drops the normalized objects after a change, the lock must be held
+func (e *entry) invalidate() {
+	e.version++
+	e.normalized = nil
+}
+
+func (e *entry) notify() {
+	if !e.needsRefresh() {
+		return
+	}
+	select {
+	case e.changed <- struct{}{}:
+	default:
+	}
+}
+
+// refresh computes the metadata of partial objects and the projections of the cached objects
+func (e *entry) refresh() {
+	objs, err := e.lister.List(labels.Everything())
+	if err != nil {
+		e.logger.Error(err, "failed to list cached objects")
+		e.setDataErr(err)
+		return
+	}
+	data, err := normalize(objs)
+	if err != nil {
+		e.logger.Error(err, "failed to convert cached objects")
+		e.setDataErr(err)
+		return
+	}
+	var projected map[string]any
+	var projectionErr error
+	if len(e.projections) > 0 {
+		if projected, projectionErr = e.projections.Apply(data); projectionErr != nil {
+			e.logger.Error(projectionErr, "failed to compute projections")
+			e.eventGen.Add(entryevent.NewErrorEvent(corev1.ObjectReference{
+				APIVersion: e.gce.APIVersion,
+				Kind:       e.gce.Kind,
+				Name:       e.gce.Name,
+				Namespace:  e.gce.Namespace,
+				UID:        e.gce.UID,
+			}, projectionErr))
+		}
+	}
+	var projectedSize int64
+	if projected != nil {
+		projectedSize = store.SizeOf(projected)
+	}
+	e.Lock()
+	defer e.Unlock()
+	// full objects are listed on demand, only the converted metadata of partial objects is kept
+	if e.metadataOnly {
+		e.data = data
+	}
+	e.dataErr = nil
+	e.projected = projected
+	e.projectionErr = projectionErr
+	e.projectedSize = projectedSize
+}
+
+func (e *entry) setDataErr(err error) {
+	e.Lock()
+	defer e.Unlock()
+	e.data = nil
+	e.dataErr = err
+}
+
+// GetNormalized returns the cached objects as json compatible values, full objects are normalized
+// on the first read following a change and kept until the next one
+func (e *entry) GetNormalized() (any, error) {
+	if e.metadataOnly {
+		return e.Get("")
+	}
+	e.RLock()
+	normalized, version := e.normalized, e.version
+	e.RUnlock()
+	if normalized != nil {
+		return normalized, nil
+	}
+	objs, err := e.lister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	if normalized, err = normalize(objs); err != nil {
+		return nil, err
+	}
+	e.Lock()
+	defer e.Unlock()
+	if e.version == version {
+		e.normalized = normalized
+	}
+	return normalized, nil
+}
+
+// normalize converts cached objects to json compatible values
+func normalize(objs []runtime.Object) ([]any, error) {
+	data, err := toData(objs)
+	if err != nil {
+		return nil, err
+	}
+	normalized, err := projection.Normalize(data)
+	if err != nil {
+		return nil, err
+	}
+	out, _ := normalized.([]any)
+	return out, nil
+}
+
+// toData converts cached objects to json compatible values, only the metadata of partial objects is kept
+// because their type meta is not consistently set by the metadata client
+func toData(objs []runtime.Object) ([]any, error) {
+	data := make([]any, 0, len(objs))
+	for _, obj := range objs {
+		switch obj := obj.(type) {
+		case *unstructured.Unstructured:
+			data = append(data, obj.UnstructuredContent())
+		case *metav1.PartialObjectMetadata:
+			meta, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&obj.ObjectMeta)
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, map[string]any{"metadata": meta})
+		}
+	}
+	return data, nil
+}
+
 func (e *entry) Stop() {
 	e.stop()
 }
@@ -0,0 +1,102 @@
+package k8sresource
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestEntry_GetMetadataOnly(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NoError(t, indexer.Add(&metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+	}))
+	e := &entry{
+		lister:       cache.NewGenericLister(indexer, schema.GroupResource{Resource: "configmaps"}),
+		logger:       logr.Discard(),
+		metadataOnly: true,
+	}
+	e.refresh()
+	data, err := e.Get("")
+	assert.NoError(t, err)
+	assert.Equal(t, []any{map[string]any{
+		"metadata": map[string]any{"name": "test", "namespace": "default", "creationTimestamp": nil},
+	}}, data)
+	// the data converted by the last refresh is returned until the next one
+	assert.NoError(t, indexer.Add(&metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+	}))
+	data, err = e.Get("")
+	assert.NoError(t, err)
+	assert.Len(t, data, 1)
+	e.refresh()
+	data, err = e.Get("")
+	assert.NoError(t, err)
+	assert.Len(t, data, 2)
+}
+
+func TestEntry_Size(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	e := &entry{
+		lister: cache.NewGenericLister(indexer, schema.GroupResource{Resource: "configmaps"}),
+		logger: logr.Discard(),
+		sizes:  map[string]int64{},
+	}
+	foo := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": "foo", "namespace": "default"},
+	}}
+	bar := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": "bar", "namespace": "default"},
+		"data":     map[string]any{"key": "value"},
+	}}
+	e.track(foo)
+	e.track(bar)
+	assert.Equal(t, store.SizeOf(foo, bar), e.Size())
+	// updates replace the size of the previous version
+	e.track(foo)
+	assert.Equal(t, store.SizeOf(foo, bar), e.Size())
+	e.untrack(cache.DeletedFinalStateUnknown{Key: "default/bar", Obj: bar})
+	assert.Equal(t, store.SizeOf(foo), e.Size())
+	// full objects without projections are not converted
+	assert.NoError(t, indexer.Add(foo))
+	e.refresh()
+	assert.Nil(t, e.data)
+	data, err := e.Get("")
+	assert.NoError(t, err)
+	assert.Len(t, data, 1)
+}
+
+func TestEntry_GetNormalized(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	e := &entry{
+		lister: cache.NewGenericLister(indexer, schema.GroupResource{Resource: "deployments"}),
+		logger: logr.Discard(),
+		sizes:  map[string]int64{},
+	}
+	foo := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": "foo", "namespace": "default"},
+		"spec":     map[string]any{"replicas": int64(3)},
+	}}
+	assert.NoError(t, indexer.Add(foo))
+	e.track(foo)
+	data, err := e.GetNormalized()
+	assert.NoError(t, err)
+	assert.Equal(t, []any{map[string]any{
+		"metadata": map[string]any{"name": "foo", "namespace": "default"},
+		"spec":     map[string]any{"replicas": float64(3)},
+	}}, data)
+	// normalized objects are kept until the next change
+	assert.NotNil(t, e.normalized)
+	e.untrack(foo)
+	assert.Nil(t, e.normalized)
+	assert.NoError(t, indexer.Delete(foo))
+	data, err = e.GetNormalized()
+	assert.NoError(t, err)
+	assert.Equal(t, []any{}, data)
+}
@@ -0,0 +1,183 @@
+package projection
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	engine "github.com/kyverno/kyverno/pkg/cel"
+	"github.com/kyverno/kyverno/pkg/cel/utils"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// DataKey is the CEL variable holding the entry data in projection expressions
+	DataKey = "data"
+	// ElementKey is the CEL variable holding the element being indexed in index expressions
+	ElementKey = "element"
+)
+
+type evaluator = func(any) (any, error)
+
+type projection struct {
+	name  string
+	value evaluator
+	index evaluator
+}
+
+// Projections are the compiled projections of a global context entry
+type Projections []projection
+
+// New compiles the projections of a global context entry
+func New(jp jmespath.Interface, specs ...kyvernov2alpha1.GlobalContextEntryProjection) (Projections, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+	env, err := engine.NewEnv()
+	if err != nil {
+		return nil, err
+	}
+	env, err = env.Extend(
+		cel.Variable(DataKey, types.DynType),
+		cel.Variable(ElementKey, types.DynType),
+	)
+	if err != nil {
+		return nil, err
+	}
+	out := make(Projections, 0, len(specs))
+	for _, spec := range specs {
+		value, err := compile(jp, env, DataKey, spec.JMESPath, spec.CEL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile projection %s: %w", spec.Name, err)
+		}
+		var index evaluator
+		if spec.Index != nil {
+			if index, err = compile(jp, env, ElementKey, spec.Index.JMESPath, spec.Index.CEL); err != nil {
+				return nil, fmt.Errorf("failed to compile index of projection %s: %w", spec.Name, err)
+			}
+		}
+		out = append(out, projection{
+			name:  spec.Name,
+			value: value,
+			index: index,
+		})
+	}
+	return out, nil
+}
+
+// Has returns true if a projection with the given name exists
+func (p Projections) Has(name string) bool {
+	for _, projection := range p {
+		if projection.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply computes the projections of the data, it must be made of json compatible values.
+// Indexed projections are maps of keys to the list of elements having that key.
+func (p Projections) Apply(data any) (map[string]any, error) {
+	out := make(map[string]any, len(p))
+	for _, projection := range p {
+		value, err := projection.value(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute projection %s: %w", projection.name, err)
+		}
+		if projection.index != nil {
+			if value, err = index(projection.index, value); err != nil {
+				return nil, fmt.Errorf("failed to index projection %s: %w", projection.name, err)
+			}
+		}
+		out[projection.name] = value
+	}
+	return out, nil
+}
+
+func compile(jp jmespath.Interface, env *cel.Env, variable string, jmesPath string, expression string) (evaluator, error) {
+	if jmesPath != "" {
+		query, err := jp.Query(jmesPath)
+		if err != nil {
+			return nil, err
+		}
+		return query.Search, nil
+	}
+	if expression != "" {
+		ast, issues := env.Compile(expression)
+		if err := issues.Err(); err != nil {
+			return nil, err
+		}
+		program, err := env.Program(ast)
+		if err != nil {
+			return nil, err
+		}
+		return func(data any) (any, error) {
+			out, _, err := program.Eval(map[string]any{variable: data})
+			if err != nil {
+				return nil, err
+			}
+			value, err := utils.ConvertToNative[*structpb.Value](out)
+			if err != nil {
+				return nil, err
+			}
+			return value.AsInterface(), nil
+		}, nil
+	}
+	// without expression the projection is the data itself
+	return func(data any) (any, error) {
+		return data, nil
+	}, nil
+}
+
+func index(key evaluator, value any) (map[string]any, error) {
+	elements, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("indexed projections must be lists, got %T", value)
+	}
+	groups := map[string][]any{}
+	for _, element := range elements {
+		keys, err := key(element)
+		if err != nil {
+			return nil, err
+		}
+		list, ok := keys.([]any)
+		if !ok {
+			list = []any{keys}
+		}
+		for _, k := range list {
+			switch k := k.(type) {
+			case nil:
+			case string:
+				groups[k] = append(groups[k], element)
+			case bool, int, int64, float64, json.Number:
+				groups[fmt.Sprint(k)] = append(groups[fmt.Sprint(k)], element)
+			default:
+				return nil, fmt.Errorf("index keys must be strings, numbers or booleans, got %T", k)
+			}
+		}
+	}
+	out := make(map[string]any, len(groups))
+	for key, elements := range groups {
+		out[key] = elements
+	}
+	return out, nil
+}
+
+// Normalize converts raw json or native values to the json compatible values expected by Apply
+func Normalize(data any) (any, error) {
+	raw, ok := data.([]byte)
+	if !ok {
+		var err error
+		if raw, err = json.Marshal(data); err != nil {
+			return nil, err
+		}
+	}
+	var out any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,175 @@
+package projection
+
+import (
+	"testing"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/stretchr/testify/assert"
+)
+
+var jp = jmespath.New(config.NewDefaultConfiguration(false))
+
+const pods = `[
+	{"metadata": {"name": "foo", "namespace": "default"}, "spec": {"serviceAccountName": "sa"}},
+	{"metadata": {"name": "bar", "namespace": "default"}, "spec": {"serviceAccountName": "sa"}},
+	{"metadata": {"name": "baz", "namespace": "kube-system"}}
+]`
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		specs   []kyvernov2alpha1.GlobalContextEntryProjection
+		wantErr bool
+	}{{
+		name: "none",
+	}, {
+		name: "valid",
+		specs: []kyvernov2alpha1.GlobalContextEntryProjection{{
+			Name:     "names",
+			JMESPath: "[].metadata.name",
+		}, {
+			Name: "by-namespace",
+			Index: &kyvernov2alpha1.GlobalContextEntryIndex{
+				CEL: "element.metadata.namespace",
+			},
+		}},
+	}, {
+		name: "invalid jmespath",
+		specs: []kyvernov2alpha1.GlobalContextEntryProjection{{
+			Name:     "names",
+			JMESPath: "[].metadata.name[",
+		}},
+		wantErr: true,
+	}, {
+		name: "invalid cel",
+		specs: []kyvernov2alpha1.GlobalContextEntryProjection{{
+			Name: "names",
+			CEL:  "data.map(",
+		}},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(jp, tt.specs...)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, got, len(tt.specs))
+			}
+		})
+	}
+}
+
+func TestProjections_Apply(t *testing.T) {
+	data, err := Normalize([]byte(pods))
+	assert.NoError(t, err)
+	tests := []struct {
+		name    string
+		spec    kyvernov2alpha1.GlobalContextEntryProjection
+		want    any
+		wantErr bool
+	}{{
+		name: "identity",
+		spec: kyvernov2alpha1.GlobalContextEntryProjection{Name: "all"},
+		want: data,
+	}, {
+		name: "jmespath",
+		spec: kyvernov2alpha1.GlobalContextEntryProjection{
+			Name:     "names",
+			JMESPath: "[].metadata.name",
+		},
+		want: []any{"foo", "bar", "baz"},
+	}, {
+		name: "cel",
+		spec: kyvernov2alpha1.GlobalContextEntryProjection{
+			Name: "names",
+			CEL:  "data.map(pod, pod.metadata.name)",
+		},
+		want: []any{"foo", "bar", "baz"},
+	}, {
+		name: "index",
+		spec: kyvernov2alpha1.GlobalContextEntryProjection{
+			Name:     "by-namespace",
+			JMESPath: "[].metadata",
+			Index: &kyvernov2alpha1.GlobalContextEntryIndex{
+				JMESPath: "namespace",
+			},
+		},
+		want: map[string]any{
+			"default": []any{
+				map[string]any{"name": "foo", "namespace": "default"},
+				map[string]any{"name": "bar", "namespace": "default"},
+			},
+			"kube-system": []any{
+				map[string]any{"name": "baz", "namespace": "kube-system"},
+			},
+		},
+	}, {
+		name: "index skips missing keys",
+		spec: kyvernov2alpha1.GlobalContextEntryProjection{
+			Name: "by-service-account",
+			CEL:  "data.map(pod, {'name': pod.metadata.name, 'serviceAccountName': pod.?spec.?serviceAccountName.orValue('')})",
+			Index: &kyvernov2alpha1.GlobalContextEntryIndex{
+				JMESPath: "serviceAccountName || null",
+			},
+		},
+		want: map[string]any{
+			"sa": []any{
+				map[string]any{"name": "foo", "serviceAccountName": "sa"},
+				map[string]any{"name": "bar", "serviceAccountName": "sa"},
+			},
+		},
+	}, {
+		name: "index with multiple keys",
+		spec: kyvernov2alpha1.GlobalContextEntryProjection{
+			Name: "by-key",
+			CEL:  "data.map(pod, pod.metadata)",
+			Index: &kyvernov2alpha1.GlobalContextEntryIndex{
+				CEL: "[element.name, element.namespace]",
+			},
+		},
+		want: map[string]any{
+			"foo":         []any{map[string]any{"name": "foo", "namespace": "default"}},
+			"bar":         []any{map[string]any{"name": "bar", "namespace": "default"}},
+			"baz":         []any{map[string]any{"name": "baz", "namespace": "kube-system"}},
+			"default":     []any{map[string]any{"name": "foo", "namespace": "default"}, map[string]any{"name": "bar", "namespace": "default"}},
+			"kube-system": []any{map[string]any{"name": "baz", "namespace": "kube-system"}},
+		},
+	}, {
+		name: "index of a non list",
+		spec: kyvernov2alpha1.GlobalContextEntryProjection{
+			Name:     "first",
+			JMESPath: "[0]",
+			Index: &kyvernov2alpha1.GlobalContextEntryIndex{
+				JMESPath: "metadata.name",
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "index with invalid keys",
+		spec: kyvernov2alpha1.GlobalContextEntryProjection{
+			Name: "by-metadata",
+			Index: &kyvernov2alpha1.GlobalContextEntryIndex{
+				JMESPath: "metadata",
+			},
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projections, err := New(jp, tt.spec)
+			assert.NoError(t, err)
+			assert.True(t, projections.Has(tt.spec.Name))
+			got, err := projections.Apply(data)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got[tt.spec.Name])
+			}
+		})
+	}
+}
@@ -0,0 +1,5 @@
+package snapshot
+
+import "github.com/kyverno/kyverno/pkg/logging"
+
+var logger = logging.WithName("globalcontext-snapshots")
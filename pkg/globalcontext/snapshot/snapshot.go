@@ -0,0 +1,248 @@
+package snapshot
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+const (
+	// ChunkSize is the maximum size of the compressed data held by a single config map
+	ChunkSize = 512 * 1024
+	dataKey   = "data"
+)
+
+// ErrNotFound is returned when no complete snapshot was published for an entry
+var ErrNotFound = errors.New("snapshot not found")
+
+// Snapshots shares the data of global context entries between controllers.
+// The publisher fetches the data and stores versioned snapshots in config maps, chunked to fit
+// in the config map size limit, other controllers load the snapshots instead of fetching the data.
+type Snapshots struct {
+	client    corev1client.ConfigMapInterface
+	lister    corev1listers.ConfigMapNamespaceLister
+	publisher atomic.Bool
+	lock      sync.Mutex
+	watchers  map[string]map[int]func()
+	nextID    int
+}
+
+// New returns snapshots stored in the config maps of the given informer, it must only
+// watch the config maps having the kyverno.LabelGlobalContext label in a single namespace
+func New(client corev1client.ConfigMapInterface, informer corev1informers.ConfigMapInformer, namespace string) *Snapshots {
+	s := &Snapshots{
+		client:   client,
+		lister:   informer.Lister().ConfigMaps(namespace),
+		watchers: map[string]map[int]func(){},
+	}
+	if _, err := controllerutils.AddEventHandlersT(informer.Informer(), s.onChange, func(_, cm *corev1.ConfigMap) { s.onChange(cm) }, s.onChange); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	return s
+}
+
+// SetPublisher sets whether this controller fetches and publishes the data of the entries
+func (s *Snapshots) SetPublisher(publisher bool) {
+	s.publisher.Store(publisher)
+}
+
+// IsPublisher returns true when this controller fetches and publishes the data of the entries
+func (s *Snapshots) IsPublisher() bool {
+	return s.publisher.Load()
+}
+
+// Watch registers a function called when the snapshot of an entry changes, the returned function unregisters it
+func (s *Snapshots) Watch(name string, notify func()) func() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	id := s.nextID
+	s.nextID++
+	if s.watchers[name] == nil {
+		s.watchers[name] = map[int]func(){}
+	}
+	s.watchers[name][id] = notify
+	return func() {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		delete(s.watchers[name], id)
+		if len(s.watchers[name]) == 0 {
+			delete(s.watchers, name)
+		}
+	}
+}
+
+// Load returns the generation and the data of the last complete snapshot of an entry
+func (s *Snapshots) Load(name string) (int64, []byte, error) {
+	cms, err := s.lister.List(labelsFor(name))
+	if err != nil {
+		return 0, nil, err
+	}
+	// chunks of the newest generation are kept, older chunks can remain while a snapshot is being published
+	var generation int64 = -1
+	chunks := map[int][]byte{}
+	count := 0
+	for _, cm := range cms {
+		gen, index, total, err := parseChunk(cm)
+		if err != nil {
+			logger.Error(err, "invalid snapshot chunk", "name", cm.Name)
+			continue
+		}
+		if gen < generation {
+			continue
+		}
+		if gen > generation {
+			generation = gen
+			chunks = map[int][]byte{}
+			count = total
+		}
+		chunks[index] = cm.BinaryData[dataKey]
+	}
+	if generation < 0 || len(chunks) != count {
+		return 0, nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	var compressed bytes.Buffer
+	for i := range count {
+		chunk, ok := chunks[i]
+		if !ok {
+			return 0, nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+		}
+		compressed.Write(chunk)
+	}
+	reader, err := gzip.NewReader(&compressed)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return 0, nil, err
+	}
+	return generation, data, nil
+}
+
+// Publish stores a snapshot of the data of an entry, chunks left from a previous larger snapshot are deleted.
+// Config maps are owned by the entry and garbage collected when it is deleted.
+func (s *Snapshots) Publish(ctx context.Context, gce *kyvernov2alpha1.GlobalContextEntry, generation int64, data []byte) error {
+	name := gce.Name
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(data); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	chunks := slices.Collect(slices.Chunk(compressed.Bytes(), ChunkSize))
+	for index, chunk := range chunks {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: chunkName(name, index),
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: kyvernov2alpha1.SchemeGroupVersion.String(),
+					Kind:       "GlobalContextEntry",
+					Name:       gce.Name,
+					UID:        gce.UID,
+				}},
+				Labels: map[string]string{
+					kyverno.LabelAppManagedBy:  kyverno.ValueKyvernoApp,
+					kyverno.LabelGlobalContext: name,
+				},
+				Annotations: map[string]string{
+					kyverno.AnnotationGlobalContextGeneration: strconv.FormatInt(generation, 10),
+					kyverno.AnnotationGlobalContextChunk:      strconv.Itoa(index),
+					kyverno.AnnotationGlobalContextChunks:     strconv.Itoa(len(chunks)),
+				},
+			},
+			BinaryData: map[string][]byte{dataKey: chunk},
+		}
+		if _, err := s.client.Update(ctx, cm, metav1.UpdateOptions{}); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			if _, err := s.client.Create(ctx, cm, metav1.CreateOptions{}); err != nil {
+				return err
+			}
+		}
+	}
+	return s.prune(ctx, name, len(chunks))
+}
+
+// prune deletes the chunks of an entry having an index greater or equal to the given one
+func (s *Snapshots) prune(ctx context.Context, name string, from int) error {
+	cms, err := s.lister.List(labelsFor(name))
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, cm := range cms {
+		index, err := strconv.Atoi(cm.Annotations[kyverno.AnnotationGlobalContextChunk])
+		if err == nil && index < from {
+			continue
+		}
+		if err := s.client.Delete(ctx, cm.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Snapshots) onChange(cm *corev1.ConfigMap) {
+	name := cm.Labels[kyverno.LabelGlobalContext]
+	if name == "" {
+		return
+	}
+	s.lock.Lock()
+	watchers := make([]func(), 0, len(s.watchers[name]))
+	for _, notify := range s.watchers[name] {
+		watchers = append(watchers, notify)
+	}
+	s.lock.Unlock()
+	for _, notify := range watchers {
+		notify()
+	}
+}
+
+func labelsFor(name string) labels.Selector {
+	return labels.SelectorFromSet(labels.Set{kyverno.LabelGlobalContext: name})
+}
+
+func chunkName(name string, index int) string {
+	return fmt.Sprintf("gctx-%s-%d", name, index)
+}
+
+func parseChunk(cm *corev1.ConfigMap) (int64, int, int, error) {
+	generation, err := strconv.ParseInt(cm.Annotations[kyverno.AnnotationGlobalContextGeneration], 10, 64)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	index, err := strconv.Atoi(cm.Annotations[kyverno.AnnotationGlobalContextChunk])
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	total, err := strconv.Atoi(cm.Annotations[kyverno.AnnotationGlobalContextChunks])
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if index < 0 || index >= total {
+		return 0, 0, 0, fmt.Errorf("chunk index %d out of range (%d chunks)", index, total)
+	}
+	return generation, index, total, nil
+}
@@ -0,0 +1,96 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const namespace = "kyverno"
+
+func newSnapshots(t *testing.T, ctx context.Context) *Snapshots {
+	t.Helper()
+	client := fake.NewSimpleClientset()
+	factory := kubeinformers.NewSharedInformerFactoryWithOptions(
+		client,
+		0,
+		kubeinformers.WithNamespace(namespace),
+		kubeinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = kyverno.LabelGlobalContext
+		}),
+	)
+	snapshots := New(client.CoreV1().ConfigMaps(namespace), factory.Core().V1().ConfigMaps(), namespace)
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
+	return snapshots
+}
+
+func TestSnapshots(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	snapshots := newSnapshots(t, ctx)
+	gce := &kyvernov2alpha1.GlobalContextEntry{ObjectMeta: metav1.ObjectMeta{Name: "entry", UID: "uid"}}
+	notified := make(chan struct{}, 100)
+	unwatch := snapshots.Watch(gce.Name, func() { notified <- struct{}{} })
+	defer unwatch()
+	// nothing was published yet
+	_, _, err := snapshots.Load(gce.Name)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	// random data does not compress and spans multiple chunks
+	large := make([]byte, ChunkSize+ChunkSize/2)
+	rand.New(rand.NewSource(1)).Read(large) //nolint:gosec
+	assert.NoError(t, snapshots.Publish(ctx, gce, 1, large))
+	assert.Eventually(t, func() bool {
+		generation, data, err := snapshots.Load(gce.Name)
+		return err == nil && generation == 1 && assert.ObjectsAreEqual(large, data)
+	}, 5*time.Second, 10*time.Millisecond)
+	select {
+	case <-notified:
+	case <-time.After(5 * time.Second):
+		t.Fatal("watcher was not notified")
+	}
+	cms, err := snapshots.lister.List(labelsFor(gce.Name))
+	assert.NoError(t, err)
+	assert.Len(t, cms, 2)
+	assert.Equal(t, gce.Name, cms[0].OwnerReferences[0].Name)
+	// a smaller snapshot prunes the chunks left from the previous one
+	small := []byte(`{"foo":"bar"}`)
+	assert.NoError(t, snapshots.Publish(ctx, gce, 2, small))
+	assert.Eventually(t, func() bool {
+		generation, data, err := snapshots.Load(gce.Name)
+		if err != nil || generation != 2 || string(data) != string(small) {
+			return false
+		}
+		cms, err := snapshots.lister.List(labelsFor(gce.Name))
+		return err == nil && len(cms) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestSnapshots_Incomplete(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	snapshots := newSnapshots(t, ctx)
+	gce := &kyvernov2alpha1.GlobalContextEntry{ObjectMeta: metav1.ObjectMeta{Name: "entry", UID: "uid"}}
+	large := make([]byte, ChunkSize+ChunkSize/2)
+	rand.New(rand.NewSource(1)).Read(large) //nolint:gosec
+	assert.NoError(t, snapshots.Publish(ctx, gce, 1, large))
+	assert.Eventually(t, func() bool {
+		_, _, err := snapshots.Load(gce.Name)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	// a missing chunk makes the snapshot unusable
+	assert.NoError(t, snapshots.client.Delete(ctx, chunkName(gce.Name, 1), metav1.DeleteOptions{}))
+	assert.Eventually(t, func() bool {
+		_, _, err := snapshots.Load(gce.Name)
+		return errors.Is(err, ErrNotFound)
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,59 @@
+package static
+
+import (
+	"fmt"
+
+	"github.com/kyverno/kyverno/pkg/globalcontext/projection"
+	"github.com/kyverno/kyverno/pkg/globalcontext/store"
+)
+
+type entry struct {
+	data          any
+	normalized    any
+	normalizedErr error
+	projected     map[string]any
+	err           error
+}
+
+func (e *entry) Get(name string) (any, error) {
+	if name == "" {
+		return e.data, nil
+	}
+	if e.err != nil {
+		return nil, e.err
+	}
+	data, ok := e.projected[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", store.ErrProjectionNotFound, name)
+	}
+	return data, nil
+}
+
+func (e *entry) GetNormalized() (any, error) {
+	return e.normalized, e.normalizedErr
+}
+
+func (e *entry) Stop() {}
+
+// New returns an entry always serving the given data, it is used when data is not fetched from a cluster
+func New(data any) *entry {
+	e := &entry{
+		data: data,
+	}
+	e.normalized, e.normalizedErr = projection.Normalize(data)
+	return e
+}
+
+// NewWithProjections returns an entry always serving the given data and its projections
+func NewWithProjections(data any, projections projection.Projections) *entry {
+	e := New(data)
+	if len(projections) == 0 {
+		return e
+	}
+	if e.normalizedErr != nil {
+		e.err = e.normalizedErr
+		return e
+	}
+	e.projected, e.err = projections.Apply(e.normalized)
+	return e
+}
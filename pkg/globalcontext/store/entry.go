@@ -1,6 +1,28 @@
 package store
 
+import (
+	"errors"
+)
+
+// ErrEntryNotReady is returned by entries that have not loaded their data yet
+var ErrEntryNotReady = errors.New("entry not ready")
+
+// ErrProjectionNotFound is returned by entries that don't declare the requested projection
+var ErrProjectionNotFound = errors.New("projection not found")
+
 type Entry interface {
-	Get() (any, error)
+	// Get returns the entry data, or the data of the named projection if projection is not empty
+	Get(projection string) (any, error)
 	Stop()
 }
+
+// Sized is implemented by entries reporting the approximate size in bytes of the data they hold
+type Sized interface {
+	Size() int64
+}
+
+// Normalized is implemented by entries serving their data as json compatible values,
+// normalized once when the data changes instead of on every read
+type Normalized interface {
+	GetNormalized() (any, error)
+}
@@ -0,0 +1,27 @@
+package store
+
+import (
+	"encoding/json"
+)
+
+type counter int64
+
+func (c *counter) Write(p []byte) (int, error) {
+	*c += counter(len(p))
+	return len(p), nil
+}
+
+// SizeOf returns the size of the json encoding of the values, it approximates the memory used to hold them.
+// Raw bytes are counted as they are.
+func SizeOf(values ...any) int64 {
+	var c counter
+	encoder := json.NewEncoder(&c)
+	for _, value := range values {
+		if bytes, ok := value.([]byte); ok {
+			c += counter(len(bytes))
+		} else if err := encoder.Encode(value); err != nil {
+			return 0
+		}
+	}
+	return int64(c)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/kyverno/kyverno/api/kyverno"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
@@ -36,10 +37,15 @@ const (
 	LabelDomainClusterPolicy                    = "cpol.kyverno.io"
 	LabelDomainPolicy                           = "pol.kyverno.io"
 	LabelDomainValidatingPolicy                 = "vpol.kyverno.io"
+	LabelDomainMutatingPolicy                   = "mpol.kyverno.io"
+	LabelDomainImageVerificationPolicy          = "ivpol.kyverno.io"
 	LabelPrefixClusterPolicy                    = LabelDomainClusterPolicy + "/"
 	LabelPrefixPolicy                           = LabelDomainPolicy + "/"
 	LabelPrefixValidatingPolicy                 = LabelDomainValidatingPolicy + "/"
+	LabelPrefixMutatingPolicy                   = LabelDomainMutatingPolicy + "/"
+	LabelPrefixImageVerificationPolicy          = LabelDomainImageVerificationPolicy + "/"
 	LabelPrefixPolicyException                  = "polex.kyverno.io/"
+	LabelPrefixCELPolicyException               = "celpolex.kyverno.io/"
 	LabelPrefixValidatingAdmissionPolicy        = "validatingadmissionpolicy.apiserver.io/"
 	LabelPrefixValidatingAdmissionPolicyBinding = "validatingadmissionpolicybinding.apiserver.io/"
 	//	aggregated admission report label
@@ -50,7 +56,10 @@ func IsPolicyLabel(label string) bool {
 	return strings.HasPrefix(label, LabelPrefixPolicy) ||
 		strings.HasPrefix(label, LabelPrefixClusterPolicy) ||
 		strings.HasPrefix(label, LabelPrefixValidatingPolicy) ||
+		strings.HasPrefix(label, LabelPrefixMutatingPolicy) ||
+		strings.HasPrefix(label, LabelPrefixImageVerificationPolicy) ||
 		strings.HasPrefix(label, LabelPrefixPolicyException) ||
+		strings.HasPrefix(label, LabelPrefixCELPolicyException) ||
 		strings.HasPrefix(label, LabelPrefixValidatingAdmissionPolicy) ||
 		strings.HasPrefix(label, LabelPrefixValidatingAdmissionPolicyBinding)
 }
@@ -77,6 +86,12 @@ func PolicyLabelPrefix(policy engineapi.GenericPolicy) string {
 	if policy.AsValidatingPolicy() != nil {
 		return LabelPrefixValidatingPolicy
 	}
+	if policy.AsMutatingPolicy() != nil {
+		return LabelPrefixMutatingPolicy
+	}
+	if policy.AsImageVerificationPolicy() != nil {
+		return LabelPrefixImageVerificationPolicy
+	}
 	// TODO: detect potential type not detected
 	return LabelPrefixValidatingAdmissionPolicy
 }
@@ -96,6 +111,10 @@ func PolicyExceptionLabel(exception kyvernov2.PolicyException) string {
 	return LabelPrefixPolicyException + exception.GetName()
 }
 
+func CELPolicyExceptionLabel(exception kyvernov2alpha1.CELPolicyException) string {
+	return LabelPrefixCELPolicyException + exception.GetName()
+}
+
 func ValidatingAdmissionPolicyBindingLabel(binding admissionregistrationv1.ValidatingAdmissionPolicyBinding) string {
 	return LabelPrefixValidatingAdmissionPolicyBinding + binding.GetName()
 }
@@ -181,6 +200,10 @@ func SetPolicyExceptionLabel(report reportsv1.ReportInterface, exception kyverno
 	controllerutils.SetLabel(report, PolicyExceptionLabel(exception), exception.GetResourceVersion())
 }
 
+func SetCELPolicyExceptionLabel(report reportsv1.ReportInterface, exception kyvernov2alpha1.CELPolicyException) {
+	controllerutils.SetLabel(report, CELPolicyExceptionLabel(exception), exception.GetResourceVersion())
+}
+
 func SetValidatingAdmissionPolicyBindingLabel(report reportsv1.ReportInterface, binding admissionregistrationv1.ValidatingAdmissionPolicyBinding) {
 	controllerutils.SetLabel(report, ValidatingAdmissionPolicyBindingLabel(binding), binding.GetResourceVersion())
 }
@@ -114,13 +114,15 @@ func ToPolicyReportResult(pol engineapi.GenericPolicy, ruleResult engineapi.Rule
 			*resource,
 		}
 	}
-	exceptions := ruleResult.Exceptions()
+	var exceptions []string
+	for _, exception := range ruleResult.Exceptions() {
+		exceptions = append(exceptions, exception.Name)
+	}
+	for _, exception := range ruleResult.CELExceptions() {
+		exceptions = append(exceptions, exception.Name)
+	}
 	if len(exceptions) > 0 {
-		var names []string
-		for _, exception := range exceptions {
-			names = append(names, exception.Name)
-		}
-		addProperty("exceptions", strings.Join(names, ","), &result)
+		addProperty("exceptions", strings.Join(exceptions, ","), &result)
 	}
 	pss := ruleResult.PodSecurityChecks()
 	if pss != nil && len(pss.Checks) > 0 {
@@ -136,6 +138,12 @@ func ToPolicyReportResult(pol engineapi.GenericPolicy, ruleResult engineapi.Rule
 	if pol.AsValidatingPolicy() != nil {
 		result.Source = SourceValidatingPolicy
 	}
+	if pol.AsMutatingPolicy() != nil {
+		result.Source = SourceMutatingPolicy
+	}
+	if pol.AsImageVerificationPolicy() != nil {
+		result.Source = SourceImageVerificationPolicy
+	}
 	return result
 }
 
@@ -8,4 +8,6 @@ const (
 	SourceKyverno                   = kyverno.ValueKyvernoApp
 	SourceValidatingAdmissionPolicy = "ValidatingAdmissionPolicy"
 	SourceValidatingPolicy          = "KyvernoValidatingPolicy"
+	SourceMutatingPolicy            = "KyvernoMutatingPolicy"
+	SourceImageVerificationPolicy   = "KyvernoImageVerificationPolicy"
 )
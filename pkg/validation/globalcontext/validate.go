@@ -5,11 +5,24 @@ import (
 
 	"github.com/go-logr/logr"
 	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/globalcontext/projection"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // Validate checks global context entry is valid
 func Validate(ctx context.Context, logger logr.Logger, gctx *kyvernov2alpha1.GlobalContextEntry) ([]string, error) {
 	var warnings []string
 	errs := gctx.Validate()
+	if len(errs) == 0 {
+		jp := jmespath.New(config.NewDefaultConfiguration(false))
+		path := field.NewPath("spec", "projections")
+		for i, spec := range gctx.Spec.Projections {
+			if _, err := projection.New(jp, spec); err != nil {
+				errs = append(errs, field.Invalid(path.Index(i), spec.Name, err.Error()))
+			}
+		}
+	}
 	return warnings, errs.ToAggregate()
 }
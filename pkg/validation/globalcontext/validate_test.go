@@ -43,6 +43,22 @@ func Test_Validate(t *testing.T) {
 			want:    0,
 			wantErr: false,
 		},
+		{
+			name: "GlobalContextEntry with valid projections",
+			args: args{
+				resource: []byte(`{"apiVersion":"kyverno.io/v2alpha1","kind":"GlobalContextEntry","metadata":{"name":"gce-projections"},"spec":{"kubernetesResource":{"version":"v1","resource":"pods"},"projections":[{"name":"names","jmesPath":"[].metadata.name"},{"name":"by-namespace","index":{"cel":"element.metadata.namespace"}}]}}`),
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "GlobalContextEntry with an invalid projection",
+			args: args{
+				resource: []byte(`{"apiVersion":"kyverno.io/v2alpha1","kind":"GlobalContextEntry","metadata":{"name":"gce-projections"},"spec":{"kubernetesResource":{"version":"v1","resource":"pods"},"projections":[{"name":"names","cel":"data.map(d,"}]}}`),
+			},
+			want:    0,
+			wantErr: true,
+		},
 		{
 			name: "GlobalContextEntry with a core KubernetesResource present",
 			args: args{
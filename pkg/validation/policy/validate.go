@@ -304,7 +304,11 @@ func Validate(policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interf
 			return warnings, err
 		}
 
-		if err := validateRuleContext(rule); err != nil {
+		if err := validateRuleContext(rule, policy.GetNamespace()); err != nil {
+			return warnings, fmt.Errorf("path: spec.rules[%d]: %v", i, err)
+		}
+
+		if err := validateNestedContexts(rule, policy.GetNamespace()); err != nil {
 			return warnings, fmt.Errorf("path: spec.rules[%d]: %v", i, err)
 		}
 
@@ -1302,7 +1306,7 @@ func validateConditionValuesKeyRequestOperation(c kyvernov1.Condition) (string,
 	return "", nil
 }
 
-func validateRuleContext(rule kyvernov1.Rule) error {
+func validateRuleContext(rule kyvernov1.Rule, namespace string) error {
 	if len(rule.Context) == 0 {
 		return nil
 	}
@@ -1328,7 +1332,7 @@ func validateRuleContext(rule kyvernov1.Rule) error {
 		if entry.ConfigMap != nil && entry.APICall == nil && entry.GlobalReference == nil && entry.ImageRegistry == nil && entry.Variable == nil {
 			err = validateConfigMap(entry)
 		} else if entry.ConfigMap == nil && entry.APICall != nil && entry.GlobalReference == nil && entry.ImageRegistry == nil && entry.Variable == nil {
-			err = validateAPICall(entry)
+			err = validateAPICall(entry, namespace)
 		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.GlobalReference != nil && entry.ImageRegistry == nil && entry.Variable == nil {
 			err = validateGlobalReference(entry)
 		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.GlobalReference == nil && entry.ImageRegistry != nil && entry.Variable == nil {
@@ -1346,6 +1350,50 @@ func validateRuleContext(rule kyvernov1.Rule) error {
 	return nil
 }
 
+// validateNestedContexts validates the API calls declared in the contexts of foreach declarations and mutate targets
+func validateNestedContexts(rule kyvernov1.Rule, namespace string) error {
+	for _, entry := range nestedContexts(rule) {
+		if err := validateAPICall(entry, namespace); err != nil {
+			return fmt.Errorf("context entry %s: %w", entry.Name, err)
+		}
+	}
+	return nil
+}
+
+// nestedContexts returns the context entries of foreach declarations, including nested ones, and mutate targets
+func nestedContexts(rule kyvernov1.Rule) []kyvernov1.ContextEntry {
+	var entries []kyvernov1.ContextEntry
+	if rule.Validation != nil {
+		var walk func([]kyvernov1.ForEachValidation)
+		walk = func(foreach []kyvernov1.ForEachValidation) {
+			for _, fe := range foreach {
+				entries = append(entries, fe.Context...)
+				walk(fe.GetForEachValidation())
+			}
+		}
+		walk(rule.Validation.ForEachValidation)
+	}
+	if rule.Mutation != nil {
+		var walk func([]kyvernov1.ForEachMutation)
+		walk = func(foreach []kyvernov1.ForEachMutation) {
+			for _, fe := range foreach {
+				entries = append(entries, fe.Context...)
+				walk(fe.GetForEachMutation())
+			}
+		}
+		walk(rule.Mutation.ForEachMutation)
+		for _, target := range rule.Mutation.Targets {
+			entries = append(entries, target.Context...)
+		}
+	}
+	if rule.HasGenerate() {
+		for _, fe := range rule.Generation.ForEachGeneration {
+			entries = append(entries, fe.Context...)
+		}
+	}
+	return entries
+}
+
 // validateRuleImageExtractorsJMESPath ensures that the rule does not
 // mutate image digests if it has an image extractor that uses a JMESPath.
 func validateRuleImageExtractorsJMESPath(rule kyvernov1.Rule) error {
@@ -1415,7 +1463,7 @@ func validateConfigMap(entry kyvernov1.ContextEntry) error {
 	return nil
 }
 
-func validateAPICall(entry kyvernov1.ContextEntry) error {
+func validateAPICall(entry kyvernov1.ContextEntry, namespace string) error {
 	if entry.APICall == nil {
 		return nil
 	}
@@ -1426,6 +1474,21 @@ func validateAPICall(entry kyvernov1.ContextEntry) error {
 		}
 	}
 
+	if entry.APICall.Service != nil && entry.APICall.Service.Auth != nil {
+		auth := entry.APICall.Service.Auth
+		if errs := auth.Validate(field.NewPath("apiCall", "service", "auth")); len(errs) != 0 {
+			return errs.ToAggregate()
+		}
+		// namespaced policies can only use the credentials of their namespace
+		if namespace != "" {
+			for _, secret := range auth.Secrets() {
+				if secret.Namespace != namespace {
+					return fmt.Errorf("secret %s/%s cannot be referenced by a policy in namespace %s", secret.Namespace, secret.Name, namespace)
+				}
+			}
+		}
+	}
+
 	// If JMESPath contains variables, the validation will fail because it's not
 	// possible to infer which value will be inserted by the variable
 	// Skip validation if a variable is detected
@@ -1446,6 +1509,10 @@ func validateGlobalReference(entry kyvernov1.ContextEntry) error {
 		return nil
 	}
 
+	if entry.GlobalReference.Key != "" && entry.GlobalReference.Projection == "" {
+		return fmt.Errorf("a projection is required to look up key %s in global context entry %s", entry.GlobalReference.Key, entry.GlobalReference.Name)
+	}
+
 	// If JMESPath contains variables, the validation will fail because it's not
 	// possible to infer which value will be inserted by the variable
 	// Skip validation if a variable is detected
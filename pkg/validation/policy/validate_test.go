@@ -3348,3 +3348,221 @@ func Test_isMapStringString(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateAPICall_Auth(t *testing.T) {
+	entry := func(auth *kyverno.ServiceCallAuth) kyverno.ContextEntry {
+		return kyverno.ContextEntry{
+			Name: "inventory",
+			APICall: &kyverno.ContextAPICall{
+				APICall: kyverno.APICall{
+					Method: "GET",
+					Service: &kyverno.ServiceCall{
+						URL:  "https://inventory.example.com",
+						Auth: auth,
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name      string
+		auth      *kyverno.ServiceCallAuth
+		namespace string
+		wantErr   bool
+	}{{
+		name: "basic auth",
+		auth: &kyverno.ServiceCallAuth{
+			Basic: &kyverno.ServiceCallSecret{Name: "creds", Namespace: "kyverno"},
+		},
+	}, {
+		name: "client cert and oauth2",
+		auth: &kyverno.ServiceCallAuth{
+			ClientCert: &kyverno.ServiceCallSecret{Name: "cert", Namespace: "kyverno"},
+			OAuth2: &kyverno.OAuth2ClientCredentials{
+				TokenURL: "https://auth.example.com/token",
+				Secret:   kyverno.ServiceCallSecret{Name: "client", Namespace: "kyverno"},
+			},
+		},
+	}, {
+		name: "basic and oauth2",
+		auth: &kyverno.ServiceCallAuth{
+			Basic: &kyverno.ServiceCallSecret{Name: "creds", Namespace: "kyverno"},
+			OAuth2: &kyverno.OAuth2ClientCredentials{
+				TokenURL: "https://auth.example.com/token",
+				Secret:   kyverno.ServiceCallSecret{Name: "client", Namespace: "kyverno"},
+			},
+		},
+		wantErr: true,
+	}, {
+		name:    "empty auth",
+		auth:    &kyverno.ServiceCallAuth{},
+		wantErr: true,
+	}, {
+		name: "missing token url",
+		auth: &kyverno.ServiceCallAuth{
+			OAuth2: &kyverno.OAuth2ClientCredentials{
+				Secret: kyverno.ServiceCallSecret{Name: "client", Namespace: "kyverno"},
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "secret in policy namespace",
+		auth: &kyverno.ServiceCallAuth{
+			Basic: &kyverno.ServiceCallSecret{Name: "creds", Namespace: "team-a"},
+		},
+		namespace: "team-a",
+	}, {
+		name: "secret in another namespace",
+		auth: &kyverno.ServiceCallAuth{
+			Basic: &kyverno.ServiceCallSecret{Name: "creds", Namespace: "kyverno"},
+		},
+		namespace: "team-a",
+		wantErr:   true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAPICall(entry(tt.auth), tt.namespace)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_validateNestedContexts_Auth(t *testing.T) {
+	contexts := func(secretNamespace string) []kyverno.ContextEntry {
+		return []kyverno.ContextEntry{{
+			Name: "inventory",
+			APICall: &kyverno.ContextAPICall{
+				APICall: kyverno.APICall{
+					Method: "GET",
+					Service: &kyverno.ServiceCall{
+						URL: "https://inventory.example.com",
+						Auth: &kyverno.ServiceCallAuth{
+							Basic: &kyverno.ServiceCallSecret{Name: "creds", Namespace: secretNamespace},
+						},
+					},
+				},
+			},
+		}}
+	}
+	rules := map[string]func(string) kyverno.Rule{
+		"validate foreach": func(ns string) kyverno.Rule {
+			return kyverno.Rule{Validation: &kyverno.Validation{
+				ForEachValidation: []kyverno.ForEachValidation{{List: "request.object.spec.containers", Context: contexts(ns)}},
+			}}
+		},
+		"nested validate foreach": func(ns string) kyverno.Rule {
+			return kyverno.Rule{Validation: &kyverno.Validation{
+				ForEachValidation: []kyverno.ForEachValidation{{
+					List: "request.object.spec.containers",
+					ForEachValidation: &kyverno.ForEachValidationWrapper{
+						Items: []kyverno.ForEachValidation{{List: "element.ports", Context: contexts(ns)}},
+					},
+				}},
+			}}
+		},
+		"mutate foreach": func(ns string) kyverno.Rule {
+			return kyverno.Rule{Mutation: &kyverno.Mutation{
+				ForEachMutation: []kyverno.ForEachMutation{{List: "request.object.spec.containers", Context: contexts(ns)}},
+			}}
+		},
+		"nested mutate foreach": func(ns string) kyverno.Rule {
+			return kyverno.Rule{Mutation: &kyverno.Mutation{
+				ForEachMutation: []kyverno.ForEachMutation{{
+					List: "request.object.spec.containers",
+					ForEachMutation: &kyverno.ForEachMutationWrapper{
+						Items: []kyverno.ForEachMutation{{List: "element.ports", Context: contexts(ns)}},
+					},
+				}},
+			}}
+		},
+		"mutate targets": func(ns string) kyverno.Rule {
+			return kyverno.Rule{Mutation: &kyverno.Mutation{
+				Targets: []kyverno.TargetResourceSpec{{Context: contexts(ns)}},
+			}}
+		},
+		"generate foreach": func(ns string) kyverno.Rule {
+			return kyverno.Rule{Generation: &kyverno.Generation{
+				ForEachGeneration: []kyverno.ForEachGeneration{{List: "request.object.spec.containers", Context: contexts(ns)}},
+			}}
+		},
+	}
+	for name, rule := range rules {
+		t.Run(name, func(t *testing.T) {
+			assert.NoError(t, validateNestedContexts(rule("team-a"), "team-a"))
+			assert.NoError(t, validateNestedContexts(rule("kyverno"), ""))
+			assert.Error(t, validateNestedContexts(rule("kyverno"), "team-a"))
+		})
+	}
+}
+
+func Test_Validate_ForEachContextSecretNamespace(t *testing.T) {
+	rawPolicy := []byte(`{
+  "apiVersion": "kyverno.io/v1",
+  "kind": "Policy",
+  "metadata": {
+    "name": "inventory",
+    "namespace": "team-a"
+  },
+  "spec": {
+    "rules": [
+      {
+        "name": "check-containers",
+        "match": {
+          "any": [
+            {
+              "resources": {
+                "kinds": [
+                  "Pod"
+                ]
+              }
+            }
+          ]
+        },
+        "validate": {
+          "foreach": [
+            {
+              "list": "request.object.spec.containers",
+              "context": [
+                {
+                  "name": "inventory",
+                  "apiCall": {
+                    "method": "GET",
+                    "service": {
+                      "url": "https://inventory.example.com",
+                      "auth": {
+                        "basic": {
+                          "name": "creds",
+                          "namespace": "kyverno"
+                        }
+                      }
+                    }
+                  }
+                }
+              ],
+              "deny": {
+                "conditions": {
+                  "any": [
+                    {
+                      "key": "{{ inventory }}",
+                      "operator": "Equals",
+                      "value": "denied"
+                    }
+                  ]
+                }
+              }
+            }
+          ]
+        }
+      }
+    ]
+  }
+}`)
+	var policy *kyverno.Policy
+	assert.NoError(t, json.Unmarshal(rawPolicy, &policy))
+	_, err := Validate(policy, nil, nil, nil, true, "", "")
+	assert.ErrorContains(t, err, "secret kyverno/creds cannot be referenced by a policy in namespace team-a")
+}
@@ -0,0 +1,104 @@
+package gpol
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	celengine "github.com/kyverno/kyverno/pkg/cel/engine"
+	celpolicy "github.com/kyverno/kyverno/pkg/cel/policy"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	"github.com/kyverno/kyverno/pkg/webhooks/updaterequest"
+)
+
+type handler struct {
+	context celpolicy.Context
+	engine  celengine.GeneratingEngine
+	urgen   updaterequest.Generator
+}
+
+func New(
+	engine celengine.GeneratingEngine,
+	context celpolicy.Context,
+	urgen updaterequest.Generator,
+) *handler {
+	return &handler{
+		context: context,
+		engine:  engine,
+		urgen:   urgen,
+	}
+}
+
+// Generate never blocks the admission request, matching policies are turned into update requests
+// processed asynchronously by the background controller.
+func (h *handler) Generate(ctx context.Context, logger logr.Logger, admissionRequest handlers.AdmissionRequest, failurePolicy string, startTime time.Time) handlers.AdmissionResponse {
+	if admissionRequest.DryRun != nil && *admissionRequest.DryRun {
+		return admissionutils.ResponseSuccess(admissionRequest.UID)
+	}
+	request := celengine.RequestFromAdmission(h.context, admissionRequest.AdmissionRequest)
+	response, err := h.engine.Handle(ctx, request)
+	if err != nil {
+		logger.Error(err, "failed to evaluate generating policies")
+		return admissionutils.ResponseSuccess(admissionRequest.UID)
+	}
+	if response.Resource == nil {
+		return admissionutils.ResponseSuccess(admissionRequest.UID)
+	}
+	trigger := kyvernov1.ResourceSpec{
+		APIVersion: response.Resource.GetAPIVersion(),
+		Kind:       response.Resource.GetKind(),
+		Namespace:  response.Resource.GetNamespace(),
+		Name:       response.Resource.GetName(),
+		UID:        response.Resource.GetUID(),
+	}
+	for _, policy := range response.Policies {
+		if !matched(policy) {
+			for _, rule := range policy.Rules {
+				logger.Error(nil, "failed to evaluate generating policy", "policy", policy.Policy.GetName(), "message", rule.Message())
+			}
+			continue
+		}
+		urSpec := kyvernov2.UpdateRequestSpec{
+			Type:   kyvernov2.CELGenerate,
+			Policy: policy.Policy.GetName(),
+			RuleContext: []kyvernov2.RuleContext{{
+				Trigger:          trigger,
+				Synchronize:      policy.Policy.GetSynchronize(),
+				DeleteDownstream: policy.DeleteDownstream,
+			}},
+			Context: h.buildURContext(admissionRequest),
+		}
+		if err := h.urgen.Apply(ctx, urSpec); err != nil {
+			logger.Error(err, "failed to create update request for generating policy", "policy", policy.Policy.GetName())
+		}
+	}
+	return admissionutils.ResponseSuccess(admissionRequest.UID)
+}
+
+func (h *handler) buildURContext(request handlers.AdmissionRequest) kyvernov2.UpdateRequestSpecContext {
+	admissionRequest := request.AdmissionRequest
+	return kyvernov2.UpdateRequestSpecContext{
+		UserRequestInfo: kyvernov2.RequestInfo{
+			AdmissionUserInfo: *admissionRequest.UserInfo.DeepCopy(),
+			Roles:             request.Roles,
+			ClusterRoles:      request.ClusterRoles,
+		},
+		AdmissionRequestInfo: kyvernov2.AdmissionRequestInfoObject{
+			AdmissionRequest: &admissionRequest,
+			Operation:        admissionRequest.Operation,
+		},
+	}
+}
+
+func matched(policy celengine.GeneratingPolicyResponse) bool {
+	for _, rule := range policy.Rules {
+		if rule.Status() != engineapi.RuleStatusPass {
+			return false
+		}
+	}
+	return len(policy.Rules) > 0
+}
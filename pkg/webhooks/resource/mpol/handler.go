@@ -0,0 +1,147 @@
+package mpol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/breaker"
+	celengine "github.com/kyverno/kyverno/pkg/cel/engine"
+	celpolicy "github.com/kyverno/kyverno/pkg/cel/policy"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
+	"go.uber.org/multierr"
+	"gomodules.xyz/jsonpatch/v2"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type handler struct {
+	context        celpolicy.Context
+	engine         celengine.MutatingEngine
+	kyvernoClient  versioned.Interface
+	reportsBreaker breaker.Breaker
+}
+
+func New(
+	engine celengine.MutatingEngine,
+	context celpolicy.Context,
+	kyvernoClient versioned.Interface,
+	reportsBreaker breaker.Breaker,
+) *handler {
+	return &handler{
+		context:        context,
+		engine:         engine,
+		kyvernoClient:  kyvernoClient,
+		reportsBreaker: reportsBreaker,
+	}
+}
+
+func (h *handler) Mutate(ctx context.Context, logger logr.Logger, admissionRequest handlers.AdmissionRequest, failurePolicy string, startTime time.Time) handlers.AdmissionResponse {
+	request := celengine.RequestFromAdmission(h.context, admissionRequest.AdmissionRequest)
+	// only evaluate the policies registered on the webhook path that received the request
+	predicate := func(policy kyvernov2alpha1.MutatingPolicy) bool {
+		return webhookutils.MatchWebhookPath(&policy, failurePolicy, admissionRequest.URLParams)
+	}
+	response, err := h.engine.Handle(ctx, request, predicate)
+	if err != nil {
+		return admissionutils.Response(admissionRequest.UID, err)
+	}
+	var group wait.Group
+	defer group.Wait()
+	group.Start(func() {
+		err := h.admissionReport(ctx, request, response)
+		if err != nil {
+			logger.Error(err, "failed to create report")
+		}
+	})
+	return h.admissionResponse(request, response)
+}
+
+func (h *handler) admissionResponse(request celengine.EngineRequest, response celengine.MutatingEngineResponse) handlers.AdmissionResponse {
+	var errs []error
+	var warnings []string
+	for _, policy := range response.Policies {
+		for _, rule := range policy.Rules {
+			if rule.Status() != engineapi.RuleStatusError {
+				continue
+			}
+			if policy.Policy.GetFailurePolicy() == admissionregistrationv1.Fail {
+				errs = append(errs, fmt.Errorf("Policy %s rule %s error: %s", policy.Policy.GetName(), rule.Name(), rule.Message()))
+			} else {
+				warnings = append(warnings, fmt.Sprintf("Policy %s rule %s error: %s", policy.Policy.GetName(), rule.Name(), rule.Message()))
+			}
+		}
+	}
+	uid := request.AdmissionRequest().UID
+	if err := multierr.Combine(errs...); err != nil {
+		return admissionutils.Response(uid, err, warnings...)
+	}
+	patches, err := patches(response)
+	if err != nil {
+		return admissionutils.Response(uid, err, warnings...)
+	}
+	return admissionutils.MutationResponse(uid, patches, warnings...)
+}
+
+func (h *handler) admissionReport(ctx context.Context, request celengine.EngineRequest, response celengine.MutatingEngineResponse) error {
+	admissionRequest := request.AdmissionRequest()
+	object, oldObject, err := admissionutils.ExtractResources(nil, admissionRequest)
+	if err != nil {
+		return err
+	}
+	if object.Object == nil {
+		object = oldObject
+	}
+	responses := make([]engineapi.EngineResponse, 0, len(response.Policies))
+	for _, r := range response.Policies {
+		engineResponse := engineapi.EngineResponse{
+			Resource: object,
+			PolicyResponse: engineapi.PolicyResponse{
+				Rules: r.Rules,
+			},
+		}
+		engineResponse = engineResponse.WithPolicy(engineapi.NewMutatingPolicy(&r.Policy))
+		responses = append(responses, engineResponse)
+	}
+	report := reportutils.BuildAdmissionReport(object, admissionRequest, responses...)
+	if len(report.GetResults()) > 0 {
+		err := h.reportsBreaker.Do(ctx, func(ctx context.Context) error {
+			_, err := reportutils.CreateReport(ctx, report, h.kyvernoClient)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// patches computes the json patch between the admitted resource and the patched one
+func patches(response celengine.MutatingEngineResponse) ([]byte, error) {
+	if response.Resource == nil || response.PatchedResource == nil {
+		return nil, nil
+	}
+	original, err := json.Marshal(response.Resource.Object)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := json.Marshal(response.PatchedResource.Object)
+	if err != nil {
+		return nil, err
+	}
+	operations, err := jsonpatch.CreatePatch(original, patched)
+	if err != nil {
+		return nil, err
+	}
+	return jsonutils.JoinPatches(patch.ConvertPatches(operations...)...), nil
+}
@@ -70,6 +70,9 @@ func NewServer(
 	globalContextLogger := logger.WithName("globalcontext")
 	verifyLogger := logger.WithName("verify")
 	vpolLogger := logger.WithName("vpol")
+	mpolLogger := logger.WithName("mpol")
+	gpolLogger := logger.WithName("gpol")
+	ivpolLogger := logger.WithName("ivpol")
 	registerWebhookHandlersWithAll(
 		mux,
 		"MUTATE",
@@ -119,6 +122,56 @@ func NewServer(
 				WithAdmission(vpolLogger.WithName("validate"))
 		},
 	)
+	registerWebhookHandlers(
+		mux,
+		"MPOL",
+		config.MutatingPolicyServicePath,
+		resourceHandlers.MutatingPolicies,
+		func(handler handlers.AdmissionHandler) handlers.HttpHandler {
+			return handler.
+				WithFilter(configuration).
+				WithProtection(toggle.FromContext(ctx).ProtectManagedResources()).
+				WithDump(debugModeOpts.DumpPayload).
+				WithTopLevelGVK(discovery).
+				WithRoles(rbLister, crbLister).
+				WithOperationFilter(admissionv1.Create, admissionv1.Update, admissionv1.Connect).
+				WithMetrics(resourceLogger, metricsConfig.Config(), metrics.WebhookMutating).
+				WithAdmission(mpolLogger.WithName("mutate"))
+		},
+	)
+	registerWebhookHandlers(
+		mux,
+		"GPOL",
+		config.GeneratingPolicyServicePath,
+		resourceHandlers.GeneratingPolicies,
+		func(handler handlers.AdmissionHandler) handlers.HttpHandler {
+			return handler.
+				WithFilter(configuration).
+				WithProtection(toggle.FromContext(ctx).ProtectManagedResources()).
+				WithDump(debugModeOpts.DumpPayload).
+				WithTopLevelGVK(discovery).
+				WithRoles(rbLister, crbLister).
+				WithMetrics(resourceLogger, metricsConfig.Config(), metrics.WebhookValidating).
+				WithAdmission(gpolLogger.WithName("generate"))
+		},
+	)
+	registerWebhookHandlers(
+		mux,
+		"IVPOL",
+		config.ImageVerificationPolicyServicePath,
+		resourceHandlers.ImageVerificationPolicies,
+		func(handler handlers.AdmissionHandler) handlers.HttpHandler {
+			return handler.
+				WithFilter(configuration).
+				WithProtection(toggle.FromContext(ctx).ProtectManagedResources()).
+				WithDump(debugModeOpts.DumpPayload).
+				WithTopLevelGVK(discovery).
+				WithRoles(rbLister, crbLister).
+				WithOperationFilter(admissionv1.Create, admissionv1.Update, admissionv1.Connect).
+				WithMetrics(resourceLogger, metricsConfig.Config(), metrics.WebhookMutating).
+				WithAdmission(ivpolLogger.WithName("mutate"))
+		},
+	)
 	mux.HandlerFunc(
 		"POST",
 		config.PolicyMutatingWebhookServicePath,
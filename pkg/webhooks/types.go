@@ -54,4 +54,10 @@ type ResourceHandlers struct {
 	Validation Handler
 	// ValidatingPolicies evaluates validating policies against kube resources
 	ValidatingPolicies Handler
+	// MutatingPolicies evaluates mutating policies against kube resources
+	MutatingPolicies Handler
+	// GeneratingPolicies evaluates generating policies against kube resources
+	GeneratingPolicies Handler
+	// ImageVerificationPolicies evaluates image verification policies against kube resources
+	ImageVerificationPolicies Handler
 }
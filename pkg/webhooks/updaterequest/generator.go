@@ -43,7 +43,7 @@ func NewGenerator(client versioned.Interface, urInformer kyvernov2informers.Upda
 
 // Apply creates update request resource
 func (g *generator) Apply(ctx context.Context, ur kyvernov2.UpdateRequestSpec) error {
-	if ur.Type == kyvernov2.Generate && len(ur.RuleContext) == 0 {
+	if (ur.Type == kyvernov2.Generate || ur.Type == kyvernov2.CELGenerate) && len(ur.RuleContext) == 0 {
 		return nil
 	}
 	logger.V(4).Info("apply Update Request", "request", ur)
@@ -72,7 +72,7 @@ func (g *generator) tryApplyResource(ctx context.Context, urSpec kyvernov2.Updat
 
 	if urSpec.GetRequestType() == kyvernov2.Mutate {
 		queryLabels = common.MutateLabelsSet(urSpec.Policy, urSpec.GetResource())
-	} else if urSpec.GetRequestType() == kyvernov2.Generate {
+	} else if urSpec.GetRequestType() == kyvernov2.Generate || urSpec.GetRequestType() == kyvernov2.CELGenerate {
 		queryLabels = common.GenerateLabelsSet(urSpec.Policy)
 	}
 
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"strings"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+)
+
+type WebhookPolicy interface {
+	GetName() string
+	GetFailurePolicy() admissionregistrationv1.FailurePolicyType
+	GetMatchConditions() []admissionregistrationv1.MatchCondition
+	GetMatchConstraints() admissionregistrationv1.MatchResources
+	GetWebhookConfiguration() *kyvernov2alpha1.WebhookConfiguration
+}
+
+// IsFineGrained returns true when the policy is served by its own webhook instead of the shared ones.
+func IsFineGrained(policy WebhookPolicy) bool {
+	if policy.GetMatchConditions() != nil {
+		return true
+	}
+	if matchPolicy := policy.GetMatchConstraints().MatchPolicy; matchPolicy != nil && *matchPolicy == admissionregistrationv1.Exact {
+		return true
+	}
+	if config := policy.GetWebhookConfiguration(); config != nil && config.TimeoutSeconds != nil {
+		return true
+	}
+	return false
+}
+
+// MatchWebhookPath returns true when the policy is registered on the webhook path identified by
+// the failure policy ("fail", "ignore" or "all") and the fine grained url params.
+func MatchWebhookPath(policy WebhookPolicy, failurePolicy string, urlParams string) bool {
+	switch failurePolicy {
+	case "fail":
+		if policy.GetFailurePolicy() != admissionregistrationv1.Fail {
+			return false
+		}
+	case "ignore":
+		if policy.GetFailurePolicy() != admissionregistrationv1.Ignore {
+			return false
+		}
+	}
+	if urlParams == "" {
+		return !IsFineGrained(policy)
+	}
+	return policy.GetName() == strings.TrimPrefix(urlParams, "/")
+}
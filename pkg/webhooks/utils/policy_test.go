@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"testing"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestMatchWebhookPath(t *testing.T) {
+	policy := func(name string, failurePolicy admissionregistrationv1.FailurePolicyType, fineGrained bool) *kyvernov2alpha1.MutatingPolicy {
+		mpol := &kyvernov2alpha1.MutatingPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: kyvernov2alpha1.MutatingPolicySpec{
+				FailurePolicy: ptr.To(failurePolicy),
+			},
+		}
+		if fineGrained {
+			mpol.Spec.MatchConditions = []admissionregistrationv1.MatchCondition{{Name: "test", Expression: "true"}}
+		}
+		return mpol
+	}
+	tests := []struct {
+		name          string
+		policy        WebhookPolicy
+		failurePolicy string
+		urlParams     string
+		want          bool
+	}{{
+		name:          "fail policy on fail path",
+		policy:        policy("test", admissionregistrationv1.Fail, false),
+		failurePolicy: "fail",
+		want:          true,
+	}, {
+		name:          "fail policy on ignore path",
+		policy:        policy("test", admissionregistrationv1.Fail, false),
+		failurePolicy: "ignore",
+		want:          false,
+	}, {
+		name:          "ignore policy on ignore path",
+		policy:        policy("test", admissionregistrationv1.Ignore, false),
+		failurePolicy: "ignore",
+		want:          true,
+	}, {
+		name:          "policy on all path",
+		policy:        policy("test", admissionregistrationv1.Ignore, false),
+		failurePolicy: "all",
+		want:          true,
+	}, {
+		name:          "fine grained policy on shared path",
+		policy:        policy("test", admissionregistrationv1.Fail, true),
+		failurePolicy: "fail",
+		want:          false,
+	}, {
+		name:          "fine grained policy on its own path",
+		policy:        policy("test", admissionregistrationv1.Fail, true),
+		failurePolicy: "fail",
+		urlParams:     "/test",
+		want:          true,
+	}, {
+		name:          "fine grained policy on another policy path",
+		policy:        policy("test", admissionregistrationv1.Fail, true),
+		failurePolicy: "fail",
+		urlParams:     "/other",
+		want:          false,
+	}, {
+		name:          "fine grained policy on its own path with another failure policy",
+		policy:        policy("test", admissionregistrationv1.Fail, true),
+		failurePolicy: "ignore",
+		urlParams:     "/test",
+		want:          false,
+	}, {
+		name:   "fine grained timeout",
+		policy: &kyvernov2alpha1.ImageVerificationPolicy{Spec: kyvernov2alpha1.ImageVerificationPolicySpec{WebhookConfiguration: &kyvernov2alpha1.WebhookConfiguration{TimeoutSeconds: ptr.To[int32](10)}}},
+		want:   false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MatchWebhookPath(tt.policy, tt.failurePolicy, tt.urlParams))
+		})
+	}
+}